@@ -42,6 +42,86 @@ func (m *MockAuthServiceClient) EXPECT() *MockAuthServiceClientMockRecorder {
 	return m.recorder
 }
 
+// AttachPermissionToRole mocks base method.
+func (m *MockAuthServiceClient) AttachPermissionToRole(ctx context.Context, in *v1.AttachPermissionToRoleRequest, opts ...grpc.CallOption) (*v1.AttachPermissionToRoleResponse, error) {
+	m.ctrl.T.Helper()
+	varargs := []any{ctx, in}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "AttachPermissionToRole", varargs...)
+	ret0, _ := ret[0].(*v1.AttachPermissionToRoleResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AttachPermissionToRole indicates an expected call of AttachPermissionToRole.
+func (mr *MockAuthServiceClientMockRecorder) AttachPermissionToRole(ctx, in any, opts ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{ctx, in}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AttachPermissionToRole", reflect.TypeOf((*MockAuthServiceClient)(nil).AttachPermissionToRole), varargs...)
+}
+
+// CreateAPIToken mocks base method.
+func (m *MockAuthServiceClient) CreateAPIToken(ctx context.Context, in *v1.CreateAPITokenRequest, opts ...grpc.CallOption) (*v1.CreateAPITokenResponse, error) {
+	m.ctrl.T.Helper()
+	varargs := []any{ctx, in}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "CreateAPIToken", varargs...)
+	ret0, _ := ret[0].(*v1.CreateAPITokenResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateAPIToken indicates an expected call of CreateAPIToken.
+func (mr *MockAuthServiceClientMockRecorder) CreateAPIToken(ctx, in any, opts ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{ctx, in}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateAPIToken", reflect.TypeOf((*MockAuthServiceClient)(nil).CreateAPIToken), varargs...)
+}
+
+// CreateOAuthClient mocks base method.
+func (m *MockAuthServiceClient) CreateOAuthClient(ctx context.Context, in *v1.CreateOAuthClientRequest, opts ...grpc.CallOption) (*v1.CreateOAuthClientResponse, error) {
+	m.ctrl.T.Helper()
+	varargs := []any{ctx, in}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "CreateOAuthClient", varargs...)
+	ret0, _ := ret[0].(*v1.CreateOAuthClientResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateOAuthClient indicates an expected call of CreateOAuthClient.
+func (mr *MockAuthServiceClientMockRecorder) CreateOAuthClient(ctx, in any, opts ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{ctx, in}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateOAuthClient", reflect.TypeOf((*MockAuthServiceClient)(nil).CreateOAuthClient), varargs...)
+}
+
+// CreateRole mocks base method.
+func (m *MockAuthServiceClient) CreateRole(ctx context.Context, in *v1.CreateRoleRequest, opts ...grpc.CallOption) (*v1.CreateRoleResponse, error) {
+	m.ctrl.T.Helper()
+	varargs := []any{ctx, in}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "CreateRole", varargs...)
+	ret0, _ := ret[0].(*v1.CreateRoleResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateRole indicates an expected call of CreateRole.
+func (mr *MockAuthServiceClientMockRecorder) CreateRole(ctx, in any, opts ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{ctx, in}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateRole", reflect.TypeOf((*MockAuthServiceClient)(nil).CreateRole), varargs...)
+}
+
 // CreateUser mocks base method.
 func (m *MockAuthServiceClient) CreateUser(ctx context.Context, in *v1.CreateUserRequest, opts ...grpc.CallOption) (*v1.CreateUserResponse, error) {
 	m.ctrl.T.Helper()
@@ -62,6 +142,46 @@ func (mr *MockAuthServiceClientMockRecorder) CreateUser(ctx, in any, opts ...any
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateUser", reflect.TypeOf((*MockAuthServiceClient)(nil).CreateUser), varargs...)
 }
 
+// DeleteOAuthClient mocks base method.
+func (m *MockAuthServiceClient) DeleteOAuthClient(ctx context.Context, in *v1.DeleteOAuthClientRequest, opts ...grpc.CallOption) (*v1.DeleteOAuthClientResponse, error) {
+	m.ctrl.T.Helper()
+	varargs := []any{ctx, in}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "DeleteOAuthClient", varargs...)
+	ret0, _ := ret[0].(*v1.DeleteOAuthClientResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteOAuthClient indicates an expected call of DeleteOAuthClient.
+func (mr *MockAuthServiceClientMockRecorder) DeleteOAuthClient(ctx, in any, opts ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{ctx, in}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteOAuthClient", reflect.TypeOf((*MockAuthServiceClient)(nil).DeleteOAuthClient), varargs...)
+}
+
+// DeleteRole mocks base method.
+func (m *MockAuthServiceClient) DeleteRole(ctx context.Context, in *v1.DeleteRoleRequest, opts ...grpc.CallOption) (*v1.DeleteRoleResponse, error) {
+	m.ctrl.T.Helper()
+	varargs := []any{ctx, in}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "DeleteRole", varargs...)
+	ret0, _ := ret[0].(*v1.DeleteRoleResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteRole indicates an expected call of DeleteRole.
+func (mr *MockAuthServiceClientMockRecorder) DeleteRole(ctx, in any, opts ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{ctx, in}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteRole", reflect.TypeOf((*MockAuthServiceClient)(nil).DeleteRole), varargs...)
+}
+
 // DeleteUser mocks base method.
 func (m *MockAuthServiceClient) DeleteUser(ctx context.Context, in *v1.DeleteUserRequest, opts ...grpc.CallOption) (*v1.DeleteUserResponse, error) {
 	m.ctrl.T.Helper()
@@ -82,6 +202,26 @@ func (mr *MockAuthServiceClientMockRecorder) DeleteUser(ctx, in any, opts ...any
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteUser", reflect.TypeOf((*MockAuthServiceClient)(nil).DeleteUser), varargs...)
 }
 
+// DetachPermissionFromRole mocks base method.
+func (m *MockAuthServiceClient) DetachPermissionFromRole(ctx context.Context, in *v1.DetachPermissionFromRoleRequest, opts ...grpc.CallOption) (*v1.DetachPermissionFromRoleResponse, error) {
+	m.ctrl.T.Helper()
+	varargs := []any{ctx, in}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "DetachPermissionFromRole", varargs...)
+	ret0, _ := ret[0].(*v1.DetachPermissionFromRoleResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DetachPermissionFromRole indicates an expected call of DetachPermissionFromRole.
+func (mr *MockAuthServiceClientMockRecorder) DetachPermissionFromRole(ctx, in any, opts ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{ctx, in}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DetachPermissionFromRole", reflect.TypeOf((*MockAuthServiceClient)(nil).DetachPermissionFromRole), varargs...)
+}
+
 // GetUserByUUID mocks base method.
 func (m *MockAuthServiceClient) GetUserByUUID(ctx context.Context, in *v1.GetUserByUUIDRequest, opts ...grpc.CallOption) (*v1.GetUserByUUIDResponse, error) {
 	m.ctrl.T.Helper()
@@ -102,6 +242,86 @@ func (mr *MockAuthServiceClientMockRecorder) GetUserByUUID(ctx, in any, opts ...
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserByUUID", reflect.TypeOf((*MockAuthServiceClient)(nil).GetUserByUUID), varargs...)
 }
 
+// GrantRoleToUser mocks base method.
+func (m *MockAuthServiceClient) GrantRoleToUser(ctx context.Context, in *v1.GrantRoleToUserRequest, opts ...grpc.CallOption) (*v1.GrantRoleToUserResponse, error) {
+	m.ctrl.T.Helper()
+	varargs := []any{ctx, in}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "GrantRoleToUser", varargs...)
+	ret0, _ := ret[0].(*v1.GrantRoleToUserResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GrantRoleToUser indicates an expected call of GrantRoleToUser.
+func (mr *MockAuthServiceClientMockRecorder) GrantRoleToUser(ctx, in any, opts ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{ctx, in}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GrantRoleToUser", reflect.TypeOf((*MockAuthServiceClient)(nil).GrantRoleToUser), varargs...)
+}
+
+// ListAPITokens mocks base method.
+func (m *MockAuthServiceClient) ListAPITokens(ctx context.Context, in *v1.ListAPITokensRequest, opts ...grpc.CallOption) (*v1.ListAPITokensResponse, error) {
+	m.ctrl.T.Helper()
+	varargs := []any{ctx, in}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "ListAPITokens", varargs...)
+	ret0, _ := ret[0].(*v1.ListAPITokensResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListAPITokens indicates an expected call of ListAPITokens.
+func (mr *MockAuthServiceClientMockRecorder) ListAPITokens(ctx, in any, opts ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{ctx, in}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListAPITokens", reflect.TypeOf((*MockAuthServiceClient)(nil).ListAPITokens), varargs...)
+}
+
+// ListOAuthClients mocks base method.
+func (m *MockAuthServiceClient) ListOAuthClients(ctx context.Context, in *v1.ListOAuthClientsRequest, opts ...grpc.CallOption) (*v1.ListOAuthClientsResponse, error) {
+	m.ctrl.T.Helper()
+	varargs := []any{ctx, in}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "ListOAuthClients", varargs...)
+	ret0, _ := ret[0].(*v1.ListOAuthClientsResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListOAuthClients indicates an expected call of ListOAuthClients.
+func (mr *MockAuthServiceClientMockRecorder) ListOAuthClients(ctx, in any, opts ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{ctx, in}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListOAuthClients", reflect.TypeOf((*MockAuthServiceClient)(nil).ListOAuthClients), varargs...)
+}
+
+// ListRoles mocks base method.
+func (m *MockAuthServiceClient) ListRoles(ctx context.Context, in *v1.ListRolesRequest, opts ...grpc.CallOption) (*v1.ListRolesResponse, error) {
+	m.ctrl.T.Helper()
+	varargs := []any{ctx, in}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "ListRoles", varargs...)
+	ret0, _ := ret[0].(*v1.ListRolesResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListRoles indicates an expected call of ListRoles.
+func (mr *MockAuthServiceClientMockRecorder) ListRoles(ctx, in any, opts ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{ctx, in}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListRoles", reflect.TypeOf((*MockAuthServiceClient)(nil).ListRoles), varargs...)
+}
+
 // ListUsers mocks base method.
 func (m *MockAuthServiceClient) ListUsers(ctx context.Context, in *v1.ListUsersRequest, opts ...grpc.CallOption) (*v1.ListUsersResponse, error) {
 	m.ctrl.T.Helper()
@@ -122,6 +342,106 @@ func (mr *MockAuthServiceClientMockRecorder) ListUsers(ctx, in any, opts ...any)
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListUsers", reflect.TypeOf((*MockAuthServiceClient)(nil).ListUsers), varargs...)
 }
 
+// ResetUserMFA mocks base method.
+func (m *MockAuthServiceClient) ResetUserMFA(ctx context.Context, in *v1.ResetUserMFARequest, opts ...grpc.CallOption) (*v1.ResetUserMFAResponse, error) {
+	m.ctrl.T.Helper()
+	varargs := []any{ctx, in}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "ResetUserMFA", varargs...)
+	ret0, _ := ret[0].(*v1.ResetUserMFAResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ResetUserMFA indicates an expected call of ResetUserMFA.
+func (mr *MockAuthServiceClientMockRecorder) ResetUserMFA(ctx, in any, opts ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{ctx, in}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ResetUserMFA", reflect.TypeOf((*MockAuthServiceClient)(nil).ResetUserMFA), varargs...)
+}
+
+// RevokeAPIToken mocks base method.
+func (m *MockAuthServiceClient) RevokeAPIToken(ctx context.Context, in *v1.RevokeAPITokenRequest, opts ...grpc.CallOption) (*v1.RevokeAPITokenResponse, error) {
+	m.ctrl.T.Helper()
+	varargs := []any{ctx, in}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "RevokeAPIToken", varargs...)
+	ret0, _ := ret[0].(*v1.RevokeAPITokenResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RevokeAPIToken indicates an expected call of RevokeAPIToken.
+func (mr *MockAuthServiceClientMockRecorder) RevokeAPIToken(ctx, in any, opts ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{ctx, in}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RevokeAPIToken", reflect.TypeOf((*MockAuthServiceClient)(nil).RevokeAPIToken), varargs...)
+}
+
+// RevokeRoleFromUser mocks base method.
+func (m *MockAuthServiceClient) RevokeRoleFromUser(ctx context.Context, in *v1.RevokeRoleFromUserRequest, opts ...grpc.CallOption) (*v1.RevokeRoleFromUserResponse, error) {
+	m.ctrl.T.Helper()
+	varargs := []any{ctx, in}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "RevokeRoleFromUser", varargs...)
+	ret0, _ := ret[0].(*v1.RevokeRoleFromUserResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RevokeRoleFromUser indicates an expected call of RevokeRoleFromUser.
+func (mr *MockAuthServiceClientMockRecorder) RevokeRoleFromUser(ctx, in any, opts ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{ctx, in}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RevokeRoleFromUser", reflect.TypeOf((*MockAuthServiceClient)(nil).RevokeRoleFromUser), varargs...)
+}
+
+// RevokeUserSessions mocks base method.
+func (m *MockAuthServiceClient) RevokeUserSessions(ctx context.Context, in *v1.RevokeUserSessionsRequest, opts ...grpc.CallOption) (*v1.RevokeUserSessionsResponse, error) {
+	m.ctrl.T.Helper()
+	varargs := []any{ctx, in}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "RevokeUserSessions", varargs...)
+	ret0, _ := ret[0].(*v1.RevokeUserSessionsResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RevokeUserSessions indicates an expected call of RevokeUserSessions.
+func (mr *MockAuthServiceClientMockRecorder) RevokeUserSessions(ctx, in any, opts ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{ctx, in}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RevokeUserSessions", reflect.TypeOf((*MockAuthServiceClient)(nil).RevokeUserSessions), varargs...)
+}
+
+// UnlockUser mocks base method.
+func (m *MockAuthServiceClient) UnlockUser(ctx context.Context, in *v1.UnlockUserRequest, opts ...grpc.CallOption) (*v1.UnlockUserResponse, error) {
+	m.ctrl.T.Helper()
+	varargs := []any{ctx, in}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "UnlockUser", varargs...)
+	ret0, _ := ret[0].(*v1.UnlockUserResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UnlockUser indicates an expected call of UnlockUser.
+func (mr *MockAuthServiceClientMockRecorder) UnlockUser(ctx, in any, opts ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{ctx, in}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UnlockUser", reflect.TypeOf((*MockAuthServiceClient)(nil).UnlockUser), varargs...)
+}
+
 // UpdateUser mocks base method.
 func (m *MockAuthServiceClient) UpdateUser(ctx context.Context, in *v1.UpdateUserRequest, opts ...grpc.CallOption) (*v1.UpdateUserResponse, error) {
 	m.ctrl.T.Helper()
@@ -166,6 +486,66 @@ func (m *MockAuthServiceServer) EXPECT() *MockAuthServiceServerMockRecorder {
 	return m.recorder
 }
 
+// AttachPermissionToRole mocks base method.
+func (m *MockAuthServiceServer) AttachPermissionToRole(arg0 context.Context, arg1 *v1.AttachPermissionToRoleRequest) (*v1.AttachPermissionToRoleResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AttachPermissionToRole", arg0, arg1)
+	ret0, _ := ret[0].(*v1.AttachPermissionToRoleResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AttachPermissionToRole indicates an expected call of AttachPermissionToRole.
+func (mr *MockAuthServiceServerMockRecorder) AttachPermissionToRole(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AttachPermissionToRole", reflect.TypeOf((*MockAuthServiceServer)(nil).AttachPermissionToRole), arg0, arg1)
+}
+
+// CreateAPIToken mocks base method.
+func (m *MockAuthServiceServer) CreateAPIToken(arg0 context.Context, arg1 *v1.CreateAPITokenRequest) (*v1.CreateAPITokenResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateAPIToken", arg0, arg1)
+	ret0, _ := ret[0].(*v1.CreateAPITokenResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateAPIToken indicates an expected call of CreateAPIToken.
+func (mr *MockAuthServiceServerMockRecorder) CreateAPIToken(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateAPIToken", reflect.TypeOf((*MockAuthServiceServer)(nil).CreateAPIToken), arg0, arg1)
+}
+
+// CreateOAuthClient mocks base method.
+func (m *MockAuthServiceServer) CreateOAuthClient(arg0 context.Context, arg1 *v1.CreateOAuthClientRequest) (*v1.CreateOAuthClientResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateOAuthClient", arg0, arg1)
+	ret0, _ := ret[0].(*v1.CreateOAuthClientResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateOAuthClient indicates an expected call of CreateOAuthClient.
+func (mr *MockAuthServiceServerMockRecorder) CreateOAuthClient(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateOAuthClient", reflect.TypeOf((*MockAuthServiceServer)(nil).CreateOAuthClient), arg0, arg1)
+}
+
+// CreateRole mocks base method.
+func (m *MockAuthServiceServer) CreateRole(arg0 context.Context, arg1 *v1.CreateRoleRequest) (*v1.CreateRoleResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateRole", arg0, arg1)
+	ret0, _ := ret[0].(*v1.CreateRoleResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateRole indicates an expected call of CreateRole.
+func (mr *MockAuthServiceServerMockRecorder) CreateRole(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateRole", reflect.TypeOf((*MockAuthServiceServer)(nil).CreateRole), arg0, arg1)
+}
+
 // CreateUser mocks base method.
 func (m *MockAuthServiceServer) CreateUser(arg0 context.Context, arg1 *v1.CreateUserRequest) (*v1.CreateUserResponse, error) {
 	m.ctrl.T.Helper()
@@ -181,6 +561,36 @@ func (mr *MockAuthServiceServerMockRecorder) CreateUser(arg0, arg1 any) *gomock.
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateUser", reflect.TypeOf((*MockAuthServiceServer)(nil).CreateUser), arg0, arg1)
 }
 
+// DeleteOAuthClient mocks base method.
+func (m *MockAuthServiceServer) DeleteOAuthClient(arg0 context.Context, arg1 *v1.DeleteOAuthClientRequest) (*v1.DeleteOAuthClientResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteOAuthClient", arg0, arg1)
+	ret0, _ := ret[0].(*v1.DeleteOAuthClientResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteOAuthClient indicates an expected call of DeleteOAuthClient.
+func (mr *MockAuthServiceServerMockRecorder) DeleteOAuthClient(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteOAuthClient", reflect.TypeOf((*MockAuthServiceServer)(nil).DeleteOAuthClient), arg0, arg1)
+}
+
+// DeleteRole mocks base method.
+func (m *MockAuthServiceServer) DeleteRole(arg0 context.Context, arg1 *v1.DeleteRoleRequest) (*v1.DeleteRoleResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteRole", arg0, arg1)
+	ret0, _ := ret[0].(*v1.DeleteRoleResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteRole indicates an expected call of DeleteRole.
+func (mr *MockAuthServiceServerMockRecorder) DeleteRole(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteRole", reflect.TypeOf((*MockAuthServiceServer)(nil).DeleteRole), arg0, arg1)
+}
+
 // DeleteUser mocks base method.
 func (m *MockAuthServiceServer) DeleteUser(arg0 context.Context, arg1 *v1.DeleteUserRequest) (*v1.DeleteUserResponse, error) {
 	m.ctrl.T.Helper()
@@ -196,6 +606,21 @@ func (mr *MockAuthServiceServerMockRecorder) DeleteUser(arg0, arg1 any) *gomock.
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteUser", reflect.TypeOf((*MockAuthServiceServer)(nil).DeleteUser), arg0, arg1)
 }
 
+// DetachPermissionFromRole mocks base method.
+func (m *MockAuthServiceServer) DetachPermissionFromRole(arg0 context.Context, arg1 *v1.DetachPermissionFromRoleRequest) (*v1.DetachPermissionFromRoleResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DetachPermissionFromRole", arg0, arg1)
+	ret0, _ := ret[0].(*v1.DetachPermissionFromRoleResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DetachPermissionFromRole indicates an expected call of DetachPermissionFromRole.
+func (mr *MockAuthServiceServerMockRecorder) DetachPermissionFromRole(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DetachPermissionFromRole", reflect.TypeOf((*MockAuthServiceServer)(nil).DetachPermissionFromRole), arg0, arg1)
+}
+
 // GetUserByUUID mocks base method.
 func (m *MockAuthServiceServer) GetUserByUUID(arg0 context.Context, arg1 *v1.GetUserByUUIDRequest) (*v1.GetUserByUUIDResponse, error) {
 	m.ctrl.T.Helper()
@@ -211,6 +636,66 @@ func (mr *MockAuthServiceServerMockRecorder) GetUserByUUID(arg0, arg1 any) *gomo
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserByUUID", reflect.TypeOf((*MockAuthServiceServer)(nil).GetUserByUUID), arg0, arg1)
 }
 
+// GrantRoleToUser mocks base method.
+func (m *MockAuthServiceServer) GrantRoleToUser(arg0 context.Context, arg1 *v1.GrantRoleToUserRequest) (*v1.GrantRoleToUserResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GrantRoleToUser", arg0, arg1)
+	ret0, _ := ret[0].(*v1.GrantRoleToUserResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GrantRoleToUser indicates an expected call of GrantRoleToUser.
+func (mr *MockAuthServiceServerMockRecorder) GrantRoleToUser(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GrantRoleToUser", reflect.TypeOf((*MockAuthServiceServer)(nil).GrantRoleToUser), arg0, arg1)
+}
+
+// ListAPITokens mocks base method.
+func (m *MockAuthServiceServer) ListAPITokens(arg0 context.Context, arg1 *v1.ListAPITokensRequest) (*v1.ListAPITokensResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListAPITokens", arg0, arg1)
+	ret0, _ := ret[0].(*v1.ListAPITokensResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListAPITokens indicates an expected call of ListAPITokens.
+func (mr *MockAuthServiceServerMockRecorder) ListAPITokens(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListAPITokens", reflect.TypeOf((*MockAuthServiceServer)(nil).ListAPITokens), arg0, arg1)
+}
+
+// ListOAuthClients mocks base method.
+func (m *MockAuthServiceServer) ListOAuthClients(arg0 context.Context, arg1 *v1.ListOAuthClientsRequest) (*v1.ListOAuthClientsResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListOAuthClients", arg0, arg1)
+	ret0, _ := ret[0].(*v1.ListOAuthClientsResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListOAuthClients indicates an expected call of ListOAuthClients.
+func (mr *MockAuthServiceServerMockRecorder) ListOAuthClients(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListOAuthClients", reflect.TypeOf((*MockAuthServiceServer)(nil).ListOAuthClients), arg0, arg1)
+}
+
+// ListRoles mocks base method.
+func (m *MockAuthServiceServer) ListRoles(arg0 context.Context, arg1 *v1.ListRolesRequest) (*v1.ListRolesResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListRoles", arg0, arg1)
+	ret0, _ := ret[0].(*v1.ListRolesResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListRoles indicates an expected call of ListRoles.
+func (mr *MockAuthServiceServerMockRecorder) ListRoles(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListRoles", reflect.TypeOf((*MockAuthServiceServer)(nil).ListRoles), arg0, arg1)
+}
+
 // ListUsers mocks base method.
 func (m *MockAuthServiceServer) ListUsers(arg0 context.Context, arg1 *v1.ListUsersRequest) (*v1.ListUsersResponse, error) {
 	m.ctrl.T.Helper()
@@ -226,6 +711,81 @@ func (mr *MockAuthServiceServerMockRecorder) ListUsers(arg0, arg1 any) *gomock.C
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListUsers", reflect.TypeOf((*MockAuthServiceServer)(nil).ListUsers), arg0, arg1)
 }
 
+// ResetUserMFA mocks base method.
+func (m *MockAuthServiceServer) ResetUserMFA(arg0 context.Context, arg1 *v1.ResetUserMFARequest) (*v1.ResetUserMFAResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ResetUserMFA", arg0, arg1)
+	ret0, _ := ret[0].(*v1.ResetUserMFAResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ResetUserMFA indicates an expected call of ResetUserMFA.
+func (mr *MockAuthServiceServerMockRecorder) ResetUserMFA(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ResetUserMFA", reflect.TypeOf((*MockAuthServiceServer)(nil).ResetUserMFA), arg0, arg1)
+}
+
+// RevokeAPIToken mocks base method.
+func (m *MockAuthServiceServer) RevokeAPIToken(arg0 context.Context, arg1 *v1.RevokeAPITokenRequest) (*v1.RevokeAPITokenResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RevokeAPIToken", arg0, arg1)
+	ret0, _ := ret[0].(*v1.RevokeAPITokenResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RevokeAPIToken indicates an expected call of RevokeAPIToken.
+func (mr *MockAuthServiceServerMockRecorder) RevokeAPIToken(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RevokeAPIToken", reflect.TypeOf((*MockAuthServiceServer)(nil).RevokeAPIToken), arg0, arg1)
+}
+
+// RevokeRoleFromUser mocks base method.
+func (m *MockAuthServiceServer) RevokeRoleFromUser(arg0 context.Context, arg1 *v1.RevokeRoleFromUserRequest) (*v1.RevokeRoleFromUserResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RevokeRoleFromUser", arg0, arg1)
+	ret0, _ := ret[0].(*v1.RevokeRoleFromUserResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RevokeRoleFromUser indicates an expected call of RevokeRoleFromUser.
+func (mr *MockAuthServiceServerMockRecorder) RevokeRoleFromUser(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RevokeRoleFromUser", reflect.TypeOf((*MockAuthServiceServer)(nil).RevokeRoleFromUser), arg0, arg1)
+}
+
+// RevokeUserSessions mocks base method.
+func (m *MockAuthServiceServer) RevokeUserSessions(arg0 context.Context, arg1 *v1.RevokeUserSessionsRequest) (*v1.RevokeUserSessionsResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RevokeUserSessions", arg0, arg1)
+	ret0, _ := ret[0].(*v1.RevokeUserSessionsResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RevokeUserSessions indicates an expected call of RevokeUserSessions.
+func (mr *MockAuthServiceServerMockRecorder) RevokeUserSessions(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RevokeUserSessions", reflect.TypeOf((*MockAuthServiceServer)(nil).RevokeUserSessions), arg0, arg1)
+}
+
+// UnlockUser mocks base method.
+func (m *MockAuthServiceServer) UnlockUser(arg0 context.Context, arg1 *v1.UnlockUserRequest) (*v1.UnlockUserResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UnlockUser", arg0, arg1)
+	ret0, _ := ret[0].(*v1.UnlockUserResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UnlockUser indicates an expected call of UnlockUser.
+func (mr *MockAuthServiceServerMockRecorder) UnlockUser(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UnlockUser", reflect.TypeOf((*MockAuthServiceServer)(nil).UnlockUser), arg0, arg1)
+}
+
 // UpdateUser mocks base method.
 func (m *MockAuthServiceServer) UpdateUser(arg0 context.Context, arg1 *v1.UpdateUserRequest) (*v1.UpdateUserResponse, error) {
 	m.ctrl.T.Helper()
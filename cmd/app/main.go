@@ -40,7 +40,9 @@ import (
 	"github.com/hasansino/go42/internal/auth"
 	authGrpcAdapterV1 "github.com/hasansino/go42/internal/auth/adapters/grpc/v1"
 	authHttpAdapterV1 "github.com/hasansino/go42/internal/auth/adapters/http/v1"
+	authHttpAdapterWellKnown "github.com/hasansino/go42/internal/auth/adapters/http/wellknown"
 	authInterceptors "github.com/hasansino/go42/internal/auth/interceptors"
+	"github.com/hasansino/go42/internal/auth/policy"
 	authRepositoryPkg "github.com/hasansino/go42/internal/auth/repository"
 	authWorkers "github.com/hasansino/go42/internal/auth/workers"
 	"github.com/hasansino/go42/internal/cache"
@@ -53,6 +55,7 @@ import (
 	mysqlMigrate "github.com/hasansino/go42/internal/database/mysql/migrate"
 	"github.com/hasansino/go42/internal/database/pgsql"
 	pgsqlMigrate "github.com/hasansino/go42/internal/database/pgsql/migrate"
+	"github.com/hasansino/go42/internal/database/pgsql/replication"
 	"github.com/hasansino/go42/internal/database/sqlite"
 	sqliteMigrate "github.com/hasansino/go42/internal/database/sqlite/migrate"
 	"github.com/hasansino/go42/internal/events"
@@ -60,12 +63,20 @@ import (
 	"github.com/hasansino/go42/internal/events/kafka"
 	"github.com/hasansino/go42/internal/events/nats"
 	"github.com/hasansino/go42/internal/events/rabbitmq"
+	"github.com/hasansino/go42/internal/mailer"
+	"github.com/hasansino/go42/internal/mailer/file"
+	"github.com/hasansino/go42/internal/mailer/smtp"
 	"github.com/hasansino/go42/internal/metrics"
 	metricsAdapterV1 "github.com/hasansino/go42/internal/metrics/adapters/http"
 	"github.com/hasansino/go42/internal/metrics/observers"
+	"github.com/hasansino/go42/internal/oidc"
 	"github.com/hasansino/go42/internal/outbox"
+	outboxCliAdapter "github.com/hasansino/go42/internal/outbox/adapters/cli"
+	outboxHttpAdapterV1 "github.com/hasansino/go42/internal/outbox/adapters/http/v1"
+	outboxDomain "github.com/hasansino/go42/internal/outbox/domain"
 	outboxRepositoryPkg "github.com/hasansino/go42/internal/outbox/repository"
 	outboxWorkers "github.com/hasansino/go42/internal/outbox/workers"
+	"github.com/hasansino/go42/internal/password"
 	"github.com/hasansino/go42/internal/tools"
 )
 
@@ -405,6 +416,38 @@ func main() {
 		slog.Info("no event engine initialized")
 	}
 
+	// mailer engine
+	var (
+		mailerEngine mailer.Mailer
+	)
+	switch cfg.Mailer.Engine {
+	case "log":
+		mailerEngine = mailer.NewLog(slog.Default().With(slog.String("component", "mailer-log")))
+		slog.Info("log mailer initialized")
+	case "file":
+		mailerEngine, err = file.New(cfg.Mailer.File.Directory, cfg.Mailer.From)
+		if err != nil {
+			log.Fatalf("failed to initialize file mailer: %v\n", err)
+		}
+		slog.Info("file mailer initialized")
+	case "smtp":
+		mailerEngine, err = smtp.New(
+			cfg.Mailer.SMTP.Host,
+			cfg.Mailer.SMTP.Port,
+			cfg.Mailer.From,
+			smtp.WithCredentials(cfg.Mailer.SMTP.Username, cfg.Mailer.SMTP.Password),
+			smtp.WithImplicitTLS(cfg.Mailer.SMTP.TLS),
+			smtp.WithTimeout(cfg.Mailer.SMTP.Timeout),
+		)
+		if err != nil {
+			log.Fatalf("failed to initialize smtp mailer: %v\n", err)
+		}
+		slog.Info("smtp mailer initialized")
+	default:
+		mailerEngine = mailer.NewNoop()
+		slog.Info("no mailer initialized")
+	}
+
 	// service layer
 
 	var (
@@ -415,41 +458,226 @@ func main() {
 		// outbox domain
 		outboxLogger := slog.Default().With(slog.String("component", "outbox-service"))
 		outboxRepository := outboxRepositoryPkg.New(database.NewBaseRepository(dbEngine))
-		outboxService = outbox.NewService(
+		outboxPublisherOpts := []outboxWorkers.OutboxMessagePublisherOption{
+			outboxWorkers.OutboxMessagePublisherWithLogger(
+				slog.Default().With(slog.String("component", "outbox-publisher")),
+			),
+			outboxWorkers.OutboxMessagePublisherWithRetryDelay(
+				cfg.Outbox.RetryBaseDelay,
+				cfg.Outbox.RetryMaxDelay,
+			),
+		}
+		if cfg.Outbox.Relay == "wal" {
+			outboxPublisherOpts = append(outboxPublisherOpts,
+				outboxWorkers.OutboxMessagePublisherWithMinAge(cfg.Outbox.WAL.FallbackDelay))
+		}
+		outboxPublisher := outboxWorkers.NewOutboxMessagePublisher(
 			outboxRepository,
-			outbox.WithLogger(outboxLogger),
+			eventsEngine,
+			outboxPublisherOpts...,
 		)
 
-		outboxPublisher := outboxWorkers.NewOutboxMessagePublisher(
+		outboxOpts := []outbox.Option{
+			outbox.WithLogger(outboxLogger),
+			outbox.WithMaxRetries(cfg.Outbox.MaxRetries),
+		}
+		if cfg.Outbox.Relay == "poll" {
+			outboxOpts = append(outboxOpts, outbox.WithWakeup(outboxPublisher.Wakeup))
+		}
+		for _, topic := range cfg.Outbox.Topics {
+			outboxOpts = append(outboxOpts, outbox.WithTopicMaxRetries(topic.Name, topic.MaxRetries))
+		}
+		outboxService = outbox.NewService(
 			outboxRepository,
-			eventsEngine,
-			outboxWorkers.OutboxMessagePublisherWithLogger(
-				slog.Default().With(slog.String("component", "outbox-publisher")),
-			),
+			outboxOpts...,
 		)
 
-		go outboxPublisher.Run(ctx, cfg.Outbox.WorkerRunInterval, cfg.Outbox.WorkerBatchSize)
+		// administration of failed messages runs instead of application, e.g. `app outbox list`
+		if len(os.Args) > 1 && os.Args[1] == "outbox" {
+			err := outboxCliAdapter.New(outboxService, os.Stdout).Run(ctx, os.Args[2:])
+			if err != nil {
+				log.Fatalf("outbox command failed: %v\n", err)
+			}
+			return
+		}
+
+		switch cfg.Outbox.Relay {
+		case "poll":
+			// messages inserted by other replicas wake up publisher through pgsql notifications
+			if cfg.Database.Engine == "pgsql" && cfg.Outbox.ListenNotify {
+				outboxListener := pgsql.NewListener(
+					cfg.Database.Pgsql.Master.DSN(),
+					outboxDomain.NotificationChannel,
+					pgsql.ListenerWithLogger(
+						slog.Default().With(slog.String("component", "outbox-listener")),
+					),
+				)
+				go outboxListener.Listen(ctx, func(_ string) {
+					outboxPublisher.Wakeup()
+				})
+			}
+
+			go outboxPublisher.Run(ctx, cfg.Outbox.WorkerRunInterval, cfg.Outbox.WorkerBatchSize)
+		case "wal":
+			// messages are read from replication slot as they are committed,
+			// messages pending before slot was created, requeued messages and retries
+			// are not inserted, they are published by poll publisher once they are old enough
+			if cfg.Database.Engine != "pgsql" {
+				log.Fatalf("outbox wal relay requires pgsql database engine, got: %v\n", cfg.Database.Engine)
+			}
+			outboxStream := replication.NewStream(
+				cfg.Database.Pgsql.Master.DSN(),
+				cfg.Outbox.WAL.SlotName,
+				outboxDomain.RelayPublication,
+				replication.WithLogger(
+					slog.Default().With(slog.String("component", "outbox-replication")),
+				),
+				replication.WithStatusInterval(cfg.Outbox.WAL.StatusInterval),
+			)
+			outboxRelay := outboxWorkers.NewOutboxWALRelay(
+				outboxRepository,
+				eventsEngine,
+				outboxStream,
+				outboxWorkers.OutboxWALRelayWithLogger(
+					slog.Default().With(slog.String("component", "outbox-relay")),
+				),
+				outboxWorkers.OutboxWALRelayWithReconnectDelay(cfg.Outbox.WAL.ReconnectDelay),
+				outboxWorkers.OutboxWALRelayWithRetryDelay(
+					cfg.Outbox.RetryBaseDelay,
+					cfg.Outbox.RetryMaxDelay,
+				),
+			)
+			go outboxRelay.Run(ctx)
+			go outboxPublisher.Run(ctx, cfg.Outbox.WorkerRunInterval, cfg.Outbox.WorkerBatchSize)
+		}
+
+		if cfg.Outbox.Cleanup.Enabled {
+			outboxCleaner := outboxWorkers.NewOutboxCleaner(
+				outboxRepository,
+				outboxWorkers.OutboxCleanerWithLogger(
+					slog.Default().With(slog.String("component", "outbox-cleaner")),
+				),
+				outboxWorkers.OutboxCleanerWithRetention(cfg.Outbox.Cleanup.Retention),
+				outboxWorkers.OutboxCleanerWithBatchSize(cfg.Outbox.Cleanup.BatchSize),
+				outboxWorkers.OutboxCleanerWithArchive(cfg.Outbox.Cleanup.Archive),
+			)
+			go outboxCleaner.Run(ctx, cfg.Outbox.Cleanup.Interval)
+		}
 
 		// auth domain
 		authLogger := slog.Default().With(slog.String("component", "auth-service"))
+		if cfg.Cache.Engine == "none" &&
+			(cfg.Auth.Lockout.IdentityMaxAttempts > 0 || cfg.Auth.Lockout.ClientMaxAttempts > 0) {
+			authLogger.Warn("login lockout is not enforced without cache engine")
+		}
 		authRepository := authRepositoryPkg.New(
 			database.NewBaseRepository(dbEngine),
 			cacheEngine,
 			cfg.Auth.Cache.Repository.Users,
 			cfg.Auth.Cache.Repository.Secrets,
 		)
-		authService = auth.NewService(
-			authRepository,
-			outboxService,
-			cacheEngine,
+		// hashes of other algorithm are still verified and upgraded on login
+		argon2Hasher := password.NewArgon2id(password.Argon2Params{
+			Memory:      cfg.Auth.Password.Argon2Memory,
+			Iterations:  cfg.Auth.Password.Argon2Iterations,
+			Parallelism: cfg.Auth.Password.Argon2Parallelism,
+		})
+		bcryptHasher := password.NewBcrypt(cfg.Auth.Password.BcryptCost)
+		passwordHasher := password.NewHasher(argon2Hasher, bcryptHasher)
+		if cfg.Auth.Password.Algorithm == "bcrypt" {
+			passwordHasher = password.NewHasher(bcryptHasher, argon2Hasher)
+		}
+
+		passwordRules := []password.Rule{
+			password.MinLength(cfg.Auth.Password.MinLength),
+			password.MaxLength(cfg.Auth.Password.MaxLength),
+			password.MinEntropy(cfg.Auth.MinPasswordEntropyBits),
+		}
+		if len(cfg.Auth.Password.BreachListPath) > 0 {
+			breachList, err := password.LoadBreachList(cfg.Auth.Password.BreachListPath)
+			if err != nil {
+				log.Fatalf("failed to load breached passwords: %v\n", err)
+			}
+			passwordRules = append(passwordRules, password.NotBreached(breachList))
+			authLogger.Info("breached passwords loaded", slog.Int("count", breachList.Len()))
+		}
+
+		policyRules := make([]policy.Rule, 0, len(cfg.Auth.Policy.Rules))
+		for _, rule := range cfg.Auth.Policy.Rules {
+			policyRules = append(policyRules, policy.Rule{
+				Name:      rule.Name,
+				Action:    rule.Action,
+				Effect:    rule.Effect,
+				Condition: rule.Condition,
+			})
+		}
+		accessPolicy, err := policy.New(policyRules)
+		if err != nil {
+			log.Fatalf("failed to compile access policy: %v\n", err)
+		}
+
+		authOpts := []auth.Option{
 			auth.WithLogger(authLogger),
 			auth.WithJWTSecrets(cfg.Auth.JWT.InitialSecrets),
+			auth.WithJWTSecretsEncryptionKey(cfg.Auth.JWT.EncryptionKey),
 			auth.WithJWTAccessTokenTTL(cfg.Auth.JWT.AccessTokenTTL),
 			auth.WithJWTRefreshTokenTTL(cfg.Auth.JWT.RefreshTokenTTL),
 			auth.WithJWTIssuer(cfg.Auth.JWT.Issuer),
 			auth.WithJWTAudience(cfg.Auth.JWT.Audience),
 			auth.WithMinPasswordEntropyBits(cfg.Auth.MinPasswordEntropyBits),
+			auth.WithPasswordHasher(passwordHasher),
+			auth.WithPasswordPolicy(password.NewPolicy(passwordRules...)),
+			auth.WithPasswordHistorySize(cfg.Auth.Password.HistorySize),
+			auth.WithLockoutPolicy(auth.LockoutPolicy{
+				IdentityMaxAttempts: cfg.Auth.Lockout.IdentityMaxAttempts,
+				ClientMaxAttempts:   cfg.Auth.Lockout.ClientMaxAttempts,
+				Window:              cfg.Auth.Lockout.Window,
+				Duration:            cfg.Auth.Lockout.Duration,
+				MaxDuration:         cfg.Auth.Lockout.MaxDuration,
+			}),
+			auth.WithMFAIssuer(cfg.Auth.MFA.Issuer),
+			auth.WithMFAChallengeTTL(cfg.Auth.MFA.ChallengeTTL),
+			auth.WithImpersonationTTL(cfg.Auth.Impersonation.TokenTTL),
+			auth.WithDeletionGracePeriod(cfg.Auth.Lifecycle.DeletionGracePeriod),
+			auth.WithDeletionMode(cfg.Auth.Lifecycle.DeletionMode),
+			auth.WithDataArchiveTTL(cfg.Auth.DataRequests.ArchiveTTL),
+			auth.WithMailer(mailerEngine),
+			auth.WithEmailPolicy(auth.EmailPolicy{
+				VerificationRequired: cfg.Auth.Email.VerificationRequired,
+				VerificationURL:      cfg.Auth.Email.VerificationURL,
+				VerificationTTL:      cfg.Auth.Email.VerificationTTL,
+				PasswordResetURL:     cfg.Auth.Email.PasswordResetURL,
+				PasswordResetTTL:     cfg.Auth.Email.PasswordResetTTL,
+			}),
+			auth.WithOAuthPolicy(auth.OAuthPolicy{
+				IssuerURL: cfg.Auth.OAuth.IssuerURL,
+				LoginURL:  cfg.Auth.OAuth.LoginURL,
+				CodeTTL:   cfg.Auth.OAuth.CodeTTL,
+			}),
+			auth.WithSSOStateTTL(cfg.Auth.SSO.StateTTL),
+			auth.WithAccessPolicy(accessPolicy),
+		}
+		for _, provider := range cfg.Auth.SSO.Providers {
+			authOpts = append(authOpts, auth.WithSSOProvider(provider.Name, auth.SSOProvider{
+				Provider: oidc.New(oidc.Config{
+					IssuerURL:    provider.IssuerURL,
+					ClientID:     provider.ClientID,
+					ClientSecret: provider.ClientSecret,
+					RedirectURL:  strings.TrimSuffix(cfg.Auth.SSO.CallbackURL, "/") + "/" + provider.Name + "/callback",
+					Scopes:       provider.Scopes,
+				}),
+				AutoProvision: provider.AutoProvision,
+			}))
+		}
+		authService, err = auth.NewService(
+			authRepository,
+			outboxService,
+			cacheEngine,
+			authOpts...,
 		)
+		if err != nil {
+			log.Fatalf("failed to create auth service: %v\n", err)
+		}
 
 		authTokenLastUsedUpdater := authWorkers.NewTokenLastUsedUpdater(
 			authRepository,
@@ -466,8 +694,18 @@ func main() {
 				slog.Default().With(slog.String("component", "auth-secret-rotation")),
 			),
 			authWorkers.SecretRotationWorkerWithSecretLength(cfg.Auth.Rotation.SecretLength),
+			authWorkers.SecretRotationWorkerWithRotationPeriod(cfg.Auth.Rotation.Period),
+			authWorkers.SecretRotationWorkerWithAlgorithm(cfg.Auth.Rotation.Algorithm),
 		)
-		go authSecretRotationWorker.Run(ctx, cfg.Auth.Rotation.Period)
+		go authSecretRotationWorker.Run(ctx, cfg.Auth.Rotation.SyncInterval)
+
+		authUserLifecycleWorker := authWorkers.NewUserLifecycleWorker(
+			authService,
+			authWorkers.UserLifecycleWorkerWithLogger(
+				slog.Default().With(slog.String("component", "auth-user-lifecycle")),
+			),
+		)
+		go authUserLifecycleWorker.Run(ctx, cfg.Auth.Lifecycle.WorkerInterval)
 
 		authEventsSubscriber := authWorkers.NewAuthEventSubscriber(
 			authRepository,
@@ -475,7 +713,29 @@ func main() {
 				slog.Default().With(slog.String("component", "auth-events-subscriber")),
 			),
 		)
-		err := authEventsSubscriber.Subscribe(ctx, eventsEngine)
+		err = authEventsSubscriber.Subscribe(ctx, eventsEngine)
+		if err != nil {
+			log.Fatalf("failed to subscribe to events: %v\n", err)
+		}
+
+		authMailSender := authWorkers.NewAuthMailSender(
+			authService,
+			authWorkers.AuthMailSenderWithLogger(
+				slog.Default().With(slog.String("component", "auth-mail-sender")),
+			),
+		)
+		err = authMailSender.Subscribe(ctx, eventsEngine)
+		if err != nil {
+			log.Fatalf("failed to subscribe to events: %v\n", err)
+		}
+
+		authDataRequestProcessor := authWorkers.NewAuthDataRequestProcessor(
+			authService,
+			authWorkers.AuthDataRequestProcessorWithLogger(
+				slog.Default().With(slog.String("component", "auth-data-request-processor")),
+			),
+		)
+		err = authDataRequestProcessor.Subscribe(ctx, eventsEngine)
 		if err != nil {
 			log.Fatalf("failed to subscribe to events: %v\n", err)
 		}
@@ -512,6 +772,8 @@ func main() {
 		authHttpAdapterV1.WithCache(cacheEngine, cfg.Auth.Cache.API),
 	)
 	httpServer.RegisterV1(authHttpAdapter)
+	httpServer.Register(authHttpAdapterWellKnown.New(authService))
+	httpServer.RegisterV1(outboxHttpAdapterV1.New(outboxService, authService))
 
 	// run server
 
@@ -550,13 +812,13 @@ func main() {
 				authInterceptors.NewUnaryAuthInterceptor(authService)),
 			grpcAPI.WithUnaryInterceptor(
 				grpcAPI.InterceptorPriorityAuthentication,
-				authInterceptors.NewUnaryAccessInterceptor(grpcPermissionRegistry)),
+				authInterceptors.NewUnaryAccessInterceptor(grpcPermissionRegistry, authService)),
 			grpcAPI.WithStreamInterceptor(
 				grpcAPI.InterceptorPriorityAuthentication,
 				authInterceptors.NewStreamAuthInterceptor(authService)),
 			grpcAPI.WithStreamInterceptor(
 				grpcAPI.InterceptorPriorityAuthentication,
-				authInterceptors.NewStreamAccessInterceptor(grpcPermissionRegistry)),
+				authInterceptors.NewStreamAccessInterceptor(grpcPermissionRegistry, authService)),
 		)
 	}
 
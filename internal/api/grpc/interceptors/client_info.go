@@ -0,0 +1,66 @@
+package interceptors
+
+import (
+	"context"
+	"net"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+
+	"github.com/hasansino/go42/internal/tools"
+)
+
+const headerNameUserAgent = "user-agent"
+
+func UnaryClientInfoInterceptor() grpc.UnaryServerInterceptor {
+	return func(
+		ctx context.Context,
+		req interface{},
+		info *grpc.UnaryServerInfo,
+		handler grpc.UnaryHandler,
+	) (interface{}, error) {
+		return handler(extractClientInfo(ctx), req)
+	}
+}
+
+type clientInfoServerStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (w *clientInfoServerStream) Context() context.Context {
+	return w.ctx
+}
+
+func StreamClientInfoInterceptor() grpc.StreamServerInterceptor {
+	return func(
+		srv interface{},
+		stream grpc.ServerStream,
+		info *grpc.StreamServerInfo,
+		handler grpc.StreamHandler,
+	) error {
+		wrappedStream := &clientInfoServerStream{
+			ServerStream: stream,
+			ctx:          extractClientInfo(stream.Context()),
+		}
+		return handler(srv, wrappedStream)
+	}
+}
+
+// extractClientInfo puts address and user agent of the client into context.
+func extractClientInfo(ctx context.Context) context.Context {
+	var info tools.ClientInfo
+	if p, ok := peer.FromContext(ctx); ok && p.Addr != nil {
+		info.IPAddress = p.Addr.String()
+		if host, _, err := net.SplitHostPort(info.IPAddress); err == nil {
+			info.IPAddress = host
+		}
+	}
+	if md, ok := metadata.FromIncomingContext(ctx); ok {
+		if agents := md.Get(headerNameUserAgent); len(agents) > 0 {
+			info.UserAgent = agents[0]
+		}
+	}
+	return tools.SetClientInfoToContext(ctx, info)
+}
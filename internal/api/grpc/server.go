@@ -94,6 +94,9 @@ func New(opts ...Option) *Server {
 	unaryPriorityQueue.Enqueue(
 		InterceptorPriorityObservability,
 		interceptors.UnaryRequestIDInterceptor())
+	unaryPriorityQueue.Enqueue(
+		InterceptorPriorityObservability,
+		interceptors.UnaryClientInfoInterceptor())
 	unaryPriorityQueue.Enqueue(
 		InterceptorPriorityBusinessLogic,
 		protovalidateInterceptor.UnaryServerInterceptor(protovalidate.GlobalValidator))
@@ -114,6 +117,9 @@ func New(opts ...Option) *Server {
 	streamPriorityQueue.Enqueue(
 		InterceptorPriorityObservability,
 		interceptors.StreamRequestIDInterceptor())
+	streamPriorityQueue.Enqueue(
+		InterceptorPriorityObservability,
+		interceptors.StreamClientInfoInterceptor())
 	streamPriorityQueue.Enqueue(
 		InterceptorPriorityBusinessLogic,
 		protovalidateInterceptor.StreamServerInterceptor(protovalidate.GlobalValidator))
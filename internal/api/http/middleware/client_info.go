@@ -0,0 +1,24 @@
+package middleware
+
+import (
+	"github.com/labstack/echo/v4"
+
+	"github.com/hasansino/go42/internal/tools"
+)
+
+// NewClientInfo puts address and user agent of the client into request context.
+func NewClientInfo() echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(ctx echo.Context) error {
+			if DefaultSkipper(ctx) {
+				return next(ctx)
+			}
+			newCtx := tools.SetClientInfoToContext(ctx.Request().Context(), tools.ClientInfo{
+				IPAddress: ctx.RealIP(),
+				UserAgent: ctx.Request().UserAgent(),
+			})
+			ctx.SetRequest(ctx.Request().WithContext(newCtx))
+			return next(ctx)
+		}
+	}
+}
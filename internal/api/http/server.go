@@ -186,6 +186,7 @@ func New(opts ...Option) *Server {
 
 	s.e.Use(customMiddleware.NewMetricsCollector())
 	s.e.Use(customMiddleware.NewRequestID())
+	s.e.Use(customMiddleware.NewClientInfo())
 
 	s.e.Use(middleware.SecureWithConfig(middleware.SecureConfig{
 		Skipper:            customMiddleware.DefaultSkipper,
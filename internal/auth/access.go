@@ -0,0 +1,66 @@
+package auth
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"github.com/hasansino/go42/internal/auth/domain"
+	"github.com/hasansino/go42/internal/metrics"
+)
+
+// CheckAccess is single decision point for protected operations of all protocols.
+// Every decision is logged and counted, denials at info level so they can be audited.
+// Policy evaluation errors are logged, resulting decision is still respected.
+func (s *Service) CheckAccess(ctx context.Context, req *domain.AccessRequest) *domain.AccessDecision {
+	if req.Time.IsZero() {
+		req.Time = time.Now()
+	}
+
+	decision, err := s.accessPolicy.Evaluate(req)
+	if err != nil {
+		s.logger.ErrorContext(ctx, "failed to evaluate access policy", slog.Any("error", err))
+	}
+	if decision == nil {
+		decision = &domain.AccessDecision{Allowed: false}
+	}
+
+	result := "allowed"
+	if !decision.Allowed {
+		result = "denied"
+	}
+
+	metrics.Counter("auth_access_decisions_total", map[string]interface{}{
+		"action": req.Action,
+		"result": result,
+		"rule":   decision.Rule,
+	}).Inc()
+
+	level := slog.LevelDebug
+	if !decision.Allowed {
+		level = slog.LevelInfo
+	}
+
+	attrs := []slog.Attr{
+		slog.String("action", req.Action),
+		slog.String("result", result),
+		slog.String("rule", decision.Rule),
+		slog.String("resource_type", req.Resource.Type),
+		slog.String("resource_owner", req.Resource.Owner),
+		slog.String("protocol", req.Protocol),
+		slog.String("ip_address", req.IPAddress),
+	}
+	if req.Subject != nil {
+		attrs = append(attrs,
+			slog.String("subject_type", string(req.Subject.Type)),
+			slog.String("subject_uuid", req.Subject.UUID),
+			slog.String("user_uuid", req.Subject.UserUUID),
+			slog.String("tenant_uuid", req.Subject.TenantUUID),
+			slog.String("actor_uuid", req.Subject.ActorUUID),
+		)
+	}
+
+	s.logger.LogAttrs(ctx, level, "access decision", attrs...)
+
+	return decision
+}
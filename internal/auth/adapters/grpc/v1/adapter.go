@@ -3,6 +3,7 @@ package adapter
 import (
 	"context"
 	"strings"
+	"time"
 
 	"google.golang.org/grpc"
 	"google.golang.org/protobuf/types/known/timestamppb"
@@ -13,11 +14,33 @@ import (
 )
 
 var adapterPermissionMapping = map[string]string{
-	"/auth.v1.AuthService/ListUsers":     domain.RBACPermissionUsersList,
-	"/auth.v1.AuthService/GetUserByUUID": domain.RBACPermissionUsersReadOthers,
-	"/auth.v1.AuthService/CreateUser":    domain.RBACPermissionUsersCreate,
-	"/auth.v1.AuthService/UpdateUser":    domain.RBACPermissionUsersUpdate,
-	"/auth.v1.AuthService/DeleteUser":    domain.RBACPermissionUsersDelete,
+	"/auth.v1.AuthService/ListUsers":          domain.RBACPermissionUsersList,
+	"/auth.v1.AuthService/GetUserByUUID":      domain.AccessActionUsersRead,
+	"/auth.v1.AuthService/CreateUser":         domain.RBACPermissionUsersCreate,
+	"/auth.v1.AuthService/UpdateUser":         domain.RBACPermissionUsersUpdate,
+	"/auth.v1.AuthService/DeleteUser":         domain.RBACPermissionUsersDelete,
+	"/auth.v1.AuthService/UnlockUser":         domain.RBACPermissionUsersUnlock,
+	"/auth.v1.AuthService/SuspendUser":        domain.RBACPermissionUsersSuspend,
+	"/auth.v1.AuthService/ReinstateUser":      domain.RBACPermissionUsersSuspend,
+	"/auth.v1.AuthService/RestoreUser":        domain.RBACPermissionUsersDelete,
+	"/auth.v1.AuthService/ListUserHistory":    domain.AccessActionHistoryRead,
+	"/auth.v1.AuthService/ResetUserMFA":       domain.RBACPermissionMFAResetOthers,
+	"/auth.v1.AuthService/RevokeUserSessions": domain.RBACPermissionSessionsRevokeOthers,
+	"/auth.v1.AuthService/ListAPITokens":      domain.RBACPermissionAPITokensManageOthers,
+	"/auth.v1.AuthService/CreateAPIToken":     domain.RBACPermissionAPITokensManageOthers,
+	"/auth.v1.AuthService/RevokeAPIToken":     domain.RBACPermissionAPITokensManageOthers,
+
+	"/auth.v1.AuthService/ListRoles":                domain.RBACPermissionRolesList,
+	"/auth.v1.AuthService/CreateRole":               domain.RBACPermissionRolesCreate,
+	"/auth.v1.AuthService/DeleteRole":               domain.RBACPermissionRolesDelete,
+	"/auth.v1.AuthService/AttachPermissionToRole":   domain.RBACPermissionRolesUpdate,
+	"/auth.v1.AuthService/DetachPermissionFromRole": domain.RBACPermissionRolesUpdate,
+	"/auth.v1.AuthService/GrantRoleToUser":          domain.RBACPermissionRolesGrant,
+	"/auth.v1.AuthService/RevokeRoleFromUser":       domain.RBACPermissionRolesRevoke,
+
+	"/auth.v1.AuthService/ListOAuthClients":  domain.RBACPermissionOAuthClientsList,
+	"/auth.v1.AuthService/CreateOAuthClient": domain.RBACPermissionOAuthClientsCreate,
+	"/auth.v1.AuthService/DeleteOAuthClient": domain.RBACPermissionOAuthClientsDelete,
 }
 
 //go:generate mockgen -source $GOFILE -package mocks -destination mocks/mocks.go
@@ -26,8 +49,31 @@ type serviceAccessor interface {
 	CreateUser(ctx context.Context, data *domain.CreateUserData) (*models.User, error)
 	UpdateUser(ctx context.Context, uuid string, data *domain.UpdateUserData) error
 	DeleteUser(ctx context.Context, uuid string) error
-	ListUsers(ctx context.Context, limit, offset int) ([]*models.User, error)
+	UnlockUser(ctx context.Context, uuid string) error
+	SuspendUser(ctx context.Context, userUUID string, data *domain.SuspendUserData) error
+	ReinstateUser(ctx context.Context, userUUID string) error
+	RestoreUser(ctx context.Context, userUUID string) error
+	ResetUserMFA(ctx context.Context, userUUID string) error
+	ListUsers(ctx context.Context, query *domain.ListUsersQuery) ([]*models.User, string, error)
+	ListUserHistory(
+		ctx context.Context, userUUID string, filter *domain.UserHistoryFilter,
+	) ([]*models.UserHistoryRecord, error)
 	GetUserByUUID(ctx context.Context, uuid string) (*models.User, error)
+	RevokeUserSessions(ctx context.Context, userUUID string) error
+	ListAPITokens(ctx context.Context, userUUID string) ([]*models.Token, error)
+	CreateAPIToken(ctx context.Context, userUUID string, data *domain.CreateAPITokenData) (*models.Token, string, error)
+	RevokeAPIToken(ctx context.Context, userUUID string, tokenUUID string) error
+	ListRoles(ctx context.Context) ([]*models.Role, error)
+	CreateRole(ctx context.Context, data *domain.CreateRoleData) (*models.Role, error)
+	DeleteRole(ctx context.Context, name string) error
+	AttachPermissionToRole(ctx context.Context, name string, permission string) error
+	DetachPermissionFromRole(ctx context.Context, name string, permission string) error
+	GrantRoleToUser(ctx context.Context, userUUID string, name string, expiresAt *time.Time) error
+	RevokeRoleFromUser(ctx context.Context, userUUID string, name string) error
+
+	ListOAuthClients(ctx context.Context) ([]*models.OAuthClient, error)
+	CreateOAuthClient(ctx context.Context, data *domain.CreateOAuthClientData) (*models.OAuthClient, string, error)
+	DeleteOAuthClient(ctx context.Context, clientID string) error
 }
 
 type permissionRegistry interface {
@@ -58,16 +104,32 @@ func (a *Adapter) Register(grpcServer *grpc.Server) {
 }
 
 func (a *Adapter) ListUsers(ctx context.Context, req *pb.ListUsersRequest) (*pb.ListUsersResponse, error) {
-	limit := int(req.Limit)
-	if limit <= 0 {
-		limit = 10
+	query := &domain.ListUsersQuery{
+		EmailPrefix: req.EmailPrefix,
+		Status:      userStatusFromProto(req.Status),
+		Role:        req.Role,
+		SortDesc:    req.SortDesc,
+		Limit:       int(req.Limit),
+		Cursor:      req.Cursor,
+	}
+	switch req.SortBy {
+	case pb.UserSortField_USER_SORT_FIELD_CREATED_AT:
+		query.SortBy = domain.UserSortByCreatedAt
+	case pb.UserSortField_USER_SORT_FIELD_EMAIL:
+		query.SortBy = domain.UserSortByEmail
+	default:
+		query.SortBy = domain.UserSortByID
 	}
-	offset := int(req.Offset)
-	if offset < 0 {
-		offset = 0
+	if req.CreatedFrom != nil {
+		from := req.CreatedFrom.AsTime()
+		query.CreatedFrom = &from
+	}
+	if req.CreatedTo != nil {
+		to := req.CreatedTo.AsTime()
+		query.CreatedTo = &to
 	}
 
-	users, err := a.service.ListUsers(ctx, limit, offset)
+	users, nextCursor, err := a.service.ListUsers(ctx, query)
 	if err != nil {
 		return nil, a.processError(err)
 	}
@@ -78,7 +140,8 @@ func (a *Adapter) ListUsers(ctx context.Context, req *pb.ListUsersRequest) (*pb.
 	}
 
 	return &pb.ListUsersResponse{
-		Users: pbUsers,
+		Users:      pbUsers,
+		NextCursor: nextCursor,
 	}, nil
 }
 
@@ -138,6 +201,277 @@ func (a *Adapter) DeleteUser(ctx context.Context, req *pb.DeleteUserRequest) (*p
 	return &pb.DeleteUserResponse{}, nil
 }
 
+func (a *Adapter) UnlockUser(ctx context.Context, req *pb.UnlockUserRequest) (*pb.UnlockUserResponse, error) {
+	err := a.service.UnlockUser(ctx, req.Uuid)
+	if err != nil {
+		return nil, a.processError(err)
+	}
+	return &pb.UnlockUserResponse{}, nil
+}
+
+func (a *Adapter) SuspendUser(ctx context.Context, req *pb.SuspendUserRequest) (*pb.SuspendUserResponse, error) {
+	data := &domain.SuspendUserData{
+		Reason: strings.TrimSpace(req.Reason),
+	}
+	if req.Until != nil {
+		until := req.Until.AsTime()
+		data.Until = &until
+	}
+	err := a.service.SuspendUser(ctx, req.Uuid, data)
+	if err != nil {
+		return nil, a.processError(err)
+	}
+	return &pb.SuspendUserResponse{}, nil
+}
+
+func (a *Adapter) ReinstateUser(ctx context.Context, req *pb.ReinstateUserRequest) (*pb.ReinstateUserResponse, error) {
+	err := a.service.ReinstateUser(ctx, req.Uuid)
+	if err != nil {
+		return nil, a.processError(err)
+	}
+	return &pb.ReinstateUserResponse{}, nil
+}
+
+func (a *Adapter) RestoreUser(ctx context.Context, req *pb.RestoreUserRequest) (*pb.RestoreUserResponse, error) {
+	err := a.service.RestoreUser(ctx, req.Uuid)
+	if err != nil {
+		return nil, a.processError(err)
+	}
+	return &pb.RestoreUserResponse{}, nil
+}
+
+func (a *Adapter) ListUserHistory(
+	ctx context.Context, req *pb.ListUserHistoryRequest,
+) (*pb.ListUserHistoryResponse, error) {
+	filter := &domain.UserHistoryFilter{
+		EventTypes: req.EventTypes,
+		Limit:      int(req.Limit),
+		Offset:     int(req.Offset),
+	}
+	if req.From != nil {
+		from := req.From.AsTime()
+		filter.From = &from
+	}
+	if req.To != nil {
+		to := req.To.AsTime()
+		filter.To = &to
+	}
+
+	records, err := a.service.ListUserHistory(ctx, req.Uuid, filter)
+	if err != nil {
+		return nil, a.processError(err)
+	}
+
+	pbRecords := make([]*pb.UserHistoryRecord, 0, len(records))
+	for _, record := range records {
+		pbRecords = append(pbRecords, historyRecordToProto(record))
+	}
+
+	return &pb.ListUserHistoryResponse{
+		Records: pbRecords,
+	}, nil
+}
+
+func (a *Adapter) ResetUserMFA(ctx context.Context, req *pb.ResetUserMFARequest) (*pb.ResetUserMFAResponse, error) {
+	err := a.service.ResetUserMFA(ctx, req.Uuid)
+	if err != nil {
+		return nil, a.processError(err)
+	}
+	return &pb.ResetUserMFAResponse{}, nil
+}
+
+func (a *Adapter) RevokeUserSessions(
+	ctx context.Context, req *pb.RevokeUserSessionsRequest,
+) (*pb.RevokeUserSessionsResponse, error) {
+	err := a.service.RevokeUserSessions(ctx, req.Uuid)
+	if err != nil {
+		return nil, a.processError(err)
+	}
+	return &pb.RevokeUserSessionsResponse{}, nil
+}
+
+func (a *Adapter) ListAPITokens(
+	ctx context.Context, req *pb.ListAPITokensRequest,
+) (*pb.ListAPITokensResponse, error) {
+	tokens, err := a.service.ListAPITokens(ctx, req.UserUuid)
+	if err != nil {
+		return nil, a.processError(err)
+	}
+	pbTokens := make([]*pb.APIToken, 0, len(tokens))
+	for _, token := range tokens {
+		pbTokens = append(pbTokens, apiTokenToProto(token))
+	}
+	return &pb.ListAPITokensResponse{
+		Tokens: pbTokens,
+	}, nil
+}
+
+func (a *Adapter) CreateAPIToken(
+	ctx context.Context, req *pb.CreateAPITokenRequest,
+) (*pb.CreateAPITokenResponse, error) {
+	data := &domain.CreateAPITokenData{
+		Name:        strings.TrimSpace(req.Name),
+		Permissions: req.Permissions,
+	}
+	if req.ExpiresAt != nil {
+		expiresAt := req.ExpiresAt.AsTime()
+		data.ExpiresAt = &expiresAt
+	}
+
+	token, plainToken, err := a.service.CreateAPIToken(ctx, req.UserUuid, data)
+	if err != nil {
+		return nil, a.processError(err)
+	}
+
+	return &pb.CreateAPITokenResponse{
+		Token:      apiTokenToProto(token),
+		PlainToken: plainToken,
+	}, nil
+}
+
+func (a *Adapter) RevokeAPIToken(
+	ctx context.Context, req *pb.RevokeAPITokenRequest,
+) (*pb.RevokeAPITokenResponse, error) {
+	err := a.service.RevokeAPIToken(ctx, req.UserUuid, req.Uuid)
+	if err != nil {
+		return nil, a.processError(err)
+	}
+	return &pb.RevokeAPITokenResponse{}, nil
+}
+
+func (a *Adapter) ListRoles(ctx context.Context, _ *pb.ListRolesRequest) (*pb.ListRolesResponse, error) {
+	roles, err := a.service.ListRoles(ctx)
+	if err != nil {
+		return nil, a.processError(err)
+	}
+	pbRoles := make([]*pb.Role, 0, len(roles))
+	for _, role := range roles {
+		pbRoles = append(pbRoles, roleToProto(role))
+	}
+	return &pb.ListRolesResponse{
+		Roles: pbRoles,
+	}, nil
+}
+
+func (a *Adapter) CreateRole(ctx context.Context, req *pb.CreateRoleRequest) (*pb.CreateRoleResponse, error) {
+	data := &domain.CreateRoleData{
+		Name:        strings.TrimSpace(req.Name),
+		Description: strings.TrimSpace(req.Description),
+		Permissions: req.Permissions,
+	}
+
+	role, err := a.service.CreateRole(ctx, data)
+	if err != nil {
+		return nil, a.processError(err)
+	}
+
+	return &pb.CreateRoleResponse{
+		Role: roleToProto(role),
+	}, nil
+}
+
+func (a *Adapter) DeleteRole(ctx context.Context, req *pb.DeleteRoleRequest) (*pb.DeleteRoleResponse, error) {
+	err := a.service.DeleteRole(ctx, req.Name)
+	if err != nil {
+		return nil, a.processError(err)
+	}
+	return &pb.DeleteRoleResponse{}, nil
+}
+
+func (a *Adapter) AttachPermissionToRole(
+	ctx context.Context, req *pb.AttachPermissionToRoleRequest,
+) (*pb.AttachPermissionToRoleResponse, error) {
+	err := a.service.AttachPermissionToRole(ctx, req.Name, req.Permission)
+	if err != nil {
+		return nil, a.processError(err)
+	}
+	return &pb.AttachPermissionToRoleResponse{}, nil
+}
+
+func (a *Adapter) DetachPermissionFromRole(
+	ctx context.Context, req *pb.DetachPermissionFromRoleRequest,
+) (*pb.DetachPermissionFromRoleResponse, error) {
+	err := a.service.DetachPermissionFromRole(ctx, req.Name, req.Permission)
+	if err != nil {
+		return nil, a.processError(err)
+	}
+	return &pb.DetachPermissionFromRoleResponse{}, nil
+}
+
+func (a *Adapter) GrantRoleToUser(
+	ctx context.Context, req *pb.GrantRoleToUserRequest,
+) (*pb.GrantRoleToUserResponse, error) {
+	var expiresAt *time.Time
+	if req.ExpiresAt != nil {
+		t := req.ExpiresAt.AsTime()
+		expiresAt = &t
+	}
+	err := a.service.GrantRoleToUser(ctx, req.UserUuid, req.Role, expiresAt)
+	if err != nil {
+		return nil, a.processError(err)
+	}
+	return &pb.GrantRoleToUserResponse{}, nil
+}
+
+func (a *Adapter) RevokeRoleFromUser(
+	ctx context.Context, req *pb.RevokeRoleFromUserRequest,
+) (*pb.RevokeRoleFromUserResponse, error) {
+	err := a.service.RevokeRoleFromUser(ctx, req.UserUuid, req.Role)
+	if err != nil {
+		return nil, a.processError(err)
+	}
+	return &pb.RevokeRoleFromUserResponse{}, nil
+}
+
+func (a *Adapter) ListOAuthClients(
+	ctx context.Context, _ *pb.ListOAuthClientsRequest,
+) (*pb.ListOAuthClientsResponse, error) {
+	clients, err := a.service.ListOAuthClients(ctx)
+	if err != nil {
+		return nil, a.processError(err)
+	}
+	pbClients := make([]*pb.OAuthClient, 0, len(clients))
+	for _, client := range clients {
+		pbClients = append(pbClients, oauthClientToProto(client))
+	}
+	return &pb.ListOAuthClientsResponse{
+		Clients: pbClients,
+	}, nil
+}
+
+func (a *Adapter) CreateOAuthClient(
+	ctx context.Context, req *pb.CreateOAuthClientRequest,
+) (*pb.CreateOAuthClientResponse, error) {
+	data := &domain.CreateOAuthClientData{
+		OwnerUUID:    req.UserUuid,
+		Name:         strings.TrimSpace(req.Name),
+		RedirectURIs: req.RedirectUris,
+		GrantTypes:   req.GrantTypes,
+		Permissions:  req.Permissions,
+		Public:       req.Public,
+	}
+
+	client, secret, err := a.service.CreateOAuthClient(ctx, data)
+	if err != nil {
+		return nil, a.processError(err)
+	}
+
+	return &pb.CreateOAuthClientResponse{
+		Client:       oauthClientToProto(client),
+		ClientSecret: secret,
+	}, nil
+}
+
+func (a *Adapter) DeleteOAuthClient(
+	ctx context.Context, req *pb.DeleteOAuthClientRequest,
+) (*pb.DeleteOAuthClientResponse, error) {
+	err := a.service.DeleteOAuthClient(ctx, req.ClientId)
+	if err != nil {
+		return nil, a.processError(err)
+	}
+	return &pb.DeleteOAuthClientResponse{}, nil
+}
+
 func userToProto(user *models.User) *pb.User {
 	var status pb.UserStatus
 	switch user.Status {
@@ -145,16 +479,106 @@ func userToProto(user *models.User) *pb.User {
 		status = pb.UserStatus_USER_STATUS_ACTIVE
 	case domain.UserStatusInactive:
 		status = pb.UserStatus_USER_STATUS_INACTIVE
+	case domain.UserStatusPending:
+		status = pb.UserStatus_USER_STATUS_PENDING
+	case domain.UserStatusSuspended:
+		status = pb.UserStatus_USER_STATUS_SUSPENDED
+	case domain.UserStatusDeletionScheduled:
+		status = pb.UserStatus_USER_STATUS_DELETION_SCHEDULED
+	case domain.UserStatusDeleted:
+		status = pb.UserStatus_USER_STATUS_DELETED
 	default:
 		status = pb.UserStatus_USER_STATUS_UNSPECIFIED
 	}
-	return &pb.User{
-		Uuid:        user.UUID.String(),
-		Email:       user.Email,
-		Status:      status,
-		Roles:       user.RoleList(),
-		Permissions: user.PermissionList(),
-		IsSystem:    user.IsSystem,
-		CreatedAt:   timestamppb.New(user.CreatedAt),
+	pbUser := &pb.User{
+		Uuid:          user.UUID.String(),
+		Email:         user.Email,
+		Status:        status,
+		Roles:         user.RoleList(),
+		Permissions:   user.PermissionList(),
+		IsSystem:      user.IsSystem,
+		CreatedAt:     timestamppb.New(user.CreatedAt),
+		EmailVerified: user.IsEmailVerified(),
+	}
+	if user.StatusReason.Valid {
+		pbUser.StatusReason = &user.StatusReason.V
+	}
+	if user.StatusUntil.Valid {
+		pbUser.StatusUntil = timestamppb.New(user.StatusUntil.V)
+	}
+	return pbUser
+}
+
+// userStatusFromProto returns empty status for unspecified one.
+func userStatusFromProto(status pb.UserStatus) string {
+	switch status {
+	case pb.UserStatus_USER_STATUS_ACTIVE:
+		return domain.UserStatusActive
+	case pb.UserStatus_USER_STATUS_INACTIVE:
+		return domain.UserStatusInactive
+	case pb.UserStatus_USER_STATUS_PENDING:
+		return domain.UserStatusPending
+	case pb.UserStatus_USER_STATUS_SUSPENDED:
+		return domain.UserStatusSuspended
+	case pb.UserStatus_USER_STATUS_DELETION_SCHEDULED:
+		return domain.UserStatusDeletionScheduled
+	case pb.UserStatus_USER_STATUS_DELETED:
+		return domain.UserStatusDeleted
+	default:
+		return ""
+	}
+}
+
+func apiTokenToProto(token *models.Token) *pb.APIToken {
+	pbToken := &pb.APIToken{
+		Uuid:        token.UUID.String(),
+		Name:        token.Name,
+		Permissions: token.PermissionList(),
+		CreatedAt:   timestamppb.New(token.CreatedAt),
+	}
+	if token.LastUsedAt.Valid {
+		pbToken.LastUsedAt = timestamppb.New(token.LastUsedAt.V)
+	}
+	if token.ExpiresAt.Valid {
+		pbToken.ExpiresAt = timestamppb.New(token.ExpiresAt.V)
+	}
+	return pbToken
+}
+
+func historyRecordToProto(record *models.UserHistoryRecord) *pb.UserHistoryRecord {
+	pbRecord := &pb.UserHistoryRecord{
+		Id:         record.ID.String(),
+		EventType:  record.EventType,
+		OccurredAt: timestamppb.New(record.OccurredAt),
+	}
+	if len(record.Data) > 0 {
+		data := string(record.Data)
+		pbRecord.Data = &data
+	}
+	if record.Metadata != "" {
+		pbRecord.Metadata = &record.Metadata
+	}
+	return pbRecord
+}
+
+func roleToProto(role *models.Role) *pb.Role {
+	return &pb.Role{
+		Name:        role.Name,
+		Description: role.Description,
+		IsSystem:    role.IsSystem,
+		Permissions: role.PermissionList(),
+		CreatedAt:   timestamppb.New(role.CreatedAt),
+	}
+}
+
+func oauthClientToProto(client *models.OAuthClient) *pb.OAuthClient {
+	return &pb.OAuthClient{
+		ClientId:     client.ClientID,
+		Name:         client.Name,
+		Public:       client.IsPublic(),
+		RedirectUris: client.RedirectURIs,
+		GrantTypes:   client.GrantTypes,
+		Permissions:  client.Permissions,
+		CreatedAt:    timestamppb.New(client.CreatedAt),
 	}
 }
@@ -3,6 +3,7 @@ package adapter
 import (
 	"errors"
 
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
 
@@ -10,16 +11,58 @@ import (
 )
 
 func (a *Adapter) processError(err error) error {
+	var policyErr *domain.PasswordPolicyError
 	switch {
+	case errors.As(err, &policyErr):
+		return passwordPolicyStatus(policyErr)
 	case errors.Is(err, domain.ErrEntityNotFound):
 		return status.Error(codes.NotFound, "not found")
 	case errors.Is(err, domain.ErrUserAlreadyExists):
 		return status.Error(codes.AlreadyExists, "user already exists")
+	case errors.Is(err, domain.ErrRoleAlreadyExists):
+		return status.Error(codes.AlreadyExists, "role already exists")
+	case errors.Is(err, domain.ErrRoleIsBuiltIn):
+		return status.Error(codes.FailedPrecondition, "built-in role can not be deleted")
+	case errors.Is(err, domain.ErrMFAAlreadyEnabled):
+		return status.Error(codes.AlreadyExists, "mfa is already enabled")
+	case errors.Is(err, domain.ErrMFANotEnabled):
+		return status.Error(codes.FailedPrecondition, "mfa is not enabled")
+	case errors.Is(err, domain.ErrUserStatusConflict):
+		return status.Error(codes.FailedPrecondition, "operation is not allowed in current user status")
+	case errors.Is(err, domain.ErrEmailNotVerified):
+		return status.Error(codes.FailedPrecondition, "email is not verified")
+	case errors.Is(err, domain.ErrAccountLocked):
+		return status.Error(codes.ResourceExhausted, "account is temporarily locked")
 	case errors.Is(err, domain.ErrInvalidCredentials):
 		return status.Error(codes.InvalidArgument, "invalid credentials")
+	case errors.Is(err, domain.ErrInvalidMFACode):
+		return status.Error(codes.InvalidArgument, "invalid mfa code")
+	case errors.Is(err, domain.ErrInvalidCursor):
+		return status.Error(codes.InvalidArgument, "invalid cursor")
 	case errors.Is(err, domain.ErrInvalidToken):
 		return status.Error(codes.Unauthenticated, "invalid token")
+	case errors.Is(err, domain.ErrOAuthInvalidRequest), errors.Is(err, domain.ErrOAuthInvalidRedirectURI),
+		errors.Is(err, domain.ErrOAuthUnsupportedGrantType):
+		return status.Error(codes.InvalidArgument, err.Error())
+	case errors.Is(err, domain.ErrPermissionDenied):
+		return status.Error(codes.PermissionDenied, "permission denied")
 	default:
 		return status.Error(codes.Internal, "internal error")
 	}
 }
+
+// passwordPolicyStatus reports rejected password as field violation with reason.
+func passwordPolicyStatus(err *domain.PasswordPolicyError) error {
+	st := status.New(codes.InvalidArgument, err.Error())
+	detailed, detailsErr := st.WithDetails(&errdetails.BadRequest{
+		FieldViolations: []*errdetails.BadRequest_FieldViolation{{
+			Field:       "password",
+			Description: err.Detail,
+			Reason:      err.Reason,
+		}},
+	})
+	if detailsErr != nil {
+		return st.Err()
+	}
+	return detailed.Err()
+}
@@ -12,6 +12,7 @@ package mocks
 import (
 	context "context"
 	reflect "reflect"
+	time "time"
 
 	domain "github.com/hasansino/go42/internal/auth/domain"
 	models "github.com/hasansino/go42/internal/auth/models"
@@ -42,6 +43,67 @@ func (m *MockserviceAccessor) EXPECT() *MockserviceAccessorMockRecorder {
 	return m.recorder
 }
 
+// AttachPermissionToRole mocks base method.
+func (m *MockserviceAccessor) AttachPermissionToRole(ctx context.Context, name, permission string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AttachPermissionToRole", ctx, name, permission)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AttachPermissionToRole indicates an expected call of AttachPermissionToRole.
+func (mr *MockserviceAccessorMockRecorder) AttachPermissionToRole(ctx, name, permission any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AttachPermissionToRole", reflect.TypeOf((*MockserviceAccessor)(nil).AttachPermissionToRole), ctx, name, permission)
+}
+
+// CreateAPIToken mocks base method.
+func (m *MockserviceAccessor) CreateAPIToken(ctx context.Context, userUUID string, data *domain.CreateAPITokenData) (*models.Token, string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateAPIToken", ctx, userUUID, data)
+	ret0, _ := ret[0].(*models.Token)
+	ret1, _ := ret[1].(string)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// CreateAPIToken indicates an expected call of CreateAPIToken.
+func (mr *MockserviceAccessorMockRecorder) CreateAPIToken(ctx, userUUID, data any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateAPIToken", reflect.TypeOf((*MockserviceAccessor)(nil).CreateAPIToken), ctx, userUUID, data)
+}
+
+// CreateOAuthClient mocks base method.
+func (m *MockserviceAccessor) CreateOAuthClient(ctx context.Context, data *domain.CreateOAuthClientData) (*models.OAuthClient, string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateOAuthClient", ctx, data)
+	ret0, _ := ret[0].(*models.OAuthClient)
+	ret1, _ := ret[1].(string)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// CreateOAuthClient indicates an expected call of CreateOAuthClient.
+func (mr *MockserviceAccessorMockRecorder) CreateOAuthClient(ctx, data any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateOAuthClient", reflect.TypeOf((*MockserviceAccessor)(nil).CreateOAuthClient), ctx, data)
+}
+
+// CreateRole mocks base method.
+func (m *MockserviceAccessor) CreateRole(ctx context.Context, data *domain.CreateRoleData) (*models.Role, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateRole", ctx, data)
+	ret0, _ := ret[0].(*models.Role)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateRole indicates an expected call of CreateRole.
+func (mr *MockserviceAccessorMockRecorder) CreateRole(ctx, data any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateRole", reflect.TypeOf((*MockserviceAccessor)(nil).CreateRole), ctx, data)
+}
+
 // CreateUser mocks base method.
 func (m *MockserviceAccessor) CreateUser(ctx context.Context, data *domain.CreateUserData) (*models.User, error) {
 	m.ctrl.T.Helper()
@@ -57,6 +119,34 @@ func (mr *MockserviceAccessorMockRecorder) CreateUser(ctx, data any) *gomock.Cal
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateUser", reflect.TypeOf((*MockserviceAccessor)(nil).CreateUser), ctx, data)
 }
 
+// DeleteOAuthClient mocks base method.
+func (m *MockserviceAccessor) DeleteOAuthClient(ctx context.Context, clientID string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteOAuthClient", ctx, clientID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteOAuthClient indicates an expected call of DeleteOAuthClient.
+func (mr *MockserviceAccessorMockRecorder) DeleteOAuthClient(ctx, clientID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteOAuthClient", reflect.TypeOf((*MockserviceAccessor)(nil).DeleteOAuthClient), ctx, clientID)
+}
+
+// DeleteRole mocks base method.
+func (m *MockserviceAccessor) DeleteRole(ctx context.Context, name string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteRole", ctx, name)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteRole indicates an expected call of DeleteRole.
+func (mr *MockserviceAccessorMockRecorder) DeleteRole(ctx, name any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteRole", reflect.TypeOf((*MockserviceAccessor)(nil).DeleteRole), ctx, name)
+}
+
 // DeleteUser mocks base method.
 func (m *MockserviceAccessor) DeleteUser(ctx context.Context, uuid string) error {
 	m.ctrl.T.Helper()
@@ -71,6 +161,20 @@ func (mr *MockserviceAccessorMockRecorder) DeleteUser(ctx, uuid any) *gomock.Cal
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteUser", reflect.TypeOf((*MockserviceAccessor)(nil).DeleteUser), ctx, uuid)
 }
 
+// DetachPermissionFromRole mocks base method.
+func (m *MockserviceAccessor) DetachPermissionFromRole(ctx context.Context, name, permission string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DetachPermissionFromRole", ctx, name, permission)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DetachPermissionFromRole indicates an expected call of DetachPermissionFromRole.
+func (mr *MockserviceAccessorMockRecorder) DetachPermissionFromRole(ctx, name, permission any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DetachPermissionFromRole", reflect.TypeOf((*MockserviceAccessor)(nil).DetachPermissionFromRole), ctx, name, permission)
+}
+
 // GetUserByUUID mocks base method.
 func (m *MockserviceAccessor) GetUserByUUID(ctx context.Context, uuid string) (*models.User, error) {
 	m.ctrl.T.Helper()
@@ -86,19 +190,206 @@ func (mr *MockserviceAccessorMockRecorder) GetUserByUUID(ctx, uuid any) *gomock.
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserByUUID", reflect.TypeOf((*MockserviceAccessor)(nil).GetUserByUUID), ctx, uuid)
 }
 
-// ListUsers mocks base method.
-func (m *MockserviceAccessor) ListUsers(ctx context.Context, limit, offset int) ([]*models.User, error) {
+// GrantRoleToUser mocks base method.
+func (m *MockserviceAccessor) GrantRoleToUser(ctx context.Context, userUUID, name string, expiresAt *time.Time) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "ListUsers", ctx, limit, offset)
-	ret0, _ := ret[0].([]*models.User)
+	ret := m.ctrl.Call(m, "GrantRoleToUser", ctx, userUUID, name, expiresAt)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// GrantRoleToUser indicates an expected call of GrantRoleToUser.
+func (mr *MockserviceAccessorMockRecorder) GrantRoleToUser(ctx, userUUID, name, expiresAt any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GrantRoleToUser", reflect.TypeOf((*MockserviceAccessor)(nil).GrantRoleToUser), ctx, userUUID, name, expiresAt)
+}
+
+// ListAPITokens mocks base method.
+func (m *MockserviceAccessor) ListAPITokens(ctx context.Context, userUUID string) ([]*models.Token, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListAPITokens", ctx, userUUID)
+	ret0, _ := ret[0].([]*models.Token)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListAPITokens indicates an expected call of ListAPITokens.
+func (mr *MockserviceAccessorMockRecorder) ListAPITokens(ctx, userUUID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListAPITokens", reflect.TypeOf((*MockserviceAccessor)(nil).ListAPITokens), ctx, userUUID)
+}
+
+// ListOAuthClients mocks base method.
+func (m *MockserviceAccessor) ListOAuthClients(ctx context.Context) ([]*models.OAuthClient, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListOAuthClients", ctx)
+	ret0, _ := ret[0].([]*models.OAuthClient)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListOAuthClients indicates an expected call of ListOAuthClients.
+func (mr *MockserviceAccessorMockRecorder) ListOAuthClients(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListOAuthClients", reflect.TypeOf((*MockserviceAccessor)(nil).ListOAuthClients), ctx)
+}
+
+// ListRoles mocks base method.
+func (m *MockserviceAccessor) ListRoles(ctx context.Context) ([]*models.Role, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListRoles", ctx)
+	ret0, _ := ret[0].([]*models.Role)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListRoles indicates an expected call of ListRoles.
+func (mr *MockserviceAccessorMockRecorder) ListRoles(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListRoles", reflect.TypeOf((*MockserviceAccessor)(nil).ListRoles), ctx)
+}
+
+// ListUserHistory mocks base method.
+func (m *MockserviceAccessor) ListUserHistory(ctx context.Context, userUUID string, filter *domain.UserHistoryFilter) ([]*models.UserHistoryRecord, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListUserHistory", ctx, userUUID, filter)
+	ret0, _ := ret[0].([]*models.UserHistoryRecord)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
+// ListUserHistory indicates an expected call of ListUserHistory.
+func (mr *MockserviceAccessorMockRecorder) ListUserHistory(ctx, userUUID, filter any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListUserHistory", reflect.TypeOf((*MockserviceAccessor)(nil).ListUserHistory), ctx, userUUID, filter)
+}
+
+// ListUsers mocks base method.
+func (m *MockserviceAccessor) ListUsers(ctx context.Context, query *domain.ListUsersQuery) ([]*models.User, string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListUsers", ctx, query)
+	ret0, _ := ret[0].([]*models.User)
+	ret1, _ := ret[1].(string)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
 // ListUsers indicates an expected call of ListUsers.
-func (mr *MockserviceAccessorMockRecorder) ListUsers(ctx, limit, offset any) *gomock.Call {
+func (mr *MockserviceAccessorMockRecorder) ListUsers(ctx, query any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListUsers", reflect.TypeOf((*MockserviceAccessor)(nil).ListUsers), ctx, query)
+}
+
+// ReinstateUser mocks base method.
+func (m *MockserviceAccessor) ReinstateUser(ctx context.Context, userUUID string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReinstateUser", ctx, userUUID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ReinstateUser indicates an expected call of ReinstateUser.
+func (mr *MockserviceAccessorMockRecorder) ReinstateUser(ctx, userUUID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReinstateUser", reflect.TypeOf((*MockserviceAccessor)(nil).ReinstateUser), ctx, userUUID)
+}
+
+// ResetUserMFA mocks base method.
+func (m *MockserviceAccessor) ResetUserMFA(ctx context.Context, userUUID string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ResetUserMFA", ctx, userUUID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ResetUserMFA indicates an expected call of ResetUserMFA.
+func (mr *MockserviceAccessorMockRecorder) ResetUserMFA(ctx, userUUID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ResetUserMFA", reflect.TypeOf((*MockserviceAccessor)(nil).ResetUserMFA), ctx, userUUID)
+}
+
+// RestoreUser mocks base method.
+func (m *MockserviceAccessor) RestoreUser(ctx context.Context, userUUID string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RestoreUser", ctx, userUUID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RestoreUser indicates an expected call of RestoreUser.
+func (mr *MockserviceAccessorMockRecorder) RestoreUser(ctx, userUUID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RestoreUser", reflect.TypeOf((*MockserviceAccessor)(nil).RestoreUser), ctx, userUUID)
+}
+
+// RevokeAPIToken mocks base method.
+func (m *MockserviceAccessor) RevokeAPIToken(ctx context.Context, userUUID, tokenUUID string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RevokeAPIToken", ctx, userUUID, tokenUUID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RevokeAPIToken indicates an expected call of RevokeAPIToken.
+func (mr *MockserviceAccessorMockRecorder) RevokeAPIToken(ctx, userUUID, tokenUUID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RevokeAPIToken", reflect.TypeOf((*MockserviceAccessor)(nil).RevokeAPIToken), ctx, userUUID, tokenUUID)
+}
+
+// RevokeRoleFromUser mocks base method.
+func (m *MockserviceAccessor) RevokeRoleFromUser(ctx context.Context, userUUID, name string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RevokeRoleFromUser", ctx, userUUID, name)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RevokeRoleFromUser indicates an expected call of RevokeRoleFromUser.
+func (mr *MockserviceAccessorMockRecorder) RevokeRoleFromUser(ctx, userUUID, name any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RevokeRoleFromUser", reflect.TypeOf((*MockserviceAccessor)(nil).RevokeRoleFromUser), ctx, userUUID, name)
+}
+
+// RevokeUserSessions mocks base method.
+func (m *MockserviceAccessor) RevokeUserSessions(ctx context.Context, userUUID string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RevokeUserSessions", ctx, userUUID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RevokeUserSessions indicates an expected call of RevokeUserSessions.
+func (mr *MockserviceAccessorMockRecorder) RevokeUserSessions(ctx, userUUID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RevokeUserSessions", reflect.TypeOf((*MockserviceAccessor)(nil).RevokeUserSessions), ctx, userUUID)
+}
+
+// SuspendUser mocks base method.
+func (m *MockserviceAccessor) SuspendUser(ctx context.Context, userUUID string, data *domain.SuspendUserData) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SuspendUser", ctx, userUUID, data)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SuspendUser indicates an expected call of SuspendUser.
+func (mr *MockserviceAccessorMockRecorder) SuspendUser(ctx, userUUID, data any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SuspendUser", reflect.TypeOf((*MockserviceAccessor)(nil).SuspendUser), ctx, userUUID, data)
+}
+
+// UnlockUser mocks base method.
+func (m *MockserviceAccessor) UnlockUser(ctx context.Context, uuid string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UnlockUser", ctx, uuid)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UnlockUser indicates an expected call of UnlockUser.
+func (mr *MockserviceAccessorMockRecorder) UnlockUser(ctx, uuid any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListUsers", reflect.TypeOf((*MockserviceAccessor)(nil).ListUsers), ctx, limit, offset)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UnlockUser", reflect.TypeOf((*MockserviceAccessor)(nil).UnlockUser), ctx, uuid)
 }
 
 // UpdateUser mocks base method.
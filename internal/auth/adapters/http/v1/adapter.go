@@ -2,7 +2,10 @@ package adapter
 
 import (
 	"context"
+	"fmt"
 	"net/http"
+	"net/url"
+	"path"
 	"strconv"
 	"strings"
 	"time"
@@ -23,20 +26,93 @@ import (
 
 type serviceAccessor interface {
 	SignUp(ctx context.Context, email string, password string) (*models.User, error)
-	Login(ctx context.Context, email string, password string) (*domain.Tokens, error)
+	Login(
+		ctx context.Context, email string, password string, client domain.ClientInfo,
+	) (*domain.Tokens, *domain.MFAChallenge, error)
+	VerifyMFA(ctx context.Context, challengeToken string, code string, client domain.ClientInfo) (*domain.Tokens, error)
+	VerifyEmail(ctx context.Context, token string) error
+	RequestEmailVerification(ctx context.Context, email string) error
+	RequestPasswordReset(ctx context.Context, email string) error
+	ResetPassword(ctx context.Context, token string, password string) error
 	Refresh(ctx context.Context, token string) (*domain.Tokens, error)
+	SwitchTenant(ctx context.Context, token string, organizationUUID string) (*domain.Tokens, error)
 	Logout(ctx context.Context, accessToken, refreshToken string) error
 
+	SSOProviders() []string
+	StartSSO(ctx context.Context, provider string) (*domain.SSOAuthorization, error)
+	CompleteSSO(
+		ctx context.Context, provider string, state string, code string, verifier string, client domain.ClientInfo,
+	) (*domain.Tokens, *domain.MFAChallenge, error)
+
 	CreateUser(ctx context.Context, data *domain.CreateUserData) (*models.User, error)
 	UpdateUser(ctx context.Context, uuid string, data *domain.UpdateUserData) error
 	DeleteUser(ctx context.Context, uuid string) error
-	ListUsers(ctx context.Context, limit, offset int) ([]*models.User, error)
+	UnlockUser(ctx context.Context, uuid string) error
+	SuspendUser(ctx context.Context, userUUID string, data *domain.SuspendUserData) error
+	ReinstateUser(ctx context.Context, userUUID string) error
+	RestoreUser(ctx context.Context, userUUID string) error
+	Impersonate(ctx context.Context, userUUID string) (*domain.ImpersonationToken, error)
+	CreateDataRequest(ctx context.Context, userUUID string, requestType string) (*models.DataRequest, error)
+	GetDataRequest(ctx context.Context, userUUID string, requestUUID string) (*models.DataRequest, error)
+	GetDataRequestArchive(ctx context.Context, userUUID string, requestUUID string) ([]byte, error)
+	ListUsers(ctx context.Context, query *domain.ListUsersQuery) ([]*models.User, string, error)
+	ListUserHistory(
+		ctx context.Context, userUUID string, filter *domain.UserHistoryFilter,
+	) ([]*models.UserHistoryRecord, error)
 	GetUserByID(ctx context.Context, id int) (*models.User, error)
 	GetUserByUUID(ctx context.Context, uuid string) (*models.User, error)
 
+	ListUserSessions(ctx context.Context, userUUID string) ([]*models.Session, error)
+	RevokeUserSession(ctx context.Context, userUUID string, sessionUUID string) error
+	RevokeUserSessions(ctx context.Context, userUUID string) error
+
+	ListAPITokens(ctx context.Context, userUUID string) ([]*models.Token, error)
+	CreateAPIToken(ctx context.Context, userUUID string, data *domain.CreateAPITokenData) (*models.Token, string, error)
+	RevokeAPIToken(ctx context.Context, userUUID string, tokenUUID string) error
+
+	GetMFAStatus(ctx context.Context, userUUID string) (*domain.MFAStatus, error)
+	EnrollMFA(ctx context.Context, userUUID string) (*domain.MFAEnrollment, error)
+	ConfirmMFA(ctx context.Context, userUUID string, code string) ([]string, error)
+	DisableMFA(ctx context.Context, userUUID string, code string) error
+	RegenerateMFARecoveryCodes(ctx context.Context, userUUID string, code string) ([]string, error)
+	ResetUserMFA(ctx context.Context, userUUID string) error
+
+	ListRoles(ctx context.Context) ([]*models.Role, error)
+	CreateRole(ctx context.Context, data *domain.CreateRoleData) (*models.Role, error)
+	DeleteRole(ctx context.Context, name string) error
+	AttachPermissionToRole(ctx context.Context, name string, permission string) error
+	DetachPermissionFromRole(ctx context.Context, name string, permission string) error
+	GrantRoleToUser(ctx context.Context, userUUID string, name string, expiresAt *time.Time) error
+	RevokeRoleFromUser(ctx context.Context, userUUID string, name string) error
+
+	ListOrganizations(ctx context.Context) ([]*models.Organization, error)
+	ListUserOrganizations(ctx context.Context, userUUID string) ([]*models.Organization, error)
+	CreateOrganization(ctx context.Context, data *domain.CreateOrganizationData) (*models.Organization, error)
+	DeleteOrganization(ctx context.Context, organizationUUID string) error
+	ListOrganizationMembers(ctx context.Context, organizationUUID string) ([]*models.OrganizationMember, error)
+	AddOrganizationMember(ctx context.Context, organizationUUID string, userUUID string) error
+	RemoveOrganizationMember(ctx context.Context, organizationUUID string, userUUID string) error
+	GrantOrganizationRole(
+		ctx context.Context, organizationUUID string, userUUID string, name string, expiresAt *time.Time,
+	) error
+	RevokeOrganizationRole(ctx context.Context, organizationUUID string, userUUID string, name string) error
+
+	ListOAuthClients(ctx context.Context) ([]*models.OAuthClient, error)
+	CreateOAuthClient(ctx context.Context, data *domain.CreateOAuthClientData) (*models.OAuthClient, string, error)
+	DeleteOAuthClient(ctx context.Context, clientID string) error
+	AuthorizationPageURL(ctx context.Context, req *domain.AuthorizeRequest, params url.Values) (string, error)
+	Authorize(ctx context.Context, userUUID string, req *domain.AuthorizeRequest) (string, error)
+	ExchangeOAuthToken(
+		ctx context.Context, req *domain.OAuthTokenRequest, client domain.ClientInfo,
+	) (*domain.OAuthTokens, error)
+
 	ValidateJWTToken(ctx context.Context, token string) (*domain.JWTClaims, error)
+	GetOrganizationMember(ctx context.Context, organizationUUID string, userID int) (*models.OrganizationMember, error)
+	RecordImpersonatedRequest(ctx context.Context, operation string, ipAddress string) error
 	InvalidateJWTToken(ctx context.Context, token string, until time.Time) error
 	ValidateAPIToken(ctx context.Context, token string) (*models.Token, error)
+
+	CheckAccess(ctx context.Context, req *domain.AccessRequest) *domain.AccessDecision
 }
 
 type cacheAccessor interface {
@@ -72,25 +148,144 @@ func (a *Adapter) Register(g *echo.Group) {
 	authGroup.POST("/signup", a.signup)
 	authGroup.POST("/login", a.login)
 	authGroup.POST("/refresh", a.refresh)
+	authGroup.POST("/tenant", a.switchTenant)
 	authGroup.POST("/logout", a.logout)
+	authGroup.POST("/mfa/verify", a.verifyMFA)
+	authGroup.POST("/verify", a.verifyEmail)
+	authGroup.POST("/verify/resend", a.resendEmailVerification)
+	authGroup.POST("/password-reset", a.requestPasswordReset)
+	authGroup.POST("/password-reset/confirm", a.confirmPasswordReset)
+	authGroup.GET("/sso", a.listSSOProviders)
+	authGroup.GET("/sso/:provider/start", a.startSSO)
+	authGroup.GET("/sso/:provider/callback", a.completeSSO)
+
+	// operations which must be performed by the user personally
+	noImpersonation := authMiddleware.NewNoImpersonationMiddleware()
 
 	userGroup := g.Group("/users", authMiddleware.NewAuthMiddleware(a.service))
 
 	userGroup.GET("/me", a.readSelf,
-		authMiddleware.NewAccessMiddleware(domain.RBACPermissionUsersReadSelf), cacheMiddleware)
+		authMiddleware.NewSelfAccessMiddleware(a.service, domain.AccessActionUsersRead), cacheMiddleware)
 	userGroup.PUT("/me", a.updateSelf,
-		authMiddleware.NewAccessMiddleware(domain.RBACPermissionUsersUpdateSelf))
+		noImpersonation, authMiddleware.NewAccessMiddleware(a.service, domain.RBACPermissionUsersUpdateSelf))
+	userGroup.GET("/me/sessions", a.listSelfSessions,
+		noImpersonation, authMiddleware.NewAccessMiddleware(a.service, domain.RBACPermissionSessionsReadSelf))
+	userGroup.DELETE("/me/sessions/:id", a.revokeSelfSession,
+		noImpersonation, authMiddleware.NewAccessMiddleware(a.service, domain.RBACPermissionSessionsRevokeSelf))
+	userGroup.GET("/me/tokens", a.listSelfAPITokens,
+		noImpersonation, authMiddleware.NewAccessMiddleware(a.service, domain.RBACPermissionAPITokensReadSelf))
+	userGroup.POST("/me/tokens", a.createSelfAPIToken,
+		noImpersonation, authMiddleware.NewAccessMiddleware(a.service, domain.RBACPermissionAPITokensCreateSelf))
+	userGroup.DELETE("/me/tokens/:id", a.revokeSelfAPIToken,
+		noImpersonation, authMiddleware.NewAccessMiddleware(a.service, domain.RBACPermissionAPITokensRevokeSelf))
+	userGroup.GET("/me/mfa", a.readSelfMFA,
+		noImpersonation, authMiddleware.NewAccessMiddleware(a.service, domain.RBACPermissionMFAManageSelf))
+	userGroup.POST("/me/mfa", a.enrollSelfMFA,
+		noImpersonation, authMiddleware.NewAccessMiddleware(a.service, domain.RBACPermissionMFAManageSelf))
+	userGroup.POST("/me/mfa/confirm", a.confirmSelfMFA,
+		noImpersonation, authMiddleware.NewAccessMiddleware(a.service, domain.RBACPermissionMFAManageSelf))
+	userGroup.POST("/me/mfa/disable", a.disableSelfMFA,
+		noImpersonation, authMiddleware.NewAccessMiddleware(a.service, domain.RBACPermissionMFAManageSelf))
+	userGroup.POST("/me/mfa/recovery-codes", a.regenerateSelfMFARecoveryCodes,
+		noImpersonation, authMiddleware.NewAccessMiddleware(a.service, domain.RBACPermissionMFAManageSelf))
+	userGroup.GET("/me/organizations", a.listSelfOrganizations,
+		authMiddleware.NewAccessMiddleware(a.service, domain.RBACPermissionUsersReadSelf))
+	userGroup.GET("/me/history", a.listSelfHistory,
+		authMiddleware.NewSelfAccessMiddleware(a.service, domain.AccessActionHistoryRead))
+	userGroup.POST("/me/data-requests", a.createSelfDataRequest,
+		noImpersonation, authMiddleware.NewAccessMiddleware(a.service, domain.RBACPermissionUsersReadSelf))
+	userGroup.GET("/me/data-requests/:id", a.readSelfDataRequest,
+		noImpersonation, authMiddleware.NewAccessMiddleware(a.service, domain.RBACPermissionUsersReadSelf))
+	userGroup.GET("/me/data-requests/:id/archive", a.downloadSelfDataArchive,
+		noImpersonation, authMiddleware.NewAccessMiddleware(a.service, domain.RBACPermissionUsersReadSelf))
 
 	userGroup.GET("", a.listUsers,
-		authMiddleware.NewAccessMiddleware(domain.RBACPermissionUsersList))
+		authMiddleware.NewAccessMiddleware(a.service, domain.RBACPermissionUsersList))
 	userGroup.GET("/:uuid", a.userByUUID,
-		authMiddleware.NewAccessMiddleware(domain.RBACPermissionUsersReadOthers))
+		authMiddleware.NewAccessMiddleware(a.service, domain.AccessActionUsersRead))
 	userGroup.POST("", a.createUser,
-		authMiddleware.NewAccessMiddleware(domain.RBACPermissionUsersCreate))
+		authMiddleware.NewAccessMiddleware(a.service, domain.RBACPermissionUsersCreate))
 	userGroup.PUT("/:uuid", a.updateUser,
-		authMiddleware.NewAccessMiddleware(domain.RBACPermissionUsersUpdate))
+		authMiddleware.NewAccessMiddleware(a.service, domain.RBACPermissionUsersUpdate))
 	userGroup.DELETE("/:uuid", a.deleteUser,
-		authMiddleware.NewAccessMiddleware(domain.RBACPermissionUsersDelete))
+		authMiddleware.NewAccessMiddleware(a.service, domain.RBACPermissionUsersDelete))
+	userGroup.POST("/:uuid/unlock", a.unlockUser,
+		authMiddleware.NewAccessMiddleware(a.service, domain.RBACPermissionUsersUnlock))
+	userGroup.POST("/:uuid/impersonate", a.impersonateUser,
+		authMiddleware.NewAccessMiddleware(a.service, domain.RBACPermissionUsersImpersonate))
+	userGroup.POST("/:uuid/suspend", a.suspendUser,
+		authMiddleware.NewAccessMiddleware(a.service, domain.RBACPermissionUsersSuspend))
+	userGroup.DELETE("/:uuid/suspend", a.reinstateUser,
+		authMiddleware.NewAccessMiddleware(a.service, domain.RBACPermissionUsersSuspend))
+	userGroup.POST("/:uuid/restore", a.restoreUser,
+		authMiddleware.NewAccessMiddleware(a.service, domain.RBACPermissionUsersDelete))
+	userGroup.GET("/:uuid/history", a.listUserHistory,
+		authMiddleware.NewAccessMiddleware(a.service, domain.AccessActionHistoryRead))
+	userGroup.POST("/:uuid/data-requests", a.createUserDataRequest,
+		authMiddleware.NewAccessMiddleware(a.service, domain.RBACPermissionUsersDataRequest))
+	userGroup.GET("/:uuid/data-requests/:id", a.readUserDataRequest,
+		authMiddleware.NewAccessMiddleware(a.service, domain.RBACPermissionUsersDataRequest))
+	userGroup.GET("/:uuid/data-requests/:id/archive", a.downloadUserDataArchive,
+		authMiddleware.NewAccessMiddleware(a.service, domain.RBACPermissionUsersDataRequest))
+	userGroup.DELETE("/:uuid/mfa", a.resetUserMFA,
+		authMiddleware.NewAccessMiddleware(a.service, domain.RBACPermissionMFAResetOthers))
+	userGroup.DELETE("/:uuid/sessions", a.revokeUserSessions,
+		authMiddleware.NewAccessMiddleware(a.service, domain.RBACPermissionSessionsRevokeOthers))
+	userGroup.POST("/:uuid/roles", a.grantUserRole,
+		authMiddleware.NewAccessMiddleware(a.service, domain.RBACPermissionRolesGrant))
+	userGroup.DELETE("/:uuid/roles/:role", a.revokeUserRole,
+		authMiddleware.NewAccessMiddleware(a.service, domain.RBACPermissionRolesRevoke))
+
+	roleGroup := g.Group("/roles", authMiddleware.NewAuthMiddleware(a.service))
+
+	roleGroup.GET("", a.listRoles,
+		authMiddleware.NewAccessMiddleware(a.service, domain.RBACPermissionRolesList))
+	roleGroup.POST("", a.createRole,
+		authMiddleware.NewAccessMiddleware(a.service, domain.RBACPermissionRolesCreate))
+	roleGroup.DELETE("/:name", a.deleteRole,
+		authMiddleware.NewAccessMiddleware(a.service, domain.RBACPermissionRolesDelete))
+	roleGroup.POST("/:name/permissions", a.attachRolePermission,
+		authMiddleware.NewAccessMiddleware(a.service, domain.RBACPermissionRolesUpdate))
+	roleGroup.DELETE("/:name/permissions/:permission", a.detachRolePermission,
+		authMiddleware.NewAccessMiddleware(a.service, domain.RBACPermissionRolesUpdate))
+
+	organizationGroup := g.Group("/organizations", authMiddleware.NewAuthMiddleware(a.service))
+
+	organizationGroup.GET("", a.listOrganizations,
+		authMiddleware.NewAccessMiddleware(a.service, domain.RBACPermissionOrganizationsList))
+	organizationGroup.POST("", a.createOrganization,
+		authMiddleware.NewAccessMiddleware(a.service, domain.RBACPermissionOrganizationsCreate))
+	organizationGroup.DELETE("/:uuid", a.deleteOrganization,
+		authMiddleware.NewAccessMiddleware(a.service, domain.RBACPermissionOrganizationsDelete))
+	organizationGroup.GET("/:uuid/members", a.listOrganizationMembers,
+		authMiddleware.NewAccessMiddleware(a.service, domain.RBACPermissionOrganizationsManageMembers))
+	organizationGroup.PUT("/:uuid/members/:user_uuid", a.addOrganizationMember,
+		authMiddleware.NewAccessMiddleware(a.service, domain.RBACPermissionOrganizationsManageMembers))
+	organizationGroup.DELETE("/:uuid/members/:user_uuid", a.removeOrganizationMember,
+		authMiddleware.NewAccessMiddleware(a.service, domain.RBACPermissionOrganizationsManageMembers))
+	organizationGroup.POST("/:uuid/members/:user_uuid/roles", a.grantOrganizationRole,
+		authMiddleware.NewAccessMiddleware(a.service, domain.RBACPermissionRolesGrant))
+	organizationGroup.DELETE("/:uuid/members/:user_uuid/roles/:role", a.revokeOrganizationRole,
+		authMiddleware.NewAccessMiddleware(a.service, domain.RBACPermissionRolesRevoke))
+
+	oauthGroup := g.Group("/oauth")
+
+	oauthGroup.GET("/authorize", a.authorizationPage)
+	oauthGroup.POST("/authorize", a.authorize, authMiddleware.NewAuthMiddleware(a.service), noImpersonation)
+	oauthGroup.POST("/token", a.oauthToken)
+	oauthGroup.GET("/userinfo", a.userInfo, authMiddleware.NewAuthMiddleware(a.service),
+		authMiddleware.NewSelfAccessMiddleware(a.service, domain.AccessActionUsersRead))
+	oauthGroup.POST("/userinfo", a.userInfo, authMiddleware.NewAuthMiddleware(a.service),
+		authMiddleware.NewSelfAccessMiddleware(a.service, domain.AccessActionUsersRead))
+
+	oauthClientGroup := oauthGroup.Group("/clients", authMiddleware.NewAuthMiddleware(a.service))
+
+	oauthClientGroup.GET("", a.listOAuthClients,
+		authMiddleware.NewAccessMiddleware(a.service, domain.RBACPermissionOAuthClientsList))
+	oauthClientGroup.POST("", a.createOAuthClient,
+		authMiddleware.NewAccessMiddleware(a.service, domain.RBACPermissionOAuthClientsCreate))
+	oauthClientGroup.DELETE("/:client_id", a.deleteOAuthClient,
+		authMiddleware.NewAccessMiddleware(a.service, domain.RBACPermissionOAuthClientsDelete))
 }
 
 type SignupRequest struct {
@@ -144,7 +339,51 @@ func (a *Adapter) login(ctx echo.Context) error {
 		)
 	}
 
-	tokens, err := a.service.Login(ctx.Request().Context(), req.Email, req.Password)
+	client := domain.ClientInfo{
+		IPAddress: ctx.RealIP(),
+		UserAgent: ctx.Request().UserAgent(),
+	}
+
+	tokens, challenge, err := a.service.Login(ctx.Request().Context(), req.Email, req.Password, client)
+	if err != nil {
+		return a.processError(ctx, err)
+	}
+
+	// second factor is required to complete login
+	if challenge != nil {
+		return ctx.JSON(http.StatusAccepted, challenge)
+	}
+
+	return ctx.JSON(http.StatusOK, tokens)
+}
+
+type VerifyMFARequest struct {
+	ChallengeToken string `json:"challenge_token" v:"required"`
+	Code           string `json:"code"            v:"required,max=32"`
+}
+
+func (a *Adapter) verifyMFA(ctx echo.Context) error {
+	req := new(VerifyMFARequest)
+
+	if err := ctx.Bind(req); err != nil {
+		return httpAPI.SendJSONError(ctx,
+			http.StatusBadRequest, http.StatusText(http.StatusBadRequest))
+	}
+
+	vErrs := tools.ValidateStruct(req)
+	if vErrs != nil {
+		return httpAPI.SendJSONError(
+			ctx, http.StatusBadRequest, http.StatusText(http.StatusBadRequest),
+			httpAPI.WithValidationErrors(vErrs...),
+		)
+	}
+
+	client := domain.ClientInfo{
+		IPAddress: ctx.RealIP(),
+		UserAgent: ctx.Request().UserAgent(),
+	}
+
+	tokens, err := a.service.VerifyMFA(ctx.Request().Context(), req.ChallengeToken, req.Code, client)
 	if err != nil {
 		return a.processError(ctx, err)
 	}
@@ -152,6 +391,113 @@ func (a *Adapter) login(ctx echo.Context) error {
 	return ctx.JSON(http.StatusOK, tokens)
 }
 
+type VerifyEmailRequest struct {
+	Token string `json:"token" v:"required"`
+}
+
+func (a *Adapter) verifyEmail(ctx echo.Context) error {
+	req := new(VerifyEmailRequest)
+
+	if err := ctx.Bind(req); err != nil {
+		return httpAPI.SendJSONError(ctx,
+			http.StatusBadRequest, http.StatusText(http.StatusBadRequest))
+	}
+
+	vErrs := tools.ValidateStruct(req)
+	if vErrs != nil {
+		return httpAPI.SendJSONError(
+			ctx, http.StatusBadRequest, http.StatusText(http.StatusBadRequest),
+			httpAPI.WithValidationErrors(vErrs...),
+		)
+	}
+
+	if err := a.service.VerifyEmail(ctx.Request().Context(), req.Token); err != nil {
+		return a.processError(ctx, err)
+	}
+
+	return ctx.NoContent(http.StatusOK)
+}
+
+type EmailRequest struct {
+	Email string `json:"email" v:"required,email"`
+}
+
+// resendEmailVerification always responds with 202, regardless of whether email is registered.
+func (a *Adapter) resendEmailVerification(ctx echo.Context) error {
+	req := new(EmailRequest)
+
+	if err := ctx.Bind(req); err != nil {
+		return httpAPI.SendJSONError(ctx,
+			http.StatusBadRequest, http.StatusText(http.StatusBadRequest))
+	}
+
+	vErrs := tools.ValidateStruct(req)
+	if vErrs != nil {
+		return httpAPI.SendJSONError(
+			ctx, http.StatusBadRequest, http.StatusText(http.StatusBadRequest),
+			httpAPI.WithValidationErrors(vErrs...),
+		)
+	}
+
+	if err := a.service.RequestEmailVerification(ctx.Request().Context(), req.Email); err != nil {
+		return a.processError(ctx, err)
+	}
+
+	return ctx.NoContent(http.StatusAccepted)
+}
+
+// requestPasswordReset always responds with 202, regardless of whether email is registered.
+func (a *Adapter) requestPasswordReset(ctx echo.Context) error {
+	req := new(EmailRequest)
+
+	if err := ctx.Bind(req); err != nil {
+		return httpAPI.SendJSONError(ctx,
+			http.StatusBadRequest, http.StatusText(http.StatusBadRequest))
+	}
+
+	vErrs := tools.ValidateStruct(req)
+	if vErrs != nil {
+		return httpAPI.SendJSONError(
+			ctx, http.StatusBadRequest, http.StatusText(http.StatusBadRequest),
+			httpAPI.WithValidationErrors(vErrs...),
+		)
+	}
+
+	if err := a.service.RequestPasswordReset(ctx.Request().Context(), req.Email); err != nil {
+		return a.processError(ctx, err)
+	}
+
+	return ctx.NoContent(http.StatusAccepted)
+}
+
+type ConfirmPasswordResetRequest struct {
+	Token    string `json:"token"    v:"required"`
+	Password string `json:"password" v:"required,min=8,max=24"`
+}
+
+func (a *Adapter) confirmPasswordReset(ctx echo.Context) error {
+	req := new(ConfirmPasswordResetRequest)
+
+	if err := ctx.Bind(req); err != nil {
+		return httpAPI.SendJSONError(ctx,
+			http.StatusBadRequest, http.StatusText(http.StatusBadRequest))
+	}
+
+	vErrs := tools.ValidateStruct(req)
+	if vErrs != nil {
+		return httpAPI.SendJSONError(
+			ctx, http.StatusBadRequest, http.StatusText(http.StatusBadRequest),
+			httpAPI.WithValidationErrors(vErrs...),
+		)
+	}
+
+	if err := a.service.ResetPassword(ctx.Request().Context(), req.Token, req.Password); err != nil {
+		return a.processError(ctx, err)
+	}
+
+	return ctx.NoContent(http.StatusOK)
+}
+
 type RefreshTokenRequest struct {
 	Token string `json:"token" v:"required"`
 }
@@ -180,6 +526,37 @@ func (a *Adapter) refresh(ctx echo.Context) error {
 	return ctx.JSON(http.StatusOK, tokens)
 }
 
+// SwitchTenantRequest scopes session of refresh token to organization,
+// empty organization makes session unscoped.
+type SwitchTenantRequest struct {
+	Token            string `json:"token"             v:"required"`
+	OrganizationUUID string `json:"organization_uuid" v:"omitempty,uuid"`
+}
+
+func (a *Adapter) switchTenant(ctx echo.Context) error {
+	req := new(SwitchTenantRequest)
+
+	if err := ctx.Bind(req); err != nil {
+		return httpAPI.SendJSONError(ctx,
+			http.StatusBadRequest, http.StatusText(http.StatusBadRequest))
+	}
+
+	vErrs := tools.ValidateStruct(req)
+	if vErrs != nil {
+		return httpAPI.SendJSONError(
+			ctx, http.StatusBadRequest, http.StatusText(http.StatusBadRequest),
+			httpAPI.WithValidationErrors(vErrs...),
+		)
+	}
+
+	tokens, err := a.service.SwitchTenant(ctx.Request().Context(), req.Token, req.OrganizationUUID)
+	if err != nil {
+		return a.processError(ctx, err)
+	}
+
+	return ctx.JSON(http.StatusOK, tokens)
+}
+
 type LogoutTokenRequest struct {
 	AccessToken  string `json:"access_token"  v:"required"`
 	RefreshToken string `json:"refresh_token" v:"required"`
@@ -211,6 +588,101 @@ func (a *Adapter) logout(ctx echo.Context) error {
 
 // ----
 
+// ssoVerifierCookie holds verifier of sso login in progress,
+// it is scoped to provider routes and never exposed to scripts.
+const ssoVerifierCookie = "sso_verifier"
+
+func (a *Adapter) listSSOProviders(ctx echo.Context) error {
+	return ctx.JSON(http.StatusOK, SSOProvidersResponse{Providers: a.service.SSOProviders()})
+}
+
+// startSSO redirects user agent to external identity provider.
+func (a *Adapter) startSSO(ctx echo.Context) error {
+	authorization, err := a.service.StartSSO(ctx.Request().Context(), ctx.Param("provider"))
+	if err != nil {
+		return a.processError(ctx, err)
+	}
+
+	ctx.SetCookie(&http.Cookie{
+		Name:     ssoVerifierCookie,
+		Value:    authorization.Verifier,
+		Path:     path.Dir(ctx.Request().URL.Path),
+		Expires:  authorization.ExpiresAt,
+		MaxAge:   int(time.Until(authorization.ExpiresAt).Seconds()),
+		Secure:   ctx.Scheme() == "https",
+		HttpOnly: true,
+		// cookie must be sent on top-level redirect back from provider
+		SameSite: http.SameSiteLaxMode,
+	})
+
+	return ctx.Redirect(http.StatusFound, authorization.AuthURL)
+}
+
+type SSOCallbackRequest struct {
+	Code  string `query:"code"  v:"required"`
+	State string `query:"state" v:"required"`
+}
+
+// completeSSO is redirect uri registered at external identity provider.
+// Same as login, it responds with tokens or with mfa challenge.
+func (a *Adapter) completeSSO(ctx echo.Context) error {
+	// provider reports errors with error parameter instead of code (RFC 6749 section 4.1.2.1)
+	if ctx.QueryParam("error") != "" {
+		return httpAPI.SendJSONError(ctx,
+			http.StatusUnauthorized, http.StatusText(http.StatusUnauthorized),
+			httpAPI.WithDetail(ctx.QueryParam("error")))
+	}
+
+	req := new(SSOCallbackRequest)
+
+	if err := ctx.Bind(req); err != nil {
+		return httpAPI.SendJSONError(ctx,
+			http.StatusBadRequest, http.StatusText(http.StatusBadRequest))
+	}
+
+	vErrs := tools.ValidateStruct(req)
+	if vErrs != nil {
+		return httpAPI.SendJSONError(
+			ctx, http.StatusBadRequest, http.StatusText(http.StatusBadRequest),
+			httpAPI.WithValidationErrors(vErrs...),
+		)
+	}
+
+	cookie, err := ctx.Cookie(ssoVerifierCookie)
+	if err != nil || cookie.Value == "" {
+		return httpAPI.SendJSONError(ctx,
+			http.StatusUnauthorized, http.StatusText(http.StatusUnauthorized))
+	}
+
+	// verifier is single use, state is invalidated by service
+	ctx.SetCookie(&http.Cookie{
+		Name:     ssoVerifierCookie,
+		Path:     path.Dir(ctx.Request().URL.Path),
+		MaxAge:   -1,
+		Secure:   ctx.Scheme() == "https",
+		HttpOnly: true,
+		SameSite: http.SameSiteLaxMode,
+	})
+
+	client := domain.ClientInfo{
+		IPAddress: ctx.RealIP(),
+		UserAgent: ctx.Request().UserAgent(),
+	}
+
+	tokens, challenge, err := a.service.CompleteSSO(
+		ctx.Request().Context(), ctx.Param("provider"), req.State, req.Code, cookie.Value, client,
+	)
+	if err != nil {
+		return a.processError(ctx, err)
+	}
+
+	if challenge != nil {
+		return ctx.JSON(http.StatusAccepted, challenge)
+	}
+
+	return ctx.JSON(http.StatusOK, tokens)
+}
+
 func (a *Adapter) readSelf(ctx echo.Context) error {
 	authInfo := auth.RetrieveAuthFromContext(ctx.Request().Context())
 	if authInfo == nil {
@@ -218,7 +690,7 @@ func (a *Adapter) readSelf(ctx echo.Context) error {
 			http.StatusUnauthorized, http.StatusText(http.StatusUnauthorized))
 	}
 
-	user, err := a.service.GetUserByID(ctx.Request().Context(), authInfo.ID)
+	user, err := a.service.GetUserByID(ctx.Request().Context(), authInfo.UserID)
 	if err != nil {
 		return a.processError(ctx, err)
 	}
@@ -264,7 +736,7 @@ func (a *Adapter) updateSelf(ctx echo.Context) error {
 		updateData.Password = &password
 	}
 
-	err := a.service.UpdateUser(ctx.Request().Context(), authInfo.UUID, updateData)
+	err := a.service.UpdateUser(ctx.Request().Context(), authInfo.UserUUID, updateData)
 	if err != nil {
 		return a.processError(ctx, err)
 	}
@@ -272,88 +744,79 @@ func (a *Adapter) updateSelf(ctx echo.Context) error {
 	return ctx.NoContent(http.StatusOK)
 }
 
-// ----
-
-func (a *Adapter) listUsers(ctx echo.Context) error {
-	limit, err := strconv.Atoi(ctx.QueryParam("limit"))
-	if err != nil || limit < 0 {
-		limit = 10
-	}
-	offSet, err := strconv.Atoi(ctx.QueryParam("offset"))
-	if err != nil || offSet < 0 {
-		offSet = 0
+func (a *Adapter) listSelfSessions(ctx echo.Context) error {
+	authInfo := auth.RetrieveAuthFromContext(ctx.Request().Context())
+	if authInfo == nil {
+		return httpAPI.SendJSONError(ctx,
+			http.StatusUnauthorized, http.StatusText(http.StatusUnauthorized))
 	}
-	r, err := a.service.ListUsers(ctx.Request().Context(), limit, offSet)
+
+	sessions, err := a.service.ListUserSessions(ctx.Request().Context(), authInfo.UserUUID)
 	if err != nil {
 		return a.processError(ctx, err)
 	}
-	resp := make([]UserResponse, len(r))
-	for i, user := range r {
-		resp[i] = UserResponseFromModel(user)
+
+	resp := make([]SessionResponse, len(sessions))
+	for i, session := range sessions {
+		resp[i] = SessionResponseFromModel(session)
 	}
 	return ctx.JSON(http.StatusOK, resp)
 }
 
-func (a *Adapter) userByUUID(ctx echo.Context) error {
-	userUUID := ctx.Param("uuid")
-	if err := uuid.Validate(userUUID); err != nil {
+func (a *Adapter) revokeSelfSession(ctx echo.Context) error {
+	authInfo := auth.RetrieveAuthFromContext(ctx.Request().Context())
+	if authInfo == nil {
+		return httpAPI.SendJSONError(ctx,
+			http.StatusUnauthorized, http.StatusText(http.StatusUnauthorized))
+	}
+
+	sessionUUID := ctx.Param("id")
+	if err := uuid.Validate(sessionUUID); err != nil {
 		return httpAPI.SendJSONError(ctx,
 			http.StatusBadRequest, http.StatusText(http.StatusBadRequest))
 	}
-	r, err := a.service.GetUserByUUID(ctx.Request().Context(), userUUID)
+
+	err := a.service.RevokeUserSession(ctx.Request().Context(), authInfo.UserUUID, sessionUUID)
 	if err != nil {
 		return a.processError(ctx, err)
 	}
-	return ctx.JSON(http.StatusOK, UserResponseFromModel(r))
-}
 
-type CreateUserRequest struct {
-	Email    string `json:"email"    v:"required,email"`
-	Password string `json:"password" v:"required,min=8,max=24"`
+	return ctx.NoContent(http.StatusOK)
 }
 
-func (a *Adapter) createUser(ctx echo.Context) error {
-	req := new(CreateUserRequest)
-
-	if err := ctx.Bind(req); err != nil {
+func (a *Adapter) listSelfAPITokens(ctx echo.Context) error {
+	authInfo := auth.RetrieveAuthFromContext(ctx.Request().Context())
+	if authInfo == nil {
 		return httpAPI.SendJSONError(ctx,
-			http.StatusBadRequest, http.StatusText(http.StatusBadRequest))
-	}
-
-	vErrs := tools.ValidateStruct(req)
-	if vErrs != nil {
-		return httpAPI.SendJSONError(
-			ctx, http.StatusBadRequest, http.StatusText(http.StatusBadRequest),
-			httpAPI.WithValidationErrors(vErrs...),
-		)
+			http.StatusUnauthorized, http.StatusText(http.StatusUnauthorized))
 	}
 
-	data := new(domain.CreateUserData)
-
-	data.Email = strings.ToLower(strings.TrimSpace(req.Email))
-	data.Password = strings.TrimSpace(req.Password)
-
-	user, err := a.service.CreateUser(ctx.Request().Context(), data)
+	tokens, err := a.service.ListAPITokens(ctx.Request().Context(), authInfo.UserUUID)
 	if err != nil {
 		return a.processError(ctx, err)
 	}
 
-	return ctx.JSON(http.StatusCreated, UserResponseFromModel(user))
+	resp := make([]APITokenResponse, len(tokens))
+	for i, token := range tokens {
+		resp[i] = APITokenResponseFromModel(token)
+	}
+	return ctx.JSON(http.StatusOK, resp)
 }
 
-type UpdateUserRequest struct {
-	Email    string `json:"email"    v:"omitempty,email"`
-	Password string `json:"password" v:"omitempty,min=8,max=24"`
+type CreateAPITokenRequest struct {
+	Name        string     `json:"name"        v:"required,max=100"`
+	Permissions []string   `json:"permissions" v:"required,min=1,dive,required"`
+	ExpiresAt   *time.Time `json:"expires_at"  v:"omitempty,gt"`
 }
 
-func (a *Adapter) updateUser(ctx echo.Context) error {
-	userUUID := ctx.Param("uuid")
-	if err := uuid.Validate(userUUID); err != nil {
+func (a *Adapter) createSelfAPIToken(ctx echo.Context) error {
+	authInfo := auth.RetrieveAuthFromContext(ctx.Request().Context())
+	if authInfo == nil {
 		return httpAPI.SendJSONError(ctx,
-			http.StatusBadRequest, http.StatusText(http.StatusBadRequest))
+			http.StatusUnauthorized, http.StatusText(http.StatusUnauthorized))
 	}
 
-	req := new(UpdateUserRequest)
+	req := new(CreateAPITokenRequest)
 
 	if err := ctx.Bind(req); err != nil {
 		return httpAPI.SendJSONError(ctx,
@@ -368,13 +831,290 @@ func (a *Adapter) updateUser(ctx echo.Context) error {
 		)
 	}
 
-	data := new(domain.UpdateUserData)
+	data := &domain.CreateAPITokenData{
+		Name:        strings.TrimSpace(req.Name),
+		Permissions: req.Permissions,
+		ExpiresAt:   req.ExpiresAt,
+	}
 
-	if req.Email != "" {
-		email := strings.ToLower(strings.TrimSpace(req.Email))
-		data.Email = &email
+	token, plainToken, err := a.service.CreateAPIToken(ctx.Request().Context(), authInfo.UserUUID, data)
+	if err != nil {
+		return a.processError(ctx, err)
 	}
-	if req.Password != "" {
+
+	return ctx.JSON(http.StatusCreated, CreateAPITokenResponse{
+		APITokenResponse: APITokenResponseFromModel(token),
+		Token:            plainToken,
+	})
+}
+
+func (a *Adapter) revokeSelfAPIToken(ctx echo.Context) error {
+	authInfo := auth.RetrieveAuthFromContext(ctx.Request().Context())
+	if authInfo == nil {
+		return httpAPI.SendJSONError(ctx,
+			http.StatusUnauthorized, http.StatusText(http.StatusUnauthorized))
+	}
+
+	tokenUUID := ctx.Param("id")
+	if err := uuid.Validate(tokenUUID); err != nil {
+		return httpAPI.SendJSONError(ctx,
+			http.StatusBadRequest, http.StatusText(http.StatusBadRequest))
+	}
+
+	err := a.service.RevokeAPIToken(ctx.Request().Context(), authInfo.UserUUID, tokenUUID)
+	if err != nil {
+		return a.processError(ctx, err)
+	}
+
+	return ctx.NoContent(http.StatusOK)
+}
+
+func (a *Adapter) readSelfMFA(ctx echo.Context) error {
+	authInfo := auth.RetrieveAuthFromContext(ctx.Request().Context())
+	if authInfo == nil {
+		return httpAPI.SendJSONError(ctx,
+			http.StatusUnauthorized, http.StatusText(http.StatusUnauthorized))
+	}
+
+	status, err := a.service.GetMFAStatus(ctx.Request().Context(), authInfo.UserUUID)
+	if err != nil {
+		return a.processError(ctx, err)
+	}
+
+	return ctx.JSON(http.StatusOK, MFAStatusResponseFromDomain(status))
+}
+
+func (a *Adapter) enrollSelfMFA(ctx echo.Context) error {
+	authInfo := auth.RetrieveAuthFromContext(ctx.Request().Context())
+	if authInfo == nil {
+		return httpAPI.SendJSONError(ctx,
+			http.StatusUnauthorized, http.StatusText(http.StatusUnauthorized))
+	}
+
+	enrollment, err := a.service.EnrollMFA(ctx.Request().Context(), authInfo.UserUUID)
+	if err != nil {
+		return a.processError(ctx, err)
+	}
+
+	return ctx.JSON(http.StatusOK, MFAEnrollmentResponse{
+		Secret:          enrollment.Secret,
+		ProvisioningURI: enrollment.ProvisioningURI,
+	})
+}
+
+type MFACodeRequest struct {
+	Code string `json:"code" v:"required,max=32"`
+}
+
+func (a *Adapter) confirmSelfMFA(ctx echo.Context) error {
+	return a.withSelfMFACode(ctx, func(ctx echo.Context, userUUID string, code string) error {
+		recoveryCodes, err := a.service.ConfirmMFA(ctx.Request().Context(), userUUID, code)
+		if err != nil {
+			return a.processError(ctx, err)
+		}
+		return ctx.JSON(http.StatusOK, MFARecoveryCodesResponse{RecoveryCodes: recoveryCodes})
+	})
+}
+
+func (a *Adapter) disableSelfMFA(ctx echo.Context) error {
+	return a.withSelfMFACode(ctx, func(ctx echo.Context, userUUID string, code string) error {
+		if err := a.service.DisableMFA(ctx.Request().Context(), userUUID, code); err != nil {
+			return a.processError(ctx, err)
+		}
+		return ctx.NoContent(http.StatusOK)
+	})
+}
+
+func (a *Adapter) regenerateSelfMFARecoveryCodes(ctx echo.Context) error {
+	return a.withSelfMFACode(ctx, func(ctx echo.Context, userUUID string, code string) error {
+		recoveryCodes, err := a.service.RegenerateMFARecoveryCodes(ctx.Request().Context(), userUUID, code)
+		if err != nil {
+			return a.processError(ctx, err)
+		}
+		return ctx.JSON(http.StatusOK, MFARecoveryCodesResponse{RecoveryCodes: recoveryCodes})
+	})
+}
+
+// withSelfMFACode binds and validates MFACodeRequest of authenticated user and passes it to fn.
+func (a *Adapter) withSelfMFACode(
+	ctx echo.Context, fn func(ctx echo.Context, userUUID string, code string) error,
+) error {
+	authInfo := auth.RetrieveAuthFromContext(ctx.Request().Context())
+	if authInfo == nil {
+		return httpAPI.SendJSONError(ctx,
+			http.StatusUnauthorized, http.StatusText(http.StatusUnauthorized))
+	}
+
+	req := new(MFACodeRequest)
+
+	if err := ctx.Bind(req); err != nil {
+		return httpAPI.SendJSONError(ctx,
+			http.StatusBadRequest, http.StatusText(http.StatusBadRequest))
+	}
+
+	vErrs := tools.ValidateStruct(req)
+	if vErrs != nil {
+		return httpAPI.SendJSONError(
+			ctx, http.StatusBadRequest, http.StatusText(http.StatusBadRequest),
+			httpAPI.WithValidationErrors(vErrs...),
+		)
+	}
+
+	return fn(ctx, authInfo.UserUUID, req.Code)
+}
+
+// ----
+
+type ListUsersRequest struct {
+	Limit       int    `query:"limit"        v:"omitempty,min=1,max=100"`
+	Cursor      string `query:"cursor"       v:"omitempty,max=500"`
+	EmailPrefix string `query:"email_prefix" v:"omitempty,max=255"`
+	Status      string `query:"status"       v:"omitempty,oneof=active inactive pending suspended deletion_scheduled deleted"`
+	Role        string `query:"role"         v:"omitempty,max=50"`
+	Sort        string `query:"sort"         v:"omitempty,oneof=id created_at email"`
+	Order       string `query:"order"        v:"omitempty,oneof=asc desc"`
+}
+
+// listUsers accepts created_from and created_to in RFC 3339 format besides ListUsersRequest.
+func (a *Adapter) listUsers(ctx echo.Context) error {
+	req := new(ListUsersRequest)
+
+	if err := ctx.Bind(req); err != nil {
+		return httpAPI.SendJSONError(ctx,
+			http.StatusBadRequest, http.StatusText(http.StatusBadRequest))
+	}
+
+	vErrs := tools.ValidateStruct(req)
+	if vErrs != nil {
+		return httpAPI.SendJSONError(
+			ctx, http.StatusBadRequest, http.StatusText(http.StatusBadRequest),
+			httpAPI.WithValidationErrors(vErrs...),
+		)
+	}
+
+	query := &domain.ListUsersQuery{
+		EmailPrefix: req.EmailPrefix,
+		Status:      req.Status,
+		Role:        req.Role,
+		SortBy:      req.Sort,
+		SortDesc:    req.Order == "desc",
+		Limit:       req.Limit,
+		Cursor:      req.Cursor,
+	}
+	var err error
+	query.CreatedFrom, err = parseQueryTime(ctx, "created_from")
+	if err != nil {
+		return httpAPI.SendJSONError(ctx,
+			http.StatusBadRequest, http.StatusText(http.StatusBadRequest))
+	}
+	query.CreatedTo, err = parseQueryTime(ctx, "created_to")
+	if err != nil {
+		return httpAPI.SendJSONError(ctx,
+			http.StatusBadRequest, http.StatusText(http.StatusBadRequest))
+	}
+	if query.CreatedFrom != nil && query.CreatedTo != nil && !query.CreatedFrom.Before(*query.CreatedTo) {
+		return httpAPI.SendJSONError(ctx,
+			http.StatusBadRequest, http.StatusText(http.StatusBadRequest))
+	}
+
+	users, nextCursor, err := a.service.ListUsers(ctx.Request().Context(), query)
+	if err != nil {
+		return a.processError(ctx, err)
+	}
+
+	resp := ListUsersResponse{
+		Users: make([]UserResponse, len(users)),
+	}
+	for i, user := range users {
+		resp.Users[i] = UserResponseFromModel(user)
+	}
+	if nextCursor != "" {
+		resp.NextCursor = &nextCursor
+	}
+	return ctx.JSON(http.StatusOK, resp)
+}
+
+func (a *Adapter) userByUUID(ctx echo.Context) error {
+	userUUID := ctx.Param("uuid")
+	if err := uuid.Validate(userUUID); err != nil {
+		return httpAPI.SendJSONError(ctx,
+			http.StatusBadRequest, http.StatusText(http.StatusBadRequest))
+	}
+	r, err := a.service.GetUserByUUID(ctx.Request().Context(), userUUID)
+	if err != nil {
+		return a.processError(ctx, err)
+	}
+	return ctx.JSON(http.StatusOK, UserResponseFromModel(r))
+}
+
+type CreateUserRequest struct {
+	Email    string `json:"email"    v:"required,email"`
+	Password string `json:"password" v:"required,min=8,max=24"`
+}
+
+func (a *Adapter) createUser(ctx echo.Context) error {
+	req := new(CreateUserRequest)
+
+	if err := ctx.Bind(req); err != nil {
+		return httpAPI.SendJSONError(ctx,
+			http.StatusBadRequest, http.StatusText(http.StatusBadRequest))
+	}
+
+	vErrs := tools.ValidateStruct(req)
+	if vErrs != nil {
+		return httpAPI.SendJSONError(
+			ctx, http.StatusBadRequest, http.StatusText(http.StatusBadRequest),
+			httpAPI.WithValidationErrors(vErrs...),
+		)
+	}
+
+	data := new(domain.CreateUserData)
+
+	data.Email = strings.ToLower(strings.TrimSpace(req.Email))
+	data.Password = strings.TrimSpace(req.Password)
+
+	user, err := a.service.CreateUser(ctx.Request().Context(), data)
+	if err != nil {
+		return a.processError(ctx, err)
+	}
+
+	return ctx.JSON(http.StatusCreated, UserResponseFromModel(user))
+}
+
+type UpdateUserRequest struct {
+	Email    string `json:"email"    v:"omitempty,email"`
+	Password string `json:"password" v:"omitempty,min=8,max=24"`
+}
+
+func (a *Adapter) updateUser(ctx echo.Context) error {
+	userUUID := ctx.Param("uuid")
+	if err := uuid.Validate(userUUID); err != nil {
+		return httpAPI.SendJSONError(ctx,
+			http.StatusBadRequest, http.StatusText(http.StatusBadRequest))
+	}
+
+	req := new(UpdateUserRequest)
+
+	if err := ctx.Bind(req); err != nil {
+		return httpAPI.SendJSONError(ctx,
+			http.StatusBadRequest, http.StatusText(http.StatusBadRequest))
+	}
+
+	vErrs := tools.ValidateStruct(req)
+	if vErrs != nil {
+		return httpAPI.SendJSONError(
+			ctx, http.StatusBadRequest, http.StatusText(http.StatusBadRequest),
+			httpAPI.WithValidationErrors(vErrs...),
+		)
+	}
+
+	data := new(domain.UpdateUserData)
+
+	if req.Email != "" {
+		email := strings.ToLower(strings.TrimSpace(req.Email))
+		data.Email = &email
+	}
+	if req.Password != "" {
 		password := strings.TrimSpace(req.Password)
 		data.Password = &password
 	}
@@ -398,3 +1138,877 @@ func (a *Adapter) deleteUser(ctx echo.Context) error {
 	}
 	return ctx.NoContent(http.StatusOK)
 }
+
+func (a *Adapter) unlockUser(ctx echo.Context) error {
+	userUUID := ctx.Param("uuid")
+	if err := uuid.Validate(userUUID); err != nil {
+		return httpAPI.SendJSONError(ctx,
+			http.StatusBadRequest, http.StatusText(http.StatusBadRequest))
+	}
+	if err := a.service.UnlockUser(ctx.Request().Context(), userUUID); err != nil {
+		return a.processError(ctx, err)
+	}
+	return ctx.NoContent(http.StatusOK)
+}
+
+func (a *Adapter) impersonateUser(ctx echo.Context) error {
+	userUUID := ctx.Param("uuid")
+	if err := uuid.Validate(userUUID); err != nil {
+		return httpAPI.SendJSONError(ctx,
+			http.StatusBadRequest, http.StatusText(http.StatusBadRequest))
+	}
+	token, err := a.service.Impersonate(ctx.Request().Context(), userUUID)
+	if err != nil {
+		return a.processError(ctx, err)
+	}
+	return ctx.JSON(http.StatusOK, token)
+}
+
+type SuspendUserRequest struct {
+	Reason string     `json:"reason" v:"max=255"`
+	Until  *time.Time `json:"until"  v:"omitempty,gt"`
+}
+
+func (a *Adapter) suspendUser(ctx echo.Context) error {
+	userUUID := ctx.Param("uuid")
+	if err := uuid.Validate(userUUID); err != nil {
+		return httpAPI.SendJSONError(ctx,
+			http.StatusBadRequest, http.StatusText(http.StatusBadRequest))
+	}
+
+	req := new(SuspendUserRequest)
+
+	if err := ctx.Bind(req); err != nil {
+		return httpAPI.SendJSONError(ctx,
+			http.StatusBadRequest, http.StatusText(http.StatusBadRequest))
+	}
+
+	vErrs := tools.ValidateStruct(req)
+	if vErrs != nil {
+		return httpAPI.SendJSONError(
+			ctx, http.StatusBadRequest, http.StatusText(http.StatusBadRequest),
+			httpAPI.WithValidationErrors(vErrs...),
+		)
+	}
+
+	err := a.service.SuspendUser(ctx.Request().Context(), userUUID, &domain.SuspendUserData{
+		Reason: strings.TrimSpace(req.Reason),
+		Until:  req.Until,
+	})
+	if err != nil {
+		return a.processError(ctx, err)
+	}
+
+	return ctx.NoContent(http.StatusOK)
+}
+
+func (a *Adapter) reinstateUser(ctx echo.Context) error {
+	userUUID := ctx.Param("uuid")
+	if err := uuid.Validate(userUUID); err != nil {
+		return httpAPI.SendJSONError(ctx,
+			http.StatusBadRequest, http.StatusText(http.StatusBadRequest))
+	}
+	if err := a.service.ReinstateUser(ctx.Request().Context(), userUUID); err != nil {
+		return a.processError(ctx, err)
+	}
+	return ctx.NoContent(http.StatusOK)
+}
+
+func (a *Adapter) restoreUser(ctx echo.Context) error {
+	userUUID := ctx.Param("uuid")
+	if err := uuid.Validate(userUUID); err != nil {
+		return httpAPI.SendJSONError(ctx,
+			http.StatusBadRequest, http.StatusText(http.StatusBadRequest))
+	}
+	if err := a.service.RestoreUser(ctx.Request().Context(), userUUID); err != nil {
+		return a.processError(ctx, err)
+	}
+	return ctx.NoContent(http.StatusOK)
+}
+
+func (a *Adapter) listSelfHistory(ctx echo.Context) error {
+	authInfo := auth.RetrieveAuthFromContext(ctx.Request().Context())
+	if authInfo == nil {
+		return httpAPI.SendJSONError(ctx,
+			http.StatusUnauthorized, http.StatusText(http.StatusUnauthorized))
+	}
+	return a.listHistory(ctx, authInfo.UserUUID)
+}
+
+func (a *Adapter) listUserHistory(ctx echo.Context) error {
+	userUUID := ctx.Param("uuid")
+	if err := uuid.Validate(userUUID); err != nil {
+		return httpAPI.SendJSONError(ctx,
+			http.StatusBadRequest, http.StatusText(http.StatusBadRequest))
+	}
+	return a.listHistory(ctx, userUUID)
+}
+
+// listHistory accepts repeated event_type, from and to in RFC 3339 format, limit and offset.
+func (a *Adapter) listHistory(ctx echo.Context, userUUID string) error {
+	filter := &domain.UserHistoryFilter{
+		EventTypes: ctx.QueryParams()["event_type"],
+	}
+	limit, err := strconv.Atoi(ctx.QueryParam("limit"))
+	if err == nil && limit > 0 {
+		filter.Limit = limit
+	}
+	offset, err := strconv.Atoi(ctx.QueryParam("offset"))
+	if err == nil && offset > 0 {
+		filter.Offset = offset
+	}
+	filter.From, err = parseQueryTime(ctx, "from")
+	if err != nil {
+		return httpAPI.SendJSONError(ctx,
+			http.StatusBadRequest, http.StatusText(http.StatusBadRequest))
+	}
+	filter.To, err = parseQueryTime(ctx, "to")
+	if err != nil {
+		return httpAPI.SendJSONError(ctx,
+			http.StatusBadRequest, http.StatusText(http.StatusBadRequest))
+	}
+	if filter.From != nil && filter.To != nil && !filter.From.Before(*filter.To) {
+		return httpAPI.SendJSONError(ctx,
+			http.StatusBadRequest, http.StatusText(http.StatusBadRequest))
+	}
+
+	records, err := a.service.ListUserHistory(ctx.Request().Context(), userUUID, filter)
+	if err != nil {
+		return a.processError(ctx, err)
+	}
+
+	resp := make([]HistoryRecordResponse, len(records))
+	for i, record := range records {
+		resp[i] = HistoryRecordResponseFromModel(record)
+	}
+	return ctx.JSON(http.StatusOK, resp)
+}
+
+// parseQueryTime returns nil if query parameter is not set.
+func parseQueryTime(ctx echo.Context, name string) (*time.Time, error) {
+	value := ctx.QueryParam(name)
+	if value == "" {
+		return nil, nil
+	}
+	t, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		return nil, err
+	}
+	return &t, nil
+}
+
+type CreateSelfDataRequestRequest struct {
+	Type string `json:"type" v:"required,oneof=export"`
+}
+
+// createSelfDataRequest only allows export, erasure of own account is done through administrator.
+func (a *Adapter) createSelfDataRequest(ctx echo.Context) error {
+	authInfo := auth.RetrieveAuthFromContext(ctx.Request().Context())
+	if authInfo == nil {
+		return httpAPI.SendJSONError(ctx,
+			http.StatusUnauthorized, http.StatusText(http.StatusUnauthorized))
+	}
+
+	req := new(CreateSelfDataRequestRequest)
+
+	if err := ctx.Bind(req); err != nil {
+		return httpAPI.SendJSONError(ctx,
+			http.StatusBadRequest, http.StatusText(http.StatusBadRequest))
+	}
+
+	vErrs := tools.ValidateStruct(req)
+	if vErrs != nil {
+		return httpAPI.SendJSONError(
+			ctx, http.StatusBadRequest, http.StatusText(http.StatusBadRequest),
+			httpAPI.WithValidationErrors(vErrs...),
+		)
+	}
+
+	request, err := a.service.CreateDataRequest(ctx.Request().Context(), authInfo.UserUUID, req.Type)
+	if err != nil {
+		return a.processError(ctx, err)
+	}
+
+	return ctx.JSON(http.StatusAccepted, DataRequestResponseFromModel(request))
+}
+
+func (a *Adapter) readSelfDataRequest(ctx echo.Context) error {
+	authInfo := auth.RetrieveAuthFromContext(ctx.Request().Context())
+	if authInfo == nil {
+		return httpAPI.SendJSONError(ctx,
+			http.StatusUnauthorized, http.StatusText(http.StatusUnauthorized))
+	}
+	return a.readDataRequest(ctx, authInfo.UserUUID)
+}
+
+func (a *Adapter) downloadSelfDataArchive(ctx echo.Context) error {
+	authInfo := auth.RetrieveAuthFromContext(ctx.Request().Context())
+	if authInfo == nil {
+		return httpAPI.SendJSONError(ctx,
+			http.StatusUnauthorized, http.StatusText(http.StatusUnauthorized))
+	}
+	return a.downloadDataArchive(ctx, authInfo.UserUUID)
+}
+
+type CreateDataRequestRequest struct {
+	Type string `json:"type" v:"required,oneof=export erasure"`
+}
+
+func (a *Adapter) createUserDataRequest(ctx echo.Context) error {
+	userUUID := ctx.Param("uuid")
+	if err := uuid.Validate(userUUID); err != nil {
+		return httpAPI.SendJSONError(ctx,
+			http.StatusBadRequest, http.StatusText(http.StatusBadRequest))
+	}
+
+	req := new(CreateDataRequestRequest)
+
+	if err := ctx.Bind(req); err != nil {
+		return httpAPI.SendJSONError(ctx,
+			http.StatusBadRequest, http.StatusText(http.StatusBadRequest))
+	}
+
+	vErrs := tools.ValidateStruct(req)
+	if vErrs != nil {
+		return httpAPI.SendJSONError(
+			ctx, http.StatusBadRequest, http.StatusText(http.StatusBadRequest),
+			httpAPI.WithValidationErrors(vErrs...),
+		)
+	}
+
+	request, err := a.service.CreateDataRequest(ctx.Request().Context(), userUUID, req.Type)
+	if err != nil {
+		return a.processError(ctx, err)
+	}
+
+	return ctx.JSON(http.StatusAccepted, DataRequestResponseFromModel(request))
+}
+
+func (a *Adapter) readUserDataRequest(ctx echo.Context) error {
+	userUUID := ctx.Param("uuid")
+	if err := uuid.Validate(userUUID); err != nil {
+		return httpAPI.SendJSONError(ctx,
+			http.StatusBadRequest, http.StatusText(http.StatusBadRequest))
+	}
+	return a.readDataRequest(ctx, userUUID)
+}
+
+func (a *Adapter) downloadUserDataArchive(ctx echo.Context) error {
+	userUUID := ctx.Param("uuid")
+	if err := uuid.Validate(userUUID); err != nil {
+		return httpAPI.SendJSONError(ctx,
+			http.StatusBadRequest, http.StatusText(http.StatusBadRequest))
+	}
+	return a.downloadDataArchive(ctx, userUUID)
+}
+
+func (a *Adapter) readDataRequest(ctx echo.Context, userUUID string) error {
+	requestUUID := ctx.Param("id")
+	if err := uuid.Validate(requestUUID); err != nil {
+		return httpAPI.SendJSONError(ctx,
+			http.StatusBadRequest, http.StatusText(http.StatusBadRequest))
+	}
+	request, err := a.service.GetDataRequest(ctx.Request().Context(), userUUID, requestUUID)
+	if err != nil {
+		return a.processError(ctx, err)
+	}
+	return ctx.JSON(http.StatusOK, DataRequestResponseFromModel(request))
+}
+
+func (a *Adapter) downloadDataArchive(ctx echo.Context, userUUID string) error {
+	requestUUID := ctx.Param("id")
+	if err := uuid.Validate(requestUUID); err != nil {
+		return httpAPI.SendJSONError(ctx,
+			http.StatusBadRequest, http.StatusText(http.StatusBadRequest))
+	}
+	archive, err := a.service.GetDataRequestArchive(ctx.Request().Context(), userUUID, requestUUID)
+	if err != nil {
+		return a.processError(ctx, err)
+	}
+	ctx.Response().Header().Set(echo.HeaderContentDisposition,
+		fmt.Sprintf("attachment; filename=%q", "data-export-"+requestUUID+".zip"))
+	return ctx.Blob(http.StatusOK, "application/zip", archive)
+}
+
+func (a *Adapter) resetUserMFA(ctx echo.Context) error {
+	userUUID := ctx.Param("uuid")
+	if err := uuid.Validate(userUUID); err != nil {
+		return httpAPI.SendJSONError(ctx,
+			http.StatusBadRequest, http.StatusText(http.StatusBadRequest))
+	}
+	if err := a.service.ResetUserMFA(ctx.Request().Context(), userUUID); err != nil {
+		return a.processError(ctx, err)
+	}
+	return ctx.NoContent(http.StatusOK)
+}
+
+func (a *Adapter) revokeUserSessions(ctx echo.Context) error {
+	userUUID := ctx.Param("uuid")
+	if err := uuid.Validate(userUUID); err != nil {
+		return httpAPI.SendJSONError(ctx,
+			http.StatusBadRequest, http.StatusText(http.StatusBadRequest))
+	}
+	if err := a.service.RevokeUserSessions(ctx.Request().Context(), userUUID); err != nil {
+		return a.processError(ctx, err)
+	}
+	return ctx.NoContent(http.StatusOK)
+}
+
+type GrantUserRoleRequest struct {
+	Role      string     `json:"role"       v:"required,max=50"`
+	ExpiresAt *time.Time `json:"expires_at" v:"omitempty,gt"`
+}
+
+func (a *Adapter) grantUserRole(ctx echo.Context) error {
+	userUUID := ctx.Param("uuid")
+	if err := uuid.Validate(userUUID); err != nil {
+		return httpAPI.SendJSONError(ctx,
+			http.StatusBadRequest, http.StatusText(http.StatusBadRequest))
+	}
+
+	req := new(GrantUserRoleRequest)
+
+	if err := ctx.Bind(req); err != nil {
+		return httpAPI.SendJSONError(ctx,
+			http.StatusBadRequest, http.StatusText(http.StatusBadRequest))
+	}
+
+	vErrs := tools.ValidateStruct(req)
+	if vErrs != nil {
+		return httpAPI.SendJSONError(
+			ctx, http.StatusBadRequest, http.StatusText(http.StatusBadRequest),
+			httpAPI.WithValidationErrors(vErrs...),
+		)
+	}
+
+	err := a.service.GrantRoleToUser(ctx.Request().Context(), userUUID, req.Role, req.ExpiresAt)
+	if err != nil {
+		return a.processError(ctx, err)
+	}
+
+	return ctx.NoContent(http.StatusOK)
+}
+
+func (a *Adapter) revokeUserRole(ctx echo.Context) error {
+	userUUID := ctx.Param("uuid")
+	if err := uuid.Validate(userUUID); err != nil {
+		return httpAPI.SendJSONError(ctx,
+			http.StatusBadRequest, http.StatusText(http.StatusBadRequest))
+	}
+	err := a.service.RevokeRoleFromUser(ctx.Request().Context(), userUUID, ctx.Param("role"))
+	if err != nil {
+		return a.processError(ctx, err)
+	}
+	return ctx.NoContent(http.StatusOK)
+}
+
+// ----
+
+func (a *Adapter) listRoles(ctx echo.Context) error {
+	roles, err := a.service.ListRoles(ctx.Request().Context())
+	if err != nil {
+		return a.processError(ctx, err)
+	}
+	resp := make([]RoleResponse, len(roles))
+	for i, role := range roles {
+		resp[i] = RoleResponseFromModel(role)
+	}
+	return ctx.JSON(http.StatusOK, resp)
+}
+
+type CreateRoleRequest struct {
+	Name        string   `json:"name"        v:"required,min=2,max=50,lowercase,excludesall= /:"`
+	Description string   `json:"description" v:"max=255"`
+	Permissions []string `json:"permissions" v:"dive,required"`
+}
+
+func (a *Adapter) createRole(ctx echo.Context) error {
+	req := new(CreateRoleRequest)
+
+	if err := ctx.Bind(req); err != nil {
+		return httpAPI.SendJSONError(ctx,
+			http.StatusBadRequest, http.StatusText(http.StatusBadRequest))
+	}
+
+	vErrs := tools.ValidateStruct(req)
+	if vErrs != nil {
+		return httpAPI.SendJSONError(
+			ctx, http.StatusBadRequest, http.StatusText(http.StatusBadRequest),
+			httpAPI.WithValidationErrors(vErrs...),
+		)
+	}
+
+	data := &domain.CreateRoleData{
+		Name:        strings.TrimSpace(req.Name),
+		Description: strings.TrimSpace(req.Description),
+		Permissions: req.Permissions,
+	}
+
+	role, err := a.service.CreateRole(ctx.Request().Context(), data)
+	if err != nil {
+		return a.processError(ctx, err)
+	}
+
+	return ctx.JSON(http.StatusCreated, RoleResponseFromModel(role))
+}
+
+func (a *Adapter) deleteRole(ctx echo.Context) error {
+	if err := a.service.DeleteRole(ctx.Request().Context(), ctx.Param("name")); err != nil {
+		return a.processError(ctx, err)
+	}
+	return ctx.NoContent(http.StatusOK)
+}
+
+type AttachRolePermissionRequest struct {
+	Permission string `json:"permission" v:"required"`
+}
+
+func (a *Adapter) attachRolePermission(ctx echo.Context) error {
+	req := new(AttachRolePermissionRequest)
+
+	if err := ctx.Bind(req); err != nil {
+		return httpAPI.SendJSONError(ctx,
+			http.StatusBadRequest, http.StatusText(http.StatusBadRequest))
+	}
+
+	vErrs := tools.ValidateStruct(req)
+	if vErrs != nil {
+		return httpAPI.SendJSONError(
+			ctx, http.StatusBadRequest, http.StatusText(http.StatusBadRequest),
+			httpAPI.WithValidationErrors(vErrs...),
+		)
+	}
+
+	err := a.service.AttachPermissionToRole(ctx.Request().Context(), ctx.Param("name"), req.Permission)
+	if err != nil {
+		return a.processError(ctx, err)
+	}
+
+	return ctx.NoContent(http.StatusOK)
+}
+
+func (a *Adapter) detachRolePermission(ctx echo.Context) error {
+	err := a.service.DetachPermissionFromRole(
+		ctx.Request().Context(), ctx.Param("name"), ctx.Param("permission"),
+	)
+	if err != nil {
+		return a.processError(ctx, err)
+	}
+	return ctx.NoContent(http.StatusOK)
+}
+
+type AuthorizeRequest struct {
+	ResponseType        string `query:"response_type"         json:"response_type"         v:"required"`
+	ClientID            string `query:"client_id"             json:"client_id"             v:"required"`
+	RedirectURI         string `query:"redirect_uri"          json:"redirect_uri"`
+	Scope               string `query:"scope"                 json:"scope"`
+	State               string `query:"state"                 json:"state"`
+	Nonce               string `query:"nonce"                 json:"nonce"`
+	CodeChallenge       string `query:"code_challenge"        json:"code_challenge"`
+	CodeChallengeMethod string `query:"code_challenge_method" json:"code_challenge_method"`
+}
+
+func (r *AuthorizeRequest) toDomain() *domain.AuthorizeRequest {
+	return &domain.AuthorizeRequest{
+		ResponseType:        r.ResponseType,
+		ClientID:            r.ClientID,
+		RedirectURI:         r.RedirectURI,
+		Scope:               r.Scope,
+		State:               r.State,
+		Nonce:               r.Nonce,
+		CodeChallenge:       r.CodeChallenge,
+		CodeChallengeMethod: r.CodeChallengeMethod,
+	}
+}
+
+// authorizationPage is entry point of authorization code flow,
+// user agent is redirected to login page which completes it with authorize call.
+func (a *Adapter) authorizationPage(ctx echo.Context) error {
+	req := new(AuthorizeRequest)
+
+	if err := ctx.Bind(req); err != nil {
+		return httpAPI.SendJSONError(ctx,
+			http.StatusBadRequest, http.StatusText(http.StatusBadRequest))
+	}
+
+	vErrs := tools.ValidateStruct(req)
+	if vErrs != nil {
+		return httpAPI.SendJSONError(
+			ctx, http.StatusBadRequest, http.StatusText(http.StatusBadRequest),
+			httpAPI.WithValidationErrors(vErrs...),
+		)
+	}
+
+	redirectTo, err := a.service.AuthorizationPageURL(
+		ctx.Request().Context(), req.toDomain(), ctx.QueryParams(),
+	)
+	if err != nil {
+		return a.processError(ctx, err)
+	}
+
+	return ctx.Redirect(http.StatusFound, redirectTo)
+}
+
+// authorize is called by login page on behalf of authenticated user who approved the request.
+// Only session tokens are accepted, api tokens can not act on behalf of the user.
+func (a *Adapter) authorize(ctx echo.Context) error {
+	authInfo := auth.RetrieveAuthFromContext(ctx.Request().Context())
+	if authInfo == nil {
+		return httpAPI.SendJSONError(ctx,
+			http.StatusUnauthorized, http.StatusText(http.StatusUnauthorized))
+	}
+	if authInfo.Type != domain.AuthenticationTypeCredentials {
+		return httpAPI.SendJSONError(ctx,
+			http.StatusForbidden, http.StatusText(http.StatusForbidden))
+	}
+
+	req := new(AuthorizeRequest)
+
+	if err := ctx.Bind(req); err != nil {
+		return httpAPI.SendJSONError(ctx,
+			http.StatusBadRequest, http.StatusText(http.StatusBadRequest))
+	}
+
+	vErrs := tools.ValidateStruct(req)
+	if vErrs != nil {
+		return httpAPI.SendJSONError(
+			ctx, http.StatusBadRequest, http.StatusText(http.StatusBadRequest),
+			httpAPI.WithValidationErrors(vErrs...),
+		)
+	}
+
+	redirectTo, err := a.service.Authorize(ctx.Request().Context(), authInfo.UserUUID, req.toDomain())
+	if err != nil {
+		return a.processError(ctx, err)
+	}
+
+	return ctx.JSON(http.StatusOK, AuthorizeResponse{RedirectTo: redirectTo})
+}
+
+type OAuthTokenRequest struct {
+	GrantType    string `form:"grant_type"`
+	ClientID     string `form:"client_id"`
+	ClientSecret string `form:"client_secret"`
+	Code         string `form:"code"`
+	RedirectURI  string `form:"redirect_uri"`
+	CodeVerifier string `form:"code_verifier"`
+	RefreshToken string `form:"refresh_token"`
+	Scope        string `form:"scope"`
+}
+
+// oauthToken is token endpoint (RFC 6749 section 3.2).
+// Clients authenticate either with basic auth or with form parameters.
+func (a *Adapter) oauthToken(ctx echo.Context) error {
+	ctx.Response().Header().Set("Cache-Control", "no-store")
+	ctx.Response().Header().Set("Pragma", "no-cache")
+
+	req := new(OAuthTokenRequest)
+
+	if err := ctx.Bind(req); err != nil {
+		return a.sendOAuthError(ctx, domain.ErrOAuthInvalidRequest, false)
+	}
+
+	clientID, clientSecret, basicAuth := ctx.Request().BasicAuth()
+	if basicAuth {
+		if req.ClientSecret != "" {
+			return a.sendOAuthError(ctx,
+				fmt.Errorf("%w: multiple client authentication methods", domain.ErrOAuthInvalidRequest), true)
+		}
+		// credentials are form-encoded before being placed into header (RFC 6749 section 2.3.1)
+		var errID, errSecret error
+		req.ClientID, errID = url.QueryUnescape(clientID)
+		req.ClientSecret, errSecret = url.QueryUnescape(clientSecret)
+		if errID != nil || errSecret != nil {
+			return a.sendOAuthError(ctx, domain.ErrOAuthInvalidClient, true)
+		}
+	}
+
+	if req.GrantType == "" || req.ClientID == "" {
+		return a.sendOAuthError(ctx,
+			fmt.Errorf("%w: grant_type and client_id are required", domain.ErrOAuthInvalidRequest), basicAuth)
+	}
+
+	client := domain.ClientInfo{
+		IPAddress: ctx.RealIP(),
+		UserAgent: ctx.Request().UserAgent(),
+	}
+
+	tokens, err := a.service.ExchangeOAuthToken(ctx.Request().Context(), &domain.OAuthTokenRequest{
+		GrantType:    req.GrantType,
+		ClientID:     req.ClientID,
+		ClientSecret: req.ClientSecret,
+		Code:         req.Code,
+		RedirectURI:  req.RedirectURI,
+		CodeVerifier: req.CodeVerifier,
+		RefreshToken: req.RefreshToken,
+		Scope:        req.Scope,
+	}, client)
+	if err != nil {
+		return a.sendOAuthError(ctx, err, basicAuth)
+	}
+
+	return ctx.JSON(http.StatusOK, tokens)
+}
+
+func (a *Adapter) userInfo(ctx echo.Context) error {
+	authInfo := auth.RetrieveAuthFromContext(ctx.Request().Context())
+	if authInfo == nil {
+		return httpAPI.SendJSONError(ctx,
+			http.StatusUnauthorized, http.StatusText(http.StatusUnauthorized))
+	}
+
+	user, err := a.service.GetUserByUUID(ctx.Request().Context(), authInfo.UserUUID)
+	if err != nil {
+		return a.processError(ctx, err)
+	}
+
+	return ctx.JSON(http.StatusOK, UserInfoResponse{
+		Subject:       user.UUID.String(),
+		Email:         user.Email,
+		EmailVerified: user.IsEmailVerified(),
+	})
+}
+
+// ----
+
+func (a *Adapter) listSelfOrganizations(ctx echo.Context) error {
+	authInfo := auth.RetrieveAuthFromContext(ctx.Request().Context())
+	if authInfo == nil {
+		return httpAPI.SendJSONError(ctx,
+			http.StatusUnauthorized, http.StatusText(http.StatusUnauthorized))
+	}
+	organizations, err := a.service.ListUserOrganizations(ctx.Request().Context(), authInfo.UserUUID)
+	if err != nil {
+		return a.processError(ctx, err)
+	}
+	return ctx.JSON(http.StatusOK, organizationsResponse(organizations))
+}
+
+func (a *Adapter) listOrganizations(ctx echo.Context) error {
+	organizations, err := a.service.ListOrganizations(ctx.Request().Context())
+	if err != nil {
+		return a.processError(ctx, err)
+	}
+	return ctx.JSON(http.StatusOK, organizationsResponse(organizations))
+}
+
+func organizationsResponse(organizations []*models.Organization) []OrganizationResponse {
+	resp := make([]OrganizationResponse, len(organizations))
+	for i, organization := range organizations {
+		resp[i] = OrganizationResponseFromModel(organization)
+	}
+	return resp
+}
+
+type CreateOrganizationRequest struct {
+	Name string `json:"name" v:"required,max=100"`
+	Slug string `json:"slug" v:"required,min=2,max=50,lowercase,excludesall= /:"`
+}
+
+func (a *Adapter) createOrganization(ctx echo.Context) error {
+	req := new(CreateOrganizationRequest)
+
+	if err := ctx.Bind(req); err != nil {
+		return httpAPI.SendJSONError(ctx,
+			http.StatusBadRequest, http.StatusText(http.StatusBadRequest))
+	}
+
+	vErrs := tools.ValidateStruct(req)
+	if vErrs != nil {
+		return httpAPI.SendJSONError(
+			ctx, http.StatusBadRequest, http.StatusText(http.StatusBadRequest),
+			httpAPI.WithValidationErrors(vErrs...),
+		)
+	}
+
+	data := &domain.CreateOrganizationData{
+		Name: strings.TrimSpace(req.Name),
+		Slug: strings.TrimSpace(req.Slug),
+	}
+
+	organization, err := a.service.CreateOrganization(ctx.Request().Context(), data)
+	if err != nil {
+		return a.processError(ctx, err)
+	}
+
+	return ctx.JSON(http.StatusCreated, OrganizationResponseFromModel(organization))
+}
+
+func (a *Adapter) deleteOrganization(ctx echo.Context) error {
+	organizationUUID := ctx.Param("uuid")
+	if err := uuid.Validate(organizationUUID); err != nil {
+		return httpAPI.SendJSONError(ctx,
+			http.StatusBadRequest, http.StatusText(http.StatusBadRequest))
+	}
+	if err := a.service.DeleteOrganization(ctx.Request().Context(), organizationUUID); err != nil {
+		return a.processError(ctx, err)
+	}
+	return ctx.NoContent(http.StatusOK)
+}
+
+func (a *Adapter) listOrganizationMembers(ctx echo.Context) error {
+	organizationUUID := ctx.Param("uuid")
+	if err := uuid.Validate(organizationUUID); err != nil {
+		return httpAPI.SendJSONError(ctx,
+			http.StatusBadRequest, http.StatusText(http.StatusBadRequest))
+	}
+	members, err := a.service.ListOrganizationMembers(ctx.Request().Context(), organizationUUID)
+	if err != nil {
+		return a.processError(ctx, err)
+	}
+	resp := make([]OrganizationMemberResponse, len(members))
+	for i, member := range members {
+		resp[i] = OrganizationMemberResponseFromModel(member)
+	}
+	return ctx.JSON(http.StatusOK, resp)
+}
+
+func (a *Adapter) addOrganizationMember(ctx echo.Context) error {
+	organizationUUID, userUUID, ok := memberParams(ctx)
+	if !ok {
+		return httpAPI.SendJSONError(ctx,
+			http.StatusBadRequest, http.StatusText(http.StatusBadRequest))
+	}
+	err := a.service.AddOrganizationMember(ctx.Request().Context(), organizationUUID, userUUID)
+	if err != nil {
+		return a.processError(ctx, err)
+	}
+	return ctx.NoContent(http.StatusOK)
+}
+
+func (a *Adapter) removeOrganizationMember(ctx echo.Context) error {
+	organizationUUID, userUUID, ok := memberParams(ctx)
+	if !ok {
+		return httpAPI.SendJSONError(ctx,
+			http.StatusBadRequest, http.StatusText(http.StatusBadRequest))
+	}
+	err := a.service.RemoveOrganizationMember(ctx.Request().Context(), organizationUUID, userUUID)
+	if err != nil {
+		return a.processError(ctx, err)
+	}
+	return ctx.NoContent(http.StatusOK)
+}
+
+func (a *Adapter) grantOrganizationRole(ctx echo.Context) error {
+	organizationUUID, userUUID, ok := memberParams(ctx)
+	if !ok {
+		return httpAPI.SendJSONError(ctx,
+			http.StatusBadRequest, http.StatusText(http.StatusBadRequest))
+	}
+
+	req := new(GrantUserRoleRequest)
+
+	if err := ctx.Bind(req); err != nil {
+		return httpAPI.SendJSONError(ctx,
+			http.StatusBadRequest, http.StatusText(http.StatusBadRequest))
+	}
+
+	vErrs := tools.ValidateStruct(req)
+	if vErrs != nil {
+		return httpAPI.SendJSONError(
+			ctx, http.StatusBadRequest, http.StatusText(http.StatusBadRequest),
+			httpAPI.WithValidationErrors(vErrs...),
+		)
+	}
+
+	err := a.service.GrantOrganizationRole(
+		ctx.Request().Context(), organizationUUID, userUUID, req.Role, req.ExpiresAt)
+	if err != nil {
+		return a.processError(ctx, err)
+	}
+
+	return ctx.NoContent(http.StatusOK)
+}
+
+func (a *Adapter) revokeOrganizationRole(ctx echo.Context) error {
+	organizationUUID, userUUID, ok := memberParams(ctx)
+	if !ok {
+		return httpAPI.SendJSONError(ctx,
+			http.StatusBadRequest, http.StatusText(http.StatusBadRequest))
+	}
+	err := a.service.RevokeOrganizationRole(
+		ctx.Request().Context(), organizationUUID, userUUID, ctx.Param("role"))
+	if err != nil {
+		return a.processError(ctx, err)
+	}
+	return ctx.NoContent(http.StatusOK)
+}
+
+func memberParams(ctx echo.Context) (string, string, bool) {
+	organizationUUID, userUUID := ctx.Param("uuid"), ctx.Param("user_uuid")
+	if uuid.Validate(organizationUUID) != nil || uuid.Validate(userUUID) != nil {
+		return "", "", false
+	}
+	return organizationUUID, userUUID, true
+}
+
+// ----
+
+func (a *Adapter) listOAuthClients(ctx echo.Context) error {
+	clients, err := a.service.ListOAuthClients(ctx.Request().Context())
+	if err != nil {
+		return a.processError(ctx, err)
+	}
+	resp := make([]OAuthClientResponse, len(clients))
+	for i, client := range clients {
+		resp[i] = OAuthClientResponseFromModel(client)
+	}
+	return ctx.JSON(http.StatusOK, resp)
+}
+
+type CreateOAuthClientRequest struct {
+	Name         string   `json:"name"          v:"required,max=100"`
+	RedirectURIs []string `json:"redirect_uris" v:"dive,required,max=2048"`
+	GrantTypes   []string `json:"grant_types"   v:"required,min=1,dive,required"`
+	Permissions  []string `json:"permissions"   v:"dive,required"`
+	Public       bool     `json:"public"`
+}
+
+func (a *Adapter) createOAuthClient(ctx echo.Context) error {
+	authInfo := auth.RetrieveAuthFromContext(ctx.Request().Context())
+	if authInfo == nil {
+		return httpAPI.SendJSONError(ctx,
+			http.StatusUnauthorized, http.StatusText(http.StatusUnauthorized))
+	}
+
+	req := new(CreateOAuthClientRequest)
+
+	if err := ctx.Bind(req); err != nil {
+		return httpAPI.SendJSONError(ctx,
+			http.StatusBadRequest, http.StatusText(http.StatusBadRequest))
+	}
+
+	vErrs := tools.ValidateStruct(req)
+	if vErrs != nil {
+		return httpAPI.SendJSONError(
+			ctx, http.StatusBadRequest, http.StatusText(http.StatusBadRequest),
+			httpAPI.WithValidationErrors(vErrs...),
+		)
+	}
+
+	data := &domain.CreateOAuthClientData{
+		OwnerUUID:    authInfo.UserUUID,
+		Name:         strings.TrimSpace(req.Name),
+		RedirectURIs: req.RedirectURIs,
+		GrantTypes:   req.GrantTypes,
+		Permissions:  req.Permissions,
+		Public:       req.Public,
+	}
+
+	client, secret, err := a.service.CreateOAuthClient(ctx.Request().Context(), data)
+	if err != nil {
+		return a.processError(ctx, err)
+	}
+
+	return ctx.JSON(http.StatusCreated, CreateOAuthClientResponse{
+		OAuthClientResponse: OAuthClientResponseFromModel(client),
+		ClientSecret:        secret,
+	})
+}
+
+func (a *Adapter) deleteOAuthClient(ctx echo.Context) error {
+	if err := a.service.DeleteOAuthClient(ctx.Request().Context(), ctx.Param("client_id")); err != nil {
+		return a.processError(ctx, err)
+	}
+	return ctx.NoContent(http.StatusOK)
+}
@@ -3,6 +3,7 @@ package adapter
 import (
 	"errors"
 	"net/http"
+	"strings"
 
 	"github.com/labstack/echo/v4"
 
@@ -11,21 +12,87 @@ import (
 )
 
 func (a *Adapter) processError(ctx echo.Context, err error) error {
+	var policyErr *domain.PasswordPolicyError
 	switch {
+	case errors.As(err, &policyErr):
+		return httpAPI.SendJSONError(ctx,
+			http.StatusBadRequest, http.StatusText(http.StatusBadRequest),
+			httpAPI.WithDetail(policyErr.Detail),
+			httpAPI.WithValidationErrors(passwordPolicyViolation{policyErr}))
 	case errors.Is(err, domain.ErrEntityNotFound):
 		return httpAPI.SendJSONError(ctx,
 			http.StatusNotFound, http.StatusText(http.StatusNotFound))
 	case errors.Is(err, domain.ErrUserAlreadyExists):
 		return httpAPI.SendJSONError(ctx,
 			http.StatusConflict, http.StatusText(http.StatusConflict))
-	case errors.Is(err, domain.ErrInvalidCredentials):
+	case errors.Is(err, domain.ErrRoleAlreadyExists), errors.Is(err, domain.ErrRoleIsBuiltIn),
+		errors.Is(err, domain.ErrOrganizationExists):
+		return httpAPI.SendJSONError(ctx,
+			http.StatusConflict, http.StatusText(http.StatusConflict))
+	case errors.Is(err, domain.ErrMFAAlreadyEnabled), errors.Is(err, domain.ErrMFANotEnabled),
+		errors.Is(err, domain.ErrUserStatusConflict):
+		return httpAPI.SendJSONError(ctx,
+			http.StatusConflict, http.StatusText(http.StatusConflict))
+	case errors.Is(err, domain.ErrAccountLocked):
+		return httpAPI.SendJSONError(ctx,
+			http.StatusTooManyRequests, http.StatusText(http.StatusTooManyRequests))
+	case errors.Is(err, domain.ErrInvalidCredentials), errors.Is(err, domain.ErrInvalidMFACode),
+		errors.Is(err, domain.ErrInvalidCursor):
 		return httpAPI.SendJSONError(ctx,
 			http.StatusBadRequest, http.StatusText(http.StatusBadRequest))
-	case errors.Is(err, domain.ErrInvalidToken):
+	case errors.Is(err, domain.ErrInvalidToken), errors.Is(err, domain.ErrSSOFailed):
 		return httpAPI.SendJSONError(ctx,
 			http.StatusUnauthorized, http.StatusText(http.StatusUnauthorized))
+	case errors.Is(err, domain.ErrOAuthInvalidRequest), errors.Is(err, domain.ErrOAuthInvalidClient),
+		errors.Is(err, domain.ErrOAuthInvalidRedirectURI), errors.Is(err, domain.ErrOAuthUnsupportedGrantType):
+		return httpAPI.SendJSONError(ctx,
+			http.StatusBadRequest, http.StatusText(http.StatusBadRequest), httpAPI.WithDetail(err.Error()))
+	case errors.Is(err, domain.ErrPermissionDenied), errors.Is(err, domain.ErrEmailNotVerified),
+		errors.Is(err, domain.ErrUserNotProvisioned):
+		return httpAPI.SendJSONError(ctx,
+			http.StatusForbidden, http.StatusText(http.StatusForbidden))
 	default:
 		return httpAPI.SendJSONError(ctx,
 			http.StatusInternalServerError, err.Error())
 	}
 }
+
+// passwordPolicyViolation renders rejected password as validation error of password field.
+type passwordPolicyViolation struct {
+	err *domain.PasswordPolicyError
+}
+
+func (v passwordPolicyViolation) Pointer() string { return "/password" }
+func (v passwordPolicyViolation) Detail() string  { return v.err.Detail }
+func (v passwordPolicyViolation) Code() string    { return v.err.Reason }
+
+var oauthErrors = []error{
+	domain.ErrOAuthInvalidRequest,
+	domain.ErrOAuthInvalidClient,
+	domain.ErrOAuthInvalidGrant,
+	domain.ErrOAuthUnauthorizedClient,
+	domain.ErrOAuthUnsupportedGrantType,
+	domain.ErrOAuthInvalidScope,
+}
+
+// sendOAuthError responds in format of RFC 6749 section 5.2, used only by token endpoint.
+func (a *Adapter) sendOAuthError(ctx echo.Context, err error, basicAuth bool) error {
+	for _, oauthErr := range oauthErrors {
+		if !errors.Is(err, oauthErr) {
+			continue
+		}
+		resp := OAuthErrorResponse{Error: oauthErr.Error()}
+		if err.Error() != oauthErr.Error() {
+			resp.ErrorDescription = strings.TrimPrefix(err.Error(), oauthErr.Error()+": ")
+		}
+		status := http.StatusBadRequest
+		if errors.Is(oauthErr, domain.ErrOAuthInvalidClient) {
+			status = http.StatusUnauthorized
+			if basicAuth {
+				ctx.Response().Header().Set(echo.HeaderWWWAuthenticate, `Basic realm="oauth"`)
+			}
+		}
+		return ctx.JSON(status, resp)
+	}
+	return ctx.JSON(http.StatusInternalServerError, OAuthErrorResponse{Error: "server_error"})
+}
@@ -11,6 +11,7 @@ package mocks
 
 import (
 	context "context"
+	url "net/url"
 	reflect "reflect"
 	time "time"
 
@@ -43,6 +44,186 @@ func (m *MockserviceAccessor) EXPECT() *MockserviceAccessorMockRecorder {
 	return m.recorder
 }
 
+// AddOrganizationMember mocks base method.
+func (m *MockserviceAccessor) AddOrganizationMember(ctx context.Context, organizationUUID, userUUID string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddOrganizationMember", ctx, organizationUUID, userUUID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AddOrganizationMember indicates an expected call of AddOrganizationMember.
+func (mr *MockserviceAccessorMockRecorder) AddOrganizationMember(ctx, organizationUUID, userUUID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddOrganizationMember", reflect.TypeOf((*MockserviceAccessor)(nil).AddOrganizationMember), ctx, organizationUUID, userUUID)
+}
+
+// AttachPermissionToRole mocks base method.
+func (m *MockserviceAccessor) AttachPermissionToRole(ctx context.Context, name, permission string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AttachPermissionToRole", ctx, name, permission)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AttachPermissionToRole indicates an expected call of AttachPermissionToRole.
+func (mr *MockserviceAccessorMockRecorder) AttachPermissionToRole(ctx, name, permission any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AttachPermissionToRole", reflect.TypeOf((*MockserviceAccessor)(nil).AttachPermissionToRole), ctx, name, permission)
+}
+
+// AuthorizationPageURL mocks base method.
+func (m *MockserviceAccessor) AuthorizationPageURL(ctx context.Context, req *domain.AuthorizeRequest, params url.Values) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AuthorizationPageURL", ctx, req, params)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AuthorizationPageURL indicates an expected call of AuthorizationPageURL.
+func (mr *MockserviceAccessorMockRecorder) AuthorizationPageURL(ctx, req, params any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AuthorizationPageURL", reflect.TypeOf((*MockserviceAccessor)(nil).AuthorizationPageURL), ctx, req, params)
+}
+
+// Authorize mocks base method.
+func (m *MockserviceAccessor) Authorize(ctx context.Context, userUUID string, req *domain.AuthorizeRequest) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Authorize", ctx, userUUID, req)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Authorize indicates an expected call of Authorize.
+func (mr *MockserviceAccessorMockRecorder) Authorize(ctx, userUUID, req any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Authorize", reflect.TypeOf((*MockserviceAccessor)(nil).Authorize), ctx, userUUID, req)
+}
+
+// CheckAccess mocks base method.
+func (m *MockserviceAccessor) CheckAccess(ctx context.Context, req *domain.AccessRequest) *domain.AccessDecision {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CheckAccess", ctx, req)
+	ret0, _ := ret[0].(*domain.AccessDecision)
+	return ret0
+}
+
+// CheckAccess indicates an expected call of CheckAccess.
+func (mr *MockserviceAccessorMockRecorder) CheckAccess(ctx, req any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CheckAccess", reflect.TypeOf((*MockserviceAccessor)(nil).CheckAccess), ctx, req)
+}
+
+// CompleteSSO mocks base method.
+func (m *MockserviceAccessor) CompleteSSO(ctx context.Context, provider, state, code, verifier string, client domain.ClientInfo) (*domain.Tokens, *domain.MFAChallenge, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CompleteSSO", ctx, provider, state, code, verifier, client)
+	ret0, _ := ret[0].(*domain.Tokens)
+	ret1, _ := ret[1].(*domain.MFAChallenge)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// CompleteSSO indicates an expected call of CompleteSSO.
+func (mr *MockserviceAccessorMockRecorder) CompleteSSO(ctx, provider, state, code, verifier, client any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CompleteSSO", reflect.TypeOf((*MockserviceAccessor)(nil).CompleteSSO), ctx, provider, state, code, verifier, client)
+}
+
+// ConfirmMFA mocks base method.
+func (m *MockserviceAccessor) ConfirmMFA(ctx context.Context, userUUID, code string) ([]string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ConfirmMFA", ctx, userUUID, code)
+	ret0, _ := ret[0].([]string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ConfirmMFA indicates an expected call of ConfirmMFA.
+func (mr *MockserviceAccessorMockRecorder) ConfirmMFA(ctx, userUUID, code any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ConfirmMFA", reflect.TypeOf((*MockserviceAccessor)(nil).ConfirmMFA), ctx, userUUID, code)
+}
+
+// CreateAPIToken mocks base method.
+func (m *MockserviceAccessor) CreateAPIToken(ctx context.Context, userUUID string, data *domain.CreateAPITokenData) (*models.Token, string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateAPIToken", ctx, userUUID, data)
+	ret0, _ := ret[0].(*models.Token)
+	ret1, _ := ret[1].(string)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// CreateAPIToken indicates an expected call of CreateAPIToken.
+func (mr *MockserviceAccessorMockRecorder) CreateAPIToken(ctx, userUUID, data any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateAPIToken", reflect.TypeOf((*MockserviceAccessor)(nil).CreateAPIToken), ctx, userUUID, data)
+}
+
+// CreateDataRequest mocks base method.
+func (m *MockserviceAccessor) CreateDataRequest(ctx context.Context, userUUID, requestType string) (*models.DataRequest, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateDataRequest", ctx, userUUID, requestType)
+	ret0, _ := ret[0].(*models.DataRequest)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateDataRequest indicates an expected call of CreateDataRequest.
+func (mr *MockserviceAccessorMockRecorder) CreateDataRequest(ctx, userUUID, requestType any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateDataRequest", reflect.TypeOf((*MockserviceAccessor)(nil).CreateDataRequest), ctx, userUUID, requestType)
+}
+
+// CreateOAuthClient mocks base method.
+func (m *MockserviceAccessor) CreateOAuthClient(ctx context.Context, data *domain.CreateOAuthClientData) (*models.OAuthClient, string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateOAuthClient", ctx, data)
+	ret0, _ := ret[0].(*models.OAuthClient)
+	ret1, _ := ret[1].(string)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// CreateOAuthClient indicates an expected call of CreateOAuthClient.
+func (mr *MockserviceAccessorMockRecorder) CreateOAuthClient(ctx, data any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateOAuthClient", reflect.TypeOf((*MockserviceAccessor)(nil).CreateOAuthClient), ctx, data)
+}
+
+// CreateOrganization mocks base method.
+func (m *MockserviceAccessor) CreateOrganization(ctx context.Context, data *domain.CreateOrganizationData) (*models.Organization, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateOrganization", ctx, data)
+	ret0, _ := ret[0].(*models.Organization)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateOrganization indicates an expected call of CreateOrganization.
+func (mr *MockserviceAccessorMockRecorder) CreateOrganization(ctx, data any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateOrganization", reflect.TypeOf((*MockserviceAccessor)(nil).CreateOrganization), ctx, data)
+}
+
+// CreateRole mocks base method.
+func (m *MockserviceAccessor) CreateRole(ctx context.Context, data *domain.CreateRoleData) (*models.Role, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateRole", ctx, data)
+	ret0, _ := ret[0].(*models.Role)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateRole indicates an expected call of CreateRole.
+func (mr *MockserviceAccessorMockRecorder) CreateRole(ctx, data any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateRole", reflect.TypeOf((*MockserviceAccessor)(nil).CreateRole), ctx, data)
+}
+
 // CreateUser mocks base method.
 func (m *MockserviceAccessor) CreateUser(ctx context.Context, data *domain.CreateUserData) (*models.User, error) {
 	m.ctrl.T.Helper()
@@ -58,6 +239,48 @@ func (mr *MockserviceAccessorMockRecorder) CreateUser(ctx, data any) *gomock.Cal
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateUser", reflect.TypeOf((*MockserviceAccessor)(nil).CreateUser), ctx, data)
 }
 
+// DeleteOAuthClient mocks base method.
+func (m *MockserviceAccessor) DeleteOAuthClient(ctx context.Context, clientID string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteOAuthClient", ctx, clientID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteOAuthClient indicates an expected call of DeleteOAuthClient.
+func (mr *MockserviceAccessorMockRecorder) DeleteOAuthClient(ctx, clientID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteOAuthClient", reflect.TypeOf((*MockserviceAccessor)(nil).DeleteOAuthClient), ctx, clientID)
+}
+
+// DeleteOrganization mocks base method.
+func (m *MockserviceAccessor) DeleteOrganization(ctx context.Context, organizationUUID string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteOrganization", ctx, organizationUUID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteOrganization indicates an expected call of DeleteOrganization.
+func (mr *MockserviceAccessorMockRecorder) DeleteOrganization(ctx, organizationUUID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteOrganization", reflect.TypeOf((*MockserviceAccessor)(nil).DeleteOrganization), ctx, organizationUUID)
+}
+
+// DeleteRole mocks base method.
+func (m *MockserviceAccessor) DeleteRole(ctx context.Context, name string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteRole", ctx, name)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteRole indicates an expected call of DeleteRole.
+func (mr *MockserviceAccessorMockRecorder) DeleteRole(ctx, name any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteRole", reflect.TypeOf((*MockserviceAccessor)(nil).DeleteRole), ctx, name)
+}
+
 // DeleteUser mocks base method.
 func (m *MockserviceAccessor) DeleteUser(ctx context.Context, uuid string) error {
 	m.ctrl.T.Helper()
@@ -72,6 +295,124 @@ func (mr *MockserviceAccessorMockRecorder) DeleteUser(ctx, uuid any) *gomock.Cal
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteUser", reflect.TypeOf((*MockserviceAccessor)(nil).DeleteUser), ctx, uuid)
 }
 
+// DetachPermissionFromRole mocks base method.
+func (m *MockserviceAccessor) DetachPermissionFromRole(ctx context.Context, name, permission string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DetachPermissionFromRole", ctx, name, permission)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DetachPermissionFromRole indicates an expected call of DetachPermissionFromRole.
+func (mr *MockserviceAccessorMockRecorder) DetachPermissionFromRole(ctx, name, permission any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DetachPermissionFromRole", reflect.TypeOf((*MockserviceAccessor)(nil).DetachPermissionFromRole), ctx, name, permission)
+}
+
+// DisableMFA mocks base method.
+func (m *MockserviceAccessor) DisableMFA(ctx context.Context, userUUID, code string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DisableMFA", ctx, userUUID, code)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DisableMFA indicates an expected call of DisableMFA.
+func (mr *MockserviceAccessorMockRecorder) DisableMFA(ctx, userUUID, code any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DisableMFA", reflect.TypeOf((*MockserviceAccessor)(nil).DisableMFA), ctx, userUUID, code)
+}
+
+// EnrollMFA mocks base method.
+func (m *MockserviceAccessor) EnrollMFA(ctx context.Context, userUUID string) (*domain.MFAEnrollment, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "EnrollMFA", ctx, userUUID)
+	ret0, _ := ret[0].(*domain.MFAEnrollment)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// EnrollMFA indicates an expected call of EnrollMFA.
+func (mr *MockserviceAccessorMockRecorder) EnrollMFA(ctx, userUUID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "EnrollMFA", reflect.TypeOf((*MockserviceAccessor)(nil).EnrollMFA), ctx, userUUID)
+}
+
+// ExchangeOAuthToken mocks base method.
+func (m *MockserviceAccessor) ExchangeOAuthToken(ctx context.Context, req *domain.OAuthTokenRequest, client domain.ClientInfo) (*domain.OAuthTokens, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ExchangeOAuthToken", ctx, req, client)
+	ret0, _ := ret[0].(*domain.OAuthTokens)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ExchangeOAuthToken indicates an expected call of ExchangeOAuthToken.
+func (mr *MockserviceAccessorMockRecorder) ExchangeOAuthToken(ctx, req, client any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ExchangeOAuthToken", reflect.TypeOf((*MockserviceAccessor)(nil).ExchangeOAuthToken), ctx, req, client)
+}
+
+// GetDataRequest mocks base method.
+func (m *MockserviceAccessor) GetDataRequest(ctx context.Context, userUUID, requestUUID string) (*models.DataRequest, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetDataRequest", ctx, userUUID, requestUUID)
+	ret0, _ := ret[0].(*models.DataRequest)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetDataRequest indicates an expected call of GetDataRequest.
+func (mr *MockserviceAccessorMockRecorder) GetDataRequest(ctx, userUUID, requestUUID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDataRequest", reflect.TypeOf((*MockserviceAccessor)(nil).GetDataRequest), ctx, userUUID, requestUUID)
+}
+
+// GetDataRequestArchive mocks base method.
+func (m *MockserviceAccessor) GetDataRequestArchive(ctx context.Context, userUUID, requestUUID string) ([]byte, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetDataRequestArchive", ctx, userUUID, requestUUID)
+	ret0, _ := ret[0].([]byte)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetDataRequestArchive indicates an expected call of GetDataRequestArchive.
+func (mr *MockserviceAccessorMockRecorder) GetDataRequestArchive(ctx, userUUID, requestUUID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDataRequestArchive", reflect.TypeOf((*MockserviceAccessor)(nil).GetDataRequestArchive), ctx, userUUID, requestUUID)
+}
+
+// GetMFAStatus mocks base method.
+func (m *MockserviceAccessor) GetMFAStatus(ctx context.Context, userUUID string) (*domain.MFAStatus, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetMFAStatus", ctx, userUUID)
+	ret0, _ := ret[0].(*domain.MFAStatus)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetMFAStatus indicates an expected call of GetMFAStatus.
+func (mr *MockserviceAccessorMockRecorder) GetMFAStatus(ctx, userUUID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetMFAStatus", reflect.TypeOf((*MockserviceAccessor)(nil).GetMFAStatus), ctx, userUUID)
+}
+
+// GetOrganizationMember mocks base method.
+func (m *MockserviceAccessor) GetOrganizationMember(ctx context.Context, organizationUUID string, userID int) (*models.OrganizationMember, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetOrganizationMember", ctx, organizationUUID, userID)
+	ret0, _ := ret[0].(*models.OrganizationMember)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetOrganizationMember indicates an expected call of GetOrganizationMember.
+func (mr *MockserviceAccessorMockRecorder) GetOrganizationMember(ctx, organizationUUID, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetOrganizationMember", reflect.TypeOf((*MockserviceAccessor)(nil).GetOrganizationMember), ctx, organizationUUID, userID)
+}
+
 // GetUserByID mocks base method.
 func (m *MockserviceAccessor) GetUserByID(ctx context.Context, id int) (*models.User, error) {
 	m.ctrl.T.Helper()
@@ -102,6 +443,49 @@ func (mr *MockserviceAccessorMockRecorder) GetUserByUUID(ctx, uuid any) *gomock.
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserByUUID", reflect.TypeOf((*MockserviceAccessor)(nil).GetUserByUUID), ctx, uuid)
 }
 
+// GrantOrganizationRole mocks base method.
+func (m *MockserviceAccessor) GrantOrganizationRole(ctx context.Context, organizationUUID, userUUID, name string, expiresAt *time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GrantOrganizationRole", ctx, organizationUUID, userUUID, name, expiresAt)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// GrantOrganizationRole indicates an expected call of GrantOrganizationRole.
+func (mr *MockserviceAccessorMockRecorder) GrantOrganizationRole(ctx, organizationUUID, userUUID, name, expiresAt any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GrantOrganizationRole", reflect.TypeOf((*MockserviceAccessor)(nil).GrantOrganizationRole), ctx, organizationUUID, userUUID, name, expiresAt)
+}
+
+// GrantRoleToUser mocks base method.
+func (m *MockserviceAccessor) GrantRoleToUser(ctx context.Context, userUUID, name string, expiresAt *time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GrantRoleToUser", ctx, userUUID, name, expiresAt)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// GrantRoleToUser indicates an expected call of GrantRoleToUser.
+func (mr *MockserviceAccessorMockRecorder) GrantRoleToUser(ctx, userUUID, name, expiresAt any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GrantRoleToUser", reflect.TypeOf((*MockserviceAccessor)(nil).GrantRoleToUser), ctx, userUUID, name, expiresAt)
+}
+
+// Impersonate mocks base method.
+func (m *MockserviceAccessor) Impersonate(ctx context.Context, userUUID string) (*domain.ImpersonationToken, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Impersonate", ctx, userUUID)
+	ret0, _ := ret[0].(*domain.ImpersonationToken)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Impersonate indicates an expected call of Impersonate.
+func (mr *MockserviceAccessorMockRecorder) Impersonate(ctx, userUUID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Impersonate", reflect.TypeOf((*MockserviceAccessor)(nil).Impersonate), ctx, userUUID)
+}
+
 // InvalidateJWTToken mocks base method.
 func (m *MockserviceAccessor) InvalidateJWTToken(ctx context.Context, token string, until time.Time) error {
 	m.ctrl.T.Helper()
@@ -116,34 +500,156 @@ func (mr *MockserviceAccessorMockRecorder) InvalidateJWTToken(ctx, token, until
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InvalidateJWTToken", reflect.TypeOf((*MockserviceAccessor)(nil).InvalidateJWTToken), ctx, token, until)
 }
 
-// ListUsers mocks base method.
-func (m *MockserviceAccessor) ListUsers(ctx context.Context, limit, offset int) ([]*models.User, error) {
+// ListAPITokens mocks base method.
+func (m *MockserviceAccessor) ListAPITokens(ctx context.Context, userUUID string) ([]*models.Token, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "ListUsers", ctx, limit, offset)
-	ret0, _ := ret[0].([]*models.User)
+	ret := m.ctrl.Call(m, "ListAPITokens", ctx, userUUID)
+	ret0, _ := ret[0].([]*models.Token)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListAPITokens indicates an expected call of ListAPITokens.
+func (mr *MockserviceAccessorMockRecorder) ListAPITokens(ctx, userUUID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListAPITokens", reflect.TypeOf((*MockserviceAccessor)(nil).ListAPITokens), ctx, userUUID)
+}
+
+// ListOAuthClients mocks base method.
+func (m *MockserviceAccessor) ListOAuthClients(ctx context.Context) ([]*models.OAuthClient, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListOAuthClients", ctx)
+	ret0, _ := ret[0].([]*models.OAuthClient)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListOAuthClients indicates an expected call of ListOAuthClients.
+func (mr *MockserviceAccessorMockRecorder) ListOAuthClients(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListOAuthClients", reflect.TypeOf((*MockserviceAccessor)(nil).ListOAuthClients), ctx)
+}
+
+// ListOrganizationMembers mocks base method.
+func (m *MockserviceAccessor) ListOrganizationMembers(ctx context.Context, organizationUUID string) ([]*models.OrganizationMember, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListOrganizationMembers", ctx, organizationUUID)
+	ret0, _ := ret[0].([]*models.OrganizationMember)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListOrganizationMembers indicates an expected call of ListOrganizationMembers.
+func (mr *MockserviceAccessorMockRecorder) ListOrganizationMembers(ctx, organizationUUID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListOrganizationMembers", reflect.TypeOf((*MockserviceAccessor)(nil).ListOrganizationMembers), ctx, organizationUUID)
+}
+
+// ListOrganizations mocks base method.
+func (m *MockserviceAccessor) ListOrganizations(ctx context.Context) ([]*models.Organization, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListOrganizations", ctx)
+	ret0, _ := ret[0].([]*models.Organization)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListOrganizations indicates an expected call of ListOrganizations.
+func (mr *MockserviceAccessorMockRecorder) ListOrganizations(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListOrganizations", reflect.TypeOf((*MockserviceAccessor)(nil).ListOrganizations), ctx)
+}
+
+// ListRoles mocks base method.
+func (m *MockserviceAccessor) ListRoles(ctx context.Context) ([]*models.Role, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListRoles", ctx)
+	ret0, _ := ret[0].([]*models.Role)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListRoles indicates an expected call of ListRoles.
+func (mr *MockserviceAccessorMockRecorder) ListRoles(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListRoles", reflect.TypeOf((*MockserviceAccessor)(nil).ListRoles), ctx)
+}
+
+// ListUserHistory mocks base method.
+func (m *MockserviceAccessor) ListUserHistory(ctx context.Context, userUUID string, filter *domain.UserHistoryFilter) ([]*models.UserHistoryRecord, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListUserHistory", ctx, userUUID, filter)
+	ret0, _ := ret[0].([]*models.UserHistoryRecord)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListUserHistory indicates an expected call of ListUserHistory.
+func (mr *MockserviceAccessorMockRecorder) ListUserHistory(ctx, userUUID, filter any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListUserHistory", reflect.TypeOf((*MockserviceAccessor)(nil).ListUserHistory), ctx, userUUID, filter)
+}
+
+// ListUserOrganizations mocks base method.
+func (m *MockserviceAccessor) ListUserOrganizations(ctx context.Context, userUUID string) ([]*models.Organization, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListUserOrganizations", ctx, userUUID)
+	ret0, _ := ret[0].([]*models.Organization)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListUserOrganizations indicates an expected call of ListUserOrganizations.
+func (mr *MockserviceAccessorMockRecorder) ListUserOrganizations(ctx, userUUID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListUserOrganizations", reflect.TypeOf((*MockserviceAccessor)(nil).ListUserOrganizations), ctx, userUUID)
+}
+
+// ListUserSessions mocks base method.
+func (m *MockserviceAccessor) ListUserSessions(ctx context.Context, userUUID string) ([]*models.Session, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListUserSessions", ctx, userUUID)
+	ret0, _ := ret[0].([]*models.Session)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
+// ListUserSessions indicates an expected call of ListUserSessions.
+func (mr *MockserviceAccessorMockRecorder) ListUserSessions(ctx, userUUID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListUserSessions", reflect.TypeOf((*MockserviceAccessor)(nil).ListUserSessions), ctx, userUUID)
+}
+
+// ListUsers mocks base method.
+func (m *MockserviceAccessor) ListUsers(ctx context.Context, query *domain.ListUsersQuery) ([]*models.User, string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListUsers", ctx, query)
+	ret0, _ := ret[0].([]*models.User)
+	ret1, _ := ret[1].(string)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
 // ListUsers indicates an expected call of ListUsers.
-func (mr *MockserviceAccessorMockRecorder) ListUsers(ctx, limit, offset any) *gomock.Call {
+func (mr *MockserviceAccessorMockRecorder) ListUsers(ctx, query any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListUsers", reflect.TypeOf((*MockserviceAccessor)(nil).ListUsers), ctx, limit, offset)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListUsers", reflect.TypeOf((*MockserviceAccessor)(nil).ListUsers), ctx, query)
 }
 
 // Login mocks base method.
-func (m *MockserviceAccessor) Login(ctx context.Context, email, password string) (*domain.Tokens, error) {
+func (m *MockserviceAccessor) Login(ctx context.Context, email, password string, client domain.ClientInfo) (*domain.Tokens, *domain.MFAChallenge, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "Login", ctx, email, password)
+	ret := m.ctrl.Call(m, "Login", ctx, email, password, client)
 	ret0, _ := ret[0].(*domain.Tokens)
-	ret1, _ := ret[1].(error)
-	return ret0, ret1
+	ret1, _ := ret[1].(*domain.MFAChallenge)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
 }
 
 // Login indicates an expected call of Login.
-func (mr *MockserviceAccessorMockRecorder) Login(ctx, email, password any) *gomock.Call {
+func (mr *MockserviceAccessorMockRecorder) Login(ctx, email, password, client any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Login", reflect.TypeOf((*MockserviceAccessor)(nil).Login), ctx, email, password)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Login", reflect.TypeOf((*MockserviceAccessor)(nil).Login), ctx, email, password, client)
 }
 
 // Logout mocks base method.
@@ -160,6 +666,20 @@ func (mr *MockserviceAccessorMockRecorder) Logout(ctx, accessToken, refreshToken
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Logout", reflect.TypeOf((*MockserviceAccessor)(nil).Logout), ctx, accessToken, refreshToken)
 }
 
+// RecordImpersonatedRequest mocks base method.
+func (m *MockserviceAccessor) RecordImpersonatedRequest(ctx context.Context, operation, ipAddress string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RecordImpersonatedRequest", ctx, operation, ipAddress)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RecordImpersonatedRequest indicates an expected call of RecordImpersonatedRequest.
+func (mr *MockserviceAccessorMockRecorder) RecordImpersonatedRequest(ctx, operation, ipAddress any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RecordImpersonatedRequest", reflect.TypeOf((*MockserviceAccessor)(nil).RecordImpersonatedRequest), ctx, operation, ipAddress)
+}
+
 // Refresh mocks base method.
 func (m *MockserviceAccessor) Refresh(ctx context.Context, token string) (*domain.Tokens, error) {
 	m.ctrl.T.Helper()
@@ -175,6 +695,203 @@ func (mr *MockserviceAccessorMockRecorder) Refresh(ctx, token any) *gomock.Call
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Refresh", reflect.TypeOf((*MockserviceAccessor)(nil).Refresh), ctx, token)
 }
 
+// RegenerateMFARecoveryCodes mocks base method.
+func (m *MockserviceAccessor) RegenerateMFARecoveryCodes(ctx context.Context, userUUID, code string) ([]string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RegenerateMFARecoveryCodes", ctx, userUUID, code)
+	ret0, _ := ret[0].([]string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RegenerateMFARecoveryCodes indicates an expected call of RegenerateMFARecoveryCodes.
+func (mr *MockserviceAccessorMockRecorder) RegenerateMFARecoveryCodes(ctx, userUUID, code any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RegenerateMFARecoveryCodes", reflect.TypeOf((*MockserviceAccessor)(nil).RegenerateMFARecoveryCodes), ctx, userUUID, code)
+}
+
+// ReinstateUser mocks base method.
+func (m *MockserviceAccessor) ReinstateUser(ctx context.Context, userUUID string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReinstateUser", ctx, userUUID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ReinstateUser indicates an expected call of ReinstateUser.
+func (mr *MockserviceAccessorMockRecorder) ReinstateUser(ctx, userUUID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReinstateUser", reflect.TypeOf((*MockserviceAccessor)(nil).ReinstateUser), ctx, userUUID)
+}
+
+// RemoveOrganizationMember mocks base method.
+func (m *MockserviceAccessor) RemoveOrganizationMember(ctx context.Context, organizationUUID, userUUID string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RemoveOrganizationMember", ctx, organizationUUID, userUUID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RemoveOrganizationMember indicates an expected call of RemoveOrganizationMember.
+func (mr *MockserviceAccessorMockRecorder) RemoveOrganizationMember(ctx, organizationUUID, userUUID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemoveOrganizationMember", reflect.TypeOf((*MockserviceAccessor)(nil).RemoveOrganizationMember), ctx, organizationUUID, userUUID)
+}
+
+// RequestEmailVerification mocks base method.
+func (m *MockserviceAccessor) RequestEmailVerification(ctx context.Context, email string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RequestEmailVerification", ctx, email)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RequestEmailVerification indicates an expected call of RequestEmailVerification.
+func (mr *MockserviceAccessorMockRecorder) RequestEmailVerification(ctx, email any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RequestEmailVerification", reflect.TypeOf((*MockserviceAccessor)(nil).RequestEmailVerification), ctx, email)
+}
+
+// RequestPasswordReset mocks base method.
+func (m *MockserviceAccessor) RequestPasswordReset(ctx context.Context, email string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RequestPasswordReset", ctx, email)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RequestPasswordReset indicates an expected call of RequestPasswordReset.
+func (mr *MockserviceAccessorMockRecorder) RequestPasswordReset(ctx, email any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RequestPasswordReset", reflect.TypeOf((*MockserviceAccessor)(nil).RequestPasswordReset), ctx, email)
+}
+
+// ResetPassword mocks base method.
+func (m *MockserviceAccessor) ResetPassword(ctx context.Context, token, password string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ResetPassword", ctx, token, password)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ResetPassword indicates an expected call of ResetPassword.
+func (mr *MockserviceAccessorMockRecorder) ResetPassword(ctx, token, password any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ResetPassword", reflect.TypeOf((*MockserviceAccessor)(nil).ResetPassword), ctx, token, password)
+}
+
+// ResetUserMFA mocks base method.
+func (m *MockserviceAccessor) ResetUserMFA(ctx context.Context, userUUID string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ResetUserMFA", ctx, userUUID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ResetUserMFA indicates an expected call of ResetUserMFA.
+func (mr *MockserviceAccessorMockRecorder) ResetUserMFA(ctx, userUUID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ResetUserMFA", reflect.TypeOf((*MockserviceAccessor)(nil).ResetUserMFA), ctx, userUUID)
+}
+
+// RestoreUser mocks base method.
+func (m *MockserviceAccessor) RestoreUser(ctx context.Context, userUUID string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RestoreUser", ctx, userUUID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RestoreUser indicates an expected call of RestoreUser.
+func (mr *MockserviceAccessorMockRecorder) RestoreUser(ctx, userUUID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RestoreUser", reflect.TypeOf((*MockserviceAccessor)(nil).RestoreUser), ctx, userUUID)
+}
+
+// RevokeAPIToken mocks base method.
+func (m *MockserviceAccessor) RevokeAPIToken(ctx context.Context, userUUID, tokenUUID string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RevokeAPIToken", ctx, userUUID, tokenUUID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RevokeAPIToken indicates an expected call of RevokeAPIToken.
+func (mr *MockserviceAccessorMockRecorder) RevokeAPIToken(ctx, userUUID, tokenUUID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RevokeAPIToken", reflect.TypeOf((*MockserviceAccessor)(nil).RevokeAPIToken), ctx, userUUID, tokenUUID)
+}
+
+// RevokeOrganizationRole mocks base method.
+func (m *MockserviceAccessor) RevokeOrganizationRole(ctx context.Context, organizationUUID, userUUID, name string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RevokeOrganizationRole", ctx, organizationUUID, userUUID, name)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RevokeOrganizationRole indicates an expected call of RevokeOrganizationRole.
+func (mr *MockserviceAccessorMockRecorder) RevokeOrganizationRole(ctx, organizationUUID, userUUID, name any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RevokeOrganizationRole", reflect.TypeOf((*MockserviceAccessor)(nil).RevokeOrganizationRole), ctx, organizationUUID, userUUID, name)
+}
+
+// RevokeRoleFromUser mocks base method.
+func (m *MockserviceAccessor) RevokeRoleFromUser(ctx context.Context, userUUID, name string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RevokeRoleFromUser", ctx, userUUID, name)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RevokeRoleFromUser indicates an expected call of RevokeRoleFromUser.
+func (mr *MockserviceAccessorMockRecorder) RevokeRoleFromUser(ctx, userUUID, name any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RevokeRoleFromUser", reflect.TypeOf((*MockserviceAccessor)(nil).RevokeRoleFromUser), ctx, userUUID, name)
+}
+
+// RevokeUserSession mocks base method.
+func (m *MockserviceAccessor) RevokeUserSession(ctx context.Context, userUUID, sessionUUID string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RevokeUserSession", ctx, userUUID, sessionUUID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RevokeUserSession indicates an expected call of RevokeUserSession.
+func (mr *MockserviceAccessorMockRecorder) RevokeUserSession(ctx, userUUID, sessionUUID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RevokeUserSession", reflect.TypeOf((*MockserviceAccessor)(nil).RevokeUserSession), ctx, userUUID, sessionUUID)
+}
+
+// RevokeUserSessions mocks base method.
+func (m *MockserviceAccessor) RevokeUserSessions(ctx context.Context, userUUID string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RevokeUserSessions", ctx, userUUID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RevokeUserSessions indicates an expected call of RevokeUserSessions.
+func (mr *MockserviceAccessorMockRecorder) RevokeUserSessions(ctx, userUUID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RevokeUserSessions", reflect.TypeOf((*MockserviceAccessor)(nil).RevokeUserSessions), ctx, userUUID)
+}
+
+// SSOProviders mocks base method.
+func (m *MockserviceAccessor) SSOProviders() []string {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SSOProviders")
+	ret0, _ := ret[0].([]string)
+	return ret0
+}
+
+// SSOProviders indicates an expected call of SSOProviders.
+func (mr *MockserviceAccessorMockRecorder) SSOProviders() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SSOProviders", reflect.TypeOf((*MockserviceAccessor)(nil).SSOProviders))
+}
+
 // SignUp mocks base method.
 func (m *MockserviceAccessor) SignUp(ctx context.Context, email, password string) (*models.User, error) {
 	m.ctrl.T.Helper()
@@ -190,6 +907,64 @@ func (mr *MockserviceAccessorMockRecorder) SignUp(ctx, email, password any) *gom
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SignUp", reflect.TypeOf((*MockserviceAccessor)(nil).SignUp), ctx, email, password)
 }
 
+// StartSSO mocks base method.
+func (m *MockserviceAccessor) StartSSO(ctx context.Context, provider string) (*domain.SSOAuthorization, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "StartSSO", ctx, provider)
+	ret0, _ := ret[0].(*domain.SSOAuthorization)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// StartSSO indicates an expected call of StartSSO.
+func (mr *MockserviceAccessorMockRecorder) StartSSO(ctx, provider any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StartSSO", reflect.TypeOf((*MockserviceAccessor)(nil).StartSSO), ctx, provider)
+}
+
+// SuspendUser mocks base method.
+func (m *MockserviceAccessor) SuspendUser(ctx context.Context, userUUID string, data *domain.SuspendUserData) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SuspendUser", ctx, userUUID, data)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SuspendUser indicates an expected call of SuspendUser.
+func (mr *MockserviceAccessorMockRecorder) SuspendUser(ctx, userUUID, data any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SuspendUser", reflect.TypeOf((*MockserviceAccessor)(nil).SuspendUser), ctx, userUUID, data)
+}
+
+// SwitchTenant mocks base method.
+func (m *MockserviceAccessor) SwitchTenant(ctx context.Context, token, organizationUUID string) (*domain.Tokens, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SwitchTenant", ctx, token, organizationUUID)
+	ret0, _ := ret[0].(*domain.Tokens)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SwitchTenant indicates an expected call of SwitchTenant.
+func (mr *MockserviceAccessorMockRecorder) SwitchTenant(ctx, token, organizationUUID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SwitchTenant", reflect.TypeOf((*MockserviceAccessor)(nil).SwitchTenant), ctx, token, organizationUUID)
+}
+
+// UnlockUser mocks base method.
+func (m *MockserviceAccessor) UnlockUser(ctx context.Context, uuid string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UnlockUser", ctx, uuid)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UnlockUser indicates an expected call of UnlockUser.
+func (mr *MockserviceAccessorMockRecorder) UnlockUser(ctx, uuid any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UnlockUser", reflect.TypeOf((*MockserviceAccessor)(nil).UnlockUser), ctx, uuid)
+}
+
 // UpdateUser mocks base method.
 func (m *MockserviceAccessor) UpdateUser(ctx context.Context, uuid string, data *domain.UpdateUserData) error {
 	m.ctrl.T.Helper()
@@ -234,6 +1009,35 @@ func (mr *MockserviceAccessorMockRecorder) ValidateJWTToken(ctx, token any) *gom
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ValidateJWTToken", reflect.TypeOf((*MockserviceAccessor)(nil).ValidateJWTToken), ctx, token)
 }
 
+// VerifyEmail mocks base method.
+func (m *MockserviceAccessor) VerifyEmail(ctx context.Context, token string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "VerifyEmail", ctx, token)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// VerifyEmail indicates an expected call of VerifyEmail.
+func (mr *MockserviceAccessorMockRecorder) VerifyEmail(ctx, token any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "VerifyEmail", reflect.TypeOf((*MockserviceAccessor)(nil).VerifyEmail), ctx, token)
+}
+
+// VerifyMFA mocks base method.
+func (m *MockserviceAccessor) VerifyMFA(ctx context.Context, challengeToken, code string, client domain.ClientInfo) (*domain.Tokens, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "VerifyMFA", ctx, challengeToken, code, client)
+	ret0, _ := ret[0].(*domain.Tokens)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// VerifyMFA indicates an expected call of VerifyMFA.
+func (mr *MockserviceAccessorMockRecorder) VerifyMFA(ctx, challengeToken, code, client any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "VerifyMFA", reflect.TypeOf((*MockserviceAccessor)(nil).VerifyMFA), ctx, challengeToken, code, client)
+}
+
 // MockcacheAccessor is a mock of cacheAccessor interface.
 type MockcacheAccessor struct {
 	ctrl     *gomock.Controller
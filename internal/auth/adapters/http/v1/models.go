@@ -1,25 +1,285 @@
 package adapter
 
 import (
+	"encoding/json"
 	"time"
 
+	"github.com/hasansino/go42/internal/auth/domain"
 	"github.com/hasansino/go42/internal/auth/models"
 )
 
 type UserResponse struct {
-	UUID        string   `json:"uuid"`
-	Email       string   `json:"email"`
+	UUID          string   `json:"uuid"`
+	Email         string   `json:"email"`
+	EmailVerified bool     `json:"email_verified"`
+	Status        string   `json:"status"`
+	StatusReason  *string  `json:"status_reason"`
+	StatusUntil   *string  `json:"status_until"`
+	CreatedAt     string   `json:"created_at"`
+	Roles         []string `json:"roles"`
+	Permissions   []string `json:"permissions"`
+}
+
+func UserResponseFromModel(user *models.User) UserResponse {
+	resp := UserResponse{
+		UUID:          user.UUID.String(),
+		Email:         user.Email,
+		EmailVerified: user.IsEmailVerified(),
+		Status:        user.Status,
+		CreatedAt:     user.CreatedAt.Format(time.DateTime),
+		Roles:         user.RoleList(),
+		Permissions:   user.PermissionList(),
+	}
+	if user.StatusReason.Valid {
+		resp.StatusReason = &user.StatusReason.V
+	}
+	if user.StatusUntil.Valid {
+		statusUntil := user.StatusUntil.V.Format(time.DateTime)
+		resp.StatusUntil = &statusUntil
+	}
+	return resp
+}
+
+// ListUsersResponse is page of users, next cursor is null for the last page.
+type ListUsersResponse struct {
+	Users      []UserResponse `json:"users"`
+	NextCursor *string        `json:"next_cursor"`
+}
+
+type SessionResponse struct {
+	ID         string `json:"id"`
+	UserAgent  string `json:"user_agent"`
+	IPAddress  string `json:"ip_address"`
+	CreatedAt  string `json:"created_at"`
+	LastSeenAt string `json:"last_seen_at"`
+	ExpiresAt  string `json:"expires_at"`
+}
+
+func SessionResponseFromModel(session *models.Session) SessionResponse {
+	return SessionResponse{
+		ID:         session.UUID.String(),
+		UserAgent:  session.UserAgent,
+		IPAddress:  session.IPAddress,
+		CreatedAt:  session.CreatedAt.Format(time.DateTime),
+		LastSeenAt: session.LastSeenAt.Format(time.DateTime),
+		ExpiresAt:  session.ExpiresAt.Format(time.DateTime),
+	}
+}
+
+type APITokenResponse struct {
+	ID          string   `json:"id"`
+	Name        string   `json:"name"`
+	Permissions []string `json:"permissions"`
 	CreatedAt   string   `json:"created_at"`
-	Roles       []string `json:"roles"`
+	LastUsedAt  *string  `json:"last_used_at"`
+	ExpiresAt   *string  `json:"expires_at"`
+}
+
+func APITokenResponseFromModel(token *models.Token) APITokenResponse {
+	resp := APITokenResponse{
+		ID:          token.UUID.String(),
+		Name:        token.Name,
+		Permissions: token.PermissionList(),
+		CreatedAt:   token.CreatedAt.Format(time.DateTime),
+	}
+	if token.LastUsedAt.Valid {
+		lastUsedAt := token.LastUsedAt.V.Format(time.DateTime)
+		resp.LastUsedAt = &lastUsedAt
+	}
+	if token.ExpiresAt.Valid {
+		expiresAt := token.ExpiresAt.V.Format(time.DateTime)
+		resp.ExpiresAt = &expiresAt
+	}
+	return resp
+}
+
+// CreateAPITokenResponse is the only response which contains plaintext token.
+type CreateAPITokenResponse struct {
+	APITokenResponse
+	Token string `json:"token"`
+}
+
+type HistoryRecordResponse struct {
+	ID         string          `json:"id"`
+	EventType  string          `json:"event_type"`
+	OccurredAt string          `json:"occurred_at"`
+	Data       json.RawMessage `json:"data"`
+	Metadata   json.RawMessage `json:"metadata"`
+}
+
+func HistoryRecordResponseFromModel(record *models.UserHistoryRecord) HistoryRecordResponse {
+	resp := HistoryRecordResponse{
+		ID:         record.ID.String(),
+		EventType:  record.EventType,
+		OccurredAt: record.OccurredAt.Format(time.DateTime),
+	}
+	// records of erased users and records written before metadata was recorded have no payloads
+	if len(record.Data) > 0 && json.Valid(record.Data) {
+		resp.Data = record.Data
+	}
+	if record.Metadata != "" && json.Valid([]byte(record.Metadata)) {
+		resp.Metadata = json.RawMessage(record.Metadata)
+	}
+	return resp
+}
+
+type DataRequestResponse struct {
+	ID          string  `json:"id"`
+	Type        string  `json:"type"`
+	Status      string  `json:"status"`
+	Error       *string `json:"error"`
+	CreatedAt   string  `json:"created_at"`
+	CompletedAt *string `json:"completed_at"`
+	ExpiresAt   *string `json:"expires_at"`
+}
+
+func DataRequestResponseFromModel(request *models.DataRequest) DataRequestResponse {
+	resp := DataRequestResponse{
+		ID:        request.UUID.String(),
+		Type:      request.Type,
+		Status:    request.Status,
+		CreatedAt: request.CreatedAt.Format(time.DateTime),
+	}
+	if request.Error.Valid {
+		resp.Error = &request.Error.V
+	}
+	if request.CompletedAt.Valid {
+		completedAt := request.CompletedAt.V.Format(time.DateTime)
+		resp.CompletedAt = &completedAt
+	}
+	if request.ExpiresAt.Valid {
+		expiresAt := request.ExpiresAt.V.Format(time.DateTime)
+		resp.ExpiresAt = &expiresAt
+	}
+	return resp
+}
+
+type RoleResponse struct {
+	Name        string   `json:"name"`
+	Description string   `json:"description"`
+	IsSystem    bool     `json:"is_system"`
 	Permissions []string `json:"permissions"`
+	CreatedAt   string   `json:"created_at"`
 }
 
-func UserResponseFromModel(user *models.User) UserResponse {
-	return UserResponse{
-		UUID:        user.UUID.String(),
-		Email:       user.Email,
-		CreatedAt:   user.CreatedAt.Format(time.DateTime),
-		Roles:       user.RoleList(),
-		Permissions: user.PermissionList(),
+func RoleResponseFromModel(role *models.Role) RoleResponse {
+	return RoleResponse{
+		Name:        role.Name,
+		Description: role.Description,
+		IsSystem:    role.IsSystem,
+		Permissions: role.PermissionList(),
+		CreatedAt:   role.CreatedAt.Format(time.DateTime),
+	}
+}
+
+type MFAStatusResponse struct {
+	Enabled           bool    `json:"enabled"`
+	EnabledAt         *string `json:"enabled_at"`
+	RecoveryCodesLeft int     `json:"recovery_codes_left"`
+}
+
+func MFAStatusResponseFromDomain(status *domain.MFAStatus) MFAStatusResponse {
+	resp := MFAStatusResponse{
+		Enabled:           status.Enabled,
+		RecoveryCodesLeft: status.RecoveryCodesLeft,
+	}
+	if status.EnabledAt != nil {
+		enabledAt := status.EnabledAt.Format(time.DateTime)
+		resp.EnabledAt = &enabledAt
+	}
+	return resp
+}
+
+type MFAEnrollmentResponse struct {
+	Secret          string `json:"secret"`
+	ProvisioningURI string `json:"provisioning_uri"`
+}
+
+// MFARecoveryCodesResponse is the only response which contains plaintext recovery codes.
+type MFARecoveryCodesResponse struct {
+	RecoveryCodes []string `json:"recovery_codes"`
+}
+
+type OAuthClientResponse struct {
+	ClientID     string   `json:"client_id"`
+	Name         string   `json:"name"`
+	Public       bool     `json:"public"`
+	RedirectURIs []string `json:"redirect_uris"`
+	GrantTypes   []string `json:"grant_types"`
+	Permissions  []string `json:"permissions"`
+	CreatedAt    string   `json:"created_at"`
+}
+
+func OAuthClientResponseFromModel(client *models.OAuthClient) OAuthClientResponse {
+	return OAuthClientResponse{
+		ClientID:     client.ClientID,
+		Name:         client.Name,
+		Public:       client.IsPublic(),
+		RedirectURIs: client.RedirectURIs,
+		GrantTypes:   client.GrantTypes,
+		Permissions:  client.Permissions,
+		CreatedAt:    client.CreatedAt.Format(time.DateTime),
 	}
 }
+
+// CreateOAuthClientResponse is the only response which contains plaintext client secret.
+type CreateOAuthClientResponse struct {
+	OAuthClientResponse
+	ClientSecret string `json:"client_secret,omitempty"`
+}
+
+type OrganizationResponse struct {
+	UUID      string `json:"uuid"`
+	Name      string `json:"name"`
+	Slug      string `json:"slug"`
+	CreatedAt string `json:"created_at"`
+}
+
+func OrganizationResponseFromModel(organization *models.Organization) OrganizationResponse {
+	return OrganizationResponse{
+		UUID:      organization.UUID.String(),
+		Name:      organization.Name,
+		Slug:      organization.Slug,
+		CreatedAt: organization.CreatedAt.Format(time.DateTime),
+	}
+}
+
+type OrganizationMemberResponse struct {
+	UserUUID  string   `json:"user_uuid"`
+	Email     string   `json:"email"`
+	Roles     []string `json:"roles"`
+	CreatedAt string   `json:"created_at"`
+}
+
+func OrganizationMemberResponseFromModel(member *models.OrganizationMember) OrganizationMemberResponse {
+	resp := OrganizationMemberResponse{
+		Roles:     member.RoleList(),
+		CreatedAt: member.CreatedAt.Format(time.DateTime),
+	}
+	if member.User != nil {
+		resp.UserUUID = member.User.UUID.String()
+		resp.Email = member.User.Email
+	}
+	return resp
+}
+
+type AuthorizeResponse struct {
+	RedirectTo string `json:"redirect_to"`
+}
+
+// OAuthErrorResponse is error response of token endpoint (RFC 6749 section 5.2).
+type OAuthErrorResponse struct {
+	Error            string `json:"error"`
+	ErrorDescription string `json:"error_description,omitempty"`
+}
+
+// UserInfoResponse contains standard OIDC claims of authenticated user.
+type UserInfoResponse struct {
+	Subject       string `json:"sub"`
+	Email         string `json:"email"`
+	EmailVerified bool   `json:"email_verified"`
+}
+
+type SSOProvidersResponse struct {
+	Providers []string `json:"providers"`
+}
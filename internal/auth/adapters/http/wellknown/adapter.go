@@ -0,0 +1,38 @@
+package adapter
+
+import (
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+
+	"github.com/hasansino/go42/internal/auth/domain"
+)
+
+//go:generate mockgen -source $GOFILE -package mocks -destination mocks/mocks.go
+
+type serviceAccessor interface {
+	JWKS() *domain.JWKS
+	OpenIDConfiguration() *domain.OpenIDConfiguration
+}
+
+// Adapter serves well-known endpoints which are not versioned.
+type Adapter struct {
+	service serviceAccessor
+}
+
+func New(service serviceAccessor) *Adapter {
+	return &Adapter{service: service}
+}
+
+func (a *Adapter) Register(g *echo.Group) {
+	g.GET("/.well-known/jwks.json", a.jwks)
+	g.GET("/.well-known/openid-configuration", a.openIDConfiguration)
+}
+
+func (a *Adapter) jwks(ctx echo.Context) error {
+	return ctx.JSON(http.StatusOK, a.service.JWKS())
+}
+
+func (a *Adapter) openIDConfiguration(ctx echo.Context) error {
+	return ctx.JSON(http.StatusOK, a.service.OpenIDConfiguration())
+}
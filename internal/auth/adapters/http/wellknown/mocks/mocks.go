@@ -0,0 +1,69 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: adapter.go
+//
+// Generated by this command:
+//
+//	mockgen -source adapter.go -package mocks -destination mocks/mocks.go
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	reflect "reflect"
+
+	domain "github.com/hasansino/go42/internal/auth/domain"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockserviceAccessor is a mock of serviceAccessor interface.
+type MockserviceAccessor struct {
+	ctrl     *gomock.Controller
+	recorder *MockserviceAccessorMockRecorder
+	isgomock struct{}
+}
+
+// MockserviceAccessorMockRecorder is the mock recorder for MockserviceAccessor.
+type MockserviceAccessorMockRecorder struct {
+	mock *MockserviceAccessor
+}
+
+// NewMockserviceAccessor creates a new mock instance.
+func NewMockserviceAccessor(ctrl *gomock.Controller) *MockserviceAccessor {
+	mock := &MockserviceAccessor{ctrl: ctrl}
+	mock.recorder = &MockserviceAccessorMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockserviceAccessor) EXPECT() *MockserviceAccessorMockRecorder {
+	return m.recorder
+}
+
+// JWKS mocks base method.
+func (m *MockserviceAccessor) JWKS() *domain.JWKS {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "JWKS")
+	ret0, _ := ret[0].(*domain.JWKS)
+	return ret0
+}
+
+// JWKS indicates an expected call of JWKS.
+func (mr *MockserviceAccessorMockRecorder) JWKS() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "JWKS", reflect.TypeOf((*MockserviceAccessor)(nil).JWKS))
+}
+
+// OpenIDConfiguration mocks base method.
+func (m *MockserviceAccessor) OpenIDConfiguration() *domain.OpenIDConfiguration {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "OpenIDConfiguration")
+	ret0, _ := ret[0].(*domain.OpenIDConfiguration)
+	return ret0
+}
+
+// OpenIDConfiguration indicates an expected call of OpenIDConfiguration.
+func (mr *MockserviceAccessorMockRecorder) OpenIDConfiguration() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "OpenIDConfiguration", reflect.TypeOf((*MockserviceAccessor)(nil).OpenIDConfiguration))
+}
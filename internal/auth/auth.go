@@ -2,17 +2,23 @@ package auth
 
 import (
 	"context"
+	"crypto/cipher"
 	"crypto/sha256"
+	"database/sql"
+	"encoding/base64"
 	"encoding/hex"
+	"encoding/json"
+	"errors"
 	"fmt"
 	"log/slog"
+	"slices"
+	"strconv"
 	"strings"
 	"sync"
 	"time"
 
 	"github.com/golang-jwt/jwt/v5"
 	"github.com/google/uuid"
-	passwordvalidator "github.com/wagslane/go-password-validator"
 	"go.opentelemetry.io/otel/attribute"
 	"go.opentelemetry.io/otel/codes"
 	"go.opentelemetry.io/otel/trace"
@@ -20,16 +26,33 @@ import (
 
 	"github.com/hasansino/go42/internal/auth/domain"
 	"github.com/hasansino/go42/internal/auth/models"
+	"github.com/hasansino/go42/internal/auth/policy"
+	"github.com/hasansino/go42/internal/mailer"
 	"github.com/hasansino/go42/internal/metrics"
 	outboxDomain "github.com/hasansino/go42/internal/outbox/domain"
+	"github.com/hasansino/go42/internal/password"
 	"github.com/hasansino/go42/internal/tools"
 )
 
 const (
 	cacheKeyInvalidatedToken   = "auth_invalidated_"
+	cacheKeyInvalidatedTokenID = "auth_invalidated_jti_"
 	cacheValueInvalidatedToken = "_"
+
+	// number of jwt secrets which are kept for token validation,
+	// it includes next secret which is not used for signing yet
+	jwtSecretsKeep = 4
+
+	// keeps event metadata within size of history metadata column
+	maxEventUserAgentLength = 255
+
+	defaultUsersPageSize = 10
+	maxUsersPageSize     = 100
 )
 
+// errRefreshClientMismatch rolls back refresh of session which belongs to another client.
+var errRefreshClientMismatch = errors.New("refresh token belongs to another client")
+
 //go:generate mockgen -source $GOFILE -package mocks -destination mocks/mocks.go
 
 type repository interface {
@@ -37,32 +60,129 @@ type repository interface {
 
 	CreateUser(ctx context.Context, user *models.User) error
 	UpdateUser(ctx context.Context, user *models.User) error
-	DeleteUser(ctx context.Context, user *models.User) error
-	ListUsers(ctx context.Context, limit, offset int) ([]*models.User, error)
+	ListUsers(ctx context.Context, query *domain.ListUsersQuery, after *domain.UserCursor) ([]*models.User, error)
 	GetUserByID(ctx context.Context, id int) (*models.User, error)
 	GetUserByUUID(ctx context.Context, uuid string) (*models.User, error)
 	GetUserByEmail(ctx context.Context, email string) (*models.User, error)
+	UpdateUserStatus(ctx context.Context, user *models.User) error
+	ListUsersWithStatusUntil(ctx context.Context, status string, until time.Time, limit int) ([]*models.User, error)
+	AnonymizeUser(ctx context.Context, user *models.User, status string) error
+	EraseUser(ctx context.Context, user *models.User) error
+
+	InvalidateUserCache(ctx context.Context, users ...*models.User)
 
 	AssignRoleToUser(ctx context.Context, userID int, role string) error
+	GrantRoleToUser(ctx context.Context, userRole *models.UserRole) error
+	RevokeRoleFromUser(ctx context.Context, userID int, roleID int) error
+	ListRoles(ctx context.Context) ([]*models.Role, error)
+	GetRoleByName(ctx context.Context, name string) (*models.Role, error)
+	CreateRole(ctx context.Context, role *models.Role) error
+	DeleteRole(ctx context.Context, role *models.Role) error
+	AttachPermissionToRole(ctx context.Context, roleID int, permissionID int) error
+	DetachPermissionFromRole(ctx context.Context, roleID int, permissionID int) error
+	ListRoleUsers(ctx context.Context, roleID int) ([]*models.User, error)
 
 	GetToken(ctx context.Context, hashedToken string) (*models.Token, error)
+	GetTokenByUUID(ctx context.Context, uuid string) (*models.Token, error)
+	ListTokens(ctx context.Context, userID int) ([]*models.Token, error)
+	CreateToken(ctx context.Context, apiToken *models.Token) error
+	DeleteToken(ctx context.Context, apiToken *models.Token) error
+	DeleteExpiredTokens(ctx context.Context, userID int, name string) error
+	GetPermissions(ctx context.Context, names []string) ([]models.Permission, error)
+
+	CreateJWTSecret(ctx context.Context, secret *models.JWTSecret) error
+	ListJWTSecrets(ctx context.Context, limit int) ([]*models.JWTSecret, error)
+	DeleteJWTSecretsBefore(ctx context.Context, generation int64) error
+
+	CreateRefreshToken(ctx context.Context, token *models.RefreshToken) error
+	GetRefreshToken(ctx context.Context, jti string) (*models.RefreshToken, error)
+	ConsumeRefreshToken(ctx context.Context, id int) error
+	RevokeRefreshTokenFamily(ctx context.Context, familyID uuid.UUID) error
+
+	CreateSession(ctx context.Context, session *models.Session) error
+	UpdateSession(ctx context.Context, session *models.Session) error
+	GetSessionByUUID(ctx context.Context, uuid string) (*models.Session, error)
+	GetSessionByFamilyID(ctx context.Context, familyID uuid.UUID) (*models.Session, error)
+	ListActiveSessions(ctx context.Context, userID int) ([]*models.Session, error)
+	RevokeSession(ctx context.Context, session *models.Session) error
+
+	GetUserMFA(ctx context.Context, userID int) (*models.UserMFA, error)
+	SaveUserMFA(ctx context.Context, mfa *models.UserMFA) error
+	ConsumeMFAStep(ctx context.Context, userID int, step int64) error
+	DeleteUserMFA(ctx context.Context, userID int) error
+	ReplaceMFARecoveryCodes(ctx context.Context, userID int, codes []*models.MFARecoveryCode) error
+	UseMFARecoveryCode(ctx context.Context, userID int, codeHash string) error
+	CountMFARecoveryCodes(ctx context.Context, userID int) (int, error)
+
+	ListOAuthClients(ctx context.Context) ([]*models.OAuthClient, error)
+	GetOAuthClient(ctx context.Context, clientID string) (*models.OAuthClient, error)
+	CreateOAuthClient(ctx context.Context, client *models.OAuthClient) error
+	DeleteOAuthClient(ctx context.Context, client *models.OAuthClient) error
+	CreateOAuthCode(ctx context.Context, code *models.OAuthCode) error
+	ConsumeOAuthCode(ctx context.Context, codeHash string) (*models.OAuthCode, error)
+	DeleteExpiredOAuthCodes(ctx context.Context, userID int) error
+
+	GetUserIdentity(ctx context.Context, provider string, subject string) (*models.UserIdentity, error)
+	CreateUserIdentity(ctx context.Context, identity *models.UserIdentity) error
+	UpdateUserIdentity(ctx context.Context, identity *models.UserIdentity) error
+
+	ListPasswordHistory(ctx context.Context, userID int, limit int) ([]*models.PasswordHistory, error)
+	CreatePasswordHistory(ctx context.Context, entry *models.PasswordHistory) error
+	DeletePasswordHistoryBefore(ctx context.Context, userID int, id int) error
+
+	ListOrganizations(ctx context.Context) ([]*models.Organization, error)
+	ListUserOrganizations(ctx context.Context, userID int) ([]*models.Organization, error)
+	GetOrganizationByID(ctx context.Context, id int) (*models.Organization, error)
+	GetOrganizationByUUID(ctx context.Context, uuid string) (*models.Organization, error)
+	CreateOrganization(ctx context.Context, organization *models.Organization) error
+	DeleteOrganization(ctx context.Context, organization *models.Organization) error
+	ListOrganizationMembers(ctx context.Context, organizationID int) ([]*models.OrganizationMember, error)
+	GetOrganizationMember(ctx context.Context, organizationID int, userID int) (*models.OrganizationMember, error)
+	AddOrganizationMember(ctx context.Context, member *models.OrganizationMember) error
+	RemoveOrganizationMember(ctx context.Context, organizationID int, userID int) error
+	GrantOrganizationRole(ctx context.Context, memberRole *models.OrganizationMemberRole) error
+	RevokeOrganizationRole(ctx context.Context, organizationID int, userID int, roleID int) error
+
+	ListUserHistoryRecords(ctx context.Context, userID int) ([]*models.UserHistoryRecord, error)
+	FilterUserHistoryRecords(
+		ctx context.Context, userID int, filter *domain.UserHistoryFilter,
+	) ([]*models.UserHistoryRecord, error)
+	PurgeUserHistoryData(ctx context.Context, userID int) error
+
+	CreateDataRequest(ctx context.Context, request *models.DataRequest) error
+	GetDataRequestByID(ctx context.Context, id int) (*models.DataRequest, error)
+	GetUserDataRequest(ctx context.Context, userUUID string, requestUUID string) (*models.DataRequest, error)
+	GetDataRequestArchive(ctx context.Context, id int) ([]byte, error)
+	ClaimDataRequest(ctx context.Context, request *models.DataRequest) error
+	CompleteDataRequest(ctx context.Context, request *models.DataRequest) error
+	DeleteExpiredDataArchives(ctx context.Context, before time.Time) error
 }
 
 type cache interface {
 	Get(ctx context.Context, key string) (string, error)
 	Set(ctx context.Context, key string, value string, ttl time.Duration) error
+	Invalidate(ctx context.Context, key string) error
 }
 
 type outboxService interface {
 	NewOutboxMessage(ctx context.Context, topic string, msg *outboxDomain.Message) error
 }
 
-// jwtSecret holds the SHA256 hash of the secret and the secret itself.
-// The SHA256 hash is used as the KID (Key ID) in JWT tokens to
-// allow for key rotation.
-type jwtSecret struct {
-	sha256 string
-	secret string
+type mailSender interface {
+	Send(ctx context.Context, msg mailer.Message) error
+}
+
+type passwordHasher interface {
+	Hash(password string) (string, error)
+	Verify(hash string, password string) (bool, error)
+}
+
+type passwordPolicy interface {
+	Check(ctx context.Context, password string) error
+}
+
+type accessPolicy interface {
+	Evaluate(req *domain.AccessRequest) (*domain.AccessDecision, error)
 }
 
 type Service struct {
@@ -70,9 +190,16 @@ type Service struct {
 	repository    repository
 	cache         cache
 	outboxService outboxService
+	mailer        mailSender
+	hasher        passwordHasher
 
-	jwtSecrets   []jwtSecret
-	jwtSecretsMu sync.RWMutex
+	// jwtStaticSecrets are secrets provided by configuration,
+	// jwtSecrets are static secrets followed by persisted ones.
+	jwtStaticSecrets    []jwtSecret
+	jwtSecrets          []jwtSecret
+	jwtSecretGeneration int64
+	jwtSecretsMu        sync.RWMutex
+	jwtSecretsCipher    cipher.AEAD
 
 	jwtIssuer   string
 	jwtAudience []string
@@ -81,8 +208,33 @@ type Service struct {
 	refreshTokenTTL time.Duration
 
 	minPasswordEntropyBits int
+	passwordPolicy         passwordPolicy
+	passwordHistorySize    int
+
+	lockoutPolicy LockoutPolicy
+
+	mfaIssuer       string
+	mfaChallengeTTL time.Duration
+
+	emailPolicy EmailPolicy
+
+	oauthPolicy OAuthPolicy
+
+	ssoProviders map[string]SSOProvider
+	ssoStateTTL  time.Duration
+
+	accessPolicy accessPolicy
+
+	impersonationTTL time.Duration
+
+	deletionGracePeriod time.Duration
+	deletionMode        string
+	dataArchiveTTL      time.Duration
 
 	tokensUsedChan chan domain.TokenWasUsed
+
+	// errors of options are returned by NewService
+	optionErrors []error
 }
 
 func NewService(
@@ -90,21 +242,70 @@ func NewService(
 	outboxService outboxService,
 	cache cache,
 	opts ...Option,
-) *Service {
+) (*Service, error) {
 	s := &Service{
 		repository:     repository,
 		outboxService:  outboxService,
 		cache:          cache,
 		jwtSecrets:     make([]jwtSecret, 0, 2),
+		ssoProviders:   make(map[string]SSOProvider),
 		tokensUsedChan: make(chan domain.TokenWasUsed, tools.BufferSize4096),
 	}
 	for _, opt := range opts {
 		opt(s)
 	}
+	if err := errors.Join(s.optionErrors...); err != nil {
+		return nil, err
+	}
+	if len(s.jwtSecrets) == 0 {
+		return nil, errors.New("at least one jwt secret is required")
+	}
+	if s.jwtSecretsCipher == nil {
+		return nil, errors.New("jwt secrets encryption key is required")
+	}
 	if s.logger == nil {
 		s.logger = slog.New(slog.DiscardHandler)
 	}
-	return s
+	if s.mailer == nil {
+		s.mailer = mailer.NewNoop()
+	}
+	if s.passwordPolicy == nil {
+		s.passwordPolicy = password.NewPolicy(
+			password.MinLength(defaultPasswordMinLength),
+			password.MaxLength(defaultPasswordMaxLength),
+			password.MinEntropy(s.minPasswordEntropyBits),
+		)
+	}
+	if s.hasher == nil {
+		s.hasher = password.NewHasher(
+			password.NewArgon2id(password.DefaultArgon2Params()),
+			password.NewBcrypt(bcrypt.DefaultCost),
+		)
+	}
+	if s.oauthPolicy.CodeTTL <= 0 {
+		s.oauthPolicy.CodeTTL = defaultOAuthCodeTTL
+	}
+	if s.ssoStateTTL <= 0 {
+		s.ssoStateTTL = defaultSSOStateTTL
+	}
+	if s.impersonationTTL <= 0 {
+		s.impersonationTTL = defaultImpersonationTTL
+	}
+	if s.deletionGracePeriod <= 0 {
+		s.deletionGracePeriod = defaultDeletionGracePeriod
+	}
+	if s.dataArchiveTTL <= 0 {
+		s.dataArchiveTTL = defaultDataArchiveTTL
+	}
+	if s.deletionMode == "" {
+		s.deletionMode = domain.UserDeletionModeAnonymize
+	}
+	if s.accessPolicy == nil {
+		// without rules engine only checks permissions and can not fail
+		s.accessPolicy, _ = policy.New(nil)
+	}
+	s.jwtStaticSecrets = slices.Clone(s.jwtSecrets)
+	return s, nil
 }
 
 func (s *Service) SignUp(ctx context.Context, email string, password string) (*models.User, error) {
@@ -121,10 +322,7 @@ func (s *Service) SignUp(ctx context.Context, email string, password string) (*m
 	err = tools.TraceReturnErr(
 		ctx, "auth.service", "signup.checkpwd",
 		func(ctx context.Context) error {
-			if err := s.CheckPasswordStrength(password); err != nil {
-				return domain.ErrPasswordWeak
-			}
-			return nil
+			return s.CheckPasswordStrength(ctx, password)
 		})
 	if err != nil {
 		return nil, err
@@ -135,11 +333,14 @@ func (s *Service) SignUp(ctx context.Context, email string, password string) (*m
 		Email:  email,
 		Status: domain.UserStatusActive,
 	}
+	if s.emailPolicy.VerificationRequired {
+		user.Status = domain.UserStatusPending
+	}
 
 	err = tools.TraceReturnErr(
 		ctx, "auth.service", "signup.setpswd",
 		func(ctx context.Context) error {
-			if err := user.SetPassword(password); err != nil {
+			if err := s.setPassword(user, password); err != nil {
 				return fmt.Errorf("failed to set password: %w", err)
 			}
 			return nil
@@ -168,6 +369,13 @@ func (s *Service) SignUp(ctx context.Context, email string, password string) (*m
 			)
 			// assuming events are non-critical, do not fail transaction
 		}
+		if err := s.enqueueMail(txCtx, user.ID, domain.MailTypeEmailVerification); err != nil {
+			s.logger.ErrorContext(
+				ctx, "failed to enqueue verification mail",
+				slog.Any("error", err),
+			)
+			// verification mail can be requested again
+		}
 		return nil
 	})
 	if err != nil {
@@ -191,7 +399,11 @@ func (s *Service) SignUp(ctx context.Context, email string, password string) (*m
 	return user, nil
 }
 
-func (s *Service) Login(ctx context.Context, email string, password string) (*domain.Tokens, error) {
+// Login authenticates user by credentials. When user has mfa enabled,
+// challenge is returned instead of tokens, see VerifyMFA.
+func (s *Service) Login(
+	ctx context.Context, email string, password string, client domain.ClientInfo,
+) (*domain.Tokens, *domain.MFAChallenge, error) {
 	startTime := time.Now()
 	defer func() {
 		metrics.Histogram("auth_operation_duration_seconds", map[string]interface{}{
@@ -199,28 +411,42 @@ func (s *Service) Login(ctx context.Context, email string, password string) (*do
 		}).Update(time.Since(startTime).Seconds())
 	}()
 
-	user, err := s.repository.GetUserByEmail(
-		ctx,
-		strings.ToLower(strings.TrimSpace(email)),
-	)
+	email = strings.ToLower(strings.TrimSpace(email))
+
+	if err := s.checkLockout(ctx, email, client); err != nil {
+		metrics.Counter("auth_login_attempts_total", map[string]interface{}{
+			"result": "locked",
+		}).Inc()
+		return nil, nil, err
+	}
+
+	user, err := s.repository.GetUserByEmail(ctx, email)
 	if err != nil {
 		metrics.Counter("auth_login_attempts_total", map[string]interface{}{
 			"result": "user_not_found",
 		}).Inc()
-		return nil, domain.ErrInvalidCredentials
+		s.registerLoginFailure(ctx, 0, email, client)
+		return nil, nil, domain.ErrInvalidCredentials
 	}
 
-	if !user.IsActive() {
+	if !user.IsActiveOrPending() {
 		metrics.Counter("auth_login_attempts_total", map[string]interface{}{
 			"result": "user_inactive",
 		}).Inc()
-		return nil, domain.ErrInvalidCredentials
+		return nil, nil, domain.ErrInvalidCredentials
 	}
 
+	var rehash bool
 	err = tools.TraceReturnErr(
-		ctx, "auth.service", "login.CompareHashAndPassword",
+		ctx, "auth.service", "login.VerifyPassword",
 		func(ctx context.Context) error {
-			if bcrypt.CompareHashAndPassword([]byte(user.Password.V), []byte(password)) != nil {
+			var err error
+			// users without password (e.g. provisioned by sso) can not log in with credentials
+			if !user.Password.Valid {
+				return domain.ErrInvalidCredentials
+			}
+			rehash, err = s.hasher.Verify(user.Password.V, password)
+			if err != nil {
 				return domain.ErrInvalidCredentials
 			}
 			return nil
@@ -229,13 +455,93 @@ func (s *Service) Login(ctx context.Context, email string, password string) (*do
 		metrics.Counter("auth_login_attempts_total", map[string]interface{}{
 			"result": "invalid_password",
 		}).Inc()
-		return nil, err
+		s.registerLoginFailure(ctx, user.ID, email, client)
+		return nil, nil, err
+	}
+
+	if rehash {
+		s.rehashPassword(ctx, user, password)
+	}
+
+	// reported only after password check, so that it does not reveal registered emails
+	if user.Status == domain.UserStatusPending {
+		metrics.Counter("auth_login_attempts_total", map[string]interface{}{
+			"result": "email_not_verified",
+		}).Inc()
+		return nil, nil, domain.ErrEmailNotVerified
+	}
+
+	challenge, err := s.mfaChallenge(ctx, user)
+	if err != nil {
+		return nil, nil, err
+	}
+	if challenge != nil {
+		// failures are not reset until second factor is verified,
+		// otherwise password holder could guess mfa codes without limit
+		metrics.Counter("auth_login_attempts_total", map[string]interface{}{
+			"result": "mfa_required",
+		}).Inc()
+		return nil, challenge, nil
 	}
 
+	s.resetLoginFailures(ctx, email, client)
+
+	tokens, err := s.completeLogin(ctx, user, client)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return tokens, nil, nil
+}
+
+// mfaChallenge returns challenge when user has mfa enabled, nil otherwise.
+func (s *Service) mfaChallenge(ctx context.Context, user *models.User) (*domain.MFAChallenge, error) {
+	mfa, err := s.repository.GetUserMFA(ctx, user.ID)
+	if err != nil && !errors.Is(err, domain.ErrEntityNotFound) {
+		return nil, fmt.Errorf("failed to get user mfa: %w", err)
+	}
+	if mfa == nil || !mfa.IsEnabled() {
+		return nil, nil
+	}
+	challenge, err := s.generateMFAChallenge(user.UUID.String())
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate mfa challenge: %w", err)
+	}
+	return challenge, nil
+}
+
+// completeLogin starts new session for authenticated user.
+func (s *Service) completeLogin(
+	ctx context.Context, user *models.User, client domain.ClientInfo,
+) (*domain.Tokens, error) {
+	return s.startSession(ctx, user, newSession(user, client))
+}
+
+// newSession returns session with its own refresh token family, each login starts new one.
+func newSession(user *models.User, client domain.ClientInfo) *models.Session {
+	return &models.Session{
+		UUID:      uuid.New(),
+		UserID:    user.ID,
+		FamilyID:  uuid.New(),
+		UserAgent: truncateString(client.UserAgent, maxUserAgentLength),
+		IPAddress: client.IPAddress,
+	}
+}
+
+// startSession issues first tokens of new session.
+func (s *Service) startSession(
+	ctx context.Context, user *models.User, session *models.Session,
+) (*domain.Tokens, error) {
 	tokens, err := tools.TraceReturnTWithErr[*domain.Tokens](
 		ctx, "auth.service", "login.generate_tokens",
 		func(ctx context.Context) (*domain.Tokens, error) {
-			return s.generateTokens(user.UUID.String())
+			var tokens *domain.Tokens
+			err := s.repository.WithTransaction(ctx, func(txCtx context.Context) error {
+				var err error
+				tokens, err = s.issueTokens(txCtx, user, session)
+				return err
+			})
+			return tokens, err
 		},
 	)
 	if err != nil {
@@ -272,7 +578,24 @@ func (s *Service) Login(ctx context.Context, email string, password string) (*do
 	return tokens, nil
 }
 
+// Refresh rotates refresh token of first-party session,
+// sessions of oauth clients are refreshed only through token endpoint.
 func (s *Service) Refresh(ctx context.Context, token string) (*domain.Tokens, error) {
+	return s.refresh(ctx, token, nil, nil)
+}
+
+// SwitchTenant refreshes session like Refresh does, issued tokens are scoped
+// to given organization, which user must be member of. Empty organization
+// makes tokens unscoped.
+func (s *Service) SwitchTenant(ctx context.Context, token string, organizationUUID string) (*domain.Tokens, error) {
+	return s.refresh(ctx, token, &organizationUUID, nil)
+}
+
+// refresh rotates refresh token of the session, optionally changing its tenant.
+// Session must belong to given oauth client, or to no client when it is nil.
+func (s *Service) refresh(
+	ctx context.Context, token string, tenant *string, oauthClient *models.OAuthClient,
+) (*domain.Tokens, error) {
 	startTime := time.Now()
 	defer func() {
 		metrics.Histogram("auth_operation_duration_seconds", map[string]interface{}{
@@ -288,6 +611,26 @@ func (s *Service) Refresh(ctx context.Context, token string) (*domain.Tokens, er
 		return nil, domain.ErrInvalidToken
 	}
 
+	refreshToken, err := s.repository.GetRefreshToken(ctx, claims.ID)
+	if err != nil {
+		metrics.Counter("auth_token_refresh_total", map[string]interface{}{
+			"result": "unknown_token",
+		}).Inc()
+		return nil, domain.ErrInvalidToken
+	}
+
+	if refreshToken.RevokedAt.Valid {
+		metrics.Counter("auth_token_refresh_total", map[string]interface{}{
+			"result": "revoked_token",
+		}).Inc()
+		return nil, domain.ErrInvalidToken
+	}
+
+	if refreshToken.ConsumedAt.Valid {
+		s.handleRefreshTokenReuse(ctx, refreshToken)
+		return nil, domain.ErrInvalidToken
+	}
+
 	user, err := s.repository.GetUserByUUID(ctx, claims.Subject)
 	if err != nil {
 		return nil, err
@@ -306,9 +649,47 @@ func (s *Service) Refresh(ctx context.Context, token string) (*domain.Tokens, er
 	tokens, err := tools.TraceReturnTWithErr[*domain.Tokens](
 		ctx, "auth.service", "login.generate_tokens",
 		func(ctx context.Context) (*domain.Tokens, error) {
-			return s.generateTokens(user.UUID.String())
+			var tokens *domain.Tokens
+			err := s.repository.WithTransaction(ctx, func(txCtx context.Context) error {
+				if err := s.repository.ConsumeRefreshToken(txCtx, refreshToken.ID); err != nil {
+					return err
+				}
+				session, err := s.repository.GetSessionByFamilyID(txCtx, refreshToken.FamilyID)
+				if errors.Is(err, domain.ErrEntityNotFound) {
+					// family was started before sessions were tracked
+					session = &models.Session{
+						UUID:     uuid.New(),
+						UserID:   user.ID,
+						FamilyID: refreshToken.FamilyID,
+					}
+				} else if err != nil {
+					return fmt.Errorf("failed to get session: %w", err)
+				}
+				if session.OAuthClientID != oauthClientID(oauthClient) {
+					return errRefreshClientMismatch
+				}
+				if tenant != nil {
+					if err := s.setSessionTenant(txCtx, user, session, *tenant); err != nil {
+						return err
+					}
+				}
+				tokens, err = s.issueTokens(txCtx, user, session)
+				return err
+			})
+			return tokens, err
 		},
 	)
+	if errors.Is(err, domain.ErrRefreshTokenReused) {
+		// token was consumed concurrently
+		s.handleRefreshTokenReuse(ctx, refreshToken)
+		return nil, domain.ErrInvalidToken
+	}
+	if errors.Is(err, errRefreshClientMismatch) {
+		metrics.Counter("auth_token_refresh_total", map[string]interface{}{
+			"result": "client_mismatch",
+		}).Inc()
+		return nil, domain.ErrInvalidToken
+	}
 	if err != nil {
 		return nil, fmt.Errorf("failed to generate tokens: %w", err)
 	}
@@ -348,6 +729,20 @@ func (s *Service) Logout(ctx context.Context, accessToken, refreshToken string)
 		return fmt.Errorf("failed to invalidate refresh token: %w", err)
 	}
 
+	storedRefreshToken, err := s.repository.GetRefreshToken(ctx, refreshTokenClaims.ID)
+	if err != nil && !errors.Is(err, domain.ErrEntityNotFound) {
+		return fmt.Errorf("failed to get refresh token: %w", err)
+	}
+	if storedRefreshToken != nil {
+		err := s.repository.WithTransaction(ctx, func(txCtx context.Context) error {
+			_, err := s.revokeTokenFamily(txCtx, storedRefreshToken.FamilyID)
+			return err
+		})
+		if err != nil {
+			return fmt.Errorf("failed to revoke session: %w", err)
+		}
+	}
+
 	user, err := s.repository.GetUserByUUID(ctx, accessTokenClaims.Subject)
 	if err != nil {
 		return fmt.Errorf("failed to get user: %w", err)
@@ -379,11 +774,60 @@ func (s *Service) Logout(ctx context.Context, accessToken, refreshToken string)
 	return nil
 }
 
+// handleRefreshTokenReuse revokes whole family of replayed refresh token,
+// since it is impossible to tell whether legitimate user or attacker presented it.
+func (s *Service) handleRefreshTokenReuse(ctx context.Context, token *models.RefreshToken) {
+	metrics.Counter("auth_token_refresh_total", map[string]interface{}{
+		"result": "reuse_detected",
+	}).Inc()
+
+	s.logger.WarnContext(
+		ctx, "refresh token reuse detected",
+		slog.Int("user_id", token.UserID),
+		slog.String("family_id", token.FamilyID.String()),
+	)
+
+	var session *models.Session
+	err := s.repository.WithTransaction(ctx, func(txCtx context.Context) error {
+		var err error
+		session, err = s.revokeTokenFamily(txCtx, token.FamilyID)
+		if err != nil {
+			return err
+		}
+		payload, err := json.Marshal(map[string]string{
+			"family_id": token.FamilyID.String(),
+		})
+		if err != nil {
+			return fmt.Errorf("failed to marshal event payload: %w", err)
+		}
+		event := outboxDomain.Message{
+			AggregateID:   token.UserID,
+			AggregateType: domain.EventTypeAuthRefreshReuse,
+			Payload:       payload,
+		}
+		if err := s.sendEvent(txCtx, domain.TopicNameAuthEvents, event); err != nil {
+			return fmt.Errorf("failed to send event: %w", err)
+		}
+		return nil
+	})
+	if err != nil {
+		s.logger.ErrorContext(
+			ctx, "failed to handle refresh token reuse",
+			slog.String("family_id", token.FamilyID.String()),
+			slog.Any("error", err),
+		)
+		return
+	}
+	if session != nil {
+		s.invalidateSessionAccessToken(ctx, session)
+	}
+}
+
 // ----
 
 func (s *Service) CreateUser(ctx context.Context, data *domain.CreateUserData) (*models.User, error) {
-	if err := s.CheckPasswordStrength(data.Password); err != nil {
-		return nil, domain.ErrPasswordWeak
+	if err := s.CheckPasswordStrength(ctx, data.Password); err != nil {
+		return nil, err
 	}
 
 	user := &models.User{
@@ -392,7 +836,7 @@ func (s *Service) CreateUser(ctx context.Context, data *domain.CreateUserData) (
 		Status: domain.UserStatusActive,
 	}
 
-	if err := user.SetPassword(data.Password); err != nil {
+	if err := s.setPassword(user, data.Password); err != nil {
 		return nil, fmt.Errorf("failed to set password: %w", err)
 	}
 
@@ -404,6 +848,16 @@ func (s *Service) CreateUser(ctx context.Context, data *domain.CreateUserData) (
 		if err := s.repository.AssignRoleToUser(txCtx, user.ID, domain.RBACRoleUser); err != nil {
 			return fmt.Errorf("failed to assign user role: %w", err)
 		}
+		// user created within tenant must be visible within it
+		if tenantID, _, ok := activeTenant(txCtx); ok {
+			err := s.repository.AddOrganizationMember(txCtx, &models.OrganizationMember{
+				OrganizationID: tenantID,
+				UserID:         user.ID,
+			})
+			if err != nil {
+				return fmt.Errorf("failed to add organization member: %w", err)
+			}
+		}
 		event := outboxDomain.Message{
 			AggregateID:   user.ID,
 			AggregateType: domain.EventTypeUserCreate,
@@ -428,21 +882,20 @@ func (s *Service) UpdateUser(ctx context.Context, uuid string, data *domain.Upda
 			return fmt.Errorf("failed to get user: %w", err)
 		}
 
-		var doUpdate bool
+		var doUpdate, emailChanged bool
 
 		if data.Email != nil {
 			if *data.Email != user.Email {
 				doUpdate = true
 				user.Email = *data.Email
+				user.EmailVerifiedAt = sql.Null[time.Time]{}
+				emailChanged = true
 			}
 		}
 		if data.Password != nil {
 			doUpdate = true
-			if err := s.CheckPasswordStrength(*data.Password); err != nil {
-				return domain.ErrPasswordWeak
-			}
-			if err := user.SetPassword(*data.Password); err != nil {
-				return fmt.Errorf("failed to set password: %w", err)
+			if err := s.changePassword(txCtx, user, *data.Password); err != nil {
+				return err
 			}
 		}
 
@@ -468,35 +921,99 @@ func (s *Service) UpdateUser(ctx context.Context, uuid string, data *domain.Upda
 			// assuming events are non-critical, do not fail transaction
 		}
 
+		if emailChanged {
+			if err := s.enqueueMail(txCtx, user.ID, domain.MailTypeEmailVerification); err != nil {
+				s.logger.ErrorContext(
+					txCtx, "failed to enqueue verification mail",
+					slog.Any("error", err),
+				)
+			}
+		}
+
 		return nil
 	})
 }
 
-func (s *Service) DeleteUser(ctx context.Context, uuid string) error {
-	return s.repository.WithTransaction(ctx, func(txCtx context.Context) error {
-		var err error
-		user, err := s.repository.GetUserByUUID(txCtx, uuid)
-		if err != nil {
-			return fmt.Errorf("failed to get user by id: %w", err)
-		}
-		err = s.repository.DeleteUser(txCtx, user)
+// ListUsers returns page of users and cursor of the next page, cursor is empty for the last page.
+func (s *Service) ListUsers(ctx context.Context, query *domain.ListUsersQuery) ([]*models.User, string, error) {
+	q := *query
+	if q.SortBy == "" {
+		q.SortBy = domain.UserSortByID
+	}
+	if !slices.Contains(domain.UserSortFields, q.SortBy) {
+		return nil, "", fmt.Errorf("unknown sort field: %s", q.SortBy)
+	}
+	if q.Limit <= 0 {
+		q.Limit = defaultUsersPageSize
+	}
+	q.Limit = min(q.Limit, maxUsersPageSize)
+	q.EmailPrefix = strings.ToLower(strings.TrimSpace(q.EmailPrefix))
+
+	var after *domain.UserCursor
+	if q.Cursor != "" {
+		cursor, err := decodeUserCursor(q.Cursor)
 		if err != nil {
-			return fmt.Errorf("failed to delete user: %w", err)
+			return nil, "", err
 		}
-		event := outboxDomain.Message{
-			AggregateID:   user.ID,
-			AggregateType: domain.EventTypeUserDelete,
+		if cursor.SortBy != q.SortBy || cursor.SortDesc != q.SortDesc {
+			return nil, "", fmt.Errorf("%w: cursor belongs to different sorting", domain.ErrInvalidCursor)
 		}
-		err = s.sendEvent(txCtx, domain.TopicNameAuthEvents, event)
-		if err != nil {
-			return fmt.Errorf("failed to send event: %w", err)
-		}
-		return nil
-	})
+		after = cursor
+	}
+
+	// one more user tells whether there is next page
+	limit := q.Limit
+	q.Limit++
+	users, err := s.repository.ListUsers(ctx, &q, after)
+	if err != nil {
+		return nil, "", err
+	}
+	if len(users) <= limit {
+		return users, "", nil
+	}
+	users = users[:limit]
+
+	last := users[len(users)-1]
+	cursor := &domain.UserCursor{
+		SortBy:   q.SortBy,
+		SortDesc: q.SortDesc,
+		ID:       last.ID,
+	}
+	switch q.SortBy {
+	case domain.UserSortByEmail:
+		cursor.Email = last.Email
+	case domain.UserSortByCreatedAt:
+		cursor.CreatedAt = last.CreatedAt
+	}
+	next, err := encodeUserCursor(cursor)
+	if err != nil {
+		return nil, "", err
+	}
+
+	return users, next, nil
 }
 
-func (s *Service) ListUsers(ctx context.Context, limit, offset int) ([]*models.User, error) {
-	return s.repository.ListUsers(ctx, limit, offset)
+func encodeUserCursor(cursor *domain.UserCursor) (string, error) {
+	data, err := json.Marshal(cursor)
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal cursor: %w", err)
+	}
+	return base64.RawURLEncoding.EncodeToString(data), nil
+}
+
+func decodeUserCursor(value string) (*domain.UserCursor, error) {
+	data, err := base64.RawURLEncoding.DecodeString(value)
+	if err != nil {
+		return nil, domain.ErrInvalidCursor
+	}
+	cursor := new(domain.UserCursor)
+	if err := json.Unmarshal(data, cursor); err != nil {
+		return nil, domain.ErrInvalidCursor
+	}
+	if cursor.ID <= 0 {
+		return nil, domain.ErrInvalidCursor
+	}
+	return cursor, nil
 }
 
 func (s *Service) GetUserByID(ctx context.Context, id int) (*models.User, error) {
@@ -509,54 +1026,148 @@ func (s *Service) GetUserByUUID(ctx context.Context, uuid string) (*models.User,
 
 // ----
 
-func (s *Service) RotateJWTSecret(newSecret string) {
+// RotateJWTSecret persists new secret for given generation and retires old ones.
+// Generation is unique across all replicas, only one of them will succeed,
+// others will receive domain.ErrJWTSecretAlreadyRotated.
+// Secret is accepted for validation after next SyncJWTSecrets call
+// and is used for signing after activeFrom, which leaves other replicas
+// time to learn about it before first token signed with it arrives.
+func (s *Service) RotateJWTSecret(
+	ctx context.Context, newSecret string, generation int64, activeFrom time.Time,
+) error {
+	sealed, err := sealJWTSecret(s.jwtSecretsCipher, newSecret)
+	if err != nil {
+		return err
+	}
+	err = s.repository.WithTransaction(ctx, func(txCtx context.Context) error {
+		err := s.repository.CreateJWTSecret(txCtx, &models.JWTSecret{
+			KID:        strToSHA256(newSecret),
+			Secret:     sealed,
+			Generation: generation,
+			ActiveFrom: activeFrom,
+		})
+		if err != nil {
+			return err
+		}
+		secrets, err := s.repository.ListJWTSecrets(txCtx, jwtSecretsKeep)
+		if err != nil {
+			return fmt.Errorf("failed to list jwt secrets: %w", err)
+		}
+		if len(secrets) > 0 {
+			err := s.repository.DeleteJWTSecretsBefore(txCtx, secrets[0].Generation)
+			if err != nil {
+				return fmt.Errorf("failed to retire jwt secrets: %w", err)
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	metrics.Counter("auth_jwt_secret_rotations_total", nil).Inc()
+
+	return nil
+}
+
+// SyncJWTSecrets loads persisted secrets and replaces in-memory secret ring.
+func (s *Service) SyncJWTSecrets(ctx context.Context) error {
+	secrets, err := s.repository.ListJWTSecrets(ctx, jwtSecretsKeep)
+	if err != nil {
+		return fmt.Errorf("failed to list jwt secrets: %w", err)
+	}
+
+	ring := slices.Clone(s.jwtStaticSecrets)
+	for _, secret := range secrets {
+		plain, err := openJWTSecret(s.jwtSecretsCipher, secret.Secret)
+		if err != nil {
+			s.logger.ErrorContext(
+				ctx, "failed to decrypt jwt secret",
+				slog.String("kid", secret.KID),
+				slog.Any("error", err),
+			)
+			continue
+		}
+		key, err := parseJWTSecret(plain)
+		if err != nil {
+			s.logger.ErrorContext(
+				ctx, "failed to parse jwt secret",
+				slog.String("kid", secret.KID),
+				slog.Any("error", err),
+			)
+			continue
+		}
+		key.activeFrom = secret.ActiveFrom
+		ring = append(ring, key)
+	}
+
+	// only last secrets are kept in memory
+	if len(ring) > jwtSecretsKeep {
+		ring = ring[len(ring)-jwtSecretsKeep:]
+	}
+
 	s.jwtSecretsMu.Lock()
 	defer s.jwtSecretsMu.Unlock()
 
-	hash := sha256.Sum256([]byte(newSecret))
-	newJWTSecret := jwtSecret{
-		sha256: hex.EncodeToString(hash[:]),
-		secret: newSecret,
+	if len(ring) > 0 {
+		s.jwtSecrets = ring
+	}
+	if len(secrets) > 0 {
+		s.jwtSecretGeneration = secrets[len(secrets)-1].Generation
 	}
 
-	s.jwtSecrets = append(s.jwtSecrets, newJWTSecret)
+	metrics.Gauge("auth_jwt_secrets_count", nil).Set(float64(len(s.jwtSecrets)))
 
-	// only 3 secrets are kept in memory
-	if len(s.jwtSecrets) > 3 {
-		s.jwtSecrets = s.jwtSecrets[len(s.jwtSecrets)-3:]
+	return nil
+}
+
+// JWKS returns public keys of asymmetric secrets in the key ring.
+func (s *Service) JWKS() *domain.JWKS {
+	s.jwtSecretsMu.RLock()
+	defer s.jwtSecretsMu.RUnlock()
+	jwks := &domain.JWKS{Keys: make([]domain.JWK, 0, len(s.jwtSecrets))}
+	for _, secret := range s.jwtSecrets {
+		if jwk, err := secret.jwk(); err == nil {
+			jwks.Keys = append(jwks.Keys, jwk)
+		}
 	}
+	return jwks
+}
 
-	metrics.Gauge("auth_jwt_secrets_count", nil).Set(float64(len(s.jwtSecrets)))
-	metrics.Counter("auth_jwt_secret_rotations_total", nil).Inc()
+// JWTSecretGeneration returns generation of latest persisted secret,
+// it can be not active yet.
+func (s *Service) JWTSecretGeneration() int64 {
+	s.jwtSecretsMu.RLock()
+	defer s.jwtSecretsMu.RUnlock()
+	return s.jwtSecretGeneration
 }
 
 func (s *Service) ValidateJWTToken(ctx context.Context, token string) (*domain.JWTClaims, error) {
 	return tools.TraceReturnTWithErr[*domain.JWTClaims](
 		ctx, "auth.service", "validate_jwt_token",
 		func(ctx context.Context) (*domain.JWTClaims, error) {
-			return s.validateJWTToken(ctx, token)
+			return s.validateJWTToken(ctx, token, "")
 		})
 }
 
-func (s *Service) validateJWTToken(ctx context.Context, token string) (*domain.JWTClaims, error) {
+// validateJWTToken validates token and checks that it was issued for given scope.
+// Access and refresh tokens have empty scope.
+func (s *Service) validateJWTToken(ctx context.Context, token string, scope string) (*domain.JWTClaims, error) {
 	startTime := time.Now()
 	defer func() {
 		metrics.Histogram("auth_jwt_validation_duration_seconds", nil).Update(time.Since(startTime).Seconds())
 	}()
 
 	t, err := jwt.ParseWithClaims(token, &domain.JWTClaims{}, func(token *jwt.Token) (interface{}, error) {
-		if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
-			return nil, fmt.Errorf("unexpected signing method: %v", token.Header["alg"])
-		}
 		claims, _ := token.Claims.(*domain.JWTClaims)
-		s.jwtSecretsMu.RLock()
-		defer s.jwtSecretsMu.RUnlock()
-		for _, secret := range s.jwtSecrets {
-			if claims.KID == secret.sha256 {
-				return []byte(secret.secret), nil
-			}
+		key, ok := s.jwtSecretByKID(claims.KID)
+		if !ok {
+			return nil, fmt.Errorf("unknown key id: %s", claims.KID)
 		}
-		return []byte(s.jwtSecrets[len(s.jwtSecrets)-1].secret), nil
+		if token.Method.Alg() != key.method.Alg() {
+			return nil, fmt.Errorf("unexpected signing method: %v", token.Header["alg"])
+		}
+		return key.verifyKey, nil
 	})
 	if err != nil {
 		metrics.Counter("auth_jwt_validations_total", map[string]interface{}{
@@ -580,6 +1191,13 @@ func (s *Service) validateJWTToken(ctx context.Context, token string) (*domain.J
 		return nil, domain.ErrInvalidToken
 	}
 
+	if claims.Scope != scope {
+		metrics.Counter("auth_jwt_validations_total", map[string]interface{}{
+			"result": "invalid_scope",
+		}).Inc()
+		return nil, domain.ErrInvalidToken
+	}
+
 	if v, err := s.cache.Get(ctx, cacheKeyInvalidatedToken+strToSHA256(token)); err != nil {
 		s.logger.ErrorContext(
 			ctx, "failed to fetch cache: %w",
@@ -591,6 +1209,17 @@ func (s *Service) validateJWTToken(ctx context.Context, token string) (*domain.J
 		return nil, domain.ErrInvalidToken
 	}
 
+	if v, err := s.cache.Get(ctx, cacheKeyInvalidatedTokenID+claims.ID); err != nil {
+		s.logger.ErrorContext(
+			ctx, "failed to fetch cache: %w",
+			slog.Any("error", err))
+	} else if v == cacheValueInvalidatedToken {
+		metrics.Counter("auth_jwt_validations_total", map[string]interface{}{
+			"result": "session_revoked",
+		}).Inc()
+		return nil, domain.ErrInvalidToken
+	}
+
 	if span := trace.SpanFromContext(ctx); span.SpanContext().IsValid() && span.IsRecording() {
 		span.AddEvent("jwt_token_validated",
 			trace.WithAttributes(
@@ -607,6 +1236,31 @@ func (s *Service) validateJWTToken(ctx context.Context, token string) (*domain.J
 	return claims, nil
 }
 
+// jwtSecretByKID returns key with given KID, including keys which are not active yet.
+func (s *Service) jwtSecretByKID(kid string) (jwtSecret, bool) {
+	s.jwtSecretsMu.RLock()
+	defer s.jwtSecretsMu.RUnlock()
+	for _, secret := range s.jwtSecrets {
+		if kid == secret.sha256 {
+			return secret, true
+		}
+	}
+	return jwtSecret{}, false
+}
+
+// signingJWTSecret returns latest active key.
+func (s *Service) signingJWTSecret() (jwtSecret, error) {
+	s.jwtSecretsMu.RLock()
+	defer s.jwtSecretsMu.RUnlock()
+	now := time.Now()
+	for i := len(s.jwtSecrets) - 1; i >= 0; i-- {
+		if !s.jwtSecrets[i].activeFrom.After(now) {
+			return s.jwtSecrets[i], nil
+		}
+	}
+	return jwtSecret{}, errors.New("no active jwt secret")
+}
+
 func (s *Service) InvalidateJWTToken(ctx context.Context, token string, until time.Time) error {
 	return s.cache.Set(
 		ctx,
@@ -616,13 +1270,76 @@ func (s *Service) InvalidateJWTToken(ctx context.Context, token string, until ti
 	)
 }
 
-func (s *Service) generateTokens(userUUID string) (*domain.Tokens, error) {
-	accessToken, err := s.generateAccessToken(userUUID)
+// invalidateJWTTokenID invalidates token by its jti, used when token itself is not known.
+func (s *Service) invalidateJWTTokenID(ctx context.Context, id string, until time.Time) error {
+	return s.cache.Set(
+		ctx,
+		cacheKeyInvalidatedTokenID+id,
+		cacheValueInvalidatedToken,
+		time.Until(until)+time.Second,
+	)
+}
+
+// issueTokens generates new token pair, stores refresh token in session family
+// and links session to issued tokens. Tokens are scoped to tenant of the session.
+// Should be called within transaction.
+func (s *Service) issueTokens(ctx context.Context, user *models.User, session *models.Session) (*domain.Tokens, error) {
+	accessTokenID := uuid.New()
+	refreshTokenID := uuid.New()
+
+	tenant, err := s.sessionTenant(ctx, user, session)
+	if err != nil {
+		return nil, err
+	}
+
+	grant, err := s.sessionGrant(ctx, session)
+	if err != nil {
+		return nil, err
+	}
+
+	tokens, err := s.generateTokens(user.UUID.String(), tenant, grant, accessTokenID.String(), refreshTokenID.String())
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+
+	err = s.repository.CreateRefreshToken(ctx, &models.RefreshToken{
+		JTI:       refreshTokenID,
+		FamilyID:  session.FamilyID,
+		UserID:    user.ID,
+		ExpiresAt: now.Add(s.refreshTokenTTL),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to store refresh token: %w", err)
+	}
+
+	session.AccessTokenJTI = accessTokenID
+	session.RefreshTokenJTI = refreshTokenID
+	session.LastSeenAt = now
+	session.ExpiresAt = now.Add(s.refreshTokenTTL)
+
+	if session.ID == 0 {
+		err = s.repository.CreateSession(ctx, session)
+	} else {
+		err = s.repository.UpdateSession(ctx, session)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to store session: %w", err)
+	}
+
+	return tokens, nil
+}
+
+func (s *Service) generateTokens(
+	userUUID string, tenant string, grant *oauthGrant, accessTokenID, refreshTokenID string,
+) (*domain.Tokens, error) {
+	accessToken, err := s.generateAccessToken(userUUID, tenant, grant, accessTokenID)
 	if err != nil {
 		return nil, err
 	}
 
-	refreshToken, err := s.generateRefreshToken(userUUID)
+	refreshToken, err := s.generateRefreshToken(userUUID, tenant, refreshTokenID)
 	if err != nil {
 		return nil, err
 	}
@@ -634,38 +1351,89 @@ func (s *Service) generateTokens(userUUID string) (*domain.Tokens, error) {
 	}, nil
 }
 
-func (s *Service) generateAccessToken(userUUID string) (string, error) {
-	s.jwtSecretsMu.RLock()
-	token := s.jwtSecrets[len(s.jwtSecrets)-1]
-	s.jwtSecretsMu.RUnlock()
-	return jwt.NewWithClaims(jwt.SigningMethodHS256, domain.JWTClaims{
+// generateAccessToken generates access token, grant limits tokens issued to oauth clients.
+func (s *Service) generateAccessToken(userUUID string, tenant string, grant *oauthGrant, id string) (string, error) {
+	key, err := s.signingJWTSecret()
+	if err != nil {
+		return "", err
+	}
+	claims := domain.JWTClaims{
 		RegisteredClaims: jwt.RegisteredClaims{
-			ID:        uuid.New().String(),
+			ID:        id,
 			Audience:  s.jwtAudience,
 			Issuer:    s.jwtIssuer,
 			Subject:   userUUID,
 			IssuedAt:  jwt.NewNumericDate(time.Now()),
 			ExpiresAt: jwt.NewNumericDate(time.Now().Add(s.accessTokenTTL)),
 		},
-		KID: token.sha256,
-	}).SignedString([]byte(token.secret))
+		KID:    key.sha256,
+		Tenant: tenant,
+	}
+	if grant != nil {
+		claims.ClientID = grant.clientID
+		claims.Permissions = grant.permissions
+	}
+	return signJWTToken(key, claims)
 }
 
-func (s *Service) generateRefreshToken(userUUID string) (string, error) {
-	s.jwtSecretsMu.RLock()
-	token := s.jwtSecrets[len(s.jwtSecrets)-1]
-	s.jwtSecretsMu.RUnlock()
-	return jwt.NewWithClaims(jwt.SigningMethodHS256, domain.JWTClaims{
+func (s *Service) generateRefreshToken(userUUID string, tenant string, id string) (string, error) {
+	key, err := s.signingJWTSecret()
+	if err != nil {
+		return "", err
+	}
+	return signJWTToken(key, domain.JWTClaims{
 		RegisteredClaims: jwt.RegisteredClaims{
-			ID:        uuid.New().String(),
+			ID:        id,
 			Audience:  s.jwtAudience,
 			Issuer:    s.jwtIssuer,
 			Subject:   userUUID,
 			IssuedAt:  jwt.NewNumericDate(time.Now()),
 			ExpiresAt: jwt.NewNumericDate(time.Now().Add(s.refreshTokenTTL)),
 		},
-		KID: token.sha256,
-	}).SignedString([]byte(token.secret))
+		KID:    key.sha256,
+		Tenant: tenant,
+	})
+}
+
+func (s *Service) generateMFAChallenge(userUUID string) (*domain.MFAChallenge, error) {
+	token, err := s.generateScopedToken(userUUID, domain.JWTScopeMFAChallenge, "", s.mfaChallengeTTL)
+	if err != nil {
+		return nil, err
+	}
+	return &domain.MFAChallenge{
+		ChallengeToken: token,
+		ExpiresIn:      int(s.mfaChallengeTTL.Seconds()),
+	}, nil
+}
+
+// generateScopedToken generates token which is accepted only by operation of given scope.
+func (s *Service) generateScopedToken(
+	userUUID string, scope string, fingerprint string, ttl time.Duration,
+) (string, error) {
+	key, err := s.signingJWTSecret()
+	if err != nil {
+		return "", err
+	}
+	return signJWTToken(key, domain.JWTClaims{
+		RegisteredClaims: jwt.RegisteredClaims{
+			ID:        uuid.New().String(),
+			Audience:  s.jwtAudience,
+			Issuer:    s.jwtIssuer,
+			Subject:   userUUID,
+			IssuedAt:  jwt.NewNumericDate(time.Now()),
+			ExpiresAt: jwt.NewNumericDate(time.Now().Add(ttl)),
+		},
+		KID:         key.sha256,
+		Scope:       scope,
+		Fingerprint: fingerprint,
+	})
+}
+
+func signJWTToken(key jwtSecret, claims jwt.Claims) (string, error) {
+	token := jwt.NewWithClaims(key.method, claims)
+	// kid header allows verification of token using published JWKS
+	token.Header["kid"] = key.sha256
+	return token.SignedString(key.signKey)
 }
 
 // ----
@@ -714,7 +1482,18 @@ func (s *Service) RecentlyUsedTokensChan() <-chan domain.TokenWasUsed {
 
 // ----
 
+// sendEvent enqueues message to the outbox, auth events are annotated with metadata of the request.
 func (s *Service) sendEvent(ctx context.Context, topic string, outboxMessage outboxDomain.Message) error {
+	if topic == domain.TopicNameAuthEvents && outboxMessage.Metadata == "" {
+		metadata, err := eventMetadata(ctx)
+		if err != nil {
+			return err
+		}
+		outboxMessage.Metadata = metadata
+	}
+	if outboxMessage.AggregateKey == "" {
+		outboxMessage.AggregateKey = eventAggregateKey(topic, outboxMessage)
+	}
 	err := s.outboxService.NewOutboxMessage(ctx, topic, &outboxMessage)
 	if err != nil {
 		return fmt.Errorf("failed to send outbox message: %w", err)
@@ -722,8 +1501,68 @@ func (s *Service) sendEvent(ctx context.Context, topic string, outboxMessage out
 	return nil
 }
 
-func (s *Service) CheckPasswordStrength(password string) error {
-	return passwordvalidator.Validate(password, float64(s.minPasswordEntropyBits))
+// eventAggregateKey identifies aggregate of the message, because aggregate type holds event type.
+// Events of the same user, role or organization are published in order regardless of their type.
+func eventAggregateKey(topic string, msg outboxDomain.Message) string {
+	aggregate := "user"
+	switch {
+	case topic == domain.TopicNameAuthDataRequests:
+		aggregate = "data_request"
+	case strings.HasPrefix(msg.AggregateType, "role."):
+		aggregate = "role"
+	case strings.HasPrefix(msg.AggregateType, "organization."):
+		aggregate = "organization"
+	}
+	return aggregate + ":" + strconv.Itoa(msg.AggregateID)
+}
+
+// sendAuthEvent sends event which ends up in history of the user, if aggregate is user.
+func (s *Service) sendAuthEvent(
+	ctx context.Context, eventType string, aggregateID int, data map[string]any,
+) error {
+	payload, err := json.Marshal(data)
+	if err != nil {
+		return fmt.Errorf("failed to marshal event payload: %w", err)
+	}
+	event := outboxDomain.Message{
+		AggregateID:   aggregateID,
+		AggregateType: eventType,
+		Payload:       payload,
+	}
+	if err := s.sendEvent(ctx, domain.TopicNameAuthEvents, event); err != nil {
+		return fmt.Errorf("failed to send event: %w", err)
+	}
+	return nil
+}
+
+// eventMetadata describes request which caused the event, so that history can be audited.
+// Empty string is returned for events which are not caused by request, e.g. by workers.
+func eventMetadata(ctx context.Context) (string, error) {
+	metadata := make(map[string]string)
+	if requestID := tools.GetRequestIDFromContext(ctx); requestID != "" {
+		metadata["request_id"] = requestID
+	}
+	client := tools.GetClientInfoFromContext(ctx)
+	if client.IPAddress != "" {
+		metadata["ip_address"] = client.IPAddress
+	}
+	if client.UserAgent != "" {
+		metadata["user_agent"] = truncateString(client.UserAgent, maxEventUserAgentLength)
+	}
+	if authInfo := RetrieveAuthFromContext(ctx); authInfo != nil {
+		metadata["caller_uuid"] = authInfo.UserUUID
+		if authInfo.ActorUUID != "" {
+			metadata["actor_uuid"] = authInfo.ActorUUID
+		}
+	}
+	if len(metadata) == 0 {
+		return "", nil
+	}
+	data, err := json.Marshal(metadata)
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal event metadata: %w", err)
+	}
+	return string(data), nil
 }
 
 func strToSHA256(token string) string {
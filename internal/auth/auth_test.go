@@ -0,0 +1,65 @@
+package auth
+
+import (
+	"context"
+	"testing"
+
+	"go.uber.org/mock/gomock"
+
+	"github.com/hasansino/go42/internal/auth/domain"
+	"github.com/hasansino/go42/internal/auth/mocks"
+	outboxDomain "github.com/hasansino/go42/internal/outbox/domain"
+)
+
+func TestService_sendEvent_AggregateKey(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	outbox := mocks.NewMockoutboxService(ctrl)
+
+	s, err := NewService(
+		mocks.NewMockrepository(ctrl), outbox, mocks.NewMockcache(ctrl),
+		WithJWTSecrets([]string{"static"}),
+		WithJWTSecretsEncryptionKey("key"),
+	)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	keys := make(map[string]string)
+	outbox.EXPECT().NewOutboxMessage(gomock.Any(), gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ context.Context, _ string, msg *outboxDomain.Message) error {
+			keys[msg.AggregateType] = msg.AggregateKey
+			return nil
+		}).AnyTimes()
+
+	ctx := context.Background()
+	events := []struct {
+		eventType   string
+		aggregateID int
+	}{
+		{domain.EventTypeUserCreate, 1},
+		{domain.EventTypeUserUpdate, 1},
+		{domain.EventTypeRoleCreate, 1},
+		{domain.EventTypeOrganizationCreate, 1},
+	}
+	for _, event := range events {
+		if err := s.sendAuthEvent(ctx, event.eventType, event.aggregateID, map[string]any{}); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+	if err := s.enqueueMail(ctx, 1, domain.MailTypePasswordReset); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	want := map[string]string{
+		domain.EventTypeUserCreate:         "user:1",
+		domain.EventTypeUserUpdate:         "user:1",
+		domain.EventTypeRoleCreate:         "role:1",
+		domain.EventTypeOrganizationCreate: "organization:1",
+		domain.MailTypePasswordReset:       "user:1",
+	}
+	for eventType, key := range want {
+		if keys[eventType] != key {
+			t.Errorf("aggregate key of %s = %q, want %q", eventType, keys[eventType], key)
+		}
+	}
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 
 	"github.com/hasansino/go42/internal/auth/domain"
+	"github.com/hasansino/go42/internal/database"
 )
 
 type contextKey string
@@ -21,6 +22,10 @@ func RetrieveAuthFromContext(ctx context.Context) *domain.ContextAuthInfo {
 }
 
 // SetAuthToContext sets auth state to context.
+// Active tenant restricts tenant scoped queries made within the context.
 func SetAuthToContext(ctx context.Context, authInfo domain.ContextAuthInfo) context.Context {
+	if authInfo.TenantID != 0 {
+		ctx = database.WithTenant(ctx, authInfo.TenantID)
+	}
 	return context.WithValue(ctx, contextKeyAuthInfo, authInfo)
 }
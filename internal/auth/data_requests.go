@@ -0,0 +1,317 @@
+package auth
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"slices"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/hasansino/go42/internal/auth/domain"
+	"github.com/hasansino/go42/internal/auth/models"
+	"github.com/hasansino/go42/internal/metrics"
+	outboxDomain "github.com/hasansino/go42/internal/outbox/domain"
+)
+
+const (
+	defaultDataArchiveTTL = 7 * 24 * time.Hour
+
+	// keeps error within size of its column
+	maxDataRequestErrorLength = 255
+)
+
+// CreateDataRequest creates pending data request of given type for the user.
+// Request is processed asynchronously by ProcessDataRequest, so its status has to be polled.
+// Requester is taken from context, system users can not be erased.
+func (s *Service) CreateDataRequest(
+	ctx context.Context, userUUID string, requestType string,
+) (*models.DataRequest, error) {
+	if !slices.Contains(domain.DataRequestTypes, requestType) {
+		return nil, fmt.Errorf("unknown data request type: %s", requestType)
+	}
+
+	user, err := s.repository.GetUserByUUID(ctx, userUUID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get user: %w", err)
+	}
+	if requestType == domain.DataRequestTypeErasure && user.IsSystem {
+		return nil, fmt.Errorf("%w: system user can not be erased", domain.ErrPermissionDenied)
+	}
+
+	request := &models.DataRequest{
+		UUID:   uuid.New(),
+		UserID: user.ID,
+		Type:   requestType,
+		Status: domain.DataRequestStatusPending,
+	}
+	if authInfo := RetrieveAuthFromContext(ctx); authInfo != nil {
+		request.RequestedBy = sql.Null[int]{V: authInfo.UserID, Valid: true}
+	}
+
+	err = s.repository.WithTransaction(ctx, func(txCtx context.Context) error {
+		if err := s.repository.CreateDataRequest(txCtx, request); err != nil {
+			return err
+		}
+		msg := outboxDomain.Message{
+			AggregateID:   request.ID,
+			AggregateType: request.Type,
+		}
+		if err := s.sendEvent(txCtx, domain.TopicNameAuthDataRequests, msg); err != nil {
+			return fmt.Errorf("failed to enqueue data request: %w", err)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return request, nil
+}
+
+// GetDataRequest returns data request of the user.
+func (s *Service) GetDataRequest(
+	ctx context.Context, userUUID string, requestUUID string,
+) (*models.DataRequest, error) {
+	return s.repository.GetUserDataRequest(ctx, userUUID, requestUUID)
+}
+
+// GetDataRequestArchive returns zip archive of completed export of the user,
+// domain.ErrEntityNotFound is returned when there is no archive or it has expired.
+func (s *Service) GetDataRequestArchive(ctx context.Context, userUUID string, requestUUID string) ([]byte, error) {
+	request, err := s.repository.GetUserDataRequest(ctx, userUUID, requestUUID)
+	if err != nil {
+		return nil, err
+	}
+	return s.repository.GetDataRequestArchive(ctx, request.ID)
+}
+
+// ProcessDataRequest executes pending data request, called by data request processor worker.
+// Request is processed once, failure is saved as its result and is not retried.
+func (s *Service) ProcessDataRequest(ctx context.Context, requestID int) error {
+	request, err := s.repository.GetDataRequestByID(ctx, requestID)
+	if err != nil {
+		return fmt.Errorf("failed to get data request: %w", err)
+	}
+	err = s.repository.ClaimDataRequest(ctx, request)
+	if errors.Is(err, domain.ErrEntityNotFound) {
+		// already processed, delivery is at least once
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	var archive []byte
+	switch request.Type {
+	case domain.DataRequestTypeExport:
+		archive, err = s.exportUserData(ctx, request)
+	case domain.DataRequestTypeErasure:
+		err = s.eraseUserData(ctx, request)
+	default:
+		err = fmt.Errorf("unknown data request type: %s", request.Type)
+	}
+
+	now := time.Now()
+	request.CompletedAt = sql.Null[time.Time]{V: now, Valid: true}
+	result := "success"
+	if err != nil {
+		result = "error"
+		s.logger.ErrorContext(ctx, "failed to process data request",
+			slog.Int("request_id", request.ID),
+			slog.String("type", request.Type),
+			slog.Any("error", err),
+		)
+		request.Status = domain.DataRequestStatusFailed
+		request.Error = sql.Null[string]{V: truncateString(err.Error(), maxDataRequestErrorLength), Valid: true}
+	} else {
+		request.Status = domain.DataRequestStatusCompleted
+		if archive != nil {
+			request.Archive = archive
+			request.ExpiresAt = sql.Null[time.Time]{V: now.Add(s.dataArchiveTTL), Valid: true}
+		}
+	}
+
+	metrics.Counter("auth_data_requests_processed_total", map[string]interface{}{
+		"type":   request.Type,
+		"result": result,
+	}).Inc()
+
+	return s.repository.CompleteDataRequest(ctx, request)
+}
+
+// dataExport is the contents of export archive, each field is separate file.
+type dataExport struct {
+	Profile   dataExportProfile    `json:"profile"`
+	Roles     []string             `json:"roles"`
+	APITokens []dataExportAPIToken `json:"api_tokens"`
+	History   []dataExportHistory  `json:"history"`
+}
+
+type dataExportProfile struct {
+	UUID            string          `json:"uuid"`
+	Email           string          `json:"email"`
+	EmailVerifiedAt *time.Time      `json:"email_verified_at"`
+	Status          string          `json:"status"`
+	Metadata        json.RawMessage `json:"metadata"`
+	CreatedAt       time.Time       `json:"created_at"`
+	UpdatedAt       time.Time       `json:"updated_at"`
+}
+
+type dataExportAPIToken struct {
+	UUID        string     `json:"uuid"`
+	Name        string     `json:"name"`
+	Permissions []string   `json:"permissions"`
+	CreatedAt   time.Time  `json:"created_at"`
+	LastUsedAt  *time.Time `json:"last_used_at"`
+	ExpiresAt   *time.Time `json:"expires_at"`
+}
+
+type dataExportHistory struct {
+	ID         string          `json:"id"`
+	OccurredAt time.Time       `json:"occurred_at"`
+	EventType  string          `json:"event_type"`
+	Data       json.RawMessage `json:"data"`
+	Metadata   string          `json:"metadata"`
+}
+
+func (s *Service) exportUserData(ctx context.Context, request *models.DataRequest) ([]byte, error) {
+	user, err := s.repository.GetUserByID(ctx, request.UserID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get user: %w", err)
+	}
+	tokens, err := s.repository.ListTokens(ctx, user.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list api tokens: %w", err)
+	}
+	history, err := s.repository.ListUserHistoryRecords(ctx, user.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list history: %w", err)
+	}
+
+	export := dataExport{
+		Profile: dataExportProfile{
+			UUID:            user.UUID.String(),
+			Email:           user.Email,
+			EmailVerifiedAt: nullTimePtr(user.EmailVerifiedAt),
+			Status:          user.Status,
+			Metadata:        user.Metadata,
+			CreatedAt:       user.CreatedAt,
+			UpdatedAt:       user.UpdatedAt,
+		},
+		Roles:     user.RoleList(),
+		APITokens: make([]dataExportAPIToken, len(tokens)),
+		History:   make([]dataExportHistory, len(history)),
+	}
+	for i, token := range tokens {
+		export.APITokens[i] = dataExportAPIToken{
+			UUID:        token.UUID.String(),
+			Name:        token.Name,
+			Permissions: token.PermissionList(),
+			CreatedAt:   token.CreatedAt,
+			LastUsedAt:  nullTimePtr(token.LastUsedAt),
+			ExpiresAt:   nullTimePtr(token.ExpiresAt),
+		}
+	}
+	for i, record := range history {
+		export.History[i] = dataExportHistory{
+			ID:         record.ID.String(),
+			OccurredAt: record.OccurredAt,
+			EventType:  record.EventType,
+			Data:       record.Data,
+			Metadata:   record.Metadata,
+		}
+	}
+
+	archive, err := buildDataArchive(map[string]any{
+		"profile.json":    export.Profile,
+		"roles.json":      export.Roles,
+		"api_tokens.json": export.APITokens,
+		"history.json":    export.History,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	err = s.sendAuthEvent(ctx, domain.EventTypeUserDataExport, user.ID, map[string]any{
+		"request": request.UUID.String(),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return archive, nil
+}
+
+// buildDataArchive writes every value as indented json file of zip archive.
+func buildDataArchive(files map[string]any) ([]byte, error) {
+	names := make([]string, 0, len(files))
+	for name := range files {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+
+	buf := new(bytes.Buffer)
+	zw := zip.NewWriter(buf)
+	for _, name := range names {
+		data, err := json.MarshalIndent(files[name], "", "  ")
+		if err != nil {
+			return nil, fmt.Errorf("failed to marshal %s: %w", name, err)
+		}
+		w, err := zw.Create(name)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create %s: %w", name, err)
+		}
+		if _, err := w.Write(data); err != nil {
+			return nil, fmt.Errorf("failed to write %s: %w", name, err)
+		}
+	}
+	if err := zw.Close(); err != nil {
+		return nil, fmt.Errorf("failed to close archive: %w", err)
+	}
+	return buf.Bytes(), nil
+}
+
+// eraseUserData pseudonymises the user and purges payloads of its history.
+// Published erasure event carries only uuid of the user, so other services can erase their data.
+func (s *Service) eraseUserData(ctx context.Context, request *models.DataRequest) error {
+	user, err := s.repository.GetUserByID(ctx, request.UserID)
+	if err != nil {
+		return fmt.Errorf("failed to get user: %w", err)
+	}
+	original := *user
+
+	err = s.repository.WithTransaction(ctx, func(txCtx context.Context) error {
+		anonymizeUser(user)
+		if err := s.repository.AnonymizeUser(txCtx, user, original.Status); err != nil {
+			return fmt.Errorf("failed to anonymize user: %w", err)
+		}
+		if err := s.repository.PurgeUserHistoryData(txCtx, user.ID); err != nil {
+			return err
+		}
+		return s.sendAuthEvent(txCtx, domain.EventTypeUserDataErasure, user.ID, map[string]any{
+			"uuid":    user.UUID.String(),
+			"request": request.UUID.String(),
+		})
+	})
+	if err != nil {
+		return err
+	}
+
+	s.repository.InvalidateUserCache(ctx, &original)
+
+	return nil
+}
+
+func nullTimePtr(t sql.Null[time.Time]) *time.Time {
+	if !t.Valid {
+		return nil
+	}
+	return &t.V
+}
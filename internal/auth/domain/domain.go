@@ -8,13 +8,16 @@ import (
 )
 
 // RBAC roles.
-// MUST reflect state of table `auth_roles`.
+// Built-in roles, MUST reflect seeded state of table `auth_roles`.
+// Built-in roles can not be deleted, other roles are managed at runtime.
 
 const (
-	RBACRoleUser = "user"
+	RBACRoleAdmin = "admin"
+	RBACRoleUser  = "user"
 )
 
 var RBACAllRoles = []string{
+	RBACRoleAdmin,
 	RBACRoleUser,
 }
 
@@ -22,13 +25,60 @@ var RBACAllRoles = []string{
 // MUST reflect state of table `auth_permissions`.
 
 const (
-	RBACPermissionUsersReadSelf   = "users:read_self"
-	RBACPermissionUsersUpdateSelf = "users:update_self"
-	RBACPermissionUsersList       = "users:list"
-	RBACPermissionUsersReadOthers = "users:read_others"
-	RBACPermissionUsersCreate     = "users:create"
-	RBACPermissionUsersUpdate     = "users:update"
-	RBACPermissionUsersDelete     = "users:delete"
+	RBACPermissionUsersReadSelf    = "users:read_self"
+	RBACPermissionUsersUpdateSelf  = "users:update_self"
+	RBACPermissionUsersList        = "users:list"
+	RBACPermissionUsersReadOthers  = "users:read_others"
+	RBACPermissionUsersCreate      = "users:create"
+	RBACPermissionUsersUpdate      = "users:update"
+	RBACPermissionUsersDelete      = "users:delete"
+	RBACPermissionUsersUnlock      = "users:unlock"
+	RBACPermissionUsersImpersonate = "users:impersonate"
+	RBACPermissionUsersSuspend     = "users:suspend"
+	RBACPermissionUsersDataRequest = "users:data_requests"
+
+	RBACPermissionSessionsReadSelf     = "sessions:read_self"
+	RBACPermissionSessionsRevokeSelf   = "sessions:revoke_self"
+	RBACPermissionSessionsRevokeOthers = "sessions:revoke_others"
+
+	RBACPermissionAPITokensReadSelf     = "api_tokens:read_self"
+	RBACPermissionAPITokensCreateSelf   = "api_tokens:create_self"
+	RBACPermissionAPITokensRevokeSelf   = "api_tokens:revoke_self"
+	RBACPermissionAPITokensManageOthers = "api_tokens:manage_others"
+
+	RBACPermissionRolesList   = "roles:list"
+	RBACPermissionRolesCreate = "roles:create"
+	RBACPermissionRolesUpdate = "roles:update"
+	RBACPermissionRolesDelete = "roles:delete"
+	RBACPermissionRolesGrant  = "roles:grant"
+	RBACPermissionRolesRevoke = "roles:revoke"
+
+	RBACPermissionMFAManageSelf  = "mfa:manage_self"
+	RBACPermissionMFAResetOthers = "mfa:reset_others"
+
+	RBACPermissionOAuthClientsList   = "oauth_clients:list"
+	RBACPermissionOAuthClientsCreate = "oauth_clients:create"
+	RBACPermissionOAuthClientsDelete = "oauth_clients:delete"
+
+	RBACPermissionOrganizationsList          = "organizations:list"
+	RBACPermissionOrganizationsCreate        = "organizations:create"
+	RBACPermissionOrganizationsDelete        = "organizations:delete"
+	RBACPermissionOrganizationsManageMembers = "organizations:manage_members"
+
+	RBACPermissionHistoryReadSelf   = "history:read_self"
+	RBACPermissionHistoryReadOthers = "history:read_others"
+
+	RBACPermissionOutboxRead   = "outbox:read"
+	RBACPermissionOutboxManage = "outbox:manage"
+)
+
+// Access policy actions, which are not permissions themselves.
+// Built-in policy rules allow them by self or others permission,
+// depending on whether resource belongs to the subject.
+
+const (
+	AccessActionUsersRead   = "users:read"
+	AccessActionHistoryRead = "history:read"
 )
 
 var RBACAllPermissions = []string{
@@ -39,43 +89,229 @@ var RBACAllPermissions = []string{
 	RBACPermissionUsersCreate,
 	RBACPermissionUsersUpdate,
 	RBACPermissionUsersDelete,
+	RBACPermissionUsersUnlock,
+	RBACPermissionUsersImpersonate,
+	RBACPermissionUsersSuspend,
+	RBACPermissionUsersDataRequest,
+	RBACPermissionSessionsReadSelf,
+	RBACPermissionSessionsRevokeSelf,
+	RBACPermissionSessionsRevokeOthers,
+	RBACPermissionAPITokensReadSelf,
+	RBACPermissionAPITokensCreateSelf,
+	RBACPermissionAPITokensRevokeSelf,
+	RBACPermissionAPITokensManageOthers,
+	RBACPermissionRolesList,
+	RBACPermissionRolesCreate,
+	RBACPermissionRolesUpdate,
+	RBACPermissionRolesDelete,
+	RBACPermissionRolesGrant,
+	RBACPermissionRolesRevoke,
+	RBACPermissionMFAManageSelf,
+	RBACPermissionMFAResetOthers,
+	RBACPermissionOAuthClientsList,
+	RBACPermissionOAuthClientsCreate,
+	RBACPermissionOAuthClientsDelete,
+	RBACPermissionOrganizationsList,
+	RBACPermissionOrganizationsCreate,
+	RBACPermissionOrganizationsDelete,
+	RBACPermissionOrganizationsManageMembers,
+	RBACPermissionHistoryReadSelf,
+	RBACPermissionHistoryReadOthers,
+	RBACPermissionOutboxRead,
+	RBACPermissionOutboxManage,
 }
 
 // ---- RBAC END
 
+// Pending users have not verified their email yet and can not login.
+// Suspended users can not login until suspension ends, suspension without end lasts until reinstated.
+// Users scheduled for deletion can be restored until grace period ends, then they are purged.
+// Deleted status is left on anonymized users, which remain only as records their history refers to.
 const (
-	UserStatusActive   = "active"
-	UserStatusInactive = "inactive"
+	UserStatusActive            = "active"
+	UserStatusInactive          = "inactive"
+	UserStatusPending           = "pending"
+	UserStatusSuspended         = "suspended"
+	UserStatusDeletionScheduled = "deletion_scheduled"
+	UserStatusDeleted           = "deleted"
 )
 
 var UserStatuses = []string{
 	UserStatusActive,
 	UserStatusInactive,
+	UserStatusPending,
+	UserStatusSuspended,
+	UserStatusDeletionScheduled,
+	UserStatusDeleted,
 }
 
+// Users past deletion grace period are either anonymized or erased with all their records.
+const (
+	UserDeletionModeAnonymize = "anonymize"
+	UserDeletionModeErase     = "erase"
+)
+
+const (
+	TopicNameAuthEvents           = "auth_events_topic"
+	EventTypeAuthSignUp           = "auth.signup"
+	EventTypeAuthLogin            = "auth.login"
+	EventTypeAuthLogout           = "auth.logout"
+	EventTypeAuthRefreshReuse     = "auth.refresh_reuse"
+	EventTypeAuthAccountLocked    = "auth.account_locked"
+	EventTypeUserCreate           = "user.create"
+	EventTypeUserUpdate           = "user.update"
+	EventTypeUserDelete           = "user.delete"
+	EventTypeUserSessionsRevoke   = "user.sessions_revoke"
+	EventTypeUserRoleGrant        = "user.role_grant"
+	EventTypeUserRoleRevoke       = "user.role_revoke"
+	EventTypeUserMFAEnable        = "user.mfa_enable"
+	EventTypeUserMFADisable       = "user.mfa_disable"
+	EventTypeUserEmailVerify      = "user.email_verify"
+	EventTypeUserPasswordReset    = "user.password_reset"
+	EventTypeUserIdentityLink     = "user.identity_link"
+	EventTypeRoleCreate           = "role.create"
+	EventTypeRoleDelete           = "role.delete"
+	EventTypeRolePermissionAttach = "role.permission_attach"
+	EventTypeRolePermissionDetach = "role.permission_detach"
+	EventTypeOAuthClientCreate    = "oauth_client.create"
+	EventTypeOAuthClientDelete    = "oauth_client.delete"
+	EventTypeOrganizationCreate   = "organization.create"
+	EventTypeOrganizationDelete   = "organization.delete"
+	EventTypeUserMembershipAdd    = "user.membership_add"
+	EventTypeUserMembershipRemove = "user.membership_remove"
+	EventTypeUserImpersonate      = "user.impersonate"
+	EventTypeUserImpersonatedCall = "user.impersonated_request"
+	EventTypeUserSuspend          = "user.suspend"
+	EventTypeUserReinstate        = "user.reinstate"
+	EventTypeUserRestore          = "user.restore"
+	EventTypeUserAnonymize        = "user.anonymize"
+	EventTypeUserErase            = "user.erase"
+	EventTypeUserDataExport       = "user.data_export"
+	EventTypeUserDataErasure      = "user.data_erasure"
+)
+
+// Mail messages are delivered through separate topic,
+// so that their contents never end up in user history.
+const (
+	TopicNameAuthMail         = "auth_mail_topic"
+	MailTypeEmailVerification = "mail.email_verification"
+	MailTypePasswordReset     = "mail.password_reset"
+)
+
+// Data requests are processed asynchronously, they are delivered to processor through separate topic.
+// Export collects data of the user into archive, erasure pseudonymises the user and purges its history.
 const (
-	TopicNameAuthEvents = "auth_events_topic"
-	EventTypeAuthSignUp = "auth.signup"
-	EventTypeAuthLogin  = "auth.login"
-	EventTypeAuthLogout = "auth.logout"
-	EventTypeUserCreate = "user.create"
-	EventTypeUserUpdate = "user.update"
-	EventTypeUserDelete = "user.delete"
+	TopicNameAuthDataRequests = "auth_data_requests_topic"
+
+	DataRequestTypeExport  = "export"
+	DataRequestTypeErasure = "erasure"
+
+	DataRequestStatusPending    = "pending"
+	DataRequestStatusProcessing = "processing"
+	DataRequestStatusCompleted  = "completed"
+	DataRequestStatusFailed     = "failed"
 )
 
+var DataRequestTypes = []string{
+	DataRequestTypeExport,
+	DataRequestTypeErasure,
+}
+
 var (
 	ErrEntityNotFound     = errors.New("entity not found")
 	ErrUserAlreadyExists  = errors.New("user already exists")
 	ErrInvalidCredentials = errors.New("invalid credentials")
 	ErrInvalidToken       = errors.New("invalid token")
 	ErrPasswordWeak       = errors.New("password is too weak")
+	ErrPermissionDenied   = errors.New("permission denied")
+	ErrAccountLocked      = errors.New("account is temporarily locked")
+	ErrRoleAlreadyExists  = errors.New("role already exists")
+	ErrRoleIsBuiltIn      = errors.New("built-in role can not be deleted")
+	ErrMFAAlreadyEnabled  = errors.New("mfa is already enabled")
+	ErrMFANotEnabled      = errors.New("mfa is not enabled")
+	ErrInvalidMFACode     = errors.New("invalid mfa code")
+	ErrEmailNotVerified   = errors.New("email is not verified")
+	ErrSSOFailed          = errors.New("sso login failed")
+	ErrUserNotProvisioned = errors.New("user is not provisioned")
+	ErrOrganizationExists = errors.New("organization already exists")
+	ErrUserStatusConflict = errors.New("operation is not allowed in current user status")
+	ErrInvalidCursor      = errors.New("invalid cursor")
+
+	ErrJWTSecretAlreadyRotated = errors.New("jwt secret already rotated")
+	ErrRefreshTokenReused      = errors.New("refresh token reused")
+
+	// OAuth errors, names follow error codes of RFC 6749.
+	ErrOAuthInvalidRequest       = errors.New("invalid_request")
+	ErrOAuthInvalidClient        = errors.New("invalid_client")
+	ErrOAuthInvalidGrant         = errors.New("invalid_grant")
+	ErrOAuthUnauthorizedClient   = errors.New("unauthorized_client")
+	ErrOAuthUnsupportedGrantType = errors.New("unsupported_grant_type")
+	ErrOAuthInvalidScope         = errors.New("invalid_scope")
+	ErrOAuthInvalidRedirectURI   = errors.New("invalid redirect uri")
+)
+
+// PasswordPolicyError describes why password was rejected by password policy.
+type PasswordPolicyError struct {
+	Reason string
+	Detail string
+}
+
+func (e *PasswordPolicyError) Error() string {
+	return ErrPasswordWeak.Error() + ": " + e.Detail
+}
+
+func (e *PasswordPolicyError) Unwrap() error {
+	return ErrPasswordWeak
+}
+
+const (
+	JWTAlgorithmHS256 = "HS256"
+	JWTAlgorithmRS256 = "RS256"
+	JWTAlgorithmES256 = "ES256"
+	JWTAlgorithmEdDSA = "EdDSA"
 )
 
 // ----
 
+// Scoped tokens are not accepted as access tokens.
+// JWTScopeMFAChallenge tokens only allow to complete login by providing second factor,
+// JWTScopeEmailVerification and JWTScopePasswordReset tokens are sent by email,
+// JWTScopeSSOState tokens are state of login with external identity provider.
+const (
+	JWTScopeMFAChallenge      = "mfa_challenge"
+	JWTScopeEmailVerification = "email_verification"
+	JWTScopePasswordReset     = "password_reset"
+	JWTScopeSSOState          = "sso_state"
+)
+
+// JWTClaims of issued tokens.
+// Fingerprint binds token to the state of the user it was issued for,
+// token becomes invalid once that state changes.
+// Tenant is uuid of organization access and refresh tokens are scoped to.
+// Actor is present in impersonation tokens only, it identifies user acting as the subject.
+// ClientID is present in access tokens issued to oauth clients, such tokens grant
+// only Permissions, which were consented to by the user.
 type JWTClaims struct {
 	jwt.RegisteredClaims
-	KID string `json:"kid,omitempty"`
+	KID         string    `json:"kid,omitempty"`
+	Scope       string    `json:"scope,omitempty"`
+	Fingerprint string    `json:"fp,omitempty"`
+	Tenant      string    `json:"tid,omitempty"`
+	Actor       *JWTActor `json:"act,omitempty"`
+	ClientID    string    `json:"azp,omitempty"`
+	Permissions []string  `json:"perm,omitempty"`
+}
+
+// JWTActor is actor claim of RFC 8693 section 4.1.
+type JWTActor struct {
+	Subject string `json:"sub"`
+}
+
+// ImpersonationToken is short-lived access token issued to actor on behalf of another user,
+// it can not be refreshed.
+type ImpersonationToken struct {
+	AccessToken string `json:"access_token"`
+	ExpiresIn   int    `json:"expires_in"`
 }
 
 // Tokens represents the structure of JWT authentication tokens.
@@ -85,6 +321,42 @@ type Tokens struct {
 	ExpiresIn    int    `json:"expires_in"`
 }
 
+// MFAChallenge is returned by login instead of Tokens when user has mfa enabled.
+// Challenge token is exchanged for Tokens together with valid mfa code.
+type MFAChallenge struct {
+	ChallengeToken string `json:"challenge_token"`
+	ExpiresIn      int    `json:"expires_in"`
+}
+
+// SSOAuthorization starts login with external identity provider.
+// Verifier must be kept by user agent which started the login (usually in cookie)
+// and presented on callback, so that callback can not be replayed by another user agent.
+type SSOAuthorization struct {
+	AuthURL   string
+	Verifier  string
+	ExpiresAt time.Time
+}
+
+// MFAEnrollment holds data required to configure authenticator application.
+// Enrollment is pending until confirmed with valid code.
+type MFAEnrollment struct {
+	Secret          string
+	ProvisioningURI string
+}
+
+// MFAStatus describes mfa state of the user.
+type MFAStatus struct {
+	Enabled           bool
+	EnabledAt         *time.Time
+	RecoveryCodesLeft int
+}
+
+// ClientInfo describes client which initiated authentication.
+type ClientInfo struct {
+	IPAddress string
+	UserAgent string
+}
+
 // TokenWasUsed is a message passed to channel for outside consumers.
 type TokenWasUsed struct {
 	ID   int
@@ -96,15 +368,28 @@ type TokenWasUsed struct {
 type AuthenticationType string
 
 const (
-	AuthenticationTypeCredentials = "credentials"
-	AuthenticationTypeApiToken    = "api_token"
+	AuthenticationTypeCredentials   = "credentials"
+	AuthenticationTypeApiToken      = "api_token"
+	AuthenticationTypeOAuth         = "oauth"
+	AuthenticationTypeImpersonation = "impersonation"
 )
 
 // ContextAuthInfo holds authentication information in the request context.
 // ID field stores authenticated subject id which is described by Type field.
+// UserID and UserUUID always identify the user on whose behalf request is made.
+// TenantID and TenantUUID identify active organization, they are empty
+// when request is not scoped to organization.
+// ActorID and ActorUUID identify user impersonating UserID, they are empty
+// when request is not impersonated.
 type ContextAuthInfo struct {
 	ID            int
 	UUID          string
+	UserID        int
+	UserUUID      string
+	TenantID      int
+	TenantUUID    string
+	ActorID       int
+	ActorUUID     string
 	Type          AuthenticationType
 	permissions   []string
 	permissionMap map[string]struct{}
@@ -126,6 +411,38 @@ func (ctx *ContextAuthInfo) HasPermission(permission string) bool {
 	return exists
 }
 
+func (ctx *ContextAuthInfo) Permissions() []string {
+	return ctx.permissions
+}
+
+func (ctx *ContextAuthInfo) IsImpersonated() bool {
+	return ctx.ActorID != 0
+}
+
+// AccessRequest is input of authorization policy: subject attempts action on resource.
+// Action is permission string required for the operation.
+type AccessRequest struct {
+	Subject   *ContextAuthInfo
+	Action    string
+	Resource  AccessResource
+	Protocol  string
+	IPAddress string
+	Time      time.Time
+}
+
+// AccessResource describes target of the action. Type is route or rpc method,
+// Owner is uuid of the user resource belongs to, if known.
+type AccessResource struct {
+	Type  string
+	Owner string
+}
+
+// AccessDecision is result of policy evaluation, Rule is name of the rule which decided it.
+type AccessDecision struct {
+	Allowed bool
+	Rule    string
+}
+
 // ----
 
 type CreateUserData struct {
@@ -137,3 +454,191 @@ type UpdateUserData struct {
 	Email    *string
 	Password *string
 }
+
+// SuspendUserData without Until suspends user until reinstated.
+type SuspendUserData struct {
+	Reason string
+	Until  *time.Time
+}
+
+const (
+	UserSortByID        = "id"
+	UserSortByCreatedAt = "created_at"
+	UserSortByEmail     = "email"
+)
+
+var UserSortFields = []string{
+	UserSortByID,
+	UserSortByCreatedAt,
+	UserSortByEmail,
+}
+
+// ListUsersQuery selects page of users, empty filters do not filter.
+// Created range includes CreatedFrom and excludes CreatedTo.
+// Cursor is returned with previous page and is valid only with the same sorting.
+type ListUsersQuery struct {
+	EmailPrefix string
+	Status      string
+	Role        string
+	CreatedFrom *time.Time
+	CreatedTo   *time.Time
+	SortBy      string
+	SortDesc    bool
+	Limit       int
+	Cursor      string
+}
+
+// UserCursor is position after the last user of the page, it is opaque for clients.
+type UserCursor struct {
+	SortBy    string    `json:"s"`
+	SortDesc  bool      `json:"d,omitempty"`
+	ID        int       `json:"i"`
+	Email     string    `json:"e,omitempty"`
+	CreatedAt time.Time `json:"c,omitzero"`
+}
+
+// UserHistoryFilter selects history records, empty fields do not filter.
+// Time range includes From and excludes To.
+type UserHistoryFilter struct {
+	EventTypes []string
+	From       *time.Time
+	To         *time.Time
+	Limit      int
+	Offset     int
+}
+
+type CreateOrganizationData struct {
+	Name string
+	Slug string
+}
+
+type CreateRoleData struct {
+	Name        string
+	Description string
+	Permissions []string
+}
+
+// OAuth grant types supported by authorization server.
+const (
+	OAuthGrantTypeAuthorizationCode = "authorization_code"
+	OAuthGrantTypeRefreshToken      = "refresh_token"
+	OAuthGrantTypeClientCredentials = "client_credentials"
+)
+
+var OAuthGrantTypes = []string{
+	OAuthGrantTypeAuthorizationCode,
+	OAuthGrantTypeRefreshToken,
+	OAuthGrantTypeClientCredentials,
+}
+
+// OIDC scopes control claims of id token, openid scope also allows to read userinfo.
+// Other scopes of authorization code flow are permission names, they must be
+// granted to the client, access tokens carry only granted permissions user still holds.
+const (
+	OAuthScopeOpenID = "openid"
+	OAuthScopeEmail  = "email"
+)
+
+var OAuthScopes = []string{
+	OAuthScopeOpenID,
+	OAuthScopeEmail,
+}
+
+// CreateOAuthClientData describes new oauth client.
+// Public clients have no secret and must use PKCE.
+// Owner must be the caller. Permissions are granted to tokens issued by
+// client credentials grant and must be a subset of client owner permissions.
+type CreateOAuthClientData struct {
+	OwnerUUID    string
+	Name         string
+	RedirectURIs []string
+	GrantTypes   []string
+	Permissions  []string
+	Public       bool
+}
+
+// AuthorizeRequest is authorization request of authorization code flow (RFC 6749 section 4.1.1).
+// Only S256 code challenge method is supported (RFC 7636).
+type AuthorizeRequest struct {
+	ResponseType        string
+	ClientID            string
+	RedirectURI         string
+	Scope               string
+	State               string
+	Nonce               string
+	CodeChallenge       string
+	CodeChallengeMethod string
+}
+
+// OAuthTokenRequest is access token request of token endpoint (RFC 6749 section 3.2).
+type OAuthTokenRequest struct {
+	GrantType    string
+	ClientID     string
+	ClientSecret string
+	Code         string
+	RedirectURI  string
+	CodeVerifier string
+	RefreshToken string
+	Scope        string
+}
+
+// OAuthTokens is successful response of token endpoint (RFC 6749 section 5.1).
+type OAuthTokens struct {
+	AccessToken  string `json:"access_token"`
+	TokenType    string `json:"token_type"`
+	ExpiresIn    int    `json:"expires_in"`
+	RefreshToken string `json:"refresh_token,omitempty"`
+	IDToken      string `json:"id_token,omitempty"`
+	Scope        string `json:"scope,omitempty"`
+}
+
+// IDTokenClaims are claims of OIDC id token.
+type IDTokenClaims struct {
+	jwt.RegisteredClaims
+	Nonce         string `json:"nonce,omitempty"`
+	Email         string `json:"email,omitempty"`
+	EmailVerified *bool  `json:"email_verified,omitempty"`
+}
+
+// OpenIDConfiguration is OIDC discovery document.
+type OpenIDConfiguration struct {
+	Issuer                            string   `json:"issuer"`
+	AuthorizationEndpoint             string   `json:"authorization_endpoint"`
+	TokenEndpoint                     string   `json:"token_endpoint"`
+	UserInfoEndpoint                  string   `json:"userinfo_endpoint"`
+	JWKSURI                           string   `json:"jwks_uri"`
+	ScopesSupported                   []string `json:"scopes_supported"`
+	ResponseTypesSupported            []string `json:"response_types_supported"`
+	GrantTypesSupported               []string `json:"grant_types_supported"`
+	SubjectTypesSupported             []string `json:"subject_types_supported"`
+	IDTokenSigningAlgValuesSupported  []string `json:"id_token_signing_alg_values_supported"`
+	TokenEndpointAuthMethodsSupported []string `json:"token_endpoint_auth_methods_supported"`
+	CodeChallengeMethodsSupported     []string `json:"code_challenge_methods_supported"`
+	ClaimsSupported                   []string `json:"claims_supported"`
+}
+
+// CreateAPITokenData describes new api token.
+// Permissions must be a subset of token owner permissions.
+type CreateAPITokenData struct {
+	Name        string
+	Permissions []string
+	ExpiresAt   *time.Time
+}
+
+// JWK is a public key in JSON Web Key format (RFC 7517).
+type JWK struct {
+	KTY string `json:"kty"`
+	KID string `json:"kid"`
+	Use string `json:"use"`
+	Alg string `json:"alg"`
+	N   string `json:"n,omitempty"`
+	E   string `json:"e,omitempty"`
+	Crv string `json:"crv,omitempty"`
+	X   string `json:"x,omitempty"`
+	Y   string `json:"y,omitempty"`
+}
+
+// JWKS is a set of public keys used for token verification.
+type JWKS struct {
+	Keys []JWK `json:"keys"`
+}
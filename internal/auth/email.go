@@ -0,0 +1,344 @@
+package auth
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/hasansino/go42/internal/auth/domain"
+	"github.com/hasansino/go42/internal/auth/models"
+	"github.com/hasansino/go42/internal/mailer"
+	"github.com/hasansino/go42/internal/metrics"
+	outboxDomain "github.com/hasansino/go42/internal/outbox/domain"
+)
+
+const (
+	cacheKeyMailThrottle = "auth_mail_throttle_"
+
+	// minimal interval between mails of the same type requested for single user
+	mailThrottleInterval = time.Minute
+)
+
+// EmailPolicy configures email verification and password reset flows.
+// Links sent to users are built by appending token query parameter to configured urls,
+// when url is empty, token itself is sent.
+type EmailPolicy struct {
+	VerificationRequired bool
+	VerificationURL      string
+	VerificationTTL      time.Duration
+	PasswordResetURL     string
+	PasswordResetTTL     time.Duration
+}
+
+// RequestEmailVerification sends new verification mail.
+// Unknown and already verified emails are silently ignored to prevent user enumeration.
+func (s *Service) RequestEmailVerification(ctx context.Context, email string) error {
+	user, err := s.repository.GetUserByEmail(ctx, strings.ToLower(strings.TrimSpace(email)))
+	if errors.Is(err, domain.ErrEntityNotFound) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("failed to get user: %w", err)
+	}
+	if user.IsEmailVerified() || !user.IsActiveOrPending() {
+		return nil
+	}
+	return s.requestMail(ctx, user, domain.MailTypeEmailVerification)
+}
+
+// VerifyEmail marks email of the user as verified and activates pending user.
+// Token is bound to the email it was issued for and can be used only once.
+func (s *Service) VerifyEmail(ctx context.Context, token string) error {
+	claims, err := s.validateJWTToken(ctx, token, domain.JWTScopeEmailVerification)
+	if err != nil {
+		metrics.Counter("auth_email_verifications_total", map[string]interface{}{
+			"result": "invalid_token",
+		}).Inc()
+		return domain.ErrInvalidToken
+	}
+
+	var user *models.User
+	err = s.repository.WithTransaction(ctx, func(txCtx context.Context) error {
+		user, err = s.repository.GetUserByUUID(txCtx, claims.Subject)
+		if err != nil {
+			return domain.ErrInvalidToken
+		}
+		if user.IsEmailVerified() || claims.Fingerprint != strToSHA256(user.Email) {
+			return domain.ErrInvalidToken
+		}
+		user.EmailVerifiedAt.V, user.EmailVerifiedAt.Valid = time.Now(), true
+		if user.Status == domain.UserStatusPending {
+			user.Status = domain.UserStatusActive
+		}
+		if err := s.repository.UpdateUser(txCtx, user); err != nil {
+			return fmt.Errorf("failed to update user: %w", err)
+		}
+		event := outboxDomain.Message{
+			AggregateID:   user.ID,
+			AggregateType: domain.EventTypeUserEmailVerify,
+		}
+		if err := s.sendEvent(txCtx, domain.TopicNameAuthEvents, event); err != nil {
+			return fmt.Errorf("failed to send event: %w", err)
+		}
+		return nil
+	})
+	if errors.Is(err, domain.ErrInvalidToken) {
+		metrics.Counter("auth_email_verifications_total", map[string]interface{}{
+			"result": "invalid_token",
+		}).Inc()
+		return err
+	}
+	if err != nil {
+		return err
+	}
+
+	s.repository.InvalidateUserCache(ctx, user)
+	s.invalidateMailToken(ctx, token, claims)
+
+	metrics.Counter("auth_email_verifications_total", map[string]interface{}{
+		"result": "success",
+	}).Inc()
+
+	return nil
+}
+
+// RequestPasswordReset sends password reset mail.
+// Unknown emails are silently ignored to prevent user enumeration.
+func (s *Service) RequestPasswordReset(ctx context.Context, email string) error {
+	user, err := s.repository.GetUserByEmail(ctx, strings.ToLower(strings.TrimSpace(email)))
+	if errors.Is(err, domain.ErrEntityNotFound) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("failed to get user: %w", err)
+	}
+	if !user.IsActiveOrPending() {
+		return nil
+	}
+	return s.requestMail(ctx, user, domain.MailTypePasswordReset)
+}
+
+// ResetPassword sets new password and revokes all sessions of the user.
+// Token is bound to the password it was issued for, so changing password
+// invalidates all previously issued reset tokens. Since reset link was
+// delivered by email, email of the user is considered verified.
+func (s *Service) ResetPassword(ctx context.Context, token string, password string) error {
+	claims, err := s.validateJWTToken(ctx, token, domain.JWTScopePasswordReset)
+	if err != nil {
+		metrics.Counter("auth_password_resets_total", map[string]interface{}{
+			"result": "invalid_token",
+		}).Inc()
+		return domain.ErrInvalidToken
+	}
+
+	if err := s.CheckPasswordStrength(ctx, password); err != nil {
+		return err
+	}
+
+	var (
+		user     *models.User
+		sessions []*models.Session
+	)
+	err = s.repository.WithTransaction(ctx, func(txCtx context.Context) error {
+		user, err = s.repository.GetUserByUUID(txCtx, claims.Subject)
+		if err != nil {
+			return domain.ErrInvalidToken
+		}
+		if !user.IsActiveOrPending() || claims.Fingerprint != strToSHA256(user.Password.V) {
+			return domain.ErrInvalidToken
+		}
+		if err := s.changePassword(txCtx, user, password); err != nil {
+			return err
+		}
+		if !user.IsEmailVerified() {
+			user.EmailVerifiedAt.V, user.EmailVerifiedAt.Valid = time.Now(), true
+		}
+		if user.Status == domain.UserStatusPending {
+			user.Status = domain.UserStatusActive
+		}
+		if err := s.repository.UpdateUser(txCtx, user); err != nil {
+			return fmt.Errorf("failed to update user: %w", err)
+		}
+		sessions, err = s.repository.ListActiveSessions(txCtx, user.ID)
+		if err != nil {
+			return fmt.Errorf("failed to list sessions: %w", err)
+		}
+		for _, session := range sessions {
+			if err := s.revokeSession(txCtx, session); err != nil {
+				return err
+			}
+		}
+		event := outboxDomain.Message{
+			AggregateID:   user.ID,
+			AggregateType: domain.EventTypeUserPasswordReset,
+		}
+		if err := s.sendEvent(txCtx, domain.TopicNameAuthEvents, event); err != nil {
+			return fmt.Errorf("failed to send event: %w", err)
+		}
+		return nil
+	})
+	if errors.Is(err, domain.ErrInvalidToken) {
+		metrics.Counter("auth_password_resets_total", map[string]interface{}{
+			"result": "invalid_token",
+		}).Inc()
+		return err
+	}
+	if err != nil {
+		return err
+	}
+
+	s.repository.InvalidateUserCache(ctx, user)
+	s.invalidateMailToken(ctx, token, claims)
+	for _, session := range sessions {
+		s.invalidateSessionAccessToken(ctx, session)
+	}
+
+	metrics.Counter("auth_password_resets_total", map[string]interface{}{
+		"result": "success",
+	}).Inc()
+
+	return nil
+}
+
+// SendAuthMail generates token for requested mail type and sends it to the user.
+// Called by mail sender worker, tokens are never stored in outbox.
+func (s *Service) SendAuthMail(ctx context.Context, userID int, mailType string) error {
+	user, err := s.repository.GetUserByID(ctx, userID)
+	if err != nil {
+		return fmt.Errorf("failed to get user: %w", err)
+	}
+
+	var (
+		msg  mailer.Message
+		link string
+	)
+
+	switch mailType {
+	case domain.MailTypeEmailVerification:
+		if user.IsEmailVerified() {
+			// verified while mail was queued
+			return nil
+		}
+		token, err := s.generateScopedToken(
+			user.UUID.String(), domain.JWTScopeEmailVerification,
+			strToSHA256(user.Email), s.emailPolicy.VerificationTTL,
+		)
+		if err != nil {
+			return fmt.Errorf("failed to generate token: %w", err)
+		}
+		link, err = mailLink(s.emailPolicy.VerificationURL, token)
+		if err != nil {
+			return err
+		}
+		msg = mailer.Message{
+			To:      user.Email,
+			Subject: "Verify your email",
+			Body: fmt.Sprintf(
+				"Please verify your email address:\n\n%s\n\nLink expires in %s.\n",
+				link, s.emailPolicy.VerificationTTL,
+			),
+		}
+	case domain.MailTypePasswordReset:
+		token, err := s.generateScopedToken(
+			user.UUID.String(), domain.JWTScopePasswordReset,
+			strToSHA256(user.Password.V), s.emailPolicy.PasswordResetTTL,
+		)
+		if err != nil {
+			return fmt.Errorf("failed to generate token: %w", err)
+		}
+		link, err = mailLink(s.emailPolicy.PasswordResetURL, token)
+		if err != nil {
+			return err
+		}
+		msg = mailer.Message{
+			To:      user.Email,
+			Subject: "Reset your password",
+			Body: fmt.Sprintf(
+				"Password reset was requested for your account:\n\n%s\n\n"+
+					"Link expires in %s. If you did not request it, ignore this email.\n",
+				link, s.emailPolicy.PasswordResetTTL,
+			),
+		}
+	default:
+		return fmt.Errorf("unknown mail type: %s", mailType)
+	}
+
+	if err := s.mailer.Send(ctx, msg); err != nil {
+		metrics.Counter("auth_mails_sent_total", map[string]interface{}{
+			"type":   mailType,
+			"result": "error",
+		}).Inc()
+		return fmt.Errorf("failed to send mail: %w", err)
+	}
+
+	metrics.Counter("auth_mails_sent_total", map[string]interface{}{
+		"type":   mailType,
+		"result": "success",
+	}).Inc()
+
+	return nil
+}
+
+// requestMail enqueues mail unless one of the same type was requested recently.
+func (s *Service) requestMail(ctx context.Context, user *models.User, mailType string) error {
+	key := cacheKeyMailThrottle + mailType + "_" + strconv.Itoa(user.ID)
+	now := time.Now()
+
+	v, err := s.cache.Get(ctx, key)
+	if err == nil && v != "" {
+		// expiration is kept inside the value, see loginAttempts
+		if until, err := strconv.ParseInt(v, 10, 64); err == nil && now.Unix() < until {
+			metrics.Counter("auth_mails_throttled_total", map[string]interface{}{
+				"type": mailType,
+			}).Inc()
+			return nil
+		}
+	}
+
+	if err := s.enqueueMail(ctx, user.ID, mailType); err != nil {
+		return err
+	}
+
+	until := now.Add(mailThrottleInterval)
+	if err := s.cache.Set(ctx, key, strconv.FormatInt(until.Unix(), 10), mailThrottleInterval); err != nil {
+		s.logger.ErrorContext(ctx, "failed to set mail throttle", slog.Any("error", err))
+	}
+
+	return nil
+}
+
+// enqueueMail schedules mail through outbox, so that it is sent outside of transaction.
+func (s *Service) enqueueMail(ctx context.Context, userID int, mailType string) error {
+	msg := outboxDomain.Message{
+		AggregateID:   userID,
+		AggregateType: mailType,
+	}
+	if err := s.sendEvent(ctx, domain.TopicNameAuthMail, msg); err != nil {
+		return fmt.Errorf("failed to enqueue mail: %w", err)
+	}
+	return nil
+}
+
+// invalidateMailToken makes sure token can not be used again.
+// Failure is not critical, tokens are also bound to user state by fingerprint.
+func (s *Service) invalidateMailToken(ctx context.Context, token string, claims *domain.JWTClaims) {
+	if err := s.InvalidateJWTToken(ctx, token, claims.ExpiresAt.Time); err != nil {
+		s.logger.ErrorContext(
+			ctx, "failed to invalidate mail token",
+			slog.String("scope", claims.Scope),
+			slog.Any("error", err),
+		)
+	}
+}
+
+func mailLink(base string, token string) (string, error) {
+	if base == "" {
+		return token, nil
+	}
+	return appendQuery(base, url.Values{"token": []string{token}})
+}
@@ -0,0 +1,34 @@
+package auth
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hasansino/go42/internal/auth/domain"
+	"github.com/hasansino/go42/internal/auth/models"
+)
+
+const (
+	defaultHistoryPageSize = 20
+	maxHistoryPageSize     = 100
+)
+
+// ListUserHistory returns page of history of the user from newest to oldest.
+// History is written asynchronously, so most recent events may be missing from it.
+func (s *Service) ListUserHistory(
+	ctx context.Context, userUUID string, filter *domain.UserHistoryFilter,
+) ([]*models.UserHistoryRecord, error) {
+	user, err := s.repository.GetUserByUUID(ctx, userUUID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get user: %w", err)
+	}
+
+	query := *filter
+	if query.Limit <= 0 {
+		query.Limit = defaultHistoryPageSize
+	}
+	query.Limit = min(query.Limit, maxHistoryPageSize)
+	query.Offset = max(query.Offset, 0)
+
+	return s.repository.FilterUserHistoryRecords(ctx, user.ID, &query)
+}
@@ -0,0 +1,103 @@
+package auth
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/google/uuid"
+
+	"github.com/hasansino/go42/internal/auth/domain"
+	"github.com/hasansino/go42/internal/metrics"
+)
+
+const (
+	defaultImpersonationTTL = 10 * time.Minute
+
+	// keeps history record within size of its data column
+	maxImpersonatedRequestLength = 100
+)
+
+// Impersonate issues access token of the user to the actor of the request.
+// Actor can impersonate only users whose permissions it has itself, so impersonation
+// never escalates privileges. Impersonated requests can not impersonate further.
+func (s *Service) Impersonate(ctx context.Context, userUUID string) (*domain.ImpersonationToken, error) {
+	actor := RetrieveAuthFromContext(ctx)
+	if actor == nil {
+		return nil, domain.ErrPermissionDenied
+	}
+	if actor.IsImpersonated() {
+		return nil, fmt.Errorf("%w: impersonation can not be nested", domain.ErrPermissionDenied)
+	}
+	if actor.UserUUID == userUUID {
+		return nil, fmt.Errorf("%w: user can not impersonate itself", domain.ErrPermissionDenied)
+	}
+
+	user, err := s.repository.GetUserByUUID(ctx, userUUID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get user: %w", err)
+	}
+	if !user.IsActive() || user.IsSystem {
+		return nil, fmt.Errorf("%w: user can not be impersonated", domain.ErrPermissionDenied)
+	}
+	for _, permission := range user.PermissionList() {
+		if !actor.HasPermission(permission) {
+			return nil, fmt.Errorf("%w: user has permissions actor lacks", domain.ErrPermissionDenied)
+		}
+	}
+
+	expiresAt := time.Now().Add(s.impersonationTTL)
+	token, err := s.generateImpersonationToken(user.UUID.String(), actor.UserUUID, expiresAt)
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate token: %w", err)
+	}
+
+	err = s.sendAuthEvent(ctx, domain.EventTypeUserImpersonate, user.ID, map[string]any{
+		"actor":      actor.UserUUID,
+		"expires_at": expiresAt,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	metrics.Counter("auth_impersonations_total", nil).Inc()
+
+	return &domain.ImpersonationToken{
+		AccessToken: token,
+		ExpiresIn:   int(s.impersonationTTL.Seconds()),
+	}, nil
+}
+
+// RecordImpersonatedRequest adds request made with impersonation token to history
+// of impersonated user, request is identified by protocol specific operation name.
+func (s *Service) RecordImpersonatedRequest(ctx context.Context, operation string, ipAddress string) error {
+	authInfo := RetrieveAuthFromContext(ctx)
+	if authInfo == nil || !authInfo.IsImpersonated() {
+		return nil
+	}
+	return s.sendAuthEvent(ctx, domain.EventTypeUserImpersonatedCall, authInfo.UserID, map[string]any{
+		"actor":      authInfo.ActorUUID,
+		"request":    truncateString(operation, maxImpersonatedRequestLength),
+		"ip_address": ipAddress,
+	})
+}
+
+func (s *Service) generateImpersonationToken(userUUID string, actorUUID string, expiresAt time.Time) (string, error) {
+	key, err := s.signingJWTSecret()
+	if err != nil {
+		return "", err
+	}
+	return signJWTToken(key, domain.JWTClaims{
+		RegisteredClaims: jwt.RegisteredClaims{
+			ID:        uuid.New().String(),
+			Audience:  s.jwtAudience,
+			Issuer:    s.jwtIssuer,
+			Subject:   userUUID,
+			IssuedAt:  jwt.NewNumericDate(time.Now()),
+			ExpiresAt: jwt.NewNumericDate(expiresAt),
+		},
+		KID:   key.sha256,
+		Actor: &domain.JWTActor{Subject: actorUUID},
+	})
+}
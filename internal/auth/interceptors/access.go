@@ -2,20 +2,40 @@ package interceptors
 
 import (
 	"context"
+	"net"
 
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/peer"
 	"google.golang.org/grpc/status"
 
 	"github.com/hasansino/go42/internal/api/grpc/interceptors"
 	"github.com/hasansino/go42/internal/auth"
+	"github.com/hasansino/go42/internal/auth/domain"
 )
 
+const protocolGRPC = "grpc"
+
 type permissionsRegistryAccessor interface {
 	PermissionsForMethod(method string) []string
 }
 
-func NewUnaryAccessInterceptor(r permissionsRegistryAccessor) grpc.UnaryServerInterceptor {
+type accessCheckerAccessor interface {
+	CheckAccess(ctx context.Context, req *domain.AccessRequest) *domain.AccessDecision
+}
+
+// messages addressing resources of particular user
+type userUUIDGetter interface {
+	GetUserUuid() string
+}
+
+type uuidGetter interface {
+	GetUuid() string
+}
+
+func NewUnaryAccessInterceptor(
+	r permissionsRegistryAccessor, checker accessCheckerAccessor,
+) grpc.UnaryServerInterceptor {
 	return func(
 		ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler,
 	) (interface{}, error) {
@@ -26,22 +46,18 @@ func NewUnaryAccessInterceptor(r permissionsRegistryAccessor) grpc.UnaryServerIn
 		if authInfo == nil {
 			return nil, status.Errorf(codes.Unauthenticated, "unauthenticated request")
 		}
-		permissions := r.PermissionsForMethod(info.FullMethod)
-		if len(permissions) == 0 {
-			return nil, status.Errorf(codes.PermissionDenied, "no permissions found for method %s", info.FullMethod)
-		}
-		for _, permission := range permissions {
-			if !authInfo.HasPermission(permission) {
-				return nil, status.Errorf(
-					codes.PermissionDenied,
-					"permission %s is required for method %s", permission, info.FullMethod)
-			}
+		if err := checkMethodAccess(ctx, r, checker, authInfo, info.FullMethod, resourceOwner(req)); err != nil {
+			return nil, err
 		}
 		return handler(ctx, req)
 	}
 }
 
-func NewStreamAccessInterceptor(r permissionsRegistryAccessor) grpc.StreamServerInterceptor {
+// NewStreamAccessInterceptor checks access before first message is received,
+// so resource owner is not known to access policy.
+func NewStreamAccessInterceptor(
+	r permissionsRegistryAccessor, checker accessCheckerAccessor,
+) grpc.StreamServerInterceptor {
 	return func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
 		if interceptors.DefaultSkipper(info.FullMethod) {
 			return handler(srv, ss)
@@ -60,17 +76,59 @@ func NewStreamAccessInterceptor(r permissionsRegistryAccessor) grpc.StreamServer
 		if authInfo == nil {
 			return status.Errorf(codes.Unauthenticated, "unauthenticated request")
 		}
-		permissions := r.PermissionsForMethod(info.FullMethod)
-		if len(permissions) == 0 {
-			return status.Errorf(codes.PermissionDenied, "no permissions found for method %s", info.FullMethod)
-		}
-		for _, permission := range permissions {
-			if !authInfo.HasPermission(permission) {
-				return status.Errorf(
-					codes.PermissionDenied,
-					"permission %s is required for method %s", permission, info.FullMethod)
-			}
+		if err := checkMethodAccess(wrapper.Context(), r, checker, authInfo, info.FullMethod, ""); err != nil {
+			return err
 		}
 		return handler(srv, ss)
 	}
 }
+
+func checkMethodAccess(
+	ctx context.Context,
+	r permissionsRegistryAccessor,
+	checker accessCheckerAccessor,
+	authInfo *domain.ContextAuthInfo,
+	method string,
+	owner string,
+) error {
+	permissions := r.PermissionsForMethod(method)
+	if len(permissions) == 0 {
+		return status.Errorf(codes.PermissionDenied, "no permissions found for method %s", method)
+	}
+	// port is dropped, so address matches one reported by http
+	var ipAddress string
+	if p, ok := peer.FromContext(ctx); ok && p.Addr != nil {
+		ipAddress = p.Addr.String()
+		if host, _, err := net.SplitHostPort(ipAddress); err == nil {
+			ipAddress = host
+		}
+	}
+	for _, permission := range permissions {
+		decision := checker.CheckAccess(ctx, &domain.AccessRequest{
+			Subject: authInfo,
+			Action:  permission,
+			Resource: domain.AccessResource{
+				Type:  method,
+				Owner: owner,
+			},
+			Protocol:  protocolGRPC,
+			IPAddress: ipAddress,
+		})
+		if !decision.Allowed {
+			return status.Errorf(
+				codes.PermissionDenied,
+				"permission %s is required for method %s", permission, method)
+		}
+	}
+	return nil
+}
+
+func resourceOwner(req interface{}) string {
+	if r, ok := req.(userUUIDGetter); ok {
+		return r.GetUserUuid()
+	}
+	if r, ok := req.(uuidGetter); ok {
+		return r.GetUuid()
+	}
+	return ""
+}
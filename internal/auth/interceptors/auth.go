@@ -85,9 +85,11 @@ func authenticateRequest(ctx context.Context, authService authServiceAccessor) (
 	}
 
 	authInfo := domain.ContextAuthInfo{
-		ID:   tokenInfo.ID,
-		UUID: tokenInfo.UUID.String(),
-		Type: domain.AuthenticationTypeApiToken,
+		ID:       tokenInfo.ID,
+		UUID:     tokenInfo.UUID.String(),
+		UserID:   user.ID,
+		UserUUID: user.UUID.String(),
+		Type:     domain.AuthenticationTypeApiToken,
 	}
 	authInfo.SetPermissions(tokenInfo.PermissionList())
 
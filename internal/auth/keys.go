@@ -0,0 +1,173 @@
+package auth
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"math/big"
+	"strings"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+
+	"github.com/hasansino/go42/internal/auth/domain"
+)
+
+// jwtSecret is a single key of the key ring.
+// The SHA256 hash of the secret is used as the KID (Key ID) in JWT tokens to
+// allow for key rotation. Secret is either a plain string, used as HMAC key,
+// or PEM encoded private key (RSA, ECDSA P-256 or Ed25519).
+type jwtSecret struct {
+	sha256     string
+	secret     string
+	method     jwt.SigningMethod
+	signKey    any
+	verifyKey  any
+	activeFrom time.Time
+}
+
+func parseJWTSecret(secret string) (jwtSecret, error) {
+	key := jwtSecret{
+		sha256: strToSHA256(secret),
+		secret: secret,
+	}
+
+	block, _ := pem.Decode([]byte(secret))
+	if block == nil {
+		key.method = jwt.SigningMethodHS256
+		key.signKey = []byte(secret)
+		key.verifyKey = []byte(secret)
+		return key, nil
+	}
+
+	privateKey, err := parsePrivateKey(block)
+	if err != nil {
+		return key, err
+	}
+
+	switch pk := privateKey.(type) {
+	case *rsa.PrivateKey:
+		key.method = jwt.SigningMethodRS256
+		key.signKey = pk
+		key.verifyKey = &pk.PublicKey
+	case *ecdsa.PrivateKey:
+		if pk.Curve != elliptic.P256() {
+			return key, fmt.Errorf("unsupported ecdsa curve: %s", pk.Curve.Params().Name)
+		}
+		key.method = jwt.SigningMethodES256
+		key.signKey = pk
+		key.verifyKey = &pk.PublicKey
+	case ed25519.PrivateKey:
+		key.method = jwt.SigningMethodEdDSA
+		key.signKey = pk
+		key.verifyKey = pk.Public()
+	default:
+		return key, fmt.Errorf("unsupported private key type: %T", privateKey)
+	}
+
+	return key, nil
+}
+
+func parsePrivateKey(block *pem.Block) (any, error) {
+	switch block.Type {
+	case "RSA PRIVATE KEY":
+		return x509.ParsePKCS1PrivateKey(block.Bytes)
+	case "EC PRIVATE KEY":
+		return x509.ParseECPrivateKey(block.Bytes)
+	case "PRIVATE KEY":
+		return x509.ParsePKCS8PrivateKey(block.Bytes)
+	default:
+		return nil, fmt.Errorf("unsupported pem block type: %s", block.Type)
+	}
+}
+
+// jwk returns public part of the key, symmetric keys are never exposed.
+func (k jwtSecret) jwk() (domain.JWK, error) {
+	jwk := domain.JWK{
+		KID: k.sha256,
+		Use: "sig",
+		Alg: k.method.Alg(),
+	}
+	switch pub := k.verifyKey.(type) {
+	case *rsa.PublicKey:
+		jwk.KTY = "RSA"
+		jwk.N = base64.RawURLEncoding.EncodeToString(pub.N.Bytes())
+		jwk.E = base64.RawURLEncoding.EncodeToString(big.NewInt(int64(pub.E)).Bytes())
+	case *ecdsa.PublicKey:
+		ecdhKey, err := pub.ECDH()
+		if err != nil {
+			return jwk, fmt.Errorf("failed to convert ecdsa key: %w", err)
+		}
+		// uncompressed point format: 0x04 || X || Y
+		point := ecdhKey.Bytes()
+		size := (len(point) - 1) / 2
+		jwk.KTY = "EC"
+		jwk.Crv = pub.Curve.Params().Name
+		jwk.X = base64.RawURLEncoding.EncodeToString(point[1 : 1+size])
+		jwk.Y = base64.RawURLEncoding.EncodeToString(point[1+size:])
+	case ed25519.PublicKey:
+		jwk.KTY = "OKP"
+		jwk.Crv = "Ed25519"
+		jwk.X = base64.RawURLEncoding.EncodeToString(pub)
+	default:
+		return jwk, errors.New("symmetric keys can not be exposed")
+	}
+	return jwk, nil
+}
+
+// jwtSecretSealedPrefix marks stored secrets encrypted by sealJWTSecret.
+const jwtSecretSealedPrefix = "sealed:"
+
+func newJWTSecretCipher(key string) (cipher.AEAD, error) {
+	if key == "" {
+		return nil, errors.New("jwt secrets encryption key is empty")
+	}
+	sum := sha256.Sum256([]byte(key))
+	block, err := aes.NewCipher(sum[:])
+	if err != nil {
+		return nil, fmt.Errorf("failed to create cipher: %w", err)
+	}
+	return cipher.NewGCM(block)
+}
+
+// sealJWTSecret encrypts rotated secret with AES-256-GCM, key is derived from configured one.
+func sealJWTSecret(aead cipher.AEAD, secret string) (string, error) {
+	nonce := make([]byte, aead.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return "", fmt.Errorf("failed to generate nonce: %w", err)
+	}
+	sealed := aead.Seal(nonce, nonce, []byte(secret), nil)
+	return jwtSecretSealedPrefix + base64.StdEncoding.EncodeToString(sealed), nil
+}
+
+// openJWTSecret decrypts stored secret. Secrets stored before encryption
+// was introduced are returned as is until they are retired.
+func openJWTSecret(aead cipher.AEAD, stored string) (string, error) {
+	encoded, ok := strings.CutPrefix(stored, jwtSecretSealedPrefix)
+	if !ok {
+		return stored, nil
+	}
+	sealed, err := base64.StdEncoding.DecodeString(encoded)
+	if err != nil {
+		return "", fmt.Errorf("failed to decode secret: %w", err)
+	}
+	if len(sealed) < aead.NonceSize() {
+		return "", errors.New("sealed secret is too short")
+	}
+	nonce, ciphertext := sealed[:aead.NonceSize()], sealed[aead.NonceSize():]
+	plain, err := aead.Open(nil, nonce, ciphertext, nil)
+	if err != nil {
+		return "", fmt.Errorf("failed to decrypt secret: %w", err)
+	}
+	return string(plain), nil
+}
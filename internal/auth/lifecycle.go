@@ -0,0 +1,247 @@
+package auth
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/hasansino/go42/internal/auth/domain"
+	"github.com/hasansino/go42/internal/auth/models"
+	"github.com/hasansino/go42/internal/metrics"
+)
+
+const (
+	defaultDeletionGracePeriod = 30 * 24 * time.Hour
+
+	// keeps history record within size of its data column
+	maxStatusReasonEventLength = 100
+
+	// number of users processed by single lifecycle run
+	lifecycleBatchSize = 100
+)
+
+// SuspendUser suspends user until given time or until reinstated.
+// Suspending already suspended user updates its reason and end time.
+func (s *Service) SuspendUser(ctx context.Context, userUUID string, data *domain.SuspendUserData) error {
+	return s.changeUserStatus(ctx, userUUID,
+		func(user *models.User) (string, map[string]any, error) {
+			if user.IsSystem {
+				return "", nil, fmt.Errorf("%w: system user can not be suspended", domain.ErrPermissionDenied)
+			}
+			if user.Status != domain.UserStatusActive && user.Status != domain.UserStatusSuspended {
+				return "", nil, domain.ErrUserStatusConflict
+			}
+			payload := map[string]any{
+				"reason": truncateString(data.Reason, maxStatusReasonEventLength),
+			}
+			user.Status = domain.UserStatusSuspended
+			user.StatusReason = sql.Null[string]{V: data.Reason, Valid: data.Reason != ""}
+			user.StatusUntil = sql.Null[time.Time]{}
+			if data.Until != nil {
+				user.StatusUntil = sql.Null[time.Time]{V: *data.Until, Valid: true}
+				payload["until"] = data.Until
+			}
+			return domain.EventTypeUserSuspend, payload, nil
+		})
+}
+
+// ReinstateUser lifts suspension of the user.
+func (s *Service) ReinstateUser(ctx context.Context, userUUID string) error {
+	return s.changeUserStatus(ctx, userUUID,
+		func(user *models.User) (string, map[string]any, error) {
+			if user.Status != domain.UserStatusSuspended {
+				return "", nil, domain.ErrUserStatusConflict
+			}
+			activateUser(user)
+			return domain.EventTypeUserReinstate, nil, nil
+		})
+}
+
+// DeleteUser schedules user for deletion, user can be restored until grace period ends.
+// Status user had is kept, so that suspension is not lifted by restoring the user.
+func (s *Service) DeleteUser(ctx context.Context, userUUID string) error {
+	return s.changeUserStatus(ctx, userUUID,
+		func(user *models.User) (string, map[string]any, error) {
+			if user.IsSystem {
+				return "", nil, fmt.Errorf("%w: system user can not be deleted", domain.ErrPermissionDenied)
+			}
+			if user.Status == domain.UserStatusDeletionScheduled || user.Status == domain.UserStatusDeleted {
+				return "", nil, domain.ErrUserStatusConflict
+			}
+			until := time.Now().Add(s.deletionGracePeriod)
+			user.PreviousStatus = sql.Null[string]{V: user.Status, Valid: true}
+			user.PreviousStatusReason = user.StatusReason
+			user.PreviousStatusUntil = user.StatusUntil
+			user.Status = domain.UserStatusDeletionScheduled
+			user.StatusReason = sql.Null[string]{}
+			user.StatusUntil = sql.Null[time.Time]{V: until, Valid: true}
+			return domain.EventTypeUserDelete, map[string]any{"until": until}, nil
+		})
+}
+
+// RestoreUser cancels scheduled deletion of the user and puts back status user had before.
+// Users scheduled for deletion before previous status was kept are activated.
+func (s *Service) RestoreUser(ctx context.Context, userUUID string) error {
+	return s.changeUserStatus(ctx, userUUID,
+		func(user *models.User) (string, map[string]any, error) {
+			if user.Status != domain.UserStatusDeletionScheduled {
+				return "", nil, domain.ErrUserStatusConflict
+			}
+			if user.PreviousStatus.Valid {
+				user.Status = user.PreviousStatus.V
+				user.StatusReason = user.PreviousStatusReason
+				user.StatusUntil = user.PreviousStatusUntil
+			} else {
+				activateUser(user)
+			}
+			clearPreviousStatus(user)
+			return domain.EventTypeUserRestore, map[string]any{"status": user.Status}, nil
+		})
+}
+
+// changeUserStatus applies transition to the user and records it with event returned by transition.
+// Users which are not active can not login or refresh tokens and their access tokens are rejected.
+func (s *Service) changeUserStatus(
+	ctx context.Context, userUUID string,
+	transition func(user *models.User) (string, map[string]any, error),
+) error {
+	var user *models.User
+	err := s.repository.WithTransaction(ctx, func(txCtx context.Context) error {
+		var err error
+		user, err = s.repository.GetUserByUUID(txCtx, userUUID)
+		if err != nil {
+			return fmt.Errorf("failed to get user: %w", err)
+		}
+		previousStatus := user.Status
+		eventType, payload, err := transition(user)
+		if err != nil {
+			return err
+		}
+		if err := s.repository.UpdateUserStatus(txCtx, user); err != nil {
+			return fmt.Errorf("failed to update user status: %w", err)
+		}
+		if payload == nil {
+			payload = make(map[string]any)
+		}
+		payload["previous_status"] = previousStatus
+		return s.sendAuthEvent(txCtx, eventType, user.ID, payload)
+	})
+	if err != nil {
+		return err
+	}
+	s.repository.InvalidateUserCache(ctx, user)
+	return nil
+}
+
+func activateUser(user *models.User) {
+	user.Status = domain.UserStatusActive
+	user.StatusReason = sql.Null[string]{}
+	user.StatusUntil = sql.Null[time.Time]{}
+}
+
+func clearPreviousStatus(user *models.User) {
+	user.PreviousStatus = sql.Null[string]{}
+	user.PreviousStatusReason = sql.Null[string]{}
+	user.PreviousStatusUntil = sql.Null[time.Time]{}
+}
+
+// ProcessUserLifecycle reinstates users whose suspension has ended, purges users
+// whose deletion grace period has ended and drops expired data export archives.
+func (s *Service) ProcessUserLifecycle(ctx context.Context) error {
+	now := time.Now()
+
+	if err := s.repository.DeleteExpiredDataArchives(ctx, now); err != nil {
+		return err
+	}
+
+	suspended, err := s.repository.ListUsersWithStatusUntil(
+		ctx, domain.UserStatusSuspended, now, lifecycleBatchSize)
+	if err != nil {
+		return fmt.Errorf("failed to list suspended users: %w", err)
+	}
+	for _, user := range suspended {
+		err := s.ReinstateUser(ctx, user.UUID.String())
+		if errors.Is(err, domain.ErrUserStatusConflict) {
+			// already reinstated by another instance
+			continue
+		}
+		if err != nil {
+			s.logger.ErrorContext(ctx, "failed to reinstate user",
+				slog.Int("user_id", user.ID),
+				slog.Any("error", err),
+			)
+		}
+	}
+
+	scheduled, err := s.repository.ListUsersWithStatusUntil(
+		ctx, domain.UserStatusDeletionScheduled, now, lifecycleBatchSize)
+	if err != nil {
+		return fmt.Errorf("failed to list users scheduled for deletion: %w", err)
+	}
+	for _, user := range scheduled {
+		err := s.purgeUser(ctx, user)
+		if errors.Is(err, domain.ErrEntityNotFound) {
+			// already purged by another instance
+			continue
+		}
+		if err != nil {
+			s.logger.ErrorContext(ctx, "failed to purge user",
+				slog.Int("user_id", user.ID),
+				slog.Any("error", err),
+			)
+		}
+	}
+
+	return nil
+}
+
+// purgeUser anonymizes or erases user according to deletion mode.
+// Erased user has no history, so its event is not recorded in history.
+func (s *Service) purgeUser(ctx context.Context, user *models.User) error {
+	// cached representations are looked up by original email
+	original := *user
+
+	eventType := domain.EventTypeUserAnonymize
+	err := s.repository.WithTransaction(ctx, func(txCtx context.Context) error {
+		if s.deletionMode == domain.UserDeletionModeErase {
+			eventType = domain.EventTypeUserErase
+			if err := s.repository.EraseUser(txCtx, user); err != nil {
+				return err
+			}
+		} else {
+			anonymizeUser(user)
+			if err := s.repository.AnonymizeUser(txCtx, user, domain.UserStatusDeletionScheduled); err != nil {
+				return err
+			}
+		}
+		return s.sendAuthEvent(txCtx, eventType, user.ID, map[string]any{
+			"uuid": user.UUID.String(),
+		})
+	})
+	if err != nil {
+		return err
+	}
+
+	s.repository.InvalidateUserCache(ctx, &original)
+
+	metrics.Counter("auth_users_purged_total", map[string]interface{}{
+		"mode": s.deletionMode,
+	}).Inc()
+
+	return nil
+}
+
+func anonymizeUser(user *models.User) {
+	user.Email = fmt.Sprintf("deleted-%s@anonymized.invalid", user.UUID.String())
+	user.EmailVerifiedAt = sql.Null[time.Time]{}
+	user.Password = sql.Null[string]{}
+	user.Metadata = json.RawMessage("{}")
+	user.Status = domain.UserStatusDeleted
+	user.StatusReason = sql.Null[string]{}
+	user.StatusUntil = sql.Null[time.Time]{}
+	clearPreviousStatus(user)
+}
@@ -0,0 +1,153 @@
+package auth
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"go.uber.org/mock/gomock"
+
+	"github.com/hasansino/go42/internal/auth/domain"
+	"github.com/hasansino/go42/internal/auth/mocks"
+	"github.com/hasansino/go42/internal/auth/models"
+)
+
+func TestService_DeleteRestoreUser(t *testing.T) {
+	until := time.Now().Add(time.Hour).Truncate(time.Second)
+
+	tests := []struct {
+		name       string
+		user       models.User
+		wantDelete error
+		wantStatus string
+	}{
+		{
+			name:       "active",
+			user:       models.User{Status: domain.UserStatusActive},
+			wantStatus: domain.UserStatusActive,
+		},
+		{
+			name: "suspended",
+			user: models.User{
+				Status:       domain.UserStatusSuspended,
+				StatusReason: sql.Null[string]{V: "abuse", Valid: true},
+				StatusUntil:  sql.Null[time.Time]{V: until, Valid: true},
+			},
+			wantStatus: domain.UserStatusSuspended,
+		},
+		{
+			name:       "pending",
+			user:       models.User{Status: domain.UserStatusPending},
+			wantStatus: domain.UserStatusPending,
+		},
+		{
+			name:       "deletion scheduled",
+			user:       models.User{Status: domain.UserStatusDeletionScheduled},
+			wantDelete: domain.ErrUserStatusConflict,
+		},
+		{
+			name:       "deleted",
+			user:       models.User{Status: domain.UserStatusDeleted},
+			wantDelete: domain.ErrUserStatusConflict,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			repo := mocks.NewMockrepository(ctrl)
+			outbox := mocks.NewMockoutboxService(ctrl)
+
+			s, err := NewService(
+				repo, outbox, mocks.NewMockcache(ctrl),
+				WithJWTSecrets([]string{"static"}),
+				WithJWTSecretsEncryptionKey("key"),
+			)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			user := tt.user
+			user.ID = 1
+			user.UUID = uuid.New()
+			original := user
+
+			repo.EXPECT().WithTransaction(gomock.Any(), gomock.Any()).DoAndReturn(
+				func(ctx context.Context, fn func(context.Context) error) error {
+					return fn(ctx)
+				},
+			).AnyTimes()
+			repo.EXPECT().GetUserByUUID(gomock.Any(), user.UUID.String()).Return(&user, nil).AnyTimes()
+			repo.EXPECT().UpdateUserStatus(gomock.Any(), gomock.Any()).Return(nil).AnyTimes()
+			repo.EXPECT().InvalidateUserCache(gomock.Any(), gomock.Any()).AnyTimes()
+			outbox.EXPECT().NewOutboxMessage(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil).AnyTimes()
+
+			err = s.DeleteUser(context.Background(), user.UUID.String())
+			if !errors.Is(err, tt.wantDelete) {
+				t.Fatalf("delete error = %v, want %v", err, tt.wantDelete)
+			}
+			if tt.wantDelete != nil {
+				return
+			}
+			if user.Status != domain.UserStatusDeletionScheduled {
+				t.Fatalf("status after delete = %s, want %s", user.Status, domain.UserStatusDeletionScheduled)
+			}
+
+			if err := s.RestoreUser(context.Background(), user.UUID.String()); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if user.Status != tt.wantStatus {
+				t.Errorf("status after restore = %s, want %s", user.Status, tt.wantStatus)
+			}
+			if user.StatusReason != original.StatusReason || !user.StatusUntil.V.Equal(original.StatusUntil.V) {
+				t.Errorf("status details after restore = %v %v, want %v %v",
+					user.StatusReason, user.StatusUntil, original.StatusReason, original.StatusUntil)
+			}
+			if user.PreviousStatus.Valid {
+				t.Error("previous status is kept after restore")
+			}
+		})
+	}
+}
+
+func TestService_RestoreUser_WithoutPreviousStatus(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	repo := mocks.NewMockrepository(ctrl)
+	outbox := mocks.NewMockoutboxService(ctrl)
+
+	s, err := NewService(
+		repo, outbox, mocks.NewMockcache(ctrl),
+		WithJWTSecrets([]string{"static"}),
+		WithJWTSecretsEncryptionKey("key"),
+	)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	// scheduled for deletion before previous status was kept
+	user := &models.User{
+		ID:          1,
+		UUID:        uuid.New(),
+		Status:      domain.UserStatusDeletionScheduled,
+		StatusUntil: sql.Null[time.Time]{V: time.Now().Add(time.Hour), Valid: true},
+	}
+
+	repo.EXPECT().WithTransaction(gomock.Any(), gomock.Any()).DoAndReturn(
+		func(ctx context.Context, fn func(context.Context) error) error {
+			return fn(ctx)
+		},
+	)
+	repo.EXPECT().GetUserByUUID(gomock.Any(), user.UUID.String()).Return(user, nil)
+	repo.EXPECT().UpdateUserStatus(gomock.Any(), user).Return(nil)
+	repo.EXPECT().InvalidateUserCache(gomock.Any(), user)
+	outbox.EXPECT().NewOutboxMessage(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil)
+
+	if err := s.RestoreUser(context.Background(), user.UUID.String()); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if user.Status != domain.UserStatusActive || user.StatusUntil.Valid {
+		t.Errorf("user = %s until %v, want active", user.Status, user.StatusUntil)
+	}
+}
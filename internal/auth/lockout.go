@@ -0,0 +1,247 @@
+package auth
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/hasansino/go42/internal/auth/domain"
+	"github.com/hasansino/go42/internal/metrics"
+	outboxDomain "github.com/hasansino/go42/internal/outbox/domain"
+)
+
+const (
+	cacheKeyLockoutIdentity = "auth_lockout_identity_"
+	cacheKeyLockoutClient   = "auth_lockout_client_"
+
+	lockoutScopeIdentity = "identity"
+	lockoutScopeClient   = "client"
+)
+
+// LockoutPolicy configures progressive lockout of login attempts.
+// Failures are counted per identity (email) and per client (ip + email),
+// reaching the limit in either scope locks further attempts in that scope.
+// Each consecutive lockout within the window doubles its duration.
+// Zero max attempts disables corresponding scope.
+type LockoutPolicy struct {
+	IdentityMaxAttempts int
+	ClientMaxAttempts   int
+	Window              time.Duration
+	Duration            time.Duration
+	MaxDuration         time.Duration
+}
+
+// loginAttempts is the state of single lockout scope, stored in cache.
+// Expiration is kept inside the value since not every cache engine supports ttl per key.
+// Counters are updated without coordination, concurrent failures across
+// replicas may be undercounted, which is acceptable for throttling.
+type loginAttempts struct {
+	Failures    int       `json:"failures"`
+	Lockouts    int       `json:"lockouts"`
+	LockedAt    time.Time `json:"locked_at"`
+	LockedUntil time.Time `json:"locked_until"`
+	UnlockedAt  time.Time `json:"unlocked_at"`
+	ExpiresAt   time.Time `json:"expires_at"`
+}
+
+func (a *loginAttempts) isLocked(now time.Time) bool {
+	return a.LockedUntil.After(now)
+}
+
+// UnlockUser lifts identity lockout and all client lockouts of the user.
+func (s *Service) UnlockUser(ctx context.Context, userUUID string) error {
+	user, err := s.repository.GetUserByUUID(ctx, userUUID)
+	if err != nil {
+		return fmt.Errorf("failed to get user: %w", err)
+	}
+
+	// client lockouts can not be enumerated, instead they are
+	// ignored if they started before the moment of unlock
+	now := time.Now()
+	attempts := &loginAttempts{
+		UnlockedAt: now,
+		ExpiresAt:  now.Add(s.lockoutPolicy.MaxDuration + s.lockoutPolicy.Window),
+	}
+	if err := s.saveLoginAttempts(ctx, lockoutIdentityKey(user.Email), attempts); err != nil {
+		return fmt.Errorf("failed to unlock user: %w", err)
+	}
+
+	metrics.Counter("auth_lockout_unlocks_total", nil).Inc()
+
+	return nil
+}
+
+// checkLockout returns domain.ErrAccountLocked if login attempts are locked
+// for given identity or client.
+func (s *Service) checkLockout(ctx context.Context, email string, client domain.ClientInfo) error {
+	if !s.lockoutEnabled() {
+		return nil
+	}
+
+	now := time.Now()
+
+	identity := s.loadLoginAttempts(ctx, lockoutIdentityKey(email), now)
+	if identity.isLocked(now) {
+		return domain.ErrAccountLocked
+	}
+
+	clientAttempts := s.loadLoginAttempts(ctx, lockoutClientKey(email, client.IPAddress), now)
+	if clientAttempts.isLocked(now) && clientAttempts.LockedAt.After(identity.UnlockedAt) {
+		return domain.ErrAccountLocked
+	}
+
+	return nil
+}
+
+// registerLoginFailure counts failed attempt in both scopes and engages lockout
+// when limit is reached. userID is zero when identity does not exist.
+func (s *Service) registerLoginFailure(ctx context.Context, userID int, email string, client domain.ClientInfo) {
+	if !s.lockoutEnabled() {
+		return
+	}
+
+	scopes := []struct {
+		name        string
+		key         string
+		maxAttempts int
+	}{
+		{lockoutScopeIdentity, lockoutIdentityKey(email), s.lockoutPolicy.IdentityMaxAttempts},
+		{lockoutScopeClient, lockoutClientKey(email, client.IPAddress), s.lockoutPolicy.ClientMaxAttempts},
+	}
+
+	now := time.Now()
+
+	for _, scope := range scopes {
+		if scope.maxAttempts <= 0 {
+			continue
+		}
+
+		attempts := s.loadLoginAttempts(ctx, scope.key, now)
+		attempts.Failures++
+		if expiresAt := now.Add(s.lockoutPolicy.Window); expiresAt.After(attempts.ExpiresAt) {
+			attempts.ExpiresAt = expiresAt
+		}
+
+		if attempts.Failures >= scope.maxAttempts {
+			attempts.Failures = 0
+			attempts.Lockouts++
+			attempts.LockedAt = now
+			attempts.LockedUntil = now.Add(s.lockoutDuration(attempts.Lockouts))
+			// keep state long enough for next lockout to be progressive
+			attempts.ExpiresAt = attempts.LockedUntil.Add(s.lockoutPolicy.Window)
+
+			metrics.Counter("auth_lockouts_total", map[string]interface{}{
+				"scope": scope.name,
+			}).Inc()
+
+			if userID > 0 {
+				s.sendAccountLockedEvent(ctx, userID, scope.name, client, attempts)
+			}
+		}
+
+		if err := s.saveLoginAttempts(ctx, scope.key, attempts); err != nil {
+			s.logger.ErrorContext(
+				ctx, "failed to save login attempts",
+				slog.String("scope", scope.name),
+				slog.Any("error", err),
+			)
+		}
+	}
+}
+
+// resetLoginFailures clears failure counters after successful login.
+func (s *Service) resetLoginFailures(ctx context.Context, email string, client domain.ClientInfo) {
+	if !s.lockoutEnabled() {
+		return
+	}
+	keys := []string{
+		lockoutIdentityKey(email),
+		lockoutClientKey(email, client.IPAddress),
+	}
+	for _, key := range keys {
+		if err := s.cache.Invalidate(ctx, key); err != nil {
+			s.logger.ErrorContext(
+				ctx, "failed to reset login attempts",
+				slog.Any("error", err),
+			)
+		}
+	}
+}
+
+func (s *Service) sendAccountLockedEvent(
+	ctx context.Context, userID int, scope string, client domain.ClientInfo, attempts *loginAttempts,
+) {
+	payload, err := json.Marshal(map[string]any{
+		"scope":        scope,
+		"ip_address":   client.IPAddress,
+		"lockouts":     attempts.Lockouts,
+		"locked_until": attempts.LockedUntil,
+	})
+	if err != nil {
+		s.logger.ErrorContext(ctx, "failed to marshal event payload", slog.Any("error", err))
+		return
+	}
+	event := outboxDomain.Message{
+		AggregateID:   userID,
+		AggregateType: domain.EventTypeAuthAccountLocked,
+		Payload:       payload,
+	}
+	if err := s.sendEvent(ctx, domain.TopicNameAuthEvents, event); err != nil {
+		s.logger.ErrorContext(
+			ctx, "failed to send event",
+			slog.String("topic", domain.TopicNameAuthEvents),
+			slog.Any("event", event),
+			slog.Any("error", err),
+		)
+	}
+}
+
+func (s *Service) lockoutEnabled() bool {
+	return s.lockoutPolicy.IdentityMaxAttempts > 0 || s.lockoutPolicy.ClientMaxAttempts > 0
+}
+
+func (s *Service) lockoutDuration(lockouts int) time.Duration {
+	duration := s.lockoutPolicy.Duration
+	for i := 1; i < lockouts && duration < s.lockoutPolicy.MaxDuration; i++ {
+		duration *= 2
+	}
+	if s.lockoutPolicy.MaxDuration > 0 {
+		return min(duration, s.lockoutPolicy.MaxDuration)
+	}
+	return duration
+}
+
+// loadLoginAttempts returns empty state if nothing is stored, stored state is expired or broken.
+func (s *Service) loadLoginAttempts(ctx context.Context, key string, now time.Time) *loginAttempts {
+	attempts := new(loginAttempts)
+	v, err := s.cache.Get(ctx, key)
+	if err != nil || v == "" {
+		return attempts
+	}
+	if err := json.Unmarshal([]byte(v), attempts); err != nil {
+		s.logger.ErrorContext(ctx, "failed to decode login attempts", slog.Any("error", err))
+		return new(loginAttempts)
+	}
+	if attempts.ExpiresAt.Before(now) {
+		return new(loginAttempts)
+	}
+	return attempts
+}
+
+func (s *Service) saveLoginAttempts(ctx context.Context, key string, attempts *loginAttempts) error {
+	v, err := json.Marshal(attempts)
+	if err != nil {
+		return err
+	}
+	return s.cache.Set(ctx, key, string(v), time.Until(attempts.ExpiresAt))
+}
+
+func lockoutIdentityKey(email string) string {
+	return cacheKeyLockoutIdentity + strToSHA256(email)
+}
+
+func lockoutClientKey(email string, ip string) string {
+	return cacheKeyLockoutClient + strToSHA256(ip+"|"+email)
+}
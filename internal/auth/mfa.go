@@ -0,0 +1,395 @@
+package auth
+
+import (
+	"context"
+	"crypto/rand"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"math/big"
+	"strings"
+	"time"
+
+	"github.com/hasansino/go42/internal/auth/domain"
+	"github.com/hasansino/go42/internal/auth/models"
+	"github.com/hasansino/go42/internal/metrics"
+	outboxDomain "github.com/hasansino/go42/internal/outbox/domain"
+)
+
+const (
+	mfaRecoveryCodesCount = 10
+	mfaRecoveryCodeLength = 10
+
+	mfaDisableMethodSelf  = "self"
+	mfaDisableMethodReset = "reset"
+)
+
+var mfaRecoveryCodeAlphabet = []byte("abcdefghjkmnpqrstuvwxyz23456789")
+
+func (s *Service) GetMFAStatus(ctx context.Context, userUUID string) (*domain.MFAStatus, error) {
+	user, err := s.repository.GetUserByUUID(ctx, userUUID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get user: %w", err)
+	}
+
+	status := new(domain.MFAStatus)
+
+	mfa, err := s.repository.GetUserMFA(ctx, user.ID)
+	if errors.Is(err, domain.ErrEntityNotFound) {
+		return status, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get user mfa: %w", err)
+	}
+	if !mfa.IsEnabled() {
+		return status, nil
+	}
+
+	status.Enabled = true
+	status.EnabledAt = &mfa.EnabledAt.V
+	status.RecoveryCodesLeft, err = s.repository.CountMFARecoveryCodes(ctx, user.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to count recovery codes: %w", err)
+	}
+
+	return status, nil
+}
+
+// EnrollMFA generates new TOTP secret for the user.
+// Enrollment must be confirmed by ConfirmMFA, repeated enrollment replaces pending one.
+func (s *Service) EnrollMFA(ctx context.Context, userUUID string) (*domain.MFAEnrollment, error) {
+	user, err := s.repository.GetUserByUUID(ctx, userUUID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get user: %w", err)
+	}
+
+	secret, err := generateTOTPSecret()
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate mfa secret: %w", err)
+	}
+
+	err = s.repository.WithTransaction(ctx, func(txCtx context.Context) error {
+		mfa, err := s.repository.GetUserMFA(txCtx, user.ID)
+		if err != nil && !errors.Is(err, domain.ErrEntityNotFound) {
+			return fmt.Errorf("failed to get user mfa: %w", err)
+		}
+		if mfa != nil && mfa.IsEnabled() {
+			return domain.ErrMFAAlreadyEnabled
+		}
+		return s.repository.SaveUserMFA(txCtx, &models.UserMFA{
+			UserID: user.ID,
+			Secret: secret,
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &domain.MFAEnrollment{
+		Secret:          secret,
+		ProvisioningURI: totpProvisioningURI(s.mfaIssuer, user.Email, secret),
+	}, nil
+}
+
+// ConfirmMFA enables pending mfa of the user if code is valid.
+// Recovery codes are returned only once, only their hashes are persisted.
+func (s *Service) ConfirmMFA(ctx context.Context, userUUID string, code string) ([]string, error) {
+	user, err := s.repository.GetUserByUUID(ctx, userUUID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get user: %w", err)
+	}
+
+	var recoveryCodes []string
+
+	err = s.repository.WithTransaction(ctx, func(txCtx context.Context) error {
+		mfa, err := s.repository.GetUserMFA(txCtx, user.ID)
+		if errors.Is(err, domain.ErrEntityNotFound) {
+			return domain.ErrMFANotEnabled
+		}
+		if err != nil {
+			return fmt.Errorf("failed to get user mfa: %w", err)
+		}
+		if mfa.IsEnabled() {
+			return domain.ErrMFAAlreadyEnabled
+		}
+
+		step, ok := validateTOTPCode(mfa.Secret, code, time.Now())
+		if !ok {
+			return domain.ErrInvalidMFACode
+		}
+
+		mfa.EnabledAt = sql.Null[time.Time]{V: time.Now(), Valid: true}
+		mfa.LastUsedStep = step
+		if err := s.repository.SaveUserMFA(txCtx, mfa); err != nil {
+			return err
+		}
+
+		recoveryCodes, err = s.replaceRecoveryCodes(txCtx, user.ID)
+		if err != nil {
+			return err
+		}
+
+		return s.sendMFAEvent(txCtx, user.ID, domain.EventTypeUserMFAEnable, nil)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	metrics.Counter("auth_mfa_enabled_total", nil).Inc()
+
+	return recoveryCodes, nil
+}
+
+// DisableMFA disables mfa of the user, valid TOTP or recovery code is required.
+func (s *Service) DisableMFA(ctx context.Context, userUUID string, code string) error {
+	user, err := s.repository.GetUserByUUID(ctx, userUUID)
+	if err != nil {
+		return fmt.Errorf("failed to get user: %w", err)
+	}
+
+	err = s.repository.WithTransaction(ctx, func(txCtx context.Context) error {
+		if err := s.verifyMFACode(txCtx, user.ID, code); err != nil {
+			return err
+		}
+		if err := s.repository.DeleteUserMFA(txCtx, user.ID); err != nil {
+			return err
+		}
+		return s.sendMFAEvent(txCtx, user.ID, domain.EventTypeUserMFADisable, map[string]string{
+			"method": mfaDisableMethodSelf,
+		})
+	})
+	if err != nil {
+		return err
+	}
+
+	metrics.Counter("auth_mfa_disabled_total", map[string]interface{}{
+		"method": mfaDisableMethodSelf,
+	}).Inc()
+
+	return nil
+}
+
+// ResetUserMFA disables mfa of the user without code,
+// used by administrators when user lost access to second factor.
+func (s *Service) ResetUserMFA(ctx context.Context, userUUID string) error {
+	user, err := s.repository.GetUserByUUID(ctx, userUUID)
+	if err != nil {
+		return fmt.Errorf("failed to get user: %w", err)
+	}
+
+	err = s.repository.WithTransaction(ctx, func(txCtx context.Context) error {
+		mfa, err := s.repository.GetUserMFA(txCtx, user.ID)
+		if errors.Is(err, domain.ErrEntityNotFound) {
+			return domain.ErrMFANotEnabled
+		}
+		if err != nil {
+			return fmt.Errorf("failed to get user mfa: %w", err)
+		}
+		if err := s.repository.DeleteUserMFA(txCtx, user.ID); err != nil {
+			return err
+		}
+		if !mfa.IsEnabled() {
+			// pending enrollment is discarded silently
+			return nil
+		}
+		return s.sendMFAEvent(txCtx, user.ID, domain.EventTypeUserMFADisable, map[string]string{
+			"method": mfaDisableMethodReset,
+		})
+	})
+	if err != nil {
+		return err
+	}
+
+	metrics.Counter("auth_mfa_disabled_total", map[string]interface{}{
+		"method": mfaDisableMethodReset,
+	}).Inc()
+
+	return nil
+}
+
+// RegenerateMFARecoveryCodes replaces recovery codes of the user,
+// valid TOTP or recovery code is required.
+func (s *Service) RegenerateMFARecoveryCodes(ctx context.Context, userUUID string, code string) ([]string, error) {
+	user, err := s.repository.GetUserByUUID(ctx, userUUID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get user: %w", err)
+	}
+
+	var recoveryCodes []string
+
+	err = s.repository.WithTransaction(ctx, func(txCtx context.Context) error {
+		if err := s.verifyMFACode(txCtx, user.ID, code); err != nil {
+			return err
+		}
+		recoveryCodes, err = s.replaceRecoveryCodes(txCtx, user.ID)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return recoveryCodes, nil
+}
+
+// VerifyMFA completes login started by Login, exchanging challenge token
+// and valid TOTP or recovery code for tokens.
+// Failed attempts are counted by lockout policy same as failed logins.
+func (s *Service) VerifyMFA(
+	ctx context.Context, challengeToken string, code string, client domain.ClientInfo,
+) (*domain.Tokens, error) {
+	startTime := time.Now()
+	defer func() {
+		metrics.Histogram("auth_operation_duration_seconds", map[string]interface{}{
+			"operation": "verify_mfa",
+		}).Update(time.Since(startTime).Seconds())
+	}()
+
+	claims, err := s.validateJWTToken(ctx, challengeToken, domain.JWTScopeMFAChallenge)
+	if err != nil {
+		metrics.Counter("auth_mfa_verifications_total", map[string]interface{}{
+			"result": "invalid_token",
+		}).Inc()
+		return nil, domain.ErrInvalidToken
+	}
+
+	user, err := s.repository.GetUserByUUID(ctx, claims.Subject)
+	if err != nil || !user.IsActive() {
+		metrics.Counter("auth_mfa_verifications_total", map[string]interface{}{
+			"result": "invalid_token",
+		}).Inc()
+		return nil, domain.ErrInvalidToken
+	}
+
+	if err := s.checkLockout(ctx, user.Email, client); err != nil {
+		metrics.Counter("auth_mfa_verifications_total", map[string]interface{}{
+			"result": "locked",
+		}).Inc()
+		return nil, err
+	}
+
+	err = s.repository.WithTransaction(ctx, func(txCtx context.Context) error {
+		return s.verifyMFACode(txCtx, user.ID, code)
+	})
+	if errors.Is(err, domain.ErrInvalidMFACode) {
+		metrics.Counter("auth_mfa_verifications_total", map[string]interface{}{
+			"result": "invalid_code",
+		}).Inc()
+		s.registerLoginFailure(ctx, user.ID, user.Email, client)
+		return nil, err
+	}
+	if errors.Is(err, domain.ErrMFANotEnabled) {
+		// mfa was disabled after challenge was issued
+		return nil, domain.ErrInvalidToken
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	metrics.Counter("auth_mfa_verifications_total", map[string]interface{}{
+		"result": "success",
+	}).Inc()
+
+	s.resetLoginFailures(ctx, user.Email, client)
+
+	if err := s.InvalidateJWTToken(ctx, challengeToken, claims.ExpiresAt.Time); err != nil {
+		s.logger.ErrorContext(
+			ctx, "failed to invalidate mfa challenge token",
+			slog.Any("error", err),
+		)
+	}
+
+	return s.completeLogin(ctx, user, client)
+}
+
+// verifyMFACode accepts either TOTP code or unused recovery code
+// of enabled mfa. Should be called within transaction.
+func (s *Service) verifyMFACode(ctx context.Context, userID int, code string) error {
+	mfa, err := s.repository.GetUserMFA(ctx, userID)
+	if errors.Is(err, domain.ErrEntityNotFound) {
+		return domain.ErrMFANotEnabled
+	}
+	if err != nil {
+		return fmt.Errorf("failed to get user mfa: %w", err)
+	}
+	if !mfa.IsEnabled() {
+		return domain.ErrMFANotEnabled
+	}
+
+	code = strings.TrimSpace(code)
+
+	if len(code) == totpDigits {
+		step, ok := validateTOTPCode(mfa.Secret, code, time.Now())
+		if !ok {
+			return domain.ErrInvalidMFACode
+		}
+		return s.repository.ConsumeMFAStep(ctx, userID, step)
+	}
+
+	return s.repository.UseMFARecoveryCode(ctx, userID, strToSHA256(normalizeRecoveryCode(code)))
+}
+
+// replaceRecoveryCodes generates new set of recovery codes.
+// Should be called within transaction.
+func (s *Service) replaceRecoveryCodes(ctx context.Context, userID int) ([]string, error) {
+	codes := make([]string, mfaRecoveryCodesCount)
+	records := make([]*models.MFARecoveryCode, mfaRecoveryCodesCount)
+	for i := range codes {
+		code, err := generateRecoveryCode()
+		if err != nil {
+			return nil, fmt.Errorf("failed to generate recovery code: %w", err)
+		}
+		codes[i] = code
+		records[i] = &models.MFARecoveryCode{
+			UserID:   userID,
+			CodeHash: strToSHA256(normalizeRecoveryCode(code)),
+		}
+	}
+	if err := s.repository.ReplaceMFARecoveryCodes(ctx, userID, records); err != nil {
+		return nil, err
+	}
+	return codes, nil
+}
+
+func (s *Service) sendMFAEvent(ctx context.Context, userID int, eventType string, data any) error {
+	var payload []byte
+	if data != nil {
+		var err error
+		payload, err = json.Marshal(data)
+		if err != nil {
+			return fmt.Errorf("failed to marshal event payload: %w", err)
+		}
+	}
+	event := outboxDomain.Message{
+		AggregateID:   userID,
+		AggregateType: eventType,
+		Payload:       payload,
+	}
+	if err := s.sendEvent(ctx, domain.TopicNameAuthEvents, event); err != nil {
+		return fmt.Errorf("failed to send event: %w", err)
+	}
+	return nil
+}
+
+// generateRecoveryCode returns code formatted as two dash separated halves,
+// alphabet omits characters which are easy to confuse.
+func generateRecoveryCode() (string, error) {
+	alphabetSize := big.NewInt(int64(len(mfaRecoveryCodeAlphabet)))
+	code := make([]byte, 0, mfaRecoveryCodeLength+1)
+	for i := range mfaRecoveryCodeLength {
+		if i == mfaRecoveryCodeLength/2 {
+			code = append(code, '-')
+		}
+		n, err := rand.Int(rand.Reader, alphabetSize)
+		if err != nil {
+			return "", err
+		}
+		code = append(code, mfaRecoveryCodeAlphabet[n.Int64()])
+	}
+	return string(code), nil
+}
+
+func normalizeRecoveryCode(code string) string {
+	return strings.ToLower(strings.ReplaceAll(strings.TrimSpace(code), "-", ""))
+}
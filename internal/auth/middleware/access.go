@@ -1,15 +1,47 @@
 package middleware
 
 import (
+	"context"
 	"net/http"
 
 	"github.com/labstack/echo/v4"
 
 	httpAPI "github.com/hasansino/go42/internal/api/http"
 	"github.com/hasansino/go42/internal/auth"
+	"github.com/hasansino/go42/internal/auth/domain"
 )
 
-func NewAccessMiddleware(permissions ...string) func(next echo.HandlerFunc) echo.HandlerFunc {
+const protocolHTTP = "http"
+
+type accessCheckerAccessor interface {
+	CheckAccess(ctx context.Context, req *domain.AccessRequest) *domain.AccessDecision
+}
+
+// NewAccessMiddleware requires every permission to be granted by access policy.
+// Resource type is route path, resource owner is `uuid` path parameter, if present.
+func NewAccessMiddleware(
+	checker accessCheckerAccessor, permissions ...string,
+) func(next echo.HandlerFunc) echo.HandlerFunc {
+	return newAccessMiddleware(checker, func(c echo.Context, _ *domain.ContextAuthInfo) string {
+		return c.Param("uuid")
+	}, permissions)
+}
+
+// NewSelfAccessMiddleware is NewAccessMiddleware for routes of authenticated user,
+// resource owner is the user request is made on behalf of.
+func NewSelfAccessMiddleware(
+	checker accessCheckerAccessor, permissions ...string,
+) func(next echo.HandlerFunc) echo.HandlerFunc {
+	return newAccessMiddleware(checker, func(_ echo.Context, authInfo *domain.ContextAuthInfo) string {
+		return authInfo.UserUUID
+	}, permissions)
+}
+
+func newAccessMiddleware(
+	checker accessCheckerAccessor,
+	owner func(c echo.Context, authInfo *domain.ContextAuthInfo) string,
+	permissions []string,
+) func(next echo.HandlerFunc) echo.HandlerFunc {
 	return func(next echo.HandlerFunc) echo.HandlerFunc {
 		return func(c echo.Context) error {
 			authInfo := auth.RetrieveAuthFromContext(c.Request().Context())
@@ -18,7 +50,17 @@ func NewAccessMiddleware(permissions ...string) func(next echo.HandlerFunc) echo
 					http.StatusUnauthorized, http.StatusText(http.StatusUnauthorized))
 			}
 			for _, permission := range permissions {
-				if !authInfo.HasPermission(permission) {
+				decision := checker.CheckAccess(c.Request().Context(), &domain.AccessRequest{
+					Subject: authInfo,
+					Action:  permission,
+					Resource: domain.AccessResource{
+						Type:  c.Path(),
+						Owner: owner(c, authInfo),
+					},
+					Protocol:  protocolHTTP,
+					IPAddress: c.RealIP(),
+				})
+				if !decision.Allowed {
 					return httpAPI.SendJSONError(c,
 						http.StatusForbidden, http.StatusText(http.StatusForbidden))
 				}
@@ -27,3 +69,22 @@ func NewAccessMiddleware(permissions ...string) func(next echo.HandlerFunc) echo
 		}
 	}
 }
+
+// NewNoImpersonationMiddleware rejects impersonated requests. It protects credentials,
+// sessions, tokens and other operations which must be performed by the user personally.
+func NewNoImpersonationMiddleware() func(next echo.HandlerFunc) echo.HandlerFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			authInfo := auth.RetrieveAuthFromContext(c.Request().Context())
+			if authInfo == nil {
+				return httpAPI.SendJSONError(c,
+					http.StatusUnauthorized, http.StatusText(http.StatusUnauthorized))
+			}
+			if authInfo.IsImpersonated() {
+				return httpAPI.SendJSONError(c,
+					http.StatusForbidden, http.StatusText(http.StatusForbidden))
+			}
+			return next(c)
+		}
+	}
+}
@@ -6,6 +6,7 @@ import (
 	"fmt"
 	"log/slog"
 	"net/http"
+	"slices"
 	"strings"
 	"time"
 
@@ -31,6 +32,8 @@ type authServiceAccessor interface {
 	ValidateJWTToken(ctx context.Context, token string) (*domain.JWTClaims, error)
 	InvalidateJWTToken(ctx context.Context, token string, until time.Time) error
 	ValidateAPIToken(ctx context.Context, token string) (*models.Token, error)
+	GetOrganizationMember(ctx context.Context, organizationUUID string, userID int) (*models.OrganizationMember, error)
+	RecordImpersonatedRequest(ctx context.Context, operation string, ipAddress string) error
 }
 
 func NewAuthMiddleware(svc authServiceAccessor) func(next echo.HandlerFunc) echo.HandlerFunc {
@@ -45,7 +48,12 @@ func NewAuthMiddleware(svc authServiceAccessor) func(next echo.HandlerFunc) echo
 					return httpAPI.SendJSONError(ctx,
 						http.StatusUnauthorized, http.StatusText(http.StatusUnauthorized))
 				}
-				if err := processUserAuth(ctx, svc, token); err != nil {
+				process := processUserAuth
+				if !isJWT(token) {
+					// api tokens issued by oauth client credentials grant are sent as bearer tokens
+					process = processTokenAuth
+				}
+				if err := process(ctx, svc, token); err != nil {
 					return httpAPI.SendJSONError(ctx,
 						http.StatusUnauthorized, http.StatusText(http.StatusUnauthorized))
 				}
@@ -77,6 +85,11 @@ func extractBearerToken(authHeader string) (string, error) {
 	return token, nil
 }
 
+// isJWT reports whether token has JWS compact serialization format.
+func isJWT(token string) bool {
+	return strings.Count(token, ".") == 2
+}
+
 func processUserAuth(ctx echo.Context, svc authServiceAccessor, token string) error {
 	claims, err := svc.ValidateJWTToken(ctx.Request().Context(), token)
 	if err != nil {
@@ -105,13 +118,63 @@ func processUserAuth(ctx echo.Context, svc authServiceAccessor, token string) er
 	}
 
 	authInfo := domain.ContextAuthInfo{
-		ID:   user.ID,
-		UUID: user.UUID.String(),
-		Type: domain.AuthenticationTypeCredentials,
+		ID:       user.ID,
+		UUID:     user.UUID.String(),
+		UserID:   user.ID,
+		UserUUID: user.UUID.String(),
+		Type:     domain.AuthenticationTypeCredentials,
+	}
+	permissions := user.PermissionList()
+
+	// actor must still be allowed to impersonate when token is used
+	if claims.Actor != nil {
+		actor, err := svc.GetUserByUUID(ctx.Request().Context(), claims.Actor.Subject)
+		if err != nil {
+			return fmt.Errorf("error retrieving actor: %w", err)
+		}
+		if !actor.IsActive() || !slices.Contains(actor.PermissionList(), domain.RBACPermissionUsersImpersonate) {
+			return errors.New("actor is not allowed to impersonate")
+		}
+		authInfo.ActorID = actor.ID
+		authInfo.ActorUUID = actor.UUID.String()
+		authInfo.Type = domain.AuthenticationTypeImpersonation
 	}
-	authInfo.SetPermissions(user.PermissionList())
+
+	// membership is checked on every request, so removed member loses access immediately
+	if claims.Tenant != "" {
+		member, err := svc.GetOrganizationMember(ctx.Request().Context(), claims.Tenant, user.ID)
+		if err != nil {
+			return fmt.Errorf("error retrieving organization membership: %w", err)
+		}
+		authInfo.TenantID = member.OrganizationID
+		authInfo.TenantUUID = member.Organization.UUID.String()
+		for _, permission := range member.PermissionList() {
+			if !slices.Contains(permissions, permission) {
+				permissions = append(permissions, permission)
+			}
+		}
+	}
+
+	// tokens of oauth clients grant only consented permissions user still holds
+	if claims.ClientID != "" {
+		authInfo.Type = domain.AuthenticationTypeOAuth
+		permissions = slices.DeleteFunc(permissions, func(permission string) bool {
+			return !slices.Contains(claims.Permissions, permission)
+		})
+	}
+
+	authInfo.SetPermissions(permissions)
 
 	newCtx := auth.SetAuthToContext(ctx.Request().Context(), authInfo)
+
+	// request is rejected if it can not be audited
+	if authInfo.IsImpersonated() {
+		operation := ctx.Request().Method + " " + ctx.Request().URL.Path
+		if err := svc.RecordImpersonatedRequest(newCtx, operation, ctx.RealIP()); err != nil {
+			return fmt.Errorf("failed to record impersonated request: %w", err)
+		}
+	}
+
 	ctx.SetRequest(ctx.Request().WithContext(newCtx))
 
 	return nil
@@ -133,9 +196,11 @@ func processTokenAuth(ctx echo.Context, svc authServiceAccessor, token string) e
 	}
 
 	authInfo := domain.ContextAuthInfo{
-		ID:   apiToken.ID,
-		UUID: apiToken.UUID.String(),
-		Type: domain.AuthenticationTypeApiToken,
+		ID:       apiToken.ID,
+		UUID:     apiToken.UUID.String(),
+		UserID:   user.ID,
+		UserUUID: user.UUID.String(),
+		Type:     domain.AuthenticationTypeApiToken,
 	}
 
 	// for api token auth we are using only token permissions
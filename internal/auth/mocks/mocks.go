@@ -14,8 +14,11 @@ import (
 	reflect "reflect"
 	time "time"
 
+	uuid "github.com/google/uuid"
+	domain "github.com/hasansino/go42/internal/auth/domain"
 	models "github.com/hasansino/go42/internal/auth/models"
-	domain "github.com/hasansino/go42/internal/outbox/domain"
+	mailer "github.com/hasansino/go42/internal/mailer"
+	domain0 "github.com/hasansino/go42/internal/outbox/domain"
 	gomock "go.uber.org/mock/gomock"
 )
 
@@ -43,121 +46,1148 @@ func (m *Mockrepository) EXPECT() *MockrepositoryMockRecorder {
 	return m.recorder
 }
 
+// AddOrganizationMember mocks base method.
+func (m *Mockrepository) AddOrganizationMember(ctx context.Context, member *models.OrganizationMember) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddOrganizationMember", ctx, member)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AddOrganizationMember indicates an expected call of AddOrganizationMember.
+func (mr *MockrepositoryMockRecorder) AddOrganizationMember(ctx, member any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddOrganizationMember", reflect.TypeOf((*Mockrepository)(nil).AddOrganizationMember), ctx, member)
+}
+
+// AnonymizeUser mocks base method.
+func (m *Mockrepository) AnonymizeUser(ctx context.Context, user *models.User, status string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AnonymizeUser", ctx, user, status)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AnonymizeUser indicates an expected call of AnonymizeUser.
+func (mr *MockrepositoryMockRecorder) AnonymizeUser(ctx, user, status any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AnonymizeUser", reflect.TypeOf((*Mockrepository)(nil).AnonymizeUser), ctx, user, status)
+}
+
 // AssignRoleToUser mocks base method.
 func (m *Mockrepository) AssignRoleToUser(ctx context.Context, userID int, role string) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "AssignRoleToUser", ctx, userID, role)
-	ret0, _ := ret[0].(error)
-	return ret0
+	ret := m.ctrl.Call(m, "AssignRoleToUser", ctx, userID, role)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AssignRoleToUser indicates an expected call of AssignRoleToUser.
+func (mr *MockrepositoryMockRecorder) AssignRoleToUser(ctx, userID, role any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AssignRoleToUser", reflect.TypeOf((*Mockrepository)(nil).AssignRoleToUser), ctx, userID, role)
+}
+
+// AttachPermissionToRole mocks base method.
+func (m *Mockrepository) AttachPermissionToRole(ctx context.Context, roleID, permissionID int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AttachPermissionToRole", ctx, roleID, permissionID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AttachPermissionToRole indicates an expected call of AttachPermissionToRole.
+func (mr *MockrepositoryMockRecorder) AttachPermissionToRole(ctx, roleID, permissionID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AttachPermissionToRole", reflect.TypeOf((*Mockrepository)(nil).AttachPermissionToRole), ctx, roleID, permissionID)
+}
+
+// ClaimDataRequest mocks base method.
+func (m *Mockrepository) ClaimDataRequest(ctx context.Context, request *models.DataRequest) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ClaimDataRequest", ctx, request)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ClaimDataRequest indicates an expected call of ClaimDataRequest.
+func (mr *MockrepositoryMockRecorder) ClaimDataRequest(ctx, request any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ClaimDataRequest", reflect.TypeOf((*Mockrepository)(nil).ClaimDataRequest), ctx, request)
+}
+
+// CompleteDataRequest mocks base method.
+func (m *Mockrepository) CompleteDataRequest(ctx context.Context, request *models.DataRequest) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CompleteDataRequest", ctx, request)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CompleteDataRequest indicates an expected call of CompleteDataRequest.
+func (mr *MockrepositoryMockRecorder) CompleteDataRequest(ctx, request any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CompleteDataRequest", reflect.TypeOf((*Mockrepository)(nil).CompleteDataRequest), ctx, request)
+}
+
+// ConsumeMFAStep mocks base method.
+func (m *Mockrepository) ConsumeMFAStep(ctx context.Context, userID int, step int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ConsumeMFAStep", ctx, userID, step)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ConsumeMFAStep indicates an expected call of ConsumeMFAStep.
+func (mr *MockrepositoryMockRecorder) ConsumeMFAStep(ctx, userID, step any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ConsumeMFAStep", reflect.TypeOf((*Mockrepository)(nil).ConsumeMFAStep), ctx, userID, step)
+}
+
+// ConsumeOAuthCode mocks base method.
+func (m *Mockrepository) ConsumeOAuthCode(ctx context.Context, codeHash string) (*models.OAuthCode, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ConsumeOAuthCode", ctx, codeHash)
+	ret0, _ := ret[0].(*models.OAuthCode)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ConsumeOAuthCode indicates an expected call of ConsumeOAuthCode.
+func (mr *MockrepositoryMockRecorder) ConsumeOAuthCode(ctx, codeHash any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ConsumeOAuthCode", reflect.TypeOf((*Mockrepository)(nil).ConsumeOAuthCode), ctx, codeHash)
+}
+
+// ConsumeRefreshToken mocks base method.
+func (m *Mockrepository) ConsumeRefreshToken(ctx context.Context, id int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ConsumeRefreshToken", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ConsumeRefreshToken indicates an expected call of ConsumeRefreshToken.
+func (mr *MockrepositoryMockRecorder) ConsumeRefreshToken(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ConsumeRefreshToken", reflect.TypeOf((*Mockrepository)(nil).ConsumeRefreshToken), ctx, id)
+}
+
+// CountMFARecoveryCodes mocks base method.
+func (m *Mockrepository) CountMFARecoveryCodes(ctx context.Context, userID int) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CountMFARecoveryCodes", ctx, userID)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CountMFARecoveryCodes indicates an expected call of CountMFARecoveryCodes.
+func (mr *MockrepositoryMockRecorder) CountMFARecoveryCodes(ctx, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountMFARecoveryCodes", reflect.TypeOf((*Mockrepository)(nil).CountMFARecoveryCodes), ctx, userID)
+}
+
+// CreateDataRequest mocks base method.
+func (m *Mockrepository) CreateDataRequest(ctx context.Context, request *models.DataRequest) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateDataRequest", ctx, request)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateDataRequest indicates an expected call of CreateDataRequest.
+func (mr *MockrepositoryMockRecorder) CreateDataRequest(ctx, request any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateDataRequest", reflect.TypeOf((*Mockrepository)(nil).CreateDataRequest), ctx, request)
+}
+
+// CreateJWTSecret mocks base method.
+func (m *Mockrepository) CreateJWTSecret(ctx context.Context, secret *models.JWTSecret) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateJWTSecret", ctx, secret)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateJWTSecret indicates an expected call of CreateJWTSecret.
+func (mr *MockrepositoryMockRecorder) CreateJWTSecret(ctx, secret any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateJWTSecret", reflect.TypeOf((*Mockrepository)(nil).CreateJWTSecret), ctx, secret)
+}
+
+// CreateOAuthClient mocks base method.
+func (m *Mockrepository) CreateOAuthClient(ctx context.Context, client *models.OAuthClient) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateOAuthClient", ctx, client)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateOAuthClient indicates an expected call of CreateOAuthClient.
+func (mr *MockrepositoryMockRecorder) CreateOAuthClient(ctx, client any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateOAuthClient", reflect.TypeOf((*Mockrepository)(nil).CreateOAuthClient), ctx, client)
+}
+
+// CreateOAuthCode mocks base method.
+func (m *Mockrepository) CreateOAuthCode(ctx context.Context, code *models.OAuthCode) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateOAuthCode", ctx, code)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateOAuthCode indicates an expected call of CreateOAuthCode.
+func (mr *MockrepositoryMockRecorder) CreateOAuthCode(ctx, code any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateOAuthCode", reflect.TypeOf((*Mockrepository)(nil).CreateOAuthCode), ctx, code)
+}
+
+// CreateOrganization mocks base method.
+func (m *Mockrepository) CreateOrganization(ctx context.Context, organization *models.Organization) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateOrganization", ctx, organization)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateOrganization indicates an expected call of CreateOrganization.
+func (mr *MockrepositoryMockRecorder) CreateOrganization(ctx, organization any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateOrganization", reflect.TypeOf((*Mockrepository)(nil).CreateOrganization), ctx, organization)
+}
+
+// CreatePasswordHistory mocks base method.
+func (m *Mockrepository) CreatePasswordHistory(ctx context.Context, entry *models.PasswordHistory) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreatePasswordHistory", ctx, entry)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreatePasswordHistory indicates an expected call of CreatePasswordHistory.
+func (mr *MockrepositoryMockRecorder) CreatePasswordHistory(ctx, entry any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreatePasswordHistory", reflect.TypeOf((*Mockrepository)(nil).CreatePasswordHistory), ctx, entry)
+}
+
+// CreateRefreshToken mocks base method.
+func (m *Mockrepository) CreateRefreshToken(ctx context.Context, token *models.RefreshToken) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateRefreshToken", ctx, token)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateRefreshToken indicates an expected call of CreateRefreshToken.
+func (mr *MockrepositoryMockRecorder) CreateRefreshToken(ctx, token any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateRefreshToken", reflect.TypeOf((*Mockrepository)(nil).CreateRefreshToken), ctx, token)
+}
+
+// CreateRole mocks base method.
+func (m *Mockrepository) CreateRole(ctx context.Context, role *models.Role) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateRole", ctx, role)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateRole indicates an expected call of CreateRole.
+func (mr *MockrepositoryMockRecorder) CreateRole(ctx, role any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateRole", reflect.TypeOf((*Mockrepository)(nil).CreateRole), ctx, role)
+}
+
+// CreateSession mocks base method.
+func (m *Mockrepository) CreateSession(ctx context.Context, session *models.Session) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateSession", ctx, session)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateSession indicates an expected call of CreateSession.
+func (mr *MockrepositoryMockRecorder) CreateSession(ctx, session any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateSession", reflect.TypeOf((*Mockrepository)(nil).CreateSession), ctx, session)
+}
+
+// CreateToken mocks base method.
+func (m *Mockrepository) CreateToken(ctx context.Context, apiToken *models.Token) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateToken", ctx, apiToken)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateToken indicates an expected call of CreateToken.
+func (mr *MockrepositoryMockRecorder) CreateToken(ctx, apiToken any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateToken", reflect.TypeOf((*Mockrepository)(nil).CreateToken), ctx, apiToken)
+}
+
+// CreateUser mocks base method.
+func (m *Mockrepository) CreateUser(ctx context.Context, user *models.User) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateUser", ctx, user)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateUser indicates an expected call of CreateUser.
+func (mr *MockrepositoryMockRecorder) CreateUser(ctx, user any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateUser", reflect.TypeOf((*Mockrepository)(nil).CreateUser), ctx, user)
+}
+
+// CreateUserIdentity mocks base method.
+func (m *Mockrepository) CreateUserIdentity(ctx context.Context, identity *models.UserIdentity) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateUserIdentity", ctx, identity)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateUserIdentity indicates an expected call of CreateUserIdentity.
+func (mr *MockrepositoryMockRecorder) CreateUserIdentity(ctx, identity any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateUserIdentity", reflect.TypeOf((*Mockrepository)(nil).CreateUserIdentity), ctx, identity)
+}
+
+// DeleteExpiredDataArchives mocks base method.
+func (m *Mockrepository) DeleteExpiredDataArchives(ctx context.Context, before time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteExpiredDataArchives", ctx, before)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteExpiredDataArchives indicates an expected call of DeleteExpiredDataArchives.
+func (mr *MockrepositoryMockRecorder) DeleteExpiredDataArchives(ctx, before any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteExpiredDataArchives", reflect.TypeOf((*Mockrepository)(nil).DeleteExpiredDataArchives), ctx, before)
+}
+
+// DeleteExpiredOAuthCodes mocks base method.
+func (m *Mockrepository) DeleteExpiredOAuthCodes(ctx context.Context, userID int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteExpiredOAuthCodes", ctx, userID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteExpiredOAuthCodes indicates an expected call of DeleteExpiredOAuthCodes.
+func (mr *MockrepositoryMockRecorder) DeleteExpiredOAuthCodes(ctx, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteExpiredOAuthCodes", reflect.TypeOf((*Mockrepository)(nil).DeleteExpiredOAuthCodes), ctx, userID)
+}
+
+// DeleteExpiredTokens mocks base method.
+func (m *Mockrepository) DeleteExpiredTokens(ctx context.Context, userID int, name string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteExpiredTokens", ctx, userID, name)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteExpiredTokens indicates an expected call of DeleteExpiredTokens.
+func (mr *MockrepositoryMockRecorder) DeleteExpiredTokens(ctx, userID, name any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteExpiredTokens", reflect.TypeOf((*Mockrepository)(nil).DeleteExpiredTokens), ctx, userID, name)
+}
+
+// DeleteJWTSecretsBefore mocks base method.
+func (m *Mockrepository) DeleteJWTSecretsBefore(ctx context.Context, generation int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteJWTSecretsBefore", ctx, generation)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteJWTSecretsBefore indicates an expected call of DeleteJWTSecretsBefore.
+func (mr *MockrepositoryMockRecorder) DeleteJWTSecretsBefore(ctx, generation any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteJWTSecretsBefore", reflect.TypeOf((*Mockrepository)(nil).DeleteJWTSecretsBefore), ctx, generation)
+}
+
+// DeleteOAuthClient mocks base method.
+func (m *Mockrepository) DeleteOAuthClient(ctx context.Context, client *models.OAuthClient) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteOAuthClient", ctx, client)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteOAuthClient indicates an expected call of DeleteOAuthClient.
+func (mr *MockrepositoryMockRecorder) DeleteOAuthClient(ctx, client any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteOAuthClient", reflect.TypeOf((*Mockrepository)(nil).DeleteOAuthClient), ctx, client)
+}
+
+// DeleteOrganization mocks base method.
+func (m *Mockrepository) DeleteOrganization(ctx context.Context, organization *models.Organization) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteOrganization", ctx, organization)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteOrganization indicates an expected call of DeleteOrganization.
+func (mr *MockrepositoryMockRecorder) DeleteOrganization(ctx, organization any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteOrganization", reflect.TypeOf((*Mockrepository)(nil).DeleteOrganization), ctx, organization)
+}
+
+// DeletePasswordHistoryBefore mocks base method.
+func (m *Mockrepository) DeletePasswordHistoryBefore(ctx context.Context, userID, id int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeletePasswordHistoryBefore", ctx, userID, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeletePasswordHistoryBefore indicates an expected call of DeletePasswordHistoryBefore.
+func (mr *MockrepositoryMockRecorder) DeletePasswordHistoryBefore(ctx, userID, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeletePasswordHistoryBefore", reflect.TypeOf((*Mockrepository)(nil).DeletePasswordHistoryBefore), ctx, userID, id)
+}
+
+// DeleteRole mocks base method.
+func (m *Mockrepository) DeleteRole(ctx context.Context, role *models.Role) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteRole", ctx, role)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteRole indicates an expected call of DeleteRole.
+func (mr *MockrepositoryMockRecorder) DeleteRole(ctx, role any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteRole", reflect.TypeOf((*Mockrepository)(nil).DeleteRole), ctx, role)
+}
+
+// DeleteToken mocks base method.
+func (m *Mockrepository) DeleteToken(ctx context.Context, apiToken *models.Token) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteToken", ctx, apiToken)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteToken indicates an expected call of DeleteToken.
+func (mr *MockrepositoryMockRecorder) DeleteToken(ctx, apiToken any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteToken", reflect.TypeOf((*Mockrepository)(nil).DeleteToken), ctx, apiToken)
+}
+
+// DeleteUserMFA mocks base method.
+func (m *Mockrepository) DeleteUserMFA(ctx context.Context, userID int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteUserMFA", ctx, userID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteUserMFA indicates an expected call of DeleteUserMFA.
+func (mr *MockrepositoryMockRecorder) DeleteUserMFA(ctx, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteUserMFA", reflect.TypeOf((*Mockrepository)(nil).DeleteUserMFA), ctx, userID)
+}
+
+// DetachPermissionFromRole mocks base method.
+func (m *Mockrepository) DetachPermissionFromRole(ctx context.Context, roleID, permissionID int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DetachPermissionFromRole", ctx, roleID, permissionID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DetachPermissionFromRole indicates an expected call of DetachPermissionFromRole.
+func (mr *MockrepositoryMockRecorder) DetachPermissionFromRole(ctx, roleID, permissionID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DetachPermissionFromRole", reflect.TypeOf((*Mockrepository)(nil).DetachPermissionFromRole), ctx, roleID, permissionID)
+}
+
+// EraseUser mocks base method.
+func (m *Mockrepository) EraseUser(ctx context.Context, user *models.User) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "EraseUser", ctx, user)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// EraseUser indicates an expected call of EraseUser.
+func (mr *MockrepositoryMockRecorder) EraseUser(ctx, user any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "EraseUser", reflect.TypeOf((*Mockrepository)(nil).EraseUser), ctx, user)
+}
+
+// FilterUserHistoryRecords mocks base method.
+func (m *Mockrepository) FilterUserHistoryRecords(ctx context.Context, userID int, filter *domain.UserHistoryFilter) ([]*models.UserHistoryRecord, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FilterUserHistoryRecords", ctx, userID, filter)
+	ret0, _ := ret[0].([]*models.UserHistoryRecord)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FilterUserHistoryRecords indicates an expected call of FilterUserHistoryRecords.
+func (mr *MockrepositoryMockRecorder) FilterUserHistoryRecords(ctx, userID, filter any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FilterUserHistoryRecords", reflect.TypeOf((*Mockrepository)(nil).FilterUserHistoryRecords), ctx, userID, filter)
+}
+
+// GetDataRequestArchive mocks base method.
+func (m *Mockrepository) GetDataRequestArchive(ctx context.Context, id int) ([]byte, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetDataRequestArchive", ctx, id)
+	ret0, _ := ret[0].([]byte)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetDataRequestArchive indicates an expected call of GetDataRequestArchive.
+func (mr *MockrepositoryMockRecorder) GetDataRequestArchive(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDataRequestArchive", reflect.TypeOf((*Mockrepository)(nil).GetDataRequestArchive), ctx, id)
+}
+
+// GetDataRequestByID mocks base method.
+func (m *Mockrepository) GetDataRequestByID(ctx context.Context, id int) (*models.DataRequest, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetDataRequestByID", ctx, id)
+	ret0, _ := ret[0].(*models.DataRequest)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetDataRequestByID indicates an expected call of GetDataRequestByID.
+func (mr *MockrepositoryMockRecorder) GetDataRequestByID(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDataRequestByID", reflect.TypeOf((*Mockrepository)(nil).GetDataRequestByID), ctx, id)
+}
+
+// GetOAuthClient mocks base method.
+func (m *Mockrepository) GetOAuthClient(ctx context.Context, clientID string) (*models.OAuthClient, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetOAuthClient", ctx, clientID)
+	ret0, _ := ret[0].(*models.OAuthClient)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetOAuthClient indicates an expected call of GetOAuthClient.
+func (mr *MockrepositoryMockRecorder) GetOAuthClient(ctx, clientID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetOAuthClient", reflect.TypeOf((*Mockrepository)(nil).GetOAuthClient), ctx, clientID)
+}
+
+// GetOrganizationByID mocks base method.
+func (m *Mockrepository) GetOrganizationByID(ctx context.Context, id int) (*models.Organization, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetOrganizationByID", ctx, id)
+	ret0, _ := ret[0].(*models.Organization)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetOrganizationByID indicates an expected call of GetOrganizationByID.
+func (mr *MockrepositoryMockRecorder) GetOrganizationByID(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetOrganizationByID", reflect.TypeOf((*Mockrepository)(nil).GetOrganizationByID), ctx, id)
+}
+
+// GetOrganizationByUUID mocks base method.
+func (m *Mockrepository) GetOrganizationByUUID(ctx context.Context, arg1 string) (*models.Organization, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetOrganizationByUUID", ctx, arg1)
+	ret0, _ := ret[0].(*models.Organization)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetOrganizationByUUID indicates an expected call of GetOrganizationByUUID.
+func (mr *MockrepositoryMockRecorder) GetOrganizationByUUID(ctx, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetOrganizationByUUID", reflect.TypeOf((*Mockrepository)(nil).GetOrganizationByUUID), ctx, arg1)
+}
+
+// GetOrganizationMember mocks base method.
+func (m *Mockrepository) GetOrganizationMember(ctx context.Context, organizationID, userID int) (*models.OrganizationMember, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetOrganizationMember", ctx, organizationID, userID)
+	ret0, _ := ret[0].(*models.OrganizationMember)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetOrganizationMember indicates an expected call of GetOrganizationMember.
+func (mr *MockrepositoryMockRecorder) GetOrganizationMember(ctx, organizationID, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetOrganizationMember", reflect.TypeOf((*Mockrepository)(nil).GetOrganizationMember), ctx, organizationID, userID)
+}
+
+// GetPermissions mocks base method.
+func (m *Mockrepository) GetPermissions(ctx context.Context, names []string) ([]models.Permission, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPermissions", ctx, names)
+	ret0, _ := ret[0].([]models.Permission)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPermissions indicates an expected call of GetPermissions.
+func (mr *MockrepositoryMockRecorder) GetPermissions(ctx, names any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPermissions", reflect.TypeOf((*Mockrepository)(nil).GetPermissions), ctx, names)
+}
+
+// GetRefreshToken mocks base method.
+func (m *Mockrepository) GetRefreshToken(ctx context.Context, jti string) (*models.RefreshToken, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetRefreshToken", ctx, jti)
+	ret0, _ := ret[0].(*models.RefreshToken)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetRefreshToken indicates an expected call of GetRefreshToken.
+func (mr *MockrepositoryMockRecorder) GetRefreshToken(ctx, jti any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRefreshToken", reflect.TypeOf((*Mockrepository)(nil).GetRefreshToken), ctx, jti)
+}
+
+// GetRoleByName mocks base method.
+func (m *Mockrepository) GetRoleByName(ctx context.Context, name string) (*models.Role, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetRoleByName", ctx, name)
+	ret0, _ := ret[0].(*models.Role)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetRoleByName indicates an expected call of GetRoleByName.
+func (mr *MockrepositoryMockRecorder) GetRoleByName(ctx, name any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRoleByName", reflect.TypeOf((*Mockrepository)(nil).GetRoleByName), ctx, name)
+}
+
+// GetSessionByFamilyID mocks base method.
+func (m *Mockrepository) GetSessionByFamilyID(ctx context.Context, familyID uuid.UUID) (*models.Session, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSessionByFamilyID", ctx, familyID)
+	ret0, _ := ret[0].(*models.Session)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSessionByFamilyID indicates an expected call of GetSessionByFamilyID.
+func (mr *MockrepositoryMockRecorder) GetSessionByFamilyID(ctx, familyID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSessionByFamilyID", reflect.TypeOf((*Mockrepository)(nil).GetSessionByFamilyID), ctx, familyID)
+}
+
+// GetSessionByUUID mocks base method.
+func (m *Mockrepository) GetSessionByUUID(ctx context.Context, arg1 string) (*models.Session, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSessionByUUID", ctx, arg1)
+	ret0, _ := ret[0].(*models.Session)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSessionByUUID indicates an expected call of GetSessionByUUID.
+func (mr *MockrepositoryMockRecorder) GetSessionByUUID(ctx, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSessionByUUID", reflect.TypeOf((*Mockrepository)(nil).GetSessionByUUID), ctx, arg1)
+}
+
+// GetToken mocks base method.
+func (m *Mockrepository) GetToken(ctx context.Context, hashedToken string) (*models.Token, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetToken", ctx, hashedToken)
+	ret0, _ := ret[0].(*models.Token)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetToken indicates an expected call of GetToken.
+func (mr *MockrepositoryMockRecorder) GetToken(ctx, hashedToken any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetToken", reflect.TypeOf((*Mockrepository)(nil).GetToken), ctx, hashedToken)
+}
+
+// GetTokenByUUID mocks base method.
+func (m *Mockrepository) GetTokenByUUID(ctx context.Context, arg1 string) (*models.Token, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTokenByUUID", ctx, arg1)
+	ret0, _ := ret[0].(*models.Token)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetTokenByUUID indicates an expected call of GetTokenByUUID.
+func (mr *MockrepositoryMockRecorder) GetTokenByUUID(ctx, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTokenByUUID", reflect.TypeOf((*Mockrepository)(nil).GetTokenByUUID), ctx, arg1)
+}
+
+// GetUserByEmail mocks base method.
+func (m *Mockrepository) GetUserByEmail(ctx context.Context, email string) (*models.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUserByEmail", ctx, email)
+	ret0, _ := ret[0].(*models.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUserByEmail indicates an expected call of GetUserByEmail.
+func (mr *MockrepositoryMockRecorder) GetUserByEmail(ctx, email any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserByEmail", reflect.TypeOf((*Mockrepository)(nil).GetUserByEmail), ctx, email)
+}
+
+// GetUserByID mocks base method.
+func (m *Mockrepository) GetUserByID(ctx context.Context, id int) (*models.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUserByID", ctx, id)
+	ret0, _ := ret[0].(*models.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUserByID indicates an expected call of GetUserByID.
+func (mr *MockrepositoryMockRecorder) GetUserByID(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserByID", reflect.TypeOf((*Mockrepository)(nil).GetUserByID), ctx, id)
+}
+
+// GetUserByUUID mocks base method.
+func (m *Mockrepository) GetUserByUUID(ctx context.Context, arg1 string) (*models.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUserByUUID", ctx, arg1)
+	ret0, _ := ret[0].(*models.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUserByUUID indicates an expected call of GetUserByUUID.
+func (mr *MockrepositoryMockRecorder) GetUserByUUID(ctx, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserByUUID", reflect.TypeOf((*Mockrepository)(nil).GetUserByUUID), ctx, arg1)
+}
+
+// GetUserDataRequest mocks base method.
+func (m *Mockrepository) GetUserDataRequest(ctx context.Context, userUUID, requestUUID string) (*models.DataRequest, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUserDataRequest", ctx, userUUID, requestUUID)
+	ret0, _ := ret[0].(*models.DataRequest)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUserDataRequest indicates an expected call of GetUserDataRequest.
+func (mr *MockrepositoryMockRecorder) GetUserDataRequest(ctx, userUUID, requestUUID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserDataRequest", reflect.TypeOf((*Mockrepository)(nil).GetUserDataRequest), ctx, userUUID, requestUUID)
+}
+
+// GetUserIdentity mocks base method.
+func (m *Mockrepository) GetUserIdentity(ctx context.Context, provider, subject string) (*models.UserIdentity, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUserIdentity", ctx, provider, subject)
+	ret0, _ := ret[0].(*models.UserIdentity)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUserIdentity indicates an expected call of GetUserIdentity.
+func (mr *MockrepositoryMockRecorder) GetUserIdentity(ctx, provider, subject any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserIdentity", reflect.TypeOf((*Mockrepository)(nil).GetUserIdentity), ctx, provider, subject)
+}
+
+// GetUserMFA mocks base method.
+func (m *Mockrepository) GetUserMFA(ctx context.Context, userID int) (*models.UserMFA, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUserMFA", ctx, userID)
+	ret0, _ := ret[0].(*models.UserMFA)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUserMFA indicates an expected call of GetUserMFA.
+func (mr *MockrepositoryMockRecorder) GetUserMFA(ctx, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserMFA", reflect.TypeOf((*Mockrepository)(nil).GetUserMFA), ctx, userID)
+}
+
+// GrantOrganizationRole mocks base method.
+func (m *Mockrepository) GrantOrganizationRole(ctx context.Context, memberRole *models.OrganizationMemberRole) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GrantOrganizationRole", ctx, memberRole)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// GrantOrganizationRole indicates an expected call of GrantOrganizationRole.
+func (mr *MockrepositoryMockRecorder) GrantOrganizationRole(ctx, memberRole any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GrantOrganizationRole", reflect.TypeOf((*Mockrepository)(nil).GrantOrganizationRole), ctx, memberRole)
+}
+
+// GrantRoleToUser mocks base method.
+func (m *Mockrepository) GrantRoleToUser(ctx context.Context, userRole *models.UserRole) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GrantRoleToUser", ctx, userRole)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// GrantRoleToUser indicates an expected call of GrantRoleToUser.
+func (mr *MockrepositoryMockRecorder) GrantRoleToUser(ctx, userRole any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GrantRoleToUser", reflect.TypeOf((*Mockrepository)(nil).GrantRoleToUser), ctx, userRole)
+}
+
+// InvalidateUserCache mocks base method.
+func (m *Mockrepository) InvalidateUserCache(ctx context.Context, users ...*models.User) {
+	m.ctrl.T.Helper()
+	varargs := []any{ctx}
+	for _, a := range users {
+		varargs = append(varargs, a)
+	}
+	m.ctrl.Call(m, "InvalidateUserCache", varargs...)
+}
+
+// InvalidateUserCache indicates an expected call of InvalidateUserCache.
+func (mr *MockrepositoryMockRecorder) InvalidateUserCache(ctx any, users ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{ctx}, users...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InvalidateUserCache", reflect.TypeOf((*Mockrepository)(nil).InvalidateUserCache), varargs...)
+}
+
+// ListActiveSessions mocks base method.
+func (m *Mockrepository) ListActiveSessions(ctx context.Context, userID int) ([]*models.Session, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListActiveSessions", ctx, userID)
+	ret0, _ := ret[0].([]*models.Session)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListActiveSessions indicates an expected call of ListActiveSessions.
+func (mr *MockrepositoryMockRecorder) ListActiveSessions(ctx, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListActiveSessions", reflect.TypeOf((*Mockrepository)(nil).ListActiveSessions), ctx, userID)
+}
+
+// ListJWTSecrets mocks base method.
+func (m *Mockrepository) ListJWTSecrets(ctx context.Context, limit int) ([]*models.JWTSecret, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListJWTSecrets", ctx, limit)
+	ret0, _ := ret[0].([]*models.JWTSecret)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListJWTSecrets indicates an expected call of ListJWTSecrets.
+func (mr *MockrepositoryMockRecorder) ListJWTSecrets(ctx, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListJWTSecrets", reflect.TypeOf((*Mockrepository)(nil).ListJWTSecrets), ctx, limit)
+}
+
+// ListOAuthClients mocks base method.
+func (m *Mockrepository) ListOAuthClients(ctx context.Context) ([]*models.OAuthClient, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListOAuthClients", ctx)
+	ret0, _ := ret[0].([]*models.OAuthClient)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListOAuthClients indicates an expected call of ListOAuthClients.
+func (mr *MockrepositoryMockRecorder) ListOAuthClients(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListOAuthClients", reflect.TypeOf((*Mockrepository)(nil).ListOAuthClients), ctx)
+}
+
+// ListOrganizationMembers mocks base method.
+func (m *Mockrepository) ListOrganizationMembers(ctx context.Context, organizationID int) ([]*models.OrganizationMember, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListOrganizationMembers", ctx, organizationID)
+	ret0, _ := ret[0].([]*models.OrganizationMember)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListOrganizationMembers indicates an expected call of ListOrganizationMembers.
+func (mr *MockrepositoryMockRecorder) ListOrganizationMembers(ctx, organizationID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListOrganizationMembers", reflect.TypeOf((*Mockrepository)(nil).ListOrganizationMembers), ctx, organizationID)
+}
+
+// ListOrganizations mocks base method.
+func (m *Mockrepository) ListOrganizations(ctx context.Context) ([]*models.Organization, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListOrganizations", ctx)
+	ret0, _ := ret[0].([]*models.Organization)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
 }
 
-// AssignRoleToUser indicates an expected call of AssignRoleToUser.
-func (mr *MockrepositoryMockRecorder) AssignRoleToUser(ctx, userID, role any) *gomock.Call {
+// ListOrganizations indicates an expected call of ListOrganizations.
+func (mr *MockrepositoryMockRecorder) ListOrganizations(ctx any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AssignRoleToUser", reflect.TypeOf((*Mockrepository)(nil).AssignRoleToUser), ctx, userID, role)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListOrganizations", reflect.TypeOf((*Mockrepository)(nil).ListOrganizations), ctx)
 }
 
-// CreateUser mocks base method.
-func (m *Mockrepository) CreateUser(ctx context.Context, user *models.User) error {
+// ListPasswordHistory mocks base method.
+func (m *Mockrepository) ListPasswordHistory(ctx context.Context, userID, limit int) ([]*models.PasswordHistory, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "CreateUser", ctx, user)
-	ret0, _ := ret[0].(error)
-	return ret0
+	ret := m.ctrl.Call(m, "ListPasswordHistory", ctx, userID, limit)
+	ret0, _ := ret[0].([]*models.PasswordHistory)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
 }
 
-// CreateUser indicates an expected call of CreateUser.
-func (mr *MockrepositoryMockRecorder) CreateUser(ctx, user any) *gomock.Call {
+// ListPasswordHistory indicates an expected call of ListPasswordHistory.
+func (mr *MockrepositoryMockRecorder) ListPasswordHistory(ctx, userID, limit any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateUser", reflect.TypeOf((*Mockrepository)(nil).CreateUser), ctx, user)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListPasswordHistory", reflect.TypeOf((*Mockrepository)(nil).ListPasswordHistory), ctx, userID, limit)
 }
 
-// DeleteUser mocks base method.
-func (m *Mockrepository) DeleteUser(ctx context.Context, user *models.User) error {
+// ListRoleUsers mocks base method.
+func (m *Mockrepository) ListRoleUsers(ctx context.Context, roleID int) ([]*models.User, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "DeleteUser", ctx, user)
-	ret0, _ := ret[0].(error)
-	return ret0
+	ret := m.ctrl.Call(m, "ListRoleUsers", ctx, roleID)
+	ret0, _ := ret[0].([]*models.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
 }
 
-// DeleteUser indicates an expected call of DeleteUser.
-func (mr *MockrepositoryMockRecorder) DeleteUser(ctx, user any) *gomock.Call {
+// ListRoleUsers indicates an expected call of ListRoleUsers.
+func (mr *MockrepositoryMockRecorder) ListRoleUsers(ctx, roleID any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteUser", reflect.TypeOf((*Mockrepository)(nil).DeleteUser), ctx, user)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListRoleUsers", reflect.TypeOf((*Mockrepository)(nil).ListRoleUsers), ctx, roleID)
 }
 
-// GetToken mocks base method.
-func (m *Mockrepository) GetToken(ctx context.Context, hashedToken string) (*models.Token, error) {
+// ListRoles mocks base method.
+func (m *Mockrepository) ListRoles(ctx context.Context) ([]*models.Role, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetToken", ctx, hashedToken)
-	ret0, _ := ret[0].(*models.Token)
+	ret := m.ctrl.Call(m, "ListRoles", ctx)
+	ret0, _ := ret[0].([]*models.Role)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// GetToken indicates an expected call of GetToken.
-func (mr *MockrepositoryMockRecorder) GetToken(ctx, hashedToken any) *gomock.Call {
+// ListRoles indicates an expected call of ListRoles.
+func (mr *MockrepositoryMockRecorder) ListRoles(ctx any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetToken", reflect.TypeOf((*Mockrepository)(nil).GetToken), ctx, hashedToken)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListRoles", reflect.TypeOf((*Mockrepository)(nil).ListRoles), ctx)
 }
 
-// GetUserByEmail mocks base method.
-func (m *Mockrepository) GetUserByEmail(ctx context.Context, email string) (*models.User, error) {
+// ListTokens mocks base method.
+func (m *Mockrepository) ListTokens(ctx context.Context, userID int) ([]*models.Token, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetUserByEmail", ctx, email)
-	ret0, _ := ret[0].(*models.User)
+	ret := m.ctrl.Call(m, "ListTokens", ctx, userID)
+	ret0, _ := ret[0].([]*models.Token)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// GetUserByEmail indicates an expected call of GetUserByEmail.
-func (mr *MockrepositoryMockRecorder) GetUserByEmail(ctx, email any) *gomock.Call {
+// ListTokens indicates an expected call of ListTokens.
+func (mr *MockrepositoryMockRecorder) ListTokens(ctx, userID any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserByEmail", reflect.TypeOf((*Mockrepository)(nil).GetUserByEmail), ctx, email)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListTokens", reflect.TypeOf((*Mockrepository)(nil).ListTokens), ctx, userID)
 }
 
-// GetUserByID mocks base method.
-func (m *Mockrepository) GetUserByID(ctx context.Context, id int) (*models.User, error) {
+// ListUserHistoryRecords mocks base method.
+func (m *Mockrepository) ListUserHistoryRecords(ctx context.Context, userID int) ([]*models.UserHistoryRecord, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetUserByID", ctx, id)
-	ret0, _ := ret[0].(*models.User)
+	ret := m.ctrl.Call(m, "ListUserHistoryRecords", ctx, userID)
+	ret0, _ := ret[0].([]*models.UserHistoryRecord)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// GetUserByID indicates an expected call of GetUserByID.
-func (mr *MockrepositoryMockRecorder) GetUserByID(ctx, id any) *gomock.Call {
+// ListUserHistoryRecords indicates an expected call of ListUserHistoryRecords.
+func (mr *MockrepositoryMockRecorder) ListUserHistoryRecords(ctx, userID any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserByID", reflect.TypeOf((*Mockrepository)(nil).GetUserByID), ctx, id)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListUserHistoryRecords", reflect.TypeOf((*Mockrepository)(nil).ListUserHistoryRecords), ctx, userID)
 }
 
-// GetUserByUUID mocks base method.
-func (m *Mockrepository) GetUserByUUID(ctx context.Context, uuid string) (*models.User, error) {
+// ListUserOrganizations mocks base method.
+func (m *Mockrepository) ListUserOrganizations(ctx context.Context, userID int) ([]*models.Organization, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetUserByUUID", ctx, uuid)
-	ret0, _ := ret[0].(*models.User)
+	ret := m.ctrl.Call(m, "ListUserOrganizations", ctx, userID)
+	ret0, _ := ret[0].([]*models.Organization)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// GetUserByUUID indicates an expected call of GetUserByUUID.
-func (mr *MockrepositoryMockRecorder) GetUserByUUID(ctx, uuid any) *gomock.Call {
+// ListUserOrganizations indicates an expected call of ListUserOrganizations.
+func (mr *MockrepositoryMockRecorder) ListUserOrganizations(ctx, userID any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserByUUID", reflect.TypeOf((*Mockrepository)(nil).GetUserByUUID), ctx, uuid)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListUserOrganizations", reflect.TypeOf((*Mockrepository)(nil).ListUserOrganizations), ctx, userID)
 }
 
 // ListUsers mocks base method.
-func (m *Mockrepository) ListUsers(ctx context.Context, limit, offset int) ([]*models.User, error) {
+func (m *Mockrepository) ListUsers(ctx context.Context, query *domain.ListUsersQuery, after *domain.UserCursor) ([]*models.User, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "ListUsers", ctx, limit, offset)
+	ret := m.ctrl.Call(m, "ListUsers", ctx, query, after)
 	ret0, _ := ret[0].([]*models.User)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // ListUsers indicates an expected call of ListUsers.
-func (mr *MockrepositoryMockRecorder) ListUsers(ctx, limit, offset any) *gomock.Call {
+func (mr *MockrepositoryMockRecorder) ListUsers(ctx, query, after any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListUsers", reflect.TypeOf((*Mockrepository)(nil).ListUsers), ctx, query, after)
+}
+
+// ListUsersWithStatusUntil mocks base method.
+func (m *Mockrepository) ListUsersWithStatusUntil(ctx context.Context, status string, until time.Time, limit int) ([]*models.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListUsersWithStatusUntil", ctx, status, until, limit)
+	ret0, _ := ret[0].([]*models.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListUsersWithStatusUntil indicates an expected call of ListUsersWithStatusUntil.
+func (mr *MockrepositoryMockRecorder) ListUsersWithStatusUntil(ctx, status, until, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListUsersWithStatusUntil", reflect.TypeOf((*Mockrepository)(nil).ListUsersWithStatusUntil), ctx, status, until, limit)
+}
+
+// PurgeUserHistoryData mocks base method.
+func (m *Mockrepository) PurgeUserHistoryData(ctx context.Context, userID int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PurgeUserHistoryData", ctx, userID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// PurgeUserHistoryData indicates an expected call of PurgeUserHistoryData.
+func (mr *MockrepositoryMockRecorder) PurgeUserHistoryData(ctx, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PurgeUserHistoryData", reflect.TypeOf((*Mockrepository)(nil).PurgeUserHistoryData), ctx, userID)
+}
+
+// RemoveOrganizationMember mocks base method.
+func (m *Mockrepository) RemoveOrganizationMember(ctx context.Context, organizationID, userID int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RemoveOrganizationMember", ctx, organizationID, userID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RemoveOrganizationMember indicates an expected call of RemoveOrganizationMember.
+func (mr *MockrepositoryMockRecorder) RemoveOrganizationMember(ctx, organizationID, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemoveOrganizationMember", reflect.TypeOf((*Mockrepository)(nil).RemoveOrganizationMember), ctx, organizationID, userID)
+}
+
+// ReplaceMFARecoveryCodes mocks base method.
+func (m *Mockrepository) ReplaceMFARecoveryCodes(ctx context.Context, userID int, codes []*models.MFARecoveryCode) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReplaceMFARecoveryCodes", ctx, userID, codes)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ReplaceMFARecoveryCodes indicates an expected call of ReplaceMFARecoveryCodes.
+func (mr *MockrepositoryMockRecorder) ReplaceMFARecoveryCodes(ctx, userID, codes any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReplaceMFARecoveryCodes", reflect.TypeOf((*Mockrepository)(nil).ReplaceMFARecoveryCodes), ctx, userID, codes)
+}
+
+// RevokeOrganizationRole mocks base method.
+func (m *Mockrepository) RevokeOrganizationRole(ctx context.Context, organizationID, userID, roleID int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RevokeOrganizationRole", ctx, organizationID, userID, roleID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RevokeOrganizationRole indicates an expected call of RevokeOrganizationRole.
+func (mr *MockrepositoryMockRecorder) RevokeOrganizationRole(ctx, organizationID, userID, roleID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RevokeOrganizationRole", reflect.TypeOf((*Mockrepository)(nil).RevokeOrganizationRole), ctx, organizationID, userID, roleID)
+}
+
+// RevokeRefreshTokenFamily mocks base method.
+func (m *Mockrepository) RevokeRefreshTokenFamily(ctx context.Context, familyID uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RevokeRefreshTokenFamily", ctx, familyID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RevokeRefreshTokenFamily indicates an expected call of RevokeRefreshTokenFamily.
+func (mr *MockrepositoryMockRecorder) RevokeRefreshTokenFamily(ctx, familyID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RevokeRefreshTokenFamily", reflect.TypeOf((*Mockrepository)(nil).RevokeRefreshTokenFamily), ctx, familyID)
+}
+
+// RevokeRoleFromUser mocks base method.
+func (m *Mockrepository) RevokeRoleFromUser(ctx context.Context, userID, roleID int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RevokeRoleFromUser", ctx, userID, roleID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RevokeRoleFromUser indicates an expected call of RevokeRoleFromUser.
+func (mr *MockrepositoryMockRecorder) RevokeRoleFromUser(ctx, userID, roleID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RevokeRoleFromUser", reflect.TypeOf((*Mockrepository)(nil).RevokeRoleFromUser), ctx, userID, roleID)
+}
+
+// RevokeSession mocks base method.
+func (m *Mockrepository) RevokeSession(ctx context.Context, session *models.Session) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RevokeSession", ctx, session)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RevokeSession indicates an expected call of RevokeSession.
+func (mr *MockrepositoryMockRecorder) RevokeSession(ctx, session any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RevokeSession", reflect.TypeOf((*Mockrepository)(nil).RevokeSession), ctx, session)
+}
+
+// SaveUserMFA mocks base method.
+func (m *Mockrepository) SaveUserMFA(ctx context.Context, mfa *models.UserMFA) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SaveUserMFA", ctx, mfa)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SaveUserMFA indicates an expected call of SaveUserMFA.
+func (mr *MockrepositoryMockRecorder) SaveUserMFA(ctx, mfa any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SaveUserMFA", reflect.TypeOf((*Mockrepository)(nil).SaveUserMFA), ctx, mfa)
+}
+
+// UpdateSession mocks base method.
+func (m *Mockrepository) UpdateSession(ctx context.Context, session *models.Session) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateSession", ctx, session)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateSession indicates an expected call of UpdateSession.
+func (mr *MockrepositoryMockRecorder) UpdateSession(ctx, session any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListUsers", reflect.TypeOf((*Mockrepository)(nil).ListUsers), ctx, limit, offset)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateSession", reflect.TypeOf((*Mockrepository)(nil).UpdateSession), ctx, session)
 }
 
 // UpdateUser mocks base method.
@@ -174,6 +1204,48 @@ func (mr *MockrepositoryMockRecorder) UpdateUser(ctx, user any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateUser", reflect.TypeOf((*Mockrepository)(nil).UpdateUser), ctx, user)
 }
 
+// UpdateUserIdentity mocks base method.
+func (m *Mockrepository) UpdateUserIdentity(ctx context.Context, identity *models.UserIdentity) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateUserIdentity", ctx, identity)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateUserIdentity indicates an expected call of UpdateUserIdentity.
+func (mr *MockrepositoryMockRecorder) UpdateUserIdentity(ctx, identity any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateUserIdentity", reflect.TypeOf((*Mockrepository)(nil).UpdateUserIdentity), ctx, identity)
+}
+
+// UpdateUserStatus mocks base method.
+func (m *Mockrepository) UpdateUserStatus(ctx context.Context, user *models.User) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateUserStatus", ctx, user)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateUserStatus indicates an expected call of UpdateUserStatus.
+func (mr *MockrepositoryMockRecorder) UpdateUserStatus(ctx, user any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateUserStatus", reflect.TypeOf((*Mockrepository)(nil).UpdateUserStatus), ctx, user)
+}
+
+// UseMFARecoveryCode mocks base method.
+func (m *Mockrepository) UseMFARecoveryCode(ctx context.Context, userID int, codeHash string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UseMFARecoveryCode", ctx, userID, codeHash)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UseMFARecoveryCode indicates an expected call of UseMFARecoveryCode.
+func (mr *MockrepositoryMockRecorder) UseMFARecoveryCode(ctx, userID, codeHash any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UseMFARecoveryCode", reflect.TypeOf((*Mockrepository)(nil).UseMFARecoveryCode), ctx, userID, codeHash)
+}
+
 // WithTransaction mocks base method.
 func (m *Mockrepository) WithTransaction(ctx context.Context, fn func(context.Context) error) error {
 	m.ctrl.T.Helper()
@@ -227,6 +1299,20 @@ func (mr *MockcacheMockRecorder) Get(ctx, key any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*Mockcache)(nil).Get), ctx, key)
 }
 
+// Invalidate mocks base method.
+func (m *Mockcache) Invalidate(ctx context.Context, key string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Invalidate", ctx, key)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Invalidate indicates an expected call of Invalidate.
+func (mr *MockcacheMockRecorder) Invalidate(ctx, key any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Invalidate", reflect.TypeOf((*Mockcache)(nil).Invalidate), ctx, key)
+}
+
 // Set mocks base method.
 func (m *Mockcache) Set(ctx context.Context, key, value string, ttl time.Duration) error {
 	m.ctrl.T.Helper()
@@ -266,7 +1352,7 @@ func (m *MockoutboxService) EXPECT() *MockoutboxServiceMockRecorder {
 }
 
 // NewOutboxMessage mocks base method.
-func (m *MockoutboxService) NewOutboxMessage(ctx context.Context, topic string, msg *domain.Message) error {
+func (m *MockoutboxService) NewOutboxMessage(ctx context.Context, topic string, msg *domain0.Message) error {
 	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "NewOutboxMessage", ctx, topic, msg)
 	ret0, _ := ret[0].(error)
@@ -278,3 +1364,172 @@ func (mr *MockoutboxServiceMockRecorder) NewOutboxMessage(ctx, topic, msg any) *
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "NewOutboxMessage", reflect.TypeOf((*MockoutboxService)(nil).NewOutboxMessage), ctx, topic, msg)
 }
+
+// MockmailSender is a mock of mailSender interface.
+type MockmailSender struct {
+	ctrl     *gomock.Controller
+	recorder *MockmailSenderMockRecorder
+	isgomock struct{}
+}
+
+// MockmailSenderMockRecorder is the mock recorder for MockmailSender.
+type MockmailSenderMockRecorder struct {
+	mock *MockmailSender
+}
+
+// NewMockmailSender creates a new mock instance.
+func NewMockmailSender(ctrl *gomock.Controller) *MockmailSender {
+	mock := &MockmailSender{ctrl: ctrl}
+	mock.recorder = &MockmailSenderMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockmailSender) EXPECT() *MockmailSenderMockRecorder {
+	return m.recorder
+}
+
+// Send mocks base method.
+func (m *MockmailSender) Send(ctx context.Context, msg mailer.Message) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Send", ctx, msg)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Send indicates an expected call of Send.
+func (mr *MockmailSenderMockRecorder) Send(ctx, msg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Send", reflect.TypeOf((*MockmailSender)(nil).Send), ctx, msg)
+}
+
+// MockpasswordHasher is a mock of passwordHasher interface.
+type MockpasswordHasher struct {
+	ctrl     *gomock.Controller
+	recorder *MockpasswordHasherMockRecorder
+	isgomock struct{}
+}
+
+// MockpasswordHasherMockRecorder is the mock recorder for MockpasswordHasher.
+type MockpasswordHasherMockRecorder struct {
+	mock *MockpasswordHasher
+}
+
+// NewMockpasswordHasher creates a new mock instance.
+func NewMockpasswordHasher(ctrl *gomock.Controller) *MockpasswordHasher {
+	mock := &MockpasswordHasher{ctrl: ctrl}
+	mock.recorder = &MockpasswordHasherMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockpasswordHasher) EXPECT() *MockpasswordHasherMockRecorder {
+	return m.recorder
+}
+
+// Hash mocks base method.
+func (m *MockpasswordHasher) Hash(password string) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Hash", password)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Hash indicates an expected call of Hash.
+func (mr *MockpasswordHasherMockRecorder) Hash(password any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Hash", reflect.TypeOf((*MockpasswordHasher)(nil).Hash), password)
+}
+
+// Verify mocks base method.
+func (m *MockpasswordHasher) Verify(hash, password string) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Verify", hash, password)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Verify indicates an expected call of Verify.
+func (mr *MockpasswordHasherMockRecorder) Verify(hash, password any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Verify", reflect.TypeOf((*MockpasswordHasher)(nil).Verify), hash, password)
+}
+
+// MockpasswordPolicy is a mock of passwordPolicy interface.
+type MockpasswordPolicy struct {
+	ctrl     *gomock.Controller
+	recorder *MockpasswordPolicyMockRecorder
+	isgomock struct{}
+}
+
+// MockpasswordPolicyMockRecorder is the mock recorder for MockpasswordPolicy.
+type MockpasswordPolicyMockRecorder struct {
+	mock *MockpasswordPolicy
+}
+
+// NewMockpasswordPolicy creates a new mock instance.
+func NewMockpasswordPolicy(ctrl *gomock.Controller) *MockpasswordPolicy {
+	mock := &MockpasswordPolicy{ctrl: ctrl}
+	mock.recorder = &MockpasswordPolicyMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockpasswordPolicy) EXPECT() *MockpasswordPolicyMockRecorder {
+	return m.recorder
+}
+
+// Check mocks base method.
+func (m *MockpasswordPolicy) Check(ctx context.Context, password string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Check", ctx, password)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Check indicates an expected call of Check.
+func (mr *MockpasswordPolicyMockRecorder) Check(ctx, password any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Check", reflect.TypeOf((*MockpasswordPolicy)(nil).Check), ctx, password)
+}
+
+// MockaccessPolicy is a mock of accessPolicy interface.
+type MockaccessPolicy struct {
+	ctrl     *gomock.Controller
+	recorder *MockaccessPolicyMockRecorder
+	isgomock struct{}
+}
+
+// MockaccessPolicyMockRecorder is the mock recorder for MockaccessPolicy.
+type MockaccessPolicyMockRecorder struct {
+	mock *MockaccessPolicy
+}
+
+// NewMockaccessPolicy creates a new mock instance.
+func NewMockaccessPolicy(ctrl *gomock.Controller) *MockaccessPolicy {
+	mock := &MockaccessPolicy{ctrl: ctrl}
+	mock.recorder = &MockaccessPolicyMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockaccessPolicy) EXPECT() *MockaccessPolicyMockRecorder {
+	return m.recorder
+}
+
+// Evaluate mocks base method.
+func (m *MockaccessPolicy) Evaluate(req *domain.AccessRequest) (*domain.AccessDecision, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Evaluate", req)
+	ret0, _ := ret[0].(*domain.AccessDecision)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Evaluate indicates an expected call of Evaluate.
+func (mr *MockaccessPolicyMockRecorder) Evaluate(req any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Evaluate", reflect.TypeOf((*MockaccessPolicy)(nil).Evaluate), req)
+}
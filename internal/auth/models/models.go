@@ -7,40 +7,55 @@ import (
 	"time"
 
 	"github.com/google/uuid"
-	"golang.org/x/crypto/bcrypt"
 	"gorm.io/gorm"
 
 	"github.com/hasansino/go42/internal/auth/domain"
 )
 
 type User struct {
-	ID        int
-	UUID      uuid.UUID
-	Email     string
-	Password  sql.Null[string]
-	Status    string
-	IsSystem  bool
-	Metadata  json.RawMessage
-	CreatedAt time.Time
-	UpdatedAt time.Time
-	DeletedAt gorm.DeletedAt
+	ID              int
+	UUID            uuid.UUID
+	Email           string
+	EmailVerifiedAt sql.Null[time.Time]
+	Password        sql.Null[string]
+	Status          string
+	StatusReason    sql.Null[string]
+	StatusUntil     sql.Null[time.Time]
+	// status user had before deletion was scheduled, it is put back when user is restored
+	PreviousStatus       sql.Null[string]
+	PreviousStatusReason sql.Null[string]
+	PreviousStatusUntil  sql.Null[time.Time]
+	IsSystem             bool
+	Metadata             json.RawMessage
+	CreatedAt            time.Time
+	UpdatedAt            time.Time
+	DeletedAt            gorm.DeletedAt
 
 	Roles []Role `gorm:"-"`
 }
 
 func (*User) TableName() string { return "auth_users" }
 
-func (u *User) SetPassword(password string) (err error) {
-	hash, err := bcrypt.GenerateFromPassword([]byte(password), bcrypt.DefaultCost)
-	if err != nil {
-		return err
+// IsActive reports whether user can authenticate.
+// Suspension with end time is over once that time has passed, even before status is updated.
+func (u *User) IsActive() bool {
+	switch u.Status {
+	case domain.UserStatusActive:
+		return true
+	case domain.UserStatusSuspended:
+		return u.StatusUntil.Valid && !u.StatusUntil.V.After(time.Now())
 	}
-	u.Password = sql.Null[string]{V: string(hash), Valid: true}
-	return
+	return false
 }
 
-func (u *User) IsActive() bool {
-	return u.Status == domain.UserStatusActive
+// IsActiveOrPending reports whether user can complete email verification or password reset,
+// which are the only ways for pending users to become active.
+func (u *User) IsActiveOrPending() bool {
+	return u.IsActive() || u.Status == domain.UserStatusPending
+}
+
+func (u *User) IsEmailVerified() bool {
+	return u.EmailVerifiedAt.Valid
 }
 
 func (u *User) RoleList() []string {
@@ -63,6 +78,37 @@ func (u *User) PermissionList() []string {
 	return permissions
 }
 
+// UserMFA is a TOTP second factor of the user.
+// Factor is pending until EnabledAt is set by confirming enrollment.
+// LastUsedStep is the time step of last accepted code, codes of
+// the same or earlier steps are rejected to prevent replay.
+type UserMFA struct {
+	UserID       int `gorm:"primaryKey;autoIncrement:false"`
+	Secret       string
+	EnabledAt    sql.Null[time.Time]
+	LastUsedStep int64
+	CreatedAt    time.Time
+	UpdatedAt    time.Time
+}
+
+func (*UserMFA) TableName() string { return "auth_user_mfa" }
+
+func (m *UserMFA) IsEnabled() bool {
+	return m.EnabledAt.Valid
+}
+
+// MFARecoveryCode is a single-use code which substitutes TOTP code.
+// Only hash of the code is stored.
+type MFARecoveryCode struct {
+	ID        int
+	UserID    int
+	CodeHash  string
+	UsedAt    sql.Null[time.Time]
+	CreatedAt time.Time
+}
+
+func (*MFARecoveryCode) TableName() string { return "auth_user_mfa_recovery_codes" }
+
 type UserHistoryRecord struct {
 	ID         uuid.UUID
 	OccurredAt time.Time
@@ -89,6 +135,14 @@ type Role struct {
 
 func (Role) TableName() string { return "auth_roles" }
 
+func (r *Role) PermissionList() []string {
+	permissions := make([]string, len(r.Permissions))
+	for i, permission := range r.Permissions {
+		permissions[i] = permission.Resource + ":" + permission.Action
+	}
+	return permissions
+}
+
 type Permission struct {
 	ID        int
 	Resource  string
@@ -98,6 +152,13 @@ type Permission struct {
 
 func (Permission) TableName() string { return "auth_permissions" }
 
+type RolePermission struct {
+	RoleID       int
+	PermissionID int
+}
+
+func (RolePermission) TableName() string { return "auth_role_permissions" }
+
 type UserRole struct {
 	UserID    int
 	RoleID    int
@@ -133,3 +194,219 @@ func (a *Token) PermissionList() []string {
 	}
 	return permissions
 }
+
+type TokenPermission struct {
+	TokenID      int
+	PermissionID int
+}
+
+func (TokenPermission) TableName() string { return "auth_api_tokens_permissions" }
+
+// JWTSecret is a signing secret shared between all replicas.
+// Generation is unique and guarantees that each rotation
+// is performed by exactly one instance.
+// Secret is accepted for validation as soon as it is created,
+// but tokens are signed with it only after ActiveFrom.
+type JWTSecret struct {
+	ID         int
+	KID        string `gorm:"column:kid"`
+	Secret     string
+	Generation int64
+	ActiveFrom time.Time
+	CreatedAt  time.Time
+}
+
+func (*JWTSecret) TableName() string { return "auth_jwt_secrets" }
+
+// RefreshToken tracks issued refresh tokens, tokens issued by
+// subsequent refreshes of one login share the same family.
+type RefreshToken struct {
+	ID         int
+	JTI        uuid.UUID `gorm:"column:jti"`
+	FamilyID   uuid.UUID
+	UserID     int
+	ExpiresAt  time.Time
+	ConsumedAt sql.Null[time.Time]
+	RevokedAt  sql.Null[time.Time]
+	CreatedAt  time.Time
+}
+
+func (*RefreshToken) TableName() string { return "auth_refresh_tokens" }
+
+// Session is a single login of the user, linked to refresh token family
+// and to the jti of latest issued access and refresh tokens.
+// OrganizationID is organization session is scoped to, tokens of the session carry it as tenant.
+// OAuthClientID is public id of oauth client which started the session, empty for first-party logins.
+// Scope is granted to the client by the user, it limits tokens of the session.
+type Session struct {
+	ID              int
+	UUID            uuid.UUID
+	UserID          int
+	OrganizationID  sql.Null[int]
+	OAuthClientID   string `gorm:"column:oauth_client_id"`
+	Scope           string
+	FamilyID        uuid.UUID
+	AccessTokenJTI  uuid.UUID `gorm:"column:access_token_jti"`
+	RefreshTokenJTI uuid.UUID `gorm:"column:refresh_token_jti"`
+	UserAgent       string
+	IPAddress       string
+	CreatedAt       time.Time
+	LastSeenAt      time.Time
+	ExpiresAt       time.Time
+	RevokedAt       sql.Null[time.Time]
+}
+
+func (*Session) TableName() string { return "auth_sessions" }
+
+func (s *Session) IsActive() bool {
+	return !s.RevokedAt.Valid && s.ExpiresAt.After(time.Now())
+}
+
+// OAuthClient is a client application registered with authorization server.
+// Client acts on behalf of its owner when using client credentials grant.
+type OAuthClient struct {
+	ID           int
+	ClientID     string
+	SecretHash   string
+	Name         string
+	RedirectURIs []string `gorm:"serializer:json"`
+	GrantTypes   []string `gorm:"serializer:json"`
+	Permissions  []string `gorm:"serializer:json"`
+	UserID       int
+	CreatedAt    time.Time
+	UpdatedAt    time.Time
+}
+
+func (*OAuthClient) TableName() string { return "auth_oauth_clients" }
+
+// IsPublic reports whether client is unable to keep its secret, such as browser or mobile application.
+func (c *OAuthClient) IsPublic() bool {
+	return c.SecretHash == ""
+}
+
+func (c *OAuthClient) HasGrantType(grantType string) bool {
+	return slices.Contains(c.GrantTypes, grantType)
+}
+
+// HasRedirectURI reports whether uri is registered, uris are compared by exact match.
+func (c *OAuthClient) HasRedirectURI(uri string) bool {
+	return slices.Contains(c.RedirectURIs, uri)
+}
+
+// OAuthCode is authorization code issued by authorization code flow.
+// Only hash of the code is stored.
+type OAuthCode struct {
+	ID            int
+	CodeHash      string
+	ClientID      int
+	UserID        int
+	RedirectURI   string
+	Scope         string
+	Nonce         string
+	CodeChallenge string
+	ExpiresAt     time.Time
+	UsedAt        sql.Null[time.Time]
+	CreatedAt     time.Time
+}
+
+func (*OAuthCode) TableName() string { return "auth_oauth_codes" }
+
+// UserIdentity links user to account of external identity provider.
+// Subject is identifier of the account assigned by provider.
+type UserIdentity struct {
+	ID          int
+	UserID      int
+	Provider    string
+	Subject     string
+	Email       string
+	CreatedAt   time.Time
+	LastLoginAt time.Time
+}
+
+func (*UserIdentity) TableName() string { return "auth_user_identities" }
+
+// PasswordHistory is hash of password user had before, used to prevent reuse.
+type PasswordHistory struct {
+	ID        int
+	UserID    int
+	Password  string
+	CreatedAt time.Time
+}
+
+func (*PasswordHistory) TableName() string { return "auth_password_history" }
+
+// Organization is a tenant, users take part in it through membership.
+type Organization struct {
+	ID        int
+	UUID      uuid.UUID
+	Name      string
+	Slug      string
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+func (*Organization) TableName() string { return "auth_organizations" }
+
+// OrganizationMember is membership of user in organization.
+// Roles are assigned within organization and are effective only
+// for requests scoped to it, in addition to global roles of the user.
+type OrganizationMember struct {
+	OrganizationID int
+	UserID         int
+	CreatedAt      time.Time
+
+	Organization *Organization `gorm:"-"`
+	User         *User         `gorm:"-"`
+	Roles        []Role        `gorm:"-"`
+}
+
+func (*OrganizationMember) TableName() string { return "auth_organization_members" }
+
+func (m *OrganizationMember) RoleList() []string {
+	roles := make([]string, len(m.Roles))
+	for i, role := range m.Roles {
+		roles[i] = role.Name
+	}
+	return roles
+}
+
+func (m *OrganizationMember) PermissionList() []string {
+	permissions := make([]string, 0)
+	for _, role := range m.Roles {
+		for _, permission := range role.PermissionList() {
+			if !slices.Contains(permissions, permission) {
+				permissions = append(permissions, permission)
+			}
+		}
+	}
+	return permissions
+}
+
+type OrganizationMemberRole struct {
+	OrganizationID int
+	UserID         int
+	RoleID         int
+	CreatedAt      time.Time
+	ExpiresAt      sql.Null[time.Time]
+}
+
+func (OrganizationMemberRole) TableName() string { return "auth_organization_member_roles" }
+
+// DataRequest is subject access request, see domain.DataRequestTypes.
+// Archive is loaded only when requested explicitly.
+type DataRequest struct {
+	ID          int
+	UUID        uuid.UUID
+	UserID      int
+	RequestedBy sql.Null[int]
+	Type        string
+	Status      string
+	Error       sql.Null[string]
+	Archive     []byte
+	ExpiresAt   sql.Null[time.Time]
+	CompletedAt sql.Null[time.Time]
+	CreatedAt   time.Time
+	UpdatedAt   time.Time
+}
+
+func (*DataRequest) TableName() string { return "auth_data_requests" }
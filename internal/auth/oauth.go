@@ -0,0 +1,684 @@
+package auth
+
+import (
+	"context"
+	"crypto/sha256"
+	"crypto/subtle"
+	"database/sql"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"net/url"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/google/uuid"
+
+	"github.com/hasansino/go42/internal/auth/domain"
+	"github.com/hasansino/go42/internal/auth/models"
+	"github.com/hasansino/go42/internal/metrics"
+	outboxDomain "github.com/hasansino/go42/internal/outbox/domain"
+)
+
+const (
+	oauthTokenTypeBearer       = "Bearer"
+	oauthResponseTypeCode      = "code"
+	oauthCodeChallengeS256     = "S256"
+	oauthMinCodeChallengeLen   = 43
+	oauthMaxCodeChallengeLen   = 128
+	oauthMaxNonceLength        = 255
+	oauthMaxScopeLength        = 255
+	oauthClientTokenNamePrefix = "oauth:"
+
+	// authorization codes should be short-lived, RFC 6749 section 4.1.2 recommends 10 minutes at most
+	defaultOAuthCodeTTL = time.Minute
+)
+
+// OAuthPolicy configures authorization server.
+// IssuerURL is public base url of the service, it is used as issuer of id tokens
+// and to build endpoint urls of discovery document.
+// LoginURL is a page which authenticates user and asks for consent,
+// authorization request parameters are passed to it unchanged.
+type OAuthPolicy struct {
+	IssuerURL string
+	LoginURL  string
+	CodeTTL   time.Duration
+}
+
+func (s *Service) ListOAuthClients(ctx context.Context) ([]*models.OAuthClient, error) {
+	return s.repository.ListOAuthClients(ctx)
+}
+
+// CreateOAuthClient registers new client.
+// Plaintext secret of confidential client is returned only once, only its hash is persisted.
+// Client is owned by the caller, and, when request itself is authenticated
+// by api token or oauth client token, its permissions must be held by that token as well.
+func (s *Service) CreateOAuthClient(
+	ctx context.Context, data *domain.CreateOAuthClientData,
+) (*models.OAuthClient, string, error) {
+	if err := requireNoTenant(ctx); err != nil {
+		return nil, "", err
+	}
+
+	authInfo := RetrieveAuthFromContext(ctx)
+	if authInfo != nil && authInfo.UserUUID != data.OwnerUUID {
+		return nil, "", fmt.Errorf("%w: client must be owned by the caller", domain.ErrPermissionDenied)
+	}
+
+	owner, err := s.repository.GetUserByUUID(ctx, data.OwnerUUID)
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to get user: %w", err)
+	}
+
+	if len(data.GrantTypes) == 0 {
+		return nil, "", fmt.Errorf("%w: grant types are required", domain.ErrOAuthInvalidRequest)
+	}
+	for _, grantType := range data.GrantTypes {
+		if !slices.Contains(domain.OAuthGrantTypes, grantType) {
+			return nil, "", fmt.Errorf("%w: %s", domain.ErrOAuthUnsupportedGrantType, grantType)
+		}
+	}
+	if slices.Contains(data.GrantTypes, domain.OAuthGrantTypeAuthorizationCode) && len(data.RedirectURIs) == 0 {
+		return nil, "", fmt.Errorf("%w: redirect uris are required", domain.ErrOAuthInvalidRequest)
+	}
+	if slices.Contains(data.GrantTypes, domain.OAuthGrantTypeRefreshToken) &&
+		!slices.Contains(data.GrantTypes, domain.OAuthGrantTypeAuthorizationCode) {
+		return nil, "", fmt.Errorf(
+			"%w: refresh token grant requires authorization code grant", domain.ErrOAuthInvalidRequest)
+	}
+	if slices.Contains(data.GrantTypes, domain.OAuthGrantTypeClientCredentials) && data.Public {
+		return nil, "", fmt.Errorf(
+			"%w: public client can not use client credentials grant", domain.ErrOAuthInvalidRequest)
+	}
+	for _, uri := range data.RedirectURIs {
+		if err := validateRedirectURI(uri); err != nil {
+			return nil, "", err
+		}
+	}
+
+	ownerPermissions := owner.PermissionList()
+	for _, permission := range data.Permissions {
+		if !slices.Contains(ownerPermissions, permission) {
+			return nil, "", fmt.Errorf("%w: %s", domain.ErrPermissionDenied, permission)
+		}
+		if authInfo != nil && authInfo.Type != domain.AuthenticationTypeCredentials &&
+			!authInfo.HasPermission(permission) {
+			return nil, "", fmt.Errorf("%w: %s", domain.ErrPermissionDenied, permission)
+		}
+	}
+
+	client := &models.OAuthClient{
+		ClientID:     uuid.New().String(),
+		Name:         data.Name,
+		RedirectURIs: data.RedirectURIs,
+		GrantTypes:   data.GrantTypes,
+		Permissions:  data.Permissions,
+		UserID:       owner.ID,
+	}
+	if client.RedirectURIs == nil {
+		client.RedirectURIs = make([]string, 0)
+	}
+	if client.Permissions == nil {
+		client.Permissions = make([]string, 0)
+	}
+
+	var secret string
+	if !data.Public {
+		secret, err = generateAPIToken()
+		if err != nil {
+			return nil, "", fmt.Errorf("failed to generate client secret: %w", err)
+		}
+		client.SecretHash = strToSHA256(secret)
+	}
+
+	err = s.repository.WithTransaction(ctx, func(txCtx context.Context) error {
+		if err := s.repository.CreateOAuthClient(txCtx, client); err != nil {
+			return err
+		}
+		return s.sendOAuthClientEvent(txCtx, client, domain.EventTypeOAuthClientCreate)
+	})
+	if err != nil {
+		return nil, "", err
+	}
+
+	metrics.Counter("auth_oauth_clients_created_total", nil).Inc()
+
+	return client, secret, nil
+}
+
+func (s *Service) DeleteOAuthClient(ctx context.Context, clientID string) error {
+	if err := requireNoTenant(ctx); err != nil {
+		return err
+	}
+
+	client, err := s.repository.GetOAuthClient(ctx, clientID)
+	if err != nil {
+		return fmt.Errorf("failed to get oauth client: %w", err)
+	}
+	return s.repository.WithTransaction(ctx, func(txCtx context.Context) error {
+		if err := s.repository.DeleteOAuthClient(txCtx, client); err != nil {
+			return err
+		}
+		return s.sendOAuthClientEvent(txCtx, client, domain.EventTypeOAuthClientDelete)
+	})
+}
+
+// ValidateAuthorizeRequest checks client and redirect uri of authorization request.
+// When redirect uri is omitted, the only registered one is used.
+// Returned errors must be shown to the user instead of redirecting to client,
+// see RFC 6749 section 4.1.2.1.
+func (s *Service) ValidateAuthorizeRequest(
+	ctx context.Context, req *domain.AuthorizeRequest,
+) (*models.OAuthClient, error) {
+	client, err := s.repository.GetOAuthClient(ctx, req.ClientID)
+	if errors.Is(err, domain.ErrEntityNotFound) {
+		return nil, domain.ErrOAuthInvalidClient
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get oauth client: %w", err)
+	}
+	if req.RedirectURI == "" && len(client.RedirectURIs) == 1 {
+		req.RedirectURI = client.RedirectURIs[0]
+	}
+	if !client.HasRedirectURI(req.RedirectURI) {
+		return nil, domain.ErrOAuthInvalidRedirectURI
+	}
+	return client, nil
+}
+
+// AuthorizationPageURL returns uri user agent should be redirected to in order to
+// authenticate and approve authorization request. When login page is not configured,
+// user agent is sent back to client with login_required error (OIDC Core section 3.1.2.6).
+func (s *Service) AuthorizationPageURL(
+	ctx context.Context, req *domain.AuthorizeRequest, params url.Values,
+) (string, error) {
+	if _, err := s.ValidateAuthorizeRequest(ctx, req); err != nil {
+		return "", err
+	}
+	if s.oauthPolicy.LoginURL == "" {
+		errParams := url.Values{}
+		errParams.Set("error", "login_required")
+		errParams.Set("error_description", "interactive login is not available")
+		if req.State != "" {
+			errParams.Set("state", req.State)
+		}
+		return appendQuery(req.RedirectURI, errParams)
+	}
+	return appendQuery(s.oauthPolicy.LoginURL, params)
+}
+
+// Authorize issues authorization code for the user and returns uri user agent
+// should be redirected to. Errors which can be safely reported to client
+// are returned as part of redirect uri.
+func (s *Service) Authorize(ctx context.Context, userUUID string, req *domain.AuthorizeRequest) (string, error) {
+	client, err := s.ValidateAuthorizeRequest(ctx, req)
+	if err != nil {
+		return "", err
+	}
+
+	redirectErr := func(code string, description string) (string, error) {
+		metrics.Counter("auth_oauth_authorizations_total", map[string]interface{}{
+			"result": code,
+		}).Inc()
+		params := url.Values{}
+		params.Set("error", code)
+		params.Set("error_description", description)
+		if req.State != "" {
+			params.Set("state", req.State)
+		}
+		return appendQuery(req.RedirectURI, params)
+	}
+
+	if req.ResponseType != oauthResponseTypeCode {
+		return redirectErr("unsupported_response_type", "only code response type is supported")
+	}
+	if !client.HasGrantType(domain.OAuthGrantTypeAuthorizationCode) {
+		return redirectErr("unauthorized_client", "client is not allowed to use authorization code grant")
+	}
+	if req.CodeChallenge == "" && client.IsPublic() {
+		return redirectErr("invalid_request", "code challenge is required")
+	}
+	if req.CodeChallenge != "" {
+		if req.CodeChallengeMethod != oauthCodeChallengeS256 {
+			return redirectErr("invalid_request", "only S256 code challenge method is supported")
+		}
+		if len(req.CodeChallenge) < oauthMinCodeChallengeLen || len(req.CodeChallenge) > oauthMaxCodeChallengeLen {
+			return redirectErr("invalid_request", "invalid code challenge")
+		}
+	}
+	if len(req.Nonce) > oauthMaxNonceLength {
+		return redirectErr("invalid_request", "nonce is too long")
+	}
+	for _, scope := range strings.Fields(req.Scope) {
+		if !slices.Contains(domain.OAuthScopes, scope) && !slices.Contains(client.Permissions, scope) {
+			return redirectErr("invalid_scope", "unsupported scope: "+scope)
+		}
+	}
+	if len(strings.Join(strings.Fields(req.Scope), " ")) > oauthMaxScopeLength {
+		return redirectErr("invalid_scope", "scope is too long")
+	}
+
+	user, err := s.repository.GetUserByUUID(ctx, userUUID)
+	if err != nil {
+		return "", fmt.Errorf("failed to get user: %w", err)
+	}
+	if !user.IsActive() {
+		return redirectErr("access_denied", "user is not active")
+	}
+
+	plainCode, err := generateAPIToken()
+	if err != nil {
+		return "", fmt.Errorf("failed to generate code: %w", err)
+	}
+
+	code := &models.OAuthCode{
+		CodeHash:      strToSHA256(plainCode),
+		ClientID:      client.ID,
+		UserID:        user.ID,
+		RedirectURI:   req.RedirectURI,
+		Scope:         strings.Join(strings.Fields(req.Scope), " "),
+		Nonce:         req.Nonce,
+		CodeChallenge: req.CodeChallenge,
+		ExpiresAt:     time.Now().Add(s.oauthPolicy.CodeTTL),
+	}
+
+	err = s.repository.WithTransaction(ctx, func(txCtx context.Context) error {
+		if err := s.repository.DeleteExpiredOAuthCodes(txCtx, user.ID); err != nil {
+			return err
+		}
+		return s.repository.CreateOAuthCode(txCtx, code)
+	})
+	if err != nil {
+		return "", err
+	}
+
+	metrics.Counter("auth_oauth_authorizations_total", map[string]interface{}{
+		"result": "success",
+	}).Inc()
+
+	params := url.Values{}
+	params.Set("code", plainCode)
+	if req.State != "" {
+		params.Set("state", req.State)
+	}
+	return appendQuery(req.RedirectURI, params)
+}
+
+// ExchangeOAuthToken handles token endpoint requests.
+// Authorization code grant starts new session, same as login does,
+// client credentials grant issues short-lived api token owned by client owner.
+func (s *Service) ExchangeOAuthToken(
+	ctx context.Context, req *domain.OAuthTokenRequest, clientInfo domain.ClientInfo,
+) (*domain.OAuthTokens, error) {
+	startTime := time.Now()
+	defer func() {
+		metrics.Histogram("auth_operation_duration_seconds", map[string]interface{}{
+			"operation": "oauth_token",
+		}).Update(time.Since(startTime).Seconds())
+	}()
+
+	if !slices.Contains(domain.OAuthGrantTypes, req.GrantType) {
+		return nil, domain.ErrOAuthUnsupportedGrantType
+	}
+
+	client, err := s.authenticateOAuthClient(ctx, req.ClientID, req.ClientSecret)
+	if err != nil {
+		return nil, err
+	}
+	if !client.HasGrantType(req.GrantType) {
+		return nil, domain.ErrOAuthUnauthorizedClient
+	}
+
+	var tokens *domain.OAuthTokens
+	switch req.GrantType {
+	case domain.OAuthGrantTypeAuthorizationCode:
+		tokens, err = s.exchangeOAuthCode(ctx, client, req, clientInfo)
+	case domain.OAuthGrantTypeRefreshToken:
+		tokens, err = s.refreshOAuthToken(ctx, client, req)
+	case domain.OAuthGrantTypeClientCredentials:
+		tokens, err = s.issueClientCredentialsToken(ctx, client, req)
+	}
+
+	result := "success"
+	if err != nil {
+		result = "error"
+	}
+	metrics.Counter("auth_oauth_tokens_issued_total", map[string]interface{}{
+		"grant_type": req.GrantType,
+		"result":     result,
+	}).Inc()
+
+	return tokens, err
+}
+
+// OpenIDConfiguration returns OIDC discovery document.
+func (s *Service) OpenIDConfiguration() *domain.OpenIDConfiguration {
+	issuer := strings.TrimSuffix(s.oauthPolicy.IssuerURL, "/")
+
+	s.jwtSecretsMu.RLock()
+	algorithms := make([]string, 0, len(s.jwtSecrets))
+	for _, secret := range s.jwtSecrets {
+		if !slices.Contains(algorithms, secret.method.Alg()) {
+			algorithms = append(algorithms, secret.method.Alg())
+		}
+	}
+	s.jwtSecretsMu.RUnlock()
+
+	return &domain.OpenIDConfiguration{
+		Issuer:                            issuer,
+		AuthorizationEndpoint:             issuer + "/api/v1/oauth/authorize",
+		TokenEndpoint:                     issuer + "/api/v1/oauth/token",
+		UserInfoEndpoint:                  issuer + "/api/v1/oauth/userinfo",
+		JWKSURI:                           issuer + "/.well-known/jwks.json",
+		ScopesSupported:                   domain.OAuthScopes,
+		ResponseTypesSupported:            []string{oauthResponseTypeCode},
+		GrantTypesSupported:               domain.OAuthGrantTypes,
+		SubjectTypesSupported:             []string{"public"},
+		IDTokenSigningAlgValuesSupported:  algorithms,
+		TokenEndpointAuthMethodsSupported: []string{"client_secret_basic", "client_secret_post", "none"},
+		CodeChallengeMethodsSupported:     []string{oauthCodeChallengeS256},
+		ClaimsSupported:                   []string{"iss", "sub", "aud", "exp", "iat", "nonce", "email", "email_verified"},
+	}
+}
+
+// oauthGrant limits access tokens issued to oauth client.
+type oauthGrant struct {
+	clientID    string
+	permissions []string
+}
+
+// sessionGrant returns grant of oauth client which started the session, nil for first-party sessions.
+// Client permissions are checked on every issue, so that revoked ones are not granted anymore.
+func (s *Service) sessionGrant(ctx context.Context, session *models.Session) (*oauthGrant, error) {
+	if session.OAuthClientID == "" {
+		return nil, nil
+	}
+	client, err := s.repository.GetOAuthClient(ctx, session.OAuthClientID)
+	if errors.Is(err, domain.ErrEntityNotFound) {
+		return nil, domain.ErrInvalidToken
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get oauth client: %w", err)
+	}
+	return &oauthGrant{
+		clientID:    client.ClientID,
+		permissions: oauthScopePermissions(session.Scope, client),
+	}, nil
+}
+
+// oauthScopePermissions returns permissions granted by scope, openid scope allows to read userinfo.
+func oauthScopePermissions(scope string, client *models.OAuthClient) []string {
+	permissions := make([]string, 0)
+	for _, s := range strings.Fields(scope) {
+		permission := s
+		if s == domain.OAuthScopeOpenID {
+			permission = domain.RBACPermissionUsersReadSelf
+		} else if !slices.Contains(client.Permissions, s) {
+			continue
+		}
+		if !slices.Contains(permissions, permission) {
+			permissions = append(permissions, permission)
+		}
+	}
+	return permissions
+}
+
+// oauthClientID returns public id of the client, empty for first-party sessions.
+func oauthClientID(client *models.OAuthClient) string {
+	if client == nil {
+		return ""
+	}
+	return client.ClientID
+}
+
+// authenticateOAuthClient authenticates confidential clients by secret,
+// public clients are identified by client id only.
+func (s *Service) authenticateOAuthClient(
+	ctx context.Context, clientID string, secret string,
+) (*models.OAuthClient, error) {
+	client, err := s.repository.GetOAuthClient(ctx, clientID)
+	if errors.Is(err, domain.ErrEntityNotFound) {
+		return nil, domain.ErrOAuthInvalidClient
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get oauth client: %w", err)
+	}
+	if client.IsPublic() {
+		if secret != "" {
+			return nil, domain.ErrOAuthInvalidClient
+		}
+		return client, nil
+	}
+	if subtle.ConstantTimeCompare([]byte(strToSHA256(secret)), []byte(client.SecretHash)) != 1 {
+		return nil, domain.ErrOAuthInvalidClient
+	}
+	return client, nil
+}
+
+func (s *Service) exchangeOAuthCode(
+	ctx context.Context, client *models.OAuthClient,
+	req *domain.OAuthTokenRequest, clientInfo domain.ClientInfo,
+) (*domain.OAuthTokens, error) {
+	// code is consumed before it is checked, so that it can not be retried
+	var code *models.OAuthCode
+	err := s.repository.WithTransaction(ctx, func(txCtx context.Context) error {
+		var err error
+		code, err = s.repository.ConsumeOAuthCode(txCtx, strToSHA256(req.Code))
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if code.ClientID != client.ID || code.RedirectURI != req.RedirectURI {
+		return nil, domain.ErrOAuthInvalidGrant
+	}
+	if !verifyCodeChallenge(code.CodeChallenge, req.CodeVerifier) {
+		return nil, domain.ErrOAuthInvalidGrant
+	}
+
+	user, err := s.repository.GetUserByID(ctx, code.UserID)
+	if err != nil || !user.IsActive() {
+		return nil, domain.ErrOAuthInvalidGrant
+	}
+
+	// session is bound to the client, its refresh token is not accepted from anyone else
+	session := newSession(user, clientInfo)
+	session.OAuthClientID = client.ClientID
+	session.Scope = code.Scope
+	tokens, err := s.startSession(ctx, user, session)
+	if err != nil {
+		return nil, err
+	}
+
+	response := &domain.OAuthTokens{
+		AccessToken: tokens.AccessToken,
+		TokenType:   oauthTokenTypeBearer,
+		ExpiresIn:   tokens.ExpiresIn,
+		Scope:       code.Scope,
+	}
+	if client.HasGrantType(domain.OAuthGrantTypeRefreshToken) {
+		response.RefreshToken = tokens.RefreshToken
+	}
+	if slices.Contains(strings.Fields(code.Scope), domain.OAuthScopeOpenID) {
+		response.IDToken, err = s.generateIDToken(user, client.ClientID, code.Nonce, code.Scope)
+		if err != nil {
+			return nil, fmt.Errorf("failed to generate id token: %w", err)
+		}
+	}
+
+	return response, nil
+}
+
+// refreshOAuthToken refreshes session started by the same client, RFC 6749 section 6.
+func (s *Service) refreshOAuthToken(
+	ctx context.Context, client *models.OAuthClient, req *domain.OAuthTokenRequest,
+) (*domain.OAuthTokens, error) {
+	tokens, err := s.refresh(ctx, req.RefreshToken, nil, client)
+	if errors.Is(err, domain.ErrInvalidToken) || errors.Is(err, domain.ErrRefreshTokenReused) {
+		return nil, domain.ErrOAuthInvalidGrant
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &domain.OAuthTokens{
+		AccessToken:  tokens.AccessToken,
+		TokenType:    oauthTokenTypeBearer,
+		ExpiresIn:    tokens.ExpiresIn,
+		RefreshToken: tokens.RefreshToken,
+	}, nil
+}
+
+// issueClientCredentialsToken issues api token limited to requested permissions,
+// which must be granted to the client and still be held by its owner.
+func (s *Service) issueClientCredentialsToken(
+	ctx context.Context, client *models.OAuthClient, req *domain.OAuthTokenRequest,
+) (*domain.OAuthTokens, error) {
+	owner, err := s.repository.GetUserByID(ctx, client.UserID)
+	if err != nil || !owner.IsActive() {
+		return nil, domain.ErrOAuthUnauthorizedClient
+	}
+
+	requested := strings.Fields(req.Scope)
+	if len(requested) == 0 {
+		requested = client.Permissions
+	}
+	ownerPermissions := owner.PermissionList()
+	for _, permission := range requested {
+		if !slices.Contains(client.Permissions, permission) || !slices.Contains(ownerPermissions, permission) {
+			return nil, domain.ErrOAuthInvalidScope
+		}
+	}
+
+	permissions, err := s.repository.GetPermissions(ctx, requested)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get permissions: %w", err)
+	}
+
+	plainToken, err := generateAPIToken()
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate api token: %w", err)
+	}
+
+	apiToken := &models.Token{
+		UUID:        uuid.New(),
+		UserID:      owner.ID,
+		Token:       strToSHA256(plainToken),
+		Name:        oauthClientTokenNamePrefix + client.ClientID,
+		Permissions: permissions,
+		ExpiresAt:   sql.Null[time.Time]{V: time.Now().Add(s.accessTokenTTL), Valid: true},
+	}
+
+	err = s.repository.WithTransaction(ctx, func(txCtx context.Context) error {
+		if err := s.repository.DeleteExpiredTokens(txCtx, owner.ID, apiToken.Name); err != nil {
+			return err
+		}
+		return s.repository.CreateToken(txCtx, apiToken)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &domain.OAuthTokens{
+		AccessToken: plainToken,
+		TokenType:   oauthTokenTypeBearer,
+		ExpiresIn:   int(s.accessTokenTTL.Seconds()),
+		Scope:       strings.Join(requested, " "),
+	}, nil
+}
+
+func (s *Service) generateIDToken(user *models.User, clientID string, nonce string, scope string) (string, error) {
+	key, err := s.signingJWTSecret()
+	if err != nil {
+		return "", err
+	}
+	claims := domain.IDTokenClaims{
+		RegisteredClaims: jwt.RegisteredClaims{
+			Issuer:    strings.TrimSuffix(s.oauthPolicy.IssuerURL, "/"),
+			Subject:   user.UUID.String(),
+			Audience:  jwt.ClaimStrings{clientID},
+			IssuedAt:  jwt.NewNumericDate(time.Now()),
+			ExpiresAt: jwt.NewNumericDate(time.Now().Add(s.accessTokenTTL)),
+		},
+		Nonce: nonce,
+	}
+	if slices.Contains(strings.Fields(scope), domain.OAuthScopeEmail) {
+		verified := user.IsEmailVerified()
+		claims.Email = user.Email
+		claims.EmailVerified = &verified
+	}
+	return signJWTToken(key, claims)
+}
+
+func (s *Service) sendOAuthClientEvent(ctx context.Context, client *models.OAuthClient, eventType string) error {
+	payload, err := json.Marshal(map[string]string{
+		"client_id": client.ClientID,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to marshal event payload: %w", err)
+	}
+	event := outboxDomain.Message{
+		AggregateID:   client.UserID,
+		AggregateType: eventType,
+		Payload:       payload,
+	}
+	if err := s.sendEvent(ctx, domain.TopicNameAuthEvents, event); err != nil {
+		return fmt.Errorf("failed to send event: %w", err)
+	}
+	return nil
+}
+
+// verifyCodeChallenge checks PKCE code verifier (RFC 7636 section 4.6).
+// Verifier must not be sent when authorization request had no challenge.
+func verifyCodeChallenge(challenge string, verifier string) bool {
+	if challenge == "" {
+		return verifier == ""
+	}
+	return subtle.ConstantTimeCompare([]byte(codeChallengeS256(verifier)), []byte(challenge)) == 1
+}
+
+func codeChallengeS256(verifier string) string {
+	sum := sha256.Sum256([]byte(verifier))
+	return base64.RawURLEncoding.EncodeToString(sum[:])
+}
+
+// validateRedirectURI requires absolute uri without fragment (RFC 6749 section 3.1.2).
+// Plain http is allowed only for loopback interface. Custom schemes of native applications
+// must be in reverse domain name form (RFC 8252 section 7.1), e.g. com.example.app,
+// which also rejects schemes like javascript, data or file.
+func validateRedirectURI(uri string) error {
+	u, err := url.Parse(uri)
+	if err != nil || u.Scheme == "" || u.Fragment != "" {
+		return fmt.Errorf("%w: %s", domain.ErrOAuthInvalidRedirectURI, uri)
+	}
+	if u.Scheme != "http" && u.Scheme != "https" && !strings.Contains(u.Scheme, ".") {
+		return fmt.Errorf("%w: %s", domain.ErrOAuthInvalidRedirectURI, uri)
+	}
+	if u.Scheme == "http" {
+		host := u.Hostname()
+		if ip := net.ParseIP(host); host != "localhost" && (ip == nil || !ip.IsLoopback()) {
+			return fmt.Errorf("%w: %s", domain.ErrOAuthInvalidRedirectURI, uri)
+		}
+	}
+	if (u.Scheme == "http" || u.Scheme == "https") && u.Host == "" {
+		return fmt.Errorf("%w: %s", domain.ErrOAuthInvalidRedirectURI, uri)
+	}
+	return nil
+}
+
+func appendQuery(uri string, params url.Values) (string, error) {
+	u, err := url.Parse(uri)
+	if err != nil {
+		return "", fmt.Errorf("failed to parse url: %w", err)
+	}
+	query := u.Query()
+	for key, values := range params {
+		query[key] = values
+	}
+	u.RawQuery = query.Encode()
+	return u.String(), nil
+}
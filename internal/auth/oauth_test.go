@@ -0,0 +1,311 @@
+package auth
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"go.uber.org/mock/gomock"
+
+	"github.com/hasansino/go42/internal/auth/domain"
+	"github.com/hasansino/go42/internal/auth/mocks"
+	"github.com/hasansino/go42/internal/auth/models"
+)
+
+// Test vector from RFC 7636 appendix B.
+func TestVerifyCodeChallenge(t *testing.T) {
+	verifier := "dBjftJeZ4CVP-mB92K27uhbUJU1p1r_wW1gFWFOEjXk"
+	challenge := "E9Melhoa2OwvFrEMTJguCHaoeK1t8URWbuGJSstw-cM"
+
+	if !verifyCodeChallenge(challenge, verifier) {
+		t.Error("valid code verifier rejected")
+	}
+	if verifyCodeChallenge(challenge, verifier+"x") {
+		t.Error("invalid code verifier accepted")
+	}
+	if verifyCodeChallenge(challenge, "") {
+		t.Error("missing code verifier accepted")
+	}
+	if !verifyCodeChallenge("", "") {
+		t.Error("request without challenge rejected")
+	}
+	if verifyCodeChallenge("", verifier) {
+		t.Error("code verifier accepted for request without challenge")
+	}
+}
+
+func TestValidateRedirectURI(t *testing.T) {
+	tests := []struct {
+		uri   string
+		valid bool
+	}{
+		{"https://example.com/callback", true},
+		{"https://example.com/callback?foo=bar", true},
+		{"http://localhost:3000/callback", true},
+		{"http://127.0.0.1/callback", true},
+		{"http://[::1]:8080/callback", true},
+		{"com.example.app:/callback", true},
+		{"http://example.com/callback", false},
+		{"https://example.com/callback#fragment", false},
+		{"/callback", false},
+		{"https:///callback", false},
+		{"", false},
+		{"javascript:alert(1)", false},
+		{"JavaScript:alert(1)", false},
+		{"data:text/html,<script>alert(1)</script>", false},
+		{"file:///etc/passwd", false},
+		{"vbscript:msgbox(1)", false},
+		{"myapp:/callback", false},
+	}
+	for _, tt := range tests {
+		err := validateRedirectURI(tt.uri)
+		if tt.valid && err != nil {
+			t.Errorf("validateRedirectURI(%q) = %v, want nil", tt.uri, err)
+		}
+		if !tt.valid && err == nil {
+			t.Errorf("validateRedirectURI(%q) = nil, want error", tt.uri)
+		}
+	}
+}
+
+func TestService_RefreshOAuthToken(t *testing.T) {
+	tests := []struct {
+		name    string
+		session string
+		client  *models.OAuthClient
+		wantErr error
+	}{
+		{
+			name:    "same client",
+			session: "client-a",
+			client:  &models.OAuthClient{ClientID: "client-a"},
+		},
+		{
+			name:    "another client",
+			session: "client-a",
+			client:  &models.OAuthClient{ClientID: "client-b"},
+			wantErr: domain.ErrOAuthInvalidGrant,
+		},
+		{
+			name:    "first-party session",
+			session: "",
+			client:  &models.OAuthClient{ClientID: "client-a"},
+			wantErr: domain.ErrOAuthInvalidGrant,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			repo := mocks.NewMockrepository(ctrl)
+			cache := mocks.NewMockcache(ctrl)
+			cache.EXPECT().Get(gomock.Any(), gomock.Any()).Return("", nil).AnyTimes()
+
+			s, err := NewService(
+				repo, nil, cache,
+				WithJWTSecrets([]string{"static"}),
+				WithJWTSecretsEncryptionKey("key"),
+				WithJWTAccessTokenTTL(time.Minute),
+				WithJWTRefreshTokenTTL(time.Hour),
+			)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			user := &models.User{ID: 1, UUID: uuid.New(), Status: domain.UserStatusActive}
+			token, err := s.generateRefreshToken(user.UUID.String(), "", uuid.NewString())
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			familyID := uuid.New()
+
+			repo.EXPECT().GetRefreshToken(gomock.Any(), gomock.Any()).
+				Return(&models.RefreshToken{ID: 1, FamilyID: familyID, UserID: user.ID}, nil)
+			repo.EXPECT().GetUserByUUID(gomock.Any(), user.UUID.String()).Return(user, nil)
+			repo.EXPECT().WithTransaction(gomock.Any(), gomock.Any()).DoAndReturn(
+				func(ctx context.Context, fn func(context.Context) error) error {
+					return fn(ctx)
+				},
+			)
+			repo.EXPECT().ConsumeRefreshToken(gomock.Any(), 1).Return(nil)
+			repo.EXPECT().GetSessionByFamilyID(gomock.Any(), familyID).Return(&models.Session{
+				ID:            1,
+				UserID:        user.ID,
+				FamilyID:      familyID,
+				OAuthClientID: tt.session,
+				Scope:         "openid users:update_self users:delete_self",
+			}, nil)
+			if tt.wantErr == nil {
+				// client is no longer allowed to delete users
+				repo.EXPECT().GetOAuthClient(gomock.Any(), tt.session).Return(&models.OAuthClient{
+					ClientID:    tt.session,
+					Permissions: []string{"users:update_self"},
+				}, nil)
+				repo.EXPECT().CreateRefreshToken(gomock.Any(), gomock.Any()).Return(nil)
+				repo.EXPECT().UpdateSession(gomock.Any(), gomock.Any()).Return(nil)
+			}
+
+			tokens, err := s.refreshOAuthToken(context.Background(), tt.client, &domain.OAuthTokenRequest{
+				RefreshToken: token,
+			})
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("error = %v, want %v", err, tt.wantErr)
+			}
+			if tt.wantErr != nil {
+				return
+			}
+			if tokens.RefreshToken == "" {
+				t.Error("refresh token is not rotated")
+			}
+			claims, err := s.ValidateJWTToken(context.Background(), tokens.AccessToken)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if claims.ClientID != tt.session {
+				t.Errorf("client id = %q, want %q", claims.ClientID, tt.session)
+			}
+			want := []string{"users:read_self", "users:update_self"}
+			if !slices.Equal(claims.Permissions, want) {
+				t.Errorf("permissions = %v, want %v", claims.Permissions, want)
+			}
+		})
+	}
+}
+
+func TestService_Refresh_OAuthSession(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	repo := mocks.NewMockrepository(ctrl)
+	cache := mocks.NewMockcache(ctrl)
+	cache.EXPECT().Get(gomock.Any(), gomock.Any()).Return("", nil).AnyTimes()
+
+	s, err := NewService(
+		repo, nil, cache,
+		WithJWTSecrets([]string{"static"}),
+		WithJWTSecretsEncryptionKey("key"),
+		WithJWTAccessTokenTTL(time.Minute),
+		WithJWTRefreshTokenTTL(time.Hour),
+	)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	user := &models.User{ID: 1, UUID: uuid.New(), Status: domain.UserStatusActive}
+	token, err := s.generateRefreshToken(user.UUID.String(), "", uuid.NewString())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	familyID := uuid.New()
+
+	repo.EXPECT().GetRefreshToken(gomock.Any(), gomock.Any()).
+		Return(&models.RefreshToken{ID: 1, FamilyID: familyID, UserID: user.ID}, nil)
+	repo.EXPECT().GetUserByUUID(gomock.Any(), user.UUID.String()).Return(user, nil)
+	repo.EXPECT().WithTransaction(gomock.Any(), gomock.Any()).DoAndReturn(
+		func(ctx context.Context, fn func(context.Context) error) error {
+			return fn(ctx)
+		},
+	)
+	repo.EXPECT().ConsumeRefreshToken(gomock.Any(), 1).Return(nil)
+	repo.EXPECT().GetSessionByFamilyID(gomock.Any(), familyID).Return(&models.Session{
+		ID:            1,
+		UserID:        user.ID,
+		FamilyID:      familyID,
+		OAuthClientID: "client-a",
+	}, nil)
+
+	// refresh token of oauth client is not accepted by first-party endpoint
+	if _, err := s.Refresh(context.Background(), token); !errors.Is(err, domain.ErrInvalidToken) {
+		t.Errorf("error = %v, want %v", err, domain.ErrInvalidToken)
+	}
+}
+
+func TestOAuthScopePermissions(t *testing.T) {
+	client := &models.OAuthClient{Permissions: []string{"users:update_self", "users:read_self"}}
+	tests := []struct {
+		name  string
+		scope string
+		want  []string
+	}{
+		{name: "empty scope", scope: "", want: []string{}},
+		{name: "claims only", scope: "email", want: []string{}},
+		{name: "openid", scope: "openid email", want: []string{"users:read_self"}},
+		{name: "permissions", scope: "users:update_self openid users:read_self", want: []string{
+			"users:update_self", "users:read_self",
+		}},
+		{name: "not granted to client", scope: "users:delete_self", want: []string{}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := oauthScopePermissions(tt.scope, client)
+			if !slices.Equal(got, tt.want) {
+				t.Errorf("permissions = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestService_CreateOAuthClient_Permissions(t *testing.T) {
+	owner := &models.User{ID: 1, Roles: []models.Role{{
+		Permissions: []models.Permission{
+			{Resource: "users", Action: "read_self"},
+			{Resource: "users", Action: "read_others"},
+		},
+	}}}
+
+	tests := []struct {
+		name     string
+		owner    string
+		authType domain.AuthenticationType
+		perms    []string
+		wantErr  error
+	}{
+		{"credentials grant owner permissions", "u1", domain.AuthenticationTypeCredentials, nil, nil},
+		{"other owner", "u2", domain.AuthenticationTypeCredentials, nil, domain.ErrPermissionDenied},
+		{"token grants held permission", "u1", domain.AuthenticationTypeApiToken, []string{"users:read_others"}, nil},
+		{"token grants permission it lacks", "u1", domain.AuthenticationTypeApiToken, nil, domain.ErrPermissionDenied},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			repo := mocks.NewMockrepository(ctrl)
+			outbox := mocks.NewMockoutboxService(ctrl)
+
+			s, err := NewService(
+				repo, outbox, mocks.NewMockcache(ctrl),
+				WithJWTSecrets([]string{"static"}),
+				WithJWTSecretsEncryptionKey("key"),
+			)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			authInfo := domain.ContextAuthInfo{UserID: 1, UserUUID: "u1", Type: tt.authType}
+			authInfo.SetPermissions(append([]string{domain.RBACPermissionOAuthClientsCreate}, tt.perms...))
+			ctx := SetAuthToContext(context.Background(), authInfo)
+
+			if tt.owner == "u1" {
+				repo.EXPECT().GetUserByUUID(gomock.Any(), "u1").Return(owner, nil)
+			}
+			if tt.wantErr == nil {
+				repo.EXPECT().WithTransaction(gomock.Any(), gomock.Any()).DoAndReturn(
+					func(ctx context.Context, fn func(txCtx context.Context) error) error {
+						return fn(ctx)
+					},
+				)
+				repo.EXPECT().CreateOAuthClient(gomock.Any(), gomock.Any()).Return(nil)
+				outbox.EXPECT().NewOutboxMessage(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil)
+			}
+
+			_, _, err = s.CreateOAuthClient(ctx, &domain.CreateOAuthClientData{
+				OwnerUUID:   tt.owner,
+				Name:        "client",
+				GrantTypes:  []string{domain.OAuthGrantTypeClientCredentials},
+				Permissions: []string{"users:read_others"},
+			})
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("err = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+}
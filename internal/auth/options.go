@@ -1,6 +1,7 @@
 package auth
 
 import (
+	"fmt"
 	"log/slog"
 	"time"
 )
@@ -13,20 +14,32 @@ func WithLogger(logger *slog.Logger) Option {
 	}
 }
 
+// WithJWTSecrets sets static secrets, invalid secret fails service creation.
 func WithJWTSecrets(secret []string) Option {
 	return func(s *Service) {
-		for _, secret := range secret {
-			s.jwtSecrets = append(
-				s.jwtSecrets,
-				jwtSecret{
-					sha256: strToSHA256(secret),
-					secret: secret,
-				},
-			)
+		for i, secret := range secret {
+			key, err := parseJWTSecret(secret)
+			if err != nil {
+				s.optionErrors = append(s.optionErrors, fmt.Errorf("failed to parse jwt secret #%d: %w", i, err))
+				continue
+			}
+			s.jwtSecrets = append(s.jwtSecrets, key)
 		}
 	}
 }
 
+// WithJWTSecretsEncryptionKey sets key which encrypts rotated secrets stored in database.
+func WithJWTSecretsEncryptionKey(key string) Option {
+	return func(s *Service) {
+		aead, err := newJWTSecretCipher(key)
+		if err != nil {
+			s.optionErrors = append(s.optionErrors, err)
+			return
+		}
+		s.jwtSecretsCipher = aead
+	}
+}
+
 func WithJWTAccessTokenTTL(ttl time.Duration) Option {
 	return func(s *Service) {
 		s.accessTokenTTL = ttl
@@ -56,3 +69,113 @@ func WithMinPasswordEntropyBits(bits int) Option {
 		s.minPasswordEntropyBits = bits
 	}
 }
+
+// WithPasswordPolicy sets policy new passwords are checked against,
+// it replaces default policy built from minimal entropy.
+func WithPasswordPolicy(policy passwordPolicy) Option {
+	return func(s *Service) {
+		s.passwordPolicy = policy
+	}
+}
+
+// WithPasswordHistorySize sets number of recent passwords user can not reuse, including current one.
+func WithPasswordHistorySize(size int) Option {
+	return func(s *Service) {
+		s.passwordHistorySize = size
+	}
+}
+
+// WithPasswordHasher sets hasher used to hash and verify user passwords.
+func WithPasswordHasher(h passwordHasher) Option {
+	return func(s *Service) {
+		s.hasher = h
+	}
+}
+
+func WithLockoutPolicy(policy LockoutPolicy) Option {
+	return func(s *Service) {
+		s.lockoutPolicy = policy
+	}
+}
+
+// WithMFAIssuer sets issuer shown by authenticator applications.
+func WithMFAIssuer(issuer string) Option {
+	return func(s *Service) {
+		s.mfaIssuer = issuer
+	}
+}
+
+func WithMFAChallengeTTL(ttl time.Duration) Option {
+	return func(s *Service) {
+		s.mfaChallengeTTL = ttl
+	}
+}
+
+// WithMailer sets mailer used to deliver verification and password reset mails.
+func WithMailer(m mailSender) Option {
+	return func(s *Service) {
+		s.mailer = m
+	}
+}
+
+func WithEmailPolicy(policy EmailPolicy) Option {
+	return func(s *Service) {
+		s.emailPolicy = policy
+	}
+}
+
+func WithOAuthPolicy(policy OAuthPolicy) Option {
+	return func(s *Service) {
+		s.oauthPolicy = policy
+	}
+}
+
+// WithSSOProvider registers external identity provider under given name.
+func WithSSOProvider(name string, provider SSOProvider) Option {
+	return func(s *Service) {
+		s.ssoProviders[name] = provider
+	}
+}
+
+func WithSSOStateTTL(ttl time.Duration) Option {
+	return func(s *Service) {
+		s.ssoStateTTL = ttl
+	}
+}
+
+// WithAccessPolicy sets policy deciding access to protected operations,
+// default policy only checks permissions of the subject.
+func WithAccessPolicy(policy accessPolicy) Option {
+	return func(s *Service) {
+		s.accessPolicy = policy
+	}
+}
+
+// WithImpersonationTTL sets lifetime of impersonation tokens.
+func WithImpersonationTTL(ttl time.Duration) Option {
+	return func(s *Service) {
+		s.impersonationTTL = ttl
+	}
+}
+
+// WithDeletionGracePeriod sets time during which user scheduled for deletion can be restored.
+func WithDeletionGracePeriod(period time.Duration) Option {
+	return func(s *Service) {
+		s.deletionGracePeriod = period
+	}
+}
+
+// WithDeletionMode sets how users are purged after deletion grace period,
+// see domain.UserDeletionModeAnonymize and domain.UserDeletionModeErase.
+func WithDeletionMode(mode string) Option {
+	return func(s *Service) {
+		s.deletionMode = mode
+	}
+}
+
+// WithDataArchiveTTL sets how long archives of data exports can be downloaded.
+func WithDataArchiveTTL(ttl time.Duration) Option {
+	return func(s *Service) {
+		s.dataArchiveTTL = ttl
+	}
+}
@@ -0,0 +1,279 @@
+package auth
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/hasansino/go42/internal/auth/domain"
+	"github.com/hasansino/go42/internal/auth/models"
+)
+
+func (s *Service) ListOrganizations(ctx context.Context) ([]*models.Organization, error) {
+	return s.repository.ListOrganizations(ctx)
+}
+
+// ListUserOrganizations lists organizations user can switch to.
+func (s *Service) ListUserOrganizations(ctx context.Context, userUUID string) ([]*models.Organization, error) {
+	user, err := s.repository.GetUserByUUID(ctx, userUUID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get user: %w", err)
+	}
+	return s.repository.ListUserOrganizations(ctx, user.ID)
+}
+
+// CreateOrganization creates tenant. Requests are scoped to tenant by tokens issued for it,
+// within tenant only its members and the organization itself are visible
+// and roles granted to members are effective in addition to global roles.
+func (s *Service) CreateOrganization(
+	ctx context.Context, data *domain.CreateOrganizationData,
+) (*models.Organization, error) {
+	if err := requireNoTenant(ctx); err != nil {
+		return nil, err
+	}
+	organization := &models.Organization{
+		UUID: uuid.New(),
+		Name: data.Name,
+		Slug: data.Slug,
+	}
+	err := s.repository.WithTransaction(ctx, func(txCtx context.Context) error {
+		if err := s.repository.CreateOrganization(txCtx, organization); err != nil {
+			return err
+		}
+		return s.sendAuthEvent(txCtx, domain.EventTypeOrganizationCreate, organization.ID, map[string]any{
+			"organization": organization.UUID.String(),
+			"slug":         organization.Slug,
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+	return organization, nil
+}
+
+// DeleteOrganization deletes organization with all memberships,
+// sessions scoped to it become unscoped on next refresh.
+func (s *Service) DeleteOrganization(ctx context.Context, organizationUUID string) error {
+	if err := requireNoTenant(ctx); err != nil {
+		return err
+	}
+	return s.repository.WithTransaction(ctx, func(txCtx context.Context) error {
+		organization, err := s.repository.GetOrganizationByUUID(txCtx, organizationUUID)
+		if err != nil {
+			return fmt.Errorf("failed to get organization: %w", err)
+		}
+		if err := s.repository.DeleteOrganization(txCtx, organization); err != nil {
+			return err
+		}
+		return s.sendAuthEvent(txCtx, domain.EventTypeOrganizationDelete, organization.ID, map[string]any{
+			"organization": organization.UUID.String(),
+		})
+	})
+}
+
+func (s *Service) ListOrganizationMembers(
+	ctx context.Context, organizationUUID string,
+) ([]*models.OrganizationMember, error) {
+	organization, err := s.repository.GetOrganizationByUUID(ctx, organizationUUID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get organization: %w", err)
+	}
+	return s.repository.ListOrganizationMembers(ctx, organization.ID)
+}
+
+// AddOrganizationMember makes user member of organization, adding existing member is not an error.
+// Within tenant users are looked up among members only, so new members can be added only without tenant.
+func (s *Service) AddOrganizationMember(ctx context.Context, organizationUUID string, userUUID string) error {
+	return s.changeMembership(
+		ctx, organizationUUID, userUUID, domain.EventTypeUserMembershipAdd,
+		func(txCtx context.Context, organization *models.Organization, user *models.User) error {
+			return s.repository.AddOrganizationMember(txCtx, &models.OrganizationMember{
+				OrganizationID: organization.ID,
+				UserID:         user.ID,
+			})
+		},
+	)
+}
+
+// RemoveOrganizationMember removes user from organization with all roles granted within it.
+// Tokens scoped to organization are rejected from now on.
+func (s *Service) RemoveOrganizationMember(ctx context.Context, organizationUUID string, userUUID string) error {
+	return s.changeMembership(
+		ctx, organizationUUID, userUUID, domain.EventTypeUserMembershipRemove,
+		func(txCtx context.Context, organization *models.Organization, user *models.User) error {
+			return s.repository.RemoveOrganizationMember(txCtx, organization.ID, user.ID)
+		},
+	)
+}
+
+func (s *Service) changeMembership(
+	ctx context.Context, organizationUUID string, userUUID string, eventType string,
+	change func(txCtx context.Context, organization *models.Organization, user *models.User) error,
+) error {
+	return s.repository.WithTransaction(ctx, func(txCtx context.Context) error {
+		organization, err := s.repository.GetOrganizationByUUID(txCtx, organizationUUID)
+		if err != nil {
+			return fmt.Errorf("failed to get organization: %w", err)
+		}
+		user, err := s.repository.GetUserByUUID(txCtx, userUUID)
+		if err != nil {
+			return fmt.Errorf("failed to get user: %w", err)
+		}
+		if err := change(txCtx, organization, user); err != nil {
+			return err
+		}
+		return s.sendAuthEvent(txCtx, eventType, user.ID, map[string]any{
+			"organization": organization.UUID.String(),
+		})
+	})
+}
+
+// GrantOrganizationRole assigns role to member, role is effective only within organization.
+// Granting already assigned role updates its expiration.
+func (s *Service) GrantOrganizationRole(
+	ctx context.Context, organizationUUID string, userUUID string, name string, expiresAt *time.Time,
+) error {
+	return s.repository.WithTransaction(ctx, func(txCtx context.Context) error {
+		member, role, err := s.getMemberRole(txCtx, organizationUUID, userUUID, name)
+		if err != nil {
+			return err
+		}
+		memberRole := &models.OrganizationMemberRole{
+			OrganizationID: member.OrganizationID,
+			UserID:         member.UserID,
+			RoleID:         role.ID,
+		}
+		payload := map[string]any{
+			"role":         role.Name,
+			"organization": organizationUUID,
+		}
+		if expiresAt != nil {
+			memberRole.ExpiresAt = sql.Null[time.Time]{V: *expiresAt, Valid: true}
+			payload["expires_at"] = expiresAt
+		}
+		if err := s.repository.GrantOrganizationRole(txCtx, memberRole); err != nil {
+			return err
+		}
+		return s.sendAuthEvent(txCtx, domain.EventTypeUserRoleGrant, member.UserID, payload)
+	})
+}
+
+func (s *Service) RevokeOrganizationRole(
+	ctx context.Context, organizationUUID string, userUUID string, name string,
+) error {
+	return s.repository.WithTransaction(ctx, func(txCtx context.Context) error {
+		member, role, err := s.getMemberRole(txCtx, organizationUUID, userUUID, name)
+		if err != nil {
+			return err
+		}
+		err = s.repository.RevokeOrganizationRole(txCtx, member.OrganizationID, member.UserID, role.ID)
+		if err != nil {
+			return err
+		}
+		return s.sendAuthEvent(txCtx, domain.EventTypeUserRoleRevoke, member.UserID, map[string]any{
+			"role":         role.Name,
+			"organization": organizationUUID,
+		})
+	})
+}
+
+func (s *Service) getMemberRole(
+	ctx context.Context, organizationUUID string, userUUID string, name string,
+) (*models.OrganizationMember, *models.Role, error) {
+	organization, err := s.repository.GetOrganizationByUUID(ctx, organizationUUID)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to get organization: %w", err)
+	}
+	user, err := s.repository.GetUserByUUID(ctx, userUUID)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to get user: %w", err)
+	}
+	member, err := s.repository.GetOrganizationMember(ctx, organization.ID, user.ID)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to get organization member: %w", err)
+	}
+	role, err := s.repository.GetRoleByName(ctx, name)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to get role: %w", err)
+	}
+	return member, role, nil
+}
+
+// GetOrganizationMember returns membership of user in organization together with organization.
+// Used to resolve tenant of incoming requests.
+func (s *Service) GetOrganizationMember(
+	ctx context.Context, organizationUUID string, userID int,
+) (*models.OrganizationMember, error) {
+	organization, err := s.repository.GetOrganizationByUUID(ctx, organizationUUID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get organization: %w", err)
+	}
+	member, err := s.repository.GetOrganizationMember(ctx, organization.ID, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get organization member: %w", err)
+	}
+	member.Organization = organization
+	return member, nil
+}
+
+// setSessionTenant scopes session to organization, empty organization removes scope.
+// Should be called within transaction.
+func (s *Service) setSessionTenant(
+	ctx context.Context, user *models.User, session *models.Session, organizationUUID string,
+) error {
+	if organizationUUID == "" {
+		session.OrganizationID = sql.Null[int]{}
+		return nil
+	}
+	member, err := s.GetOrganizationMember(ctx, organizationUUID, user.ID)
+	if errors.Is(err, domain.ErrEntityNotFound) {
+		return fmt.Errorf("%w: not a member of organization", domain.ErrPermissionDenied)
+	}
+	if err != nil {
+		return err
+	}
+	session.OrganizationID = sql.Null[int]{V: member.OrganizationID, Valid: true}
+	return nil
+}
+
+// sessionTenant returns uuid of organization session is scoped to.
+// Scope is dropped when user is no longer member of organization.
+func (s *Service) sessionTenant(ctx context.Context, user *models.User, session *models.Session) (string, error) {
+	if !session.OrganizationID.Valid {
+		return "", nil
+	}
+	organization, err := s.repository.GetOrganizationByID(ctx, session.OrganizationID.V)
+	if err == nil {
+		_, err = s.repository.GetOrganizationMember(ctx, organization.ID, user.ID)
+	}
+	if errors.Is(err, domain.ErrEntityNotFound) {
+		session.OrganizationID = sql.Null[int]{}
+		return "", nil
+	}
+	if err != nil {
+		return "", fmt.Errorf("failed to resolve session tenant: %w", err)
+	}
+	return organization.UUID.String(), nil
+}
+
+// requireNoTenant rejects request scoped to tenant. Roles, oauth clients and organizations
+// are global, so they are managed only with unscoped token.
+func requireNoTenant(ctx context.Context) error {
+	if _, _, ok := activeTenant(ctx); ok {
+		return fmt.Errorf("%w: not allowed within tenant", domain.ErrPermissionDenied)
+	}
+	return nil
+}
+
+// activeTenant returns organization of the request, if request is scoped to one.
+func activeTenant(ctx context.Context) (int, string, bool) {
+	authInfo := RetrieveAuthFromContext(ctx)
+	if authInfo == nil || authInfo.TenantID == 0 {
+		return 0, "", false
+	}
+	return authInfo.TenantID, authInfo.TenantUUID, true
+}
@@ -0,0 +1,76 @@
+package auth
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"go.uber.org/mock/gomock"
+
+	"github.com/hasansino/go42/internal/auth/domain"
+	"github.com/hasansino/go42/internal/auth/mocks"
+)
+
+func TestService_GlobalMutationsWithinTenant(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	// repository must not be touched at all
+	repo := mocks.NewMockrepository(ctrl)
+	cache := mocks.NewMockcache(ctrl)
+
+	s, err := NewService(
+		repo, nil, cache,
+		WithJWTSecrets([]string{"static"}),
+		WithJWTSecretsEncryptionKey("key"),
+	)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	ctx := SetAuthToContext(context.Background(), domain.ContextAuthInfo{
+		UserID:     1,
+		UserUUID:   "u1",
+		TenantID:   2,
+		TenantUUID: "o2",
+		Type:       domain.AuthenticationTypeCredentials,
+	})
+
+	tests := []struct {
+		name string
+		call func() error
+	}{
+		{"CreateRole", func() error {
+			_, err := s.CreateRole(ctx, &domain.CreateRoleData{Name: "role"})
+			return err
+		}},
+		{"DeleteRole", func() error {
+			return s.DeleteRole(ctx, "role")
+		}},
+		{"AttachPermissionToRole", func() error {
+			return s.AttachPermissionToRole(ctx, "role", domain.RBACPermissionUsersList)
+		}},
+		{"DetachPermissionFromRole", func() error {
+			return s.DetachPermissionFromRole(ctx, "role", domain.RBACPermissionUsersList)
+		}},
+		{"CreateOAuthClient", func() error {
+			_, _, err := s.CreateOAuthClient(ctx, &domain.CreateOAuthClientData{OwnerUUID: "u1"})
+			return err
+		}},
+		{"DeleteOAuthClient", func() error {
+			return s.DeleteOAuthClient(ctx, "client")
+		}},
+		{"CreateOrganization", func() error {
+			_, err := s.CreateOrganization(ctx, &domain.CreateOrganizationData{Name: "org", Slug: "org"})
+			return err
+		}},
+		{"DeleteOrganization", func() error {
+			return s.DeleteOrganization(ctx, "o2")
+		}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.call(); !errors.Is(err, domain.ErrPermissionDenied) {
+				t.Errorf("error = %v, want %v", err, domain.ErrPermissionDenied)
+			}
+		})
+	}
+}
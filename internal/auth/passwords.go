@@ -0,0 +1,146 @@
+package auth
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"log/slog"
+
+	"github.com/hasansino/go42/internal/auth/domain"
+	"github.com/hasansino/go42/internal/auth/models"
+	"github.com/hasansino/go42/internal/metrics"
+	"github.com/hasansino/go42/internal/password"
+)
+
+// same as length constraints of api
+const (
+	defaultPasswordMinLength = 8
+	defaultPasswordMaxLength = 24
+)
+
+// CheckPasswordStrength checks password against password policy.
+// Returned *domain.PasswordPolicyError describes violated rule.
+func (s *Service) CheckPasswordStrength(ctx context.Context, pswd string) error {
+	err := s.passwordPolicy.Check(ctx, pswd)
+	if err == nil {
+		return nil
+	}
+	var violation *password.Violation
+	if errors.As(err, &violation) {
+		metrics.Counter("auth_password_rejected_total", map[string]interface{}{
+			"reason": violation.Reason,
+		}).Inc()
+		return &domain.PasswordPolicyError{
+			Reason: violation.Reason,
+			Detail: violation.Message,
+		}
+	}
+	return fmt.Errorf("failed to check password: %w", err)
+}
+
+// changePassword sets new password of existing user, previous password is kept in history.
+// Password must differ from current one and from those in history.
+// Should be called within transaction, user is not saved.
+func (s *Service) changePassword(ctx context.Context, user *models.User, pswd string) error {
+	if err := s.CheckPasswordStrength(ctx, pswd); err != nil {
+		return err
+	}
+
+	if s.passwordHistorySize > 0 {
+		if err := s.checkPasswordHistory(ctx, user, pswd); err != nil {
+			return err
+		}
+	}
+
+	previous := user.Password
+
+	if err := s.setPassword(user, pswd); err != nil {
+		return fmt.Errorf("failed to set password: %w", err)
+	}
+
+	// current password is checked separately, so history holds one entry less
+	if s.passwordHistorySize > 1 && previous.Valid {
+		if err := s.recordPasswordHistory(ctx, user.ID, previous.V); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// checkPasswordHistory rejects current password and passwords from history.
+// Current password counts towards history size.
+func (s *Service) checkPasswordHistory(ctx context.Context, user *models.User, pswd string) error {
+	hashes := make([]string, 0, s.passwordHistorySize)
+	if user.Password.Valid {
+		hashes = append(hashes, user.Password.V)
+	}
+	if s.passwordHistorySize > 1 {
+		history, err := s.repository.ListPasswordHistory(ctx, user.ID, s.passwordHistorySize-1)
+		if err != nil {
+			return fmt.Errorf("failed to list password history: %w", err)
+		}
+		for _, entry := range history {
+			hashes = append(hashes, entry.Password)
+		}
+	}
+
+	for _, hash := range hashes {
+		if _, err := s.hasher.Verify(hash, pswd); err == nil {
+			metrics.Counter("auth_password_rejected_total", map[string]interface{}{
+				"reason": password.ReasonReused,
+			}).Inc()
+			return &domain.PasswordPolicyError{
+				Reason: password.ReasonReused,
+				Detail: fmt.Sprintf("password must differ from last %d passwords", s.passwordHistorySize),
+			}
+		}
+	}
+
+	return nil
+}
+
+// recordPasswordHistory keeps previous password hash, entries beyond history size are deleted.
+func (s *Service) recordPasswordHistory(ctx context.Context, userID int, hash string) error {
+	entry := &models.PasswordHistory{
+		UserID:   userID,
+		Password: hash,
+	}
+	if err := s.repository.CreatePasswordHistory(ctx, entry); err != nil {
+		return fmt.Errorf("failed to record password history: %w", err)
+	}
+	history, err := s.repository.ListPasswordHistory(ctx, userID, s.passwordHistorySize-1)
+	if err != nil {
+		return fmt.Errorf("failed to list password history: %w", err)
+	}
+	oldest := history[len(history)-1]
+	if err := s.repository.DeletePasswordHistoryBefore(ctx, userID, oldest.ID); err != nil {
+		return fmt.Errorf("failed to trim password history: %w", err)
+	}
+	return nil
+}
+
+func (s *Service) setPassword(user *models.User, pswd string) error {
+	hash, err := s.hasher.Hash(pswd)
+	if err != nil {
+		return err
+	}
+	user.Password = sql.Null[string]{V: hash, Valid: true}
+	return nil
+}
+
+// rehashPassword replaces hash produced by legacy algorithm or with outdated parameters.
+// Failure is not fatal, hash will be upgraded on next login.
+func (s *Service) rehashPassword(ctx context.Context, user *models.User, pswd string) {
+	if err := s.setPassword(user, pswd); err != nil {
+		s.logger.ErrorContext(ctx, "failed to rehash password", slog.Any("error", err))
+		return
+	}
+	if err := s.repository.UpdateUser(ctx, user); err != nil {
+		s.logger.ErrorContext(ctx, "failed to update password hash", slog.Any("error", err))
+		return
+	}
+	s.repository.InvalidateUserCache(ctx, user)
+	metrics.Counter("auth_password_rehashed_total", nil).Inc()
+}
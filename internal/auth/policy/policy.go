@@ -0,0 +1,214 @@
+package policy
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/google/cel-go/cel"
+
+	"github.com/hasansino/go42/internal/auth/domain"
+)
+
+const (
+	EffectAllow = "allow"
+	EffectDeny  = "deny"
+
+	// names of rules which decide when no configured rule does
+	RuleRBAC    = "rbac"
+	RuleDefault = "default"
+
+	// limits cost of single expression evaluation
+	costLimit = 10000
+)
+
+// Rule grants or denies actions matching pattern when condition holds.
+// Action pattern is permission string, "*" or prefix followed by "*", e.g. "users:*".
+// Condition is CEL expression over variables:
+//
+//	action   string
+//	subject  map: id, uuid, user_uuid, tenant, actor, type, permissions
+//	resource map: type, owner
+//	request  map: protocol, ip, time (timestamp)
+//
+// Example: resource.owner == subject.user_uuid && "users:read_self" in subject.permissions
+type Rule struct {
+	Name      string
+	Action    string
+	Effect    string
+	Condition string
+}
+
+// builtinRules decide actions allowed by self or others permission.
+// They are evaluated after configured rules, so configured deny rules still apply.
+var builtinRules = []Rule{
+	{
+		Name:      "users_read_self",
+		Action:    domain.AccessActionUsersRead,
+		Effect:    EffectAllow,
+		Condition: `resource.owner != "" && resource.owner == subject.user_uuid && "users:read_self" in subject.permissions`,
+	},
+	{
+		Name:      "users_read_others",
+		Action:    domain.AccessActionUsersRead,
+		Effect:    EffectAllow,
+		Condition: `resource.owner != subject.user_uuid && "users:read_others" in subject.permissions`,
+	},
+	{
+		Name:      "history_read_self",
+		Action:    domain.AccessActionHistoryRead,
+		Effect:    EffectAllow,
+		Condition: `resource.owner != "" && resource.owner == subject.user_uuid && "history:read_self" in subject.permissions`,
+	},
+	{
+		Name:      "history_read_others",
+		Action:    domain.AccessActionHistoryRead,
+		Effect:    EffectAllow,
+		Condition: `resource.owner != subject.user_uuid && "history:read_others" in subject.permissions`,
+	},
+}
+
+type compiledRule struct {
+	Rule
+	program cel.Program
+}
+
+// Engine evaluates access requests. Matching deny rules take precedence,
+// then permission of the subject is checked, then matching allow rules.
+// Without configured rules engine checks permissions and applies built-in rules.
+type Engine struct {
+	rules []compiledRule
+}
+
+// New compiles rules followed by built-in ones, invalid rule is reported with its name.
+func New(rules []Rule) (*Engine, error) {
+	env, err := cel.NewEnv(
+		cel.Variable("action", cel.StringType),
+		cel.Variable("subject", cel.MapType(cel.StringType, cel.DynType)),
+		cel.Variable("resource", cel.MapType(cel.StringType, cel.DynType)),
+		cel.Variable("request", cel.MapType(cel.StringType, cel.DynType)),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create cel environment: %w", err)
+	}
+
+	rules = append(slices.Clone(rules), builtinRules...)
+
+	engine := &Engine{rules: make([]compiledRule, 0, len(rules))}
+	for _, rule := range rules {
+		if rule.Effect != EffectAllow && rule.Effect != EffectDeny {
+			return nil, fmt.Errorf("rule %s: invalid effect %q", rule.Name, rule.Effect)
+		}
+		if rule.Action == "" {
+			return nil, fmt.Errorf("rule %s: action is required", rule.Name)
+		}
+		ast, issues := env.Compile(rule.Condition)
+		if issues != nil && issues.Err() != nil {
+			return nil, fmt.Errorf("rule %s: %w", rule.Name, issues.Err())
+		}
+		if ast.OutputType() != cel.BoolType {
+			return nil, fmt.Errorf("rule %s: condition must evaluate to bool", rule.Name)
+		}
+		program, err := env.Program(ast, cel.CostLimit(costLimit))
+		if err != nil {
+			return nil, fmt.Errorf("rule %s: %w", rule.Name, err)
+		}
+		engine.rules = append(engine.rules, compiledRule{Rule: rule, program: program})
+	}
+
+	return engine, nil
+}
+
+// Evaluate decides access request. Condition which fails to evaluate
+// is considered true for deny rules and false for allow rules.
+func (e *Engine) Evaluate(req *domain.AccessRequest) (*domain.AccessDecision, error) {
+	if req.Subject == nil {
+		return &domain.AccessDecision{Allowed: false, Rule: RuleDefault}, nil
+	}
+
+	var (
+		vars = variables(req)
+		errs []error
+	)
+
+	for _, rule := range e.rules {
+		if rule.Effect != EffectDeny || !matchAction(rule.Action, req.Action) {
+			continue
+		}
+		matched, err := rule.eval(vars)
+		if err != nil {
+			errs = append(errs, err)
+		}
+		if matched || err != nil {
+			return &domain.AccessDecision{Allowed: false, Rule: rule.Name}, errors.Join(errs...)
+		}
+	}
+
+	if req.Subject.HasPermission(req.Action) {
+		return &domain.AccessDecision{Allowed: true, Rule: RuleRBAC}, nil
+	}
+
+	for _, rule := range e.rules {
+		if rule.Effect != EffectAllow || !matchAction(rule.Action, req.Action) {
+			continue
+		}
+		matched, err := rule.eval(vars)
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		if matched {
+			return &domain.AccessDecision{Allowed: true, Rule: rule.Name}, errors.Join(errs...)
+		}
+	}
+
+	return &domain.AccessDecision{Allowed: false, Rule: RuleDefault}, errors.Join(errs...)
+}
+
+func (r compiledRule) eval(vars map[string]any) (bool, error) {
+	out, _, err := r.program.Eval(vars)
+	if err != nil {
+		return false, fmt.Errorf("rule %s: %w", r.Name, err)
+	}
+	matched, ok := out.Value().(bool)
+	if !ok {
+		return false, fmt.Errorf("rule %s: condition is not bool", r.Name)
+	}
+	return matched, nil
+}
+
+func variables(req *domain.AccessRequest) map[string]any {
+	permissions := req.Subject.Permissions()
+	if permissions == nil {
+		permissions = []string{}
+	}
+	return map[string]any{
+		"action": req.Action,
+		"subject": map[string]any{
+			"id":          req.Subject.ID,
+			"uuid":        req.Subject.UUID,
+			"user_uuid":   req.Subject.UserUUID,
+			"tenant":      req.Subject.TenantUUID,
+			"actor":       req.Subject.ActorUUID,
+			"type":        string(req.Subject.Type),
+			"permissions": permissions,
+		},
+		"resource": map[string]any{
+			"type":  req.Resource.Type,
+			"owner": req.Resource.Owner,
+		},
+		"request": map[string]any{
+			"protocol": req.Protocol,
+			"ip":       req.IPAddress,
+			"time":     req.Time,
+		},
+	}
+}
+
+func matchAction(pattern string, action string) bool {
+	if prefix, ok := strings.CutSuffix(pattern, "*"); ok {
+		return strings.HasPrefix(action, prefix)
+	}
+	return pattern == action
+}
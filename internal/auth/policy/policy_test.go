@@ -0,0 +1,174 @@
+package policy
+
+import (
+	"testing"
+	"time"
+
+	"github.com/hasansino/go42/internal/auth/domain"
+)
+
+func TestEngine(t *testing.T) {
+	engine, err := New([]Rule{
+		{
+			Name:      "read_own_user",
+			Action:    domain.RBACPermissionUsersReadOthers,
+			Effect:    EffectAllow,
+			Condition: `resource.owner == subject.user_uuid && "users:read_self" in subject.permissions`,
+		},
+		{
+			Name:      "api_tokens_read_only",
+			Action:    "roles:*",
+			Effect:    EffectDeny,
+			Condition: `subject.type == "api_token" && action != "roles:list"`,
+		},
+		{
+			Name:      "office_hours",
+			Action:    domain.RBACPermissionUsersDelete,
+			Effect:    EffectDeny,
+			Condition: `request.time.getHours("UTC") < 9 || request.time.getHours("UTC") >= 18`,
+		},
+		{
+			Name:      "impersonation_read_only",
+			Action:    domain.RBACPermissionUsersDelete,
+			Effect:    EffectDeny,
+			Condition: `subject.actor != ""`,
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	user := &domain.ContextAuthInfo{UserUUID: "u1", Type: domain.AuthenticationTypeCredentials}
+	user.SetPermissions([]string{domain.RBACPermissionUsersReadSelf})
+
+	admin := &domain.ContextAuthInfo{UserUUID: "u2", Type: domain.AuthenticationTypeCredentials}
+	admin.SetPermissions([]string{
+		domain.RBACPermissionUsersReadOthers,
+		domain.RBACPermissionUsersDelete,
+		domain.RBACPermissionRolesList,
+		domain.RBACPermissionRolesCreate,
+	})
+
+	token := &domain.ContextAuthInfo{UserUUID: "u2", Type: domain.AuthenticationTypeApiToken}
+	token.SetPermissions(admin.Permissions())
+
+	impersonated := &domain.ContextAuthInfo{
+		UserUUID: "u2", ActorID: 4, ActorUUID: "u4", Type: domain.AuthenticationTypeCredentials,
+	}
+	impersonated.SetPermissions(admin.Permissions())
+
+	noon := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+	night := time.Date(2026, 1, 1, 23, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name    string
+		subject *domain.ContextAuthInfo
+		action  string
+		owner   string
+		time    time.Time
+		allowed bool
+		rule    string
+	}{
+		{"OwnUser", user, domain.RBACPermissionUsersReadOthers, "u1", noon, true, "read_own_user"},
+		{"OtherUser", user, domain.RBACPermissionUsersReadOthers, "u3", noon, false, RuleDefault},
+		{"Permission", admin, domain.RBACPermissionUsersReadOthers, "u3", noon, true, RuleRBAC},
+		{"MissingPermission", user, domain.RBACPermissionRolesList, "", noon, false, RuleDefault},
+		{"TokenList", token, domain.RBACPermissionRolesList, "", noon, true, RuleRBAC},
+		{"TokenCreate", token, domain.RBACPermissionRolesCreate, "", noon, false, "api_tokens_read_only"},
+		{"OfficeHours", admin, domain.RBACPermissionUsersDelete, "u3", noon, true, RuleRBAC},
+		{"AfterHours", admin, domain.RBACPermissionUsersDelete, "u3", night, false, "office_hours"},
+		{"ImpersonatedRead", impersonated, domain.RBACPermissionUsersReadOthers, "u3", noon, true, RuleRBAC},
+		{"ImpersonatedDelete", impersonated, domain.RBACPermissionUsersDelete, "u3", noon, false, "impersonation_read_only"},
+		{"Anonymous", nil, domain.RBACPermissionUsersReadOthers, "", noon, false, RuleDefault},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			decision, err := engine.Evaluate(&domain.AccessRequest{
+				Subject:  tt.subject,
+				Action:   tt.action,
+				Resource: domain.AccessResource{Owner: tt.owner},
+				Time:     tt.time,
+			})
+			if err != nil {
+				t.Fatal(err)
+			}
+			if decision.Allowed != tt.allowed || decision.Rule != tt.rule {
+				t.Errorf("got %+v, want allowed=%v rule=%s", decision, tt.allowed, tt.rule)
+			}
+		})
+	}
+}
+
+func TestEngine_BuiltinRules(t *testing.T) {
+	engine, err := New([]Rule{
+		{
+			Name:      "impersonation_own_user",
+			Action:    domain.AccessActionUsersRead,
+			Effect:    EffectDeny,
+			Condition: `subject.actor != "" && resource.owner != subject.user_uuid`,
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	user := &domain.ContextAuthInfo{UserUUID: "u1", Type: domain.AuthenticationTypeCredentials}
+	user.SetPermissions([]string{domain.RBACPermissionUsersReadSelf, domain.RBACPermissionHistoryReadSelf})
+
+	admin := &domain.ContextAuthInfo{UserUUID: "u2", Type: domain.AuthenticationTypeCredentials}
+	admin.SetPermissions([]string{domain.RBACPermissionUsersReadOthers, domain.RBACPermissionHistoryReadOthers})
+
+	impersonated := &domain.ContextAuthInfo{
+		UserUUID: "u2", ActorID: 4, ActorUUID: "u4", Type: domain.AuthenticationTypeCredentials,
+	}
+	impersonated.SetPermissions(admin.Permissions())
+
+	tests := []struct {
+		name    string
+		subject *domain.ContextAuthInfo
+		action  string
+		owner   string
+		allowed bool
+		rule    string
+	}{
+		{"ReadSelf", user, domain.AccessActionUsersRead, "u1", true, "users_read_self"},
+		{"ReadOthersWithSelfPermission", user, domain.AccessActionUsersRead, "u3", false, RuleDefault},
+		{"ReadUnknownOwner", user, domain.AccessActionUsersRead, "", false, RuleDefault},
+		{"ReadOthers", admin, domain.AccessActionUsersRead, "u3", true, "users_read_others"},
+		{"ReadSelfWithOthersPermission", admin, domain.AccessActionUsersRead, "u2", false, RuleDefault},
+		{"HistorySelf", user, domain.AccessActionHistoryRead, "u1", true, "history_read_self"},
+		{"HistoryOthers", admin, domain.AccessActionHistoryRead, "u3", true, "history_read_others"},
+		{"HistoryOthersWithSelfPermission", user, domain.AccessActionHistoryRead, "u3", false, RuleDefault},
+		{"ConfiguredDeny", impersonated, domain.AccessActionUsersRead, "u3", false, "impersonation_own_user"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			decision, err := engine.Evaluate(&domain.AccessRequest{
+				Subject:  tt.subject,
+				Action:   tt.action,
+				Resource: domain.AccessResource{Owner: tt.owner},
+			})
+			if err != nil {
+				t.Fatal(err)
+			}
+			if decision.Allowed != tt.allowed || decision.Rule != tt.rule {
+				t.Errorf("got %+v, want allowed=%v rule=%s", decision, tt.allowed, tt.rule)
+			}
+		})
+	}
+}
+
+func TestNew_InvalidRule(t *testing.T) {
+	rules := []Rule{
+		{Name: "syntax", Action: "*", Effect: EffectAllow, Condition: "subject.type =="},
+		{Name: "not_bool", Action: "*", Effect: EffectAllow, Condition: "subject.type"},
+		{Name: "unknown_variable", Action: "*", Effect: EffectAllow, Condition: "user.type == 'x'"},
+		{Name: "effect", Action: "*", Effect: "maybe", Condition: "true"},
+		{Name: "action", Effect: EffectAllow, Condition: "true"},
+	}
+	for _, rule := range rules {
+		if _, err := New([]Rule{rule}); err == nil {
+			t.Errorf("rule %s accepted", rule.Name)
+		}
+	}
+}
@@ -5,8 +5,14 @@ import (
 	"errors"
 	"fmt"
 	"log/slog"
+	"slices"
+	"strings"
 	"time"
 
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
 	"github.com/hasansino/go42/internal/auth/domain"
 	"github.com/hasansino/go42/internal/auth/models"
 	"github.com/hasansino/go42/internal/cache"
@@ -63,24 +69,216 @@ func (r *Repository) UpdateUser(ctx context.Context, user *models.User) error {
 	if result.RowsAffected == 0 {
 		return errors.New("error updating user: no rows affected")
 	}
+	// zero values are skipped by updates, verification is reset when email changes
+	if !user.EmailVerifiedAt.Valid {
+		result = r.GetTx(ctx).Model(user).Update("email_verified_at", nil)
+		if result.Error != nil {
+			return fmt.Errorf("error updating user: %w", result.Error)
+		}
+	}
+	return nil
+}
+
+// UpdateUserStatus saves status of the user together with its reason and end time, which may be empty.
+func (r *Repository) UpdateUserStatus(ctx context.Context, user *models.User) error {
+	result := r.GetTx(ctx).
+		Model(user).
+		Select(
+			"status", "status_reason", "status_until",
+			"previous_status", "previous_status_reason", "previous_status_until", "updated_at",
+		).
+		Updates(user)
+	if result.Error != nil {
+		return fmt.Errorf("error updating user status: %w", result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return domain.ErrEntityNotFound
+	}
 	return nil
 }
 
-func (r *Repository) DeleteUser(ctx context.Context, user *models.User) error {
-	result := r.GetTx(ctx).Delete(user)
+// ListUsersWithStatusUntil lists users in given status whose status end time has passed.
+func (r *Repository) ListUsersWithStatusUntil(
+	ctx context.Context, status string, until time.Time, limit int,
+) ([]*models.User, error) {
+	var users []*models.User
+	err := r.GetReadDB(ctx).
+		Where("status = ?", status).
+		Where("status_until IS NOT NULL AND status_until <= ?", until).
+		Order("status_until ASC").
+		Limit(limit).
+		Find(&users).Error
+	if err != nil {
+		return nil, fmt.Errorf("error listing users: %w", err)
+	}
+	return users, nil
+}
+
+// AnonymizeUser deletes all credentials and memberships of the user and replaces its personal data
+// with anonymized values, user record and its history are kept soft-deleted. User is anonymized only
+// while it has given status, so that concurrent changes of the user are not overwritten.
+func (r *Repository) AnonymizeUser(ctx context.Context, user *models.User, status string) error {
+	if err := r.deleteUserRecords(ctx, user.ID); err != nil {
+		return err
+	}
+	err := r.GetTx(ctx).
+		Model(&models.DataRequest{}).
+		Where("user_id = ?", user.ID).
+		Update("archive", nil).Error
+	if err != nil {
+		return fmt.Errorf("error deleting user data archives: %w", err)
+	}
+	result := r.GetTx(ctx).
+		Model(user).
+		Where("status = ?", status).
+		Select(
+			"email", "email_verified_at", "password", "metadata",
+			"status", "status_reason", "status_until",
+			"previous_status", "previous_status_reason", "previous_status_until", "updated_at",
+		).
+		Updates(user)
+	if result.Error != nil {
+		return fmt.Errorf("error anonymizing user: %w", result.Error)
+	}
 	if result.RowsAffected == 0 {
 		return domain.ErrEntityNotFound
 	}
+	if err := r.GetTx(ctx).Delete(user).Error; err != nil {
+		return fmt.Errorf("error deleting user: %w", err)
+	}
+	return nil
+}
+
+// EraseUser permanently deletes user with all its records including history.
+func (r *Repository) EraseUser(ctx context.Context, user *models.User) error {
+	if err := r.deleteUserRecords(ctx, user.ID); err != nil {
+		return err
+	}
+	err := r.GetTx(ctx).
+		Where("user_id = ?", user.ID).
+		Delete(&models.UserHistoryRecord{}).Error
+	if err != nil {
+		return fmt.Errorf("error deleting user history: %w", err)
+	}
+	err = r.GetTx(ctx).
+		Where("user_id = ?", user.ID).
+		Delete(&models.DataRequest{}).Error
+	if err != nil {
+		return fmt.Errorf("error deleting user data requests: %w", err)
+	}
+	result := r.GetTx(ctx).Unscoped().Delete(user)
 	if result.Error != nil {
-		return fmt.Errorf("error deleting user: %w", result.Error)
+		return fmt.Errorf("error erasing user: %w", result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return domain.ErrEntityNotFound
+	}
+	return nil
+}
+
+// deleteUserRecords explicitly deletes rows referencing the user,
+// foreign key cascades are not enforced by every database configuration.
+func (r *Repository) deleteUserRecords(ctx context.Context, userID int) error {
+	tx := r.GetTx(ctx)
+
+	ownedClients := tx.Model(&models.OAuthClient{}).Select("id").Where("user_id = ?", userID)
+	userTokens := tx.Unscoped().Model(&models.Token{}).Select("id").Where("user_id = ?", userID)
+
+	deletions := []struct {
+		name  string
+		query *gorm.DB
+		model any
+	}{
+		{"oauth codes", tx.Where("user_id = ? OR client_id IN (?)", userID, ownedClients), &models.OAuthCode{}},
+		{"oauth clients", tx.Where("user_id = ?", userID), &models.OAuthClient{}},
+		{"api token permissions", tx.Where("token_id IN (?)", userTokens), &models.TokenPermission{}},
+		{"api tokens", tx.Unscoped().Where("user_id = ?", userID), &models.Token{}},
+		{"refresh tokens", tx.Where("user_id = ?", userID), &models.RefreshToken{}},
+		{"sessions", tx.Where("user_id = ?", userID), &models.Session{}},
+		{"mfa recovery codes", tx.Where("user_id = ?", userID), &models.MFARecoveryCode{}},
+		{"mfa", tx.Where("user_id = ?", userID), &models.UserMFA{}},
+		{"identities", tx.Where("user_id = ?", userID), &models.UserIdentity{}},
+		{"password history", tx.Where("user_id = ?", userID), &models.PasswordHistory{}},
+		{"organization roles", tx.Where("user_id = ?", userID), &models.OrganizationMemberRole{}},
+		{"organization memberships", tx.Where("user_id = ?", userID), &models.OrganizationMember{}},
+		{"roles", tx.Where("user_id = ?", userID), &models.UserRole{}},
+	}
+	for _, d := range deletions {
+		if err := d.query.Delete(d.model).Error; err != nil {
+			return fmt.Errorf("error deleting user %s: %w", d.name, err)
+		}
 	}
+
 	return nil
 }
 
-func (r *Repository) ListUsers(ctx context.Context, limit, offset int) ([]*models.User, error) {
+// ListUsers lists users matching the query which are positioned after the cursor,
+// within active tenant only its members are listed. Query cursor is not used.
+func (r *Repository) ListUsers(
+	ctx context.Context, q *domain.ListUsersQuery, after *domain.UserCursor,
+) ([]*models.User, error) {
 	var users []*models.User
 
-	result := r.GetReadDB(ctx).Limit(limit).Offset(offset).Order("id ASC").Find(&users)
+	query := r.GetReadDB(ctx)
+	if _, ok := database.TenantFromContext(ctx); ok {
+		query = query.Where("id IN (?)", r.tenantMembers(ctx))
+	}
+	if q.EmailPrefix != "" {
+		query = query.Where("email LIKE ? ESCAPE '!'", escapeLike(q.EmailPrefix)+"%")
+	}
+	if q.Status != "" {
+		query = query.Where("status = ?", q.Status)
+	}
+	if q.Role != "" {
+		query = query.Where("id IN (?)", r.GetReadDB(ctx).
+			Table("auth_user_roles").
+			Select("auth_user_roles.user_id").
+			Joins("JOIN auth_roles ON auth_roles.id = auth_user_roles.role_id").
+			Where("auth_roles.name = ?", q.Role).
+			Where("auth_roles.deleted_at IS NULL").
+			Where("auth_user_roles.expires_at IS NULL OR auth_user_roles.expires_at > ?", time.Now()))
+	}
+	if q.CreatedFrom != nil {
+		query = query.Where("created_at >= ?", *q.CreatedFrom)
+	}
+	if q.CreatedTo != nil {
+		query = query.Where("created_at < ?", *q.CreatedTo)
+	}
+
+	// keyset pagination, id breaks ties of sort column
+	op, direction := ">", "ASC"
+	if q.SortDesc {
+		op, direction = "<", "DESC"
+	}
+	var column string
+	var value any
+	switch q.SortBy {
+	case domain.UserSortByCreatedAt:
+		column = "created_at"
+		if after != nil {
+			value = after.CreatedAt
+		}
+	case domain.UserSortByEmail:
+		column = "email"
+		if after != nil {
+			value = after.Email
+		}
+	}
+	if after != nil {
+		if column == "" {
+			query = query.Where(fmt.Sprintf("id %s ?", op), after.ID)
+		} else {
+			query = query.Where(
+				fmt.Sprintf("(%[1]s %[2]s ? OR (%[1]s = ? AND id %[2]s ?))", column, op),
+				value, value, after.ID)
+		}
+	}
+	if column != "" {
+		query = query.Order(column + " " + direction)
+	}
+	query = query.Order("id " + direction)
+
+	result := query.Limit(q.Limit).Find(&users)
 	if result.Error != nil {
 		return nil, fmt.Errorf("error listing users: %w", result.Error)
 	}
@@ -158,6 +356,11 @@ func (r *Repository) ListUsers(ctx context.Context, limit, offset int) ([]*model
 	return users, nil
 }
 
+// escapeLike escapes wildcards of LIKE pattern with '!'.
+func escapeLike(value string) string {
+	return strings.NewReplacer("!", "!!", "%", "!%", "_", "!_").Replace(value)
+}
+
 func (r *Repository) GetUserByID(ctx context.Context, id int) (*models.User, error) {
 	return tools.TraceReturnTWithErr[*models.User](
 		ctx, "auth", "auth.repository.GetUserByID",
@@ -182,7 +385,35 @@ func (r *Repository) GetUserByEmail(ctx context.Context, email string) (*models.
 		})
 }
 
+// getUser returns user matching filter, within active tenant only its members are found.
+// Cached user is shared between tenants, so membership is checked separately.
 func (r *Repository) getUser(ctx context.Context, filter map[string]any) (*models.User, error) {
+	user, err := r.getCachedUser(ctx, filter)
+	if err != nil {
+		return nil, err
+	}
+	if _, ok := database.TenantFromContext(ctx); ok {
+		var count int64
+		err := r.tenantMembers(ctx).Where("user_id = ?", user.ID).Count(&count).Error
+		if err != nil {
+			return nil, fmt.Errorf("error checking membership: %w", err)
+		}
+		if count == 0 {
+			return nil, domain.ErrEntityNotFound
+		}
+	}
+	return user, nil
+}
+
+// tenantMembers selects ids of users which are members of active tenant.
+func (r *Repository) tenantMembers(ctx context.Context) *gorm.DB {
+	return r.GetReadDB(ctx).
+		Model(&models.OrganizationMember{}).
+		Select("user_id").
+		Scopes(r.TenantScope(ctx, "organization_id"))
+}
+
+func (r *Repository) getCachedUser(ctx context.Context, filter map[string]any) (*models.User, error) {
 	cacheKey := generateUserCacheKey(filter)
 	cachedUser, err := cache.GetDecode[*models.User](ctx, r.cache, cacheKey)
 	if err != nil {
@@ -278,6 +509,26 @@ func generateUserCacheKey(filter map[string]any) string {
 	return key
 }
 
+// InvalidateUserCache drops all cached representations of given users.
+func (r *Repository) InvalidateUserCache(ctx context.Context, users ...*models.User) {
+	for _, user := range users {
+		keys := []string{
+			generateUserCacheKey(map[string]any{"id": user.ID}),
+			generateUserCacheKey(map[string]any{"uuid": user.UUID.String()}),
+			generateUserCacheKey(map[string]any{"email": user.Email}),
+		}
+		for _, key := range keys {
+			if err := r.cache.Invalidate(ctx, key); err != nil {
+				slog.Default().ErrorContext(
+					ctx, "error invalidating cached user",
+					slog.Int("user_id", user.ID),
+					slog.Any("err", err),
+				)
+			}
+		}
+	}
+}
+
 func (r *Repository) AssignRoleToUser(ctx context.Context, userID int, roleName string) error {
 	var role models.Role
 	err := r.GetTx(ctx).
@@ -300,10 +551,185 @@ func (r *Repository) AssignRoleToUser(ctx context.Context, userID int, roleName
 	return nil
 }
 
+// GrantRoleToUser assigns role to user, existing assignment is updated with new expiration.
+func (r *Repository) GrantRoleToUser(ctx context.Context, userRole *models.UserRole) error {
+	err := r.GetTx(ctx).
+		Clauses(clause.OnConflict{
+			Columns:   []clause.Column{{Name: "user_id"}, {Name: "role_id"}},
+			DoUpdates: clause.AssignmentColumns([]string{"expires_at"}),
+		}).
+		Create(userRole).Error
+	if err != nil {
+		return fmt.Errorf("error granting role to user: %w", err)
+	}
+	return nil
+}
+
+func (r *Repository) RevokeRoleFromUser(ctx context.Context, userID int, roleID int) error {
+	result := r.GetTx(ctx).
+		Where("user_id = ? AND role_id = ?", userID, roleID).
+		Delete(&models.UserRole{})
+	if result.Error != nil {
+		return fmt.Errorf("error revoking role from user: %w", result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return domain.ErrEntityNotFound
+	}
+	return nil
+}
+
+func (r *Repository) ListRoles(ctx context.Context) ([]*models.Role, error) {
+	var roles []*models.Role
+	err := r.GetReadDB(ctx).Order("id ASC").Find(&roles).Error
+	if err != nil {
+		return nil, fmt.Errorf("error listing roles: %w", err)
+	}
+	if err := r.loadRolePermissions(ctx, roles...); err != nil {
+		return nil, err
+	}
+	return roles, nil
+}
+
+func (r *Repository) GetRoleByName(ctx context.Context, name string) (*models.Role, error) {
+	var role models.Role
+	err := r.GetReadDB(ctx).Where("name = ?", name).First(&role).Error
+	if r.IsNotFoundError(err) {
+		return nil, domain.ErrEntityNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("error retrieving role: %w", err)
+	}
+	if err := r.loadRolePermissions(ctx, &role); err != nil {
+		return nil, err
+	}
+	return &role, nil
+}
+
+// CreateRole saves role together with its permissions,
+// permissions are expected to be already existing records.
+// Should be called within transaction.
+func (r *Repository) CreateRole(ctx context.Context, role *models.Role) error {
+	err := r.GetTx(ctx).Create(role).Error
+	if err != nil {
+		if r.IsDuplicateKeyError(err) {
+			return domain.ErrRoleAlreadyExists
+		}
+		return fmt.Errorf("error creating role: %w", err)
+	}
+	for _, permission := range role.Permissions {
+		if err := r.AttachPermissionToRole(ctx, role.ID, permission.ID); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// DeleteRole removes role permanently, so its name can be reused,
+// together with all assignments of the role.
+// Should be called within transaction.
+func (r *Repository) DeleteRole(ctx context.Context, role *models.Role) error {
+	err := r.GetTx(ctx).Where("role_id = ?", role.ID).Delete(&models.UserRole{}).Error
+	if err != nil {
+		return fmt.Errorf("error deleting role assignments: %w", err)
+	}
+	err = r.GetTx(ctx).Where("role_id = ?", role.ID).Delete(&models.OrganizationMemberRole{}).Error
+	if err != nil {
+		return fmt.Errorf("error deleting organization role assignments: %w", err)
+	}
+	err = r.GetTx(ctx).Where("role_id = ?", role.ID).Delete(&models.RolePermission{}).Error
+	if err != nil {
+		return fmt.Errorf("error deleting role permissions: %w", err)
+	}
+	result := r.GetTx(ctx).Unscoped().Delete(role)
+	if result.Error != nil {
+		return fmt.Errorf("error deleting role: %w", result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return domain.ErrEntityNotFound
+	}
+	return nil
+}
+
+// AttachPermissionToRole is idempotent, attaching already attached permission is not an error.
+func (r *Repository) AttachPermissionToRole(ctx context.Context, roleID int, permissionID int) error {
+	err := r.GetTx(ctx).
+		Clauses(clause.OnConflict{DoNothing: true}).
+		Create(&models.RolePermission{RoleID: roleID, PermissionID: permissionID}).Error
+	if err != nil {
+		return fmt.Errorf("error attaching permission to role: %w", err)
+	}
+	return nil
+}
+
+func (r *Repository) DetachPermissionFromRole(ctx context.Context, roleID int, permissionID int) error {
+	result := r.GetTx(ctx).
+		Where("role_id = ? AND permission_id = ?", roleID, permissionID).
+		Delete(&models.RolePermission{})
+	if result.Error != nil {
+		return fmt.Errorf("error detaching permission from role: %w", result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return domain.ErrEntityNotFound
+	}
+	return nil
+}
+
+// ListRoleUsers returns all users which have the role assigned, including expired assignments.
+func (r *Repository) ListRoleUsers(ctx context.Context, roleID int) ([]*models.User, error) {
+	var users []*models.User
+	err := r.GetReadDB(ctx).
+		Joins("JOIN auth_user_roles ON auth_user_roles.user_id = auth_users.id").
+		Where("auth_user_roles.role_id = ?", roleID).
+		Find(&users).Error
+	if err != nil {
+		return nil, fmt.Errorf("error listing role users: %w", err)
+	}
+	return users, nil
+}
+
+func (r *Repository) loadRolePermissions(ctx context.Context, roles ...*models.Role) error {
+	if len(roles) == 0 {
+		return nil
+	}
+
+	roleIDs := make([]int, len(roles))
+	for i, role := range roles {
+		roleIDs[i] = role.ID
+	}
+
+	var permissions []struct {
+		RoleID     int
+		Permission models.Permission `gorm:"embedded"`
+	}
+
+	err := r.GetReadDB(ctx).
+		Table("auth_permissions").
+		Select("auth_role_permissions.role_id, auth_permissions.*").
+		Joins("JOIN auth_role_permissions ON auth_role_permissions.permission_id = auth_permissions.id").
+		Where("auth_role_permissions.role_id IN ?", roleIDs).
+		Order("auth_permissions.id ASC").
+		Scan(&permissions).Error
+
+	if err != nil {
+		return fmt.Errorf("error fetching role permissions: %w", err)
+	}
+
+	permissionMap := make(map[int][]models.Permission)
+	for _, p := range permissions {
+		permissionMap[p.RoleID] = append(permissionMap[p.RoleID], p.Permission)
+	}
+
+	for _, role := range roles {
+		role.Permissions = permissionMap[role.ID]
+	}
+
+	return nil
+}
+
 const tokenCacheKeyPrefix = "cache:token"
 
 func (r *Repository) GetToken(ctx context.Context, hashedToken string) (*models.Token, error) {
-	cacheKey := fmt.Sprintf("%s:%s", tokenCacheKeyPrefix, hashedToken)
+	cacheKey := generateTokenCacheKey(hashedToken)
 	cachedToken, err := cache.GetDecode[*models.Token](ctx, r.cache, cacheKey)
 	if err != nil {
 		slog.Default().ErrorContext(
@@ -317,13 +743,19 @@ func (r *Repository) GetToken(ctx context.Context, hashedToken string) (*models.
 
 	var apiToken models.Token
 	err = r.GetReadDB(ctx).
-		Preload("Permissions").
 		Where("token = ?", hashedToken).
 		First(&apiToken).Error
 
 	if r.IsNotFoundError(err) {
 		return nil, domain.ErrEntityNotFound
 	}
+	if err != nil {
+		return nil, fmt.Errorf("error retrieving api token: %w", err)
+	}
+
+	if err := r.loadTokenPermissions(ctx, &apiToken); err != nil {
+		return nil, err
+	}
 
 	if err := cache.SetEncode[*models.Token](
 		ctx, r.cache, cacheKey, &apiToken, r.secretCacheTTL,
@@ -335,7 +767,160 @@ func (r *Repository) GetToken(ctx context.Context, hashedToken string) (*models.
 		)
 	}
 
-	return &apiToken, err
+	return &apiToken, nil
+}
+
+func generateTokenCacheKey(hashedToken string) string {
+	return fmt.Sprintf("%s:%s", tokenCacheKeyPrefix, hashedToken)
+}
+
+func (r *Repository) GetTokenByUUID(ctx context.Context, uuid string) (*models.Token, error) {
+	var apiToken models.Token
+	err := r.GetReadDB(ctx).Where("uuid = ?", uuid).First(&apiToken).Error
+	if r.IsNotFoundError(err) {
+		return nil, domain.ErrEntityNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("error retrieving api token: %w", err)
+	}
+	if err := r.loadTokenPermissions(ctx, &apiToken); err != nil {
+		return nil, err
+	}
+	return &apiToken, nil
+}
+
+// ListTokens returns not revoked tokens of the user, including expired ones.
+func (r *Repository) ListTokens(ctx context.Context, userID int) ([]*models.Token, error) {
+	var apiTokens []*models.Token
+	err := r.GetReadDB(ctx).
+		Where("user_id = ?", userID).
+		Order("created_at DESC").
+		Find(&apiTokens).Error
+	if err != nil {
+		return nil, fmt.Errorf("error listing api tokens: %w", err)
+	}
+	if err := r.loadTokenPermissions(ctx, apiTokens...); err != nil {
+		return nil, err
+	}
+	return apiTokens, nil
+}
+
+// CreateToken saves token together with its permissions,
+// permissions are expected to be already existing records.
+// Should be called within transaction.
+func (r *Repository) CreateToken(ctx context.Context, apiToken *models.Token) error {
+	err := r.GetTx(ctx).Create(apiToken).Error
+	if err != nil {
+		return fmt.Errorf("error creating api token: %w", err)
+	}
+	if len(apiToken.Permissions) == 0 {
+		return nil
+	}
+	tokenPermissions := make([]models.TokenPermission, len(apiToken.Permissions))
+	for i, permission := range apiToken.Permissions {
+		tokenPermissions[i] = models.TokenPermission{
+			TokenID:      apiToken.ID,
+			PermissionID: permission.ID,
+		}
+	}
+	err = r.GetTx(ctx).Create(&tokenPermissions).Error
+	if err != nil {
+		return fmt.Errorf("error assigning permissions to api token: %w", err)
+	}
+	return nil
+}
+
+// DeleteExpiredTokens deletes expired tokens of the user with given name.
+func (r *Repository) DeleteExpiredTokens(ctx context.Context, userID int, name string) error {
+	err := r.GetTx(ctx).
+		Where("user_id = ?", userID).
+		Where("name = ?", name).
+		Where("expires_at < ?", time.Now()).
+		Delete(&models.Token{}).Error
+	if err != nil {
+		return fmt.Errorf("error deleting expired api tokens: %w", err)
+	}
+	return nil
+}
+
+func (r *Repository) DeleteToken(ctx context.Context, apiToken *models.Token) error {
+	err := r.GetTx(ctx).Delete(apiToken).Error
+	if err != nil {
+		return fmt.Errorf("error deleting api token: %w", err)
+	}
+	if err := r.cache.Invalidate(ctx, generateTokenCacheKey(apiToken.Token)); err != nil {
+		slog.Default().ErrorContext(
+			ctx, "error invalidating cached api token",
+			slog.Int("token_id", apiToken.ID),
+			slog.Any("err", err),
+		)
+	}
+	return nil
+}
+
+func (r *Repository) loadTokenPermissions(ctx context.Context, apiTokens ...*models.Token) error {
+	if len(apiTokens) == 0 {
+		return nil
+	}
+
+	tokenIDs := make([]int, len(apiTokens))
+	for i, apiToken := range apiTokens {
+		tokenIDs[i] = apiToken.ID
+	}
+
+	var permissions []struct {
+		TokenID    int
+		Permission models.Permission `gorm:"embedded"`
+	}
+
+	err := r.GetReadDB(ctx).
+		Table("auth_permissions").
+		Select("auth_api_tokens_permissions.token_id, auth_permissions.*").
+		Joins("JOIN auth_api_tokens_permissions ON auth_api_tokens_permissions.permission_id = auth_permissions.id").
+		Where("auth_api_tokens_permissions.token_id IN ?", tokenIDs).
+		Scan(&permissions).Error
+
+	if err != nil {
+		return fmt.Errorf("error fetching api token permissions: %w", err)
+	}
+
+	permissionMap := make(map[int][]models.Permission)
+	for _, p := range permissions {
+		permissionMap[p.TokenID] = append(permissionMap[p.TokenID], p.Permission)
+	}
+
+	for _, apiToken := range apiTokens {
+		apiToken.Permissions = permissionMap[apiToken.ID]
+	}
+
+	return nil
+}
+
+// GetPermissions returns permissions by their names in `resource:action` format.
+// Unknown names are ignored.
+func (r *Repository) GetPermissions(ctx context.Context, names []string) ([]models.Permission, error) {
+	if len(names) == 0 {
+		return nil, nil
+	}
+	pairs := make([][]any, 0, len(names))
+	for _, name := range names {
+		resource, action, ok := strings.Cut(name, ":")
+		if !ok {
+			continue
+		}
+		pairs = append(pairs, []any{resource, action})
+	}
+	if len(pairs) == 0 {
+		return nil, nil
+	}
+	var permissions []models.Permission
+	err := r.GetReadDB(ctx).
+		Where("(resource, action) IN ?", pairs).
+		Find(&permissions).Error
+	if err != nil {
+		return nil, fmt.Errorf("error retrieving permissions: %w", err)
+	}
+	return permissions, nil
 }
 
 func (r *Repository) UpdateTokenLastUsed(ctx context.Context, tokenID int, when time.Time) error {
@@ -356,3 +941,856 @@ func (r *Repository) UpdateTokenLastUsed(ctx context.Context, tokenID int, when
 func (r *Repository) SaveUserHistoryRecord(ctx context.Context, record *models.UserHistoryRecord) error {
 	return r.GetTx(ctx).Create(record).Error
 }
+
+// ListUserHistoryRecords returns whole history of the user from oldest to newest.
+func (r *Repository) ListUserHistoryRecords(ctx context.Context, userID int) ([]*models.UserHistoryRecord, error) {
+	var records []*models.UserHistoryRecord
+	err := r.GetReadDB(ctx).
+		Where("user_id = ?", userID).
+		Order("occurred_at ASC").
+		Find(&records).Error
+	if err != nil {
+		return nil, fmt.Errorf("error listing user history: %w", err)
+	}
+	return records, nil
+}
+
+// FilterUserHistoryRecords returns page of history of the user from newest to oldest.
+func (r *Repository) FilterUserHistoryRecords(
+	ctx context.Context, userID int, filter *domain.UserHistoryFilter,
+) ([]*models.UserHistoryRecord, error) {
+	query := r.GetReadDB(ctx).Where("user_id = ?", userID)
+	if len(filter.EventTypes) > 0 {
+		query = query.Where("event_type IN ?", filter.EventTypes)
+	}
+	if filter.From != nil {
+		query = query.Where("occurred_at >= ?", *filter.From)
+	}
+	if filter.To != nil {
+		query = query.Where("occurred_at < ?", *filter.To)
+	}
+
+	var records []*models.UserHistoryRecord
+	err := query.
+		Order("occurred_at DESC").
+		Order("id DESC").
+		Limit(filter.Limit).
+		Offset(filter.Offset).
+		Find(&records).Error
+	if err != nil {
+		return nil, fmt.Errorf("error filtering user history: %w", err)
+	}
+	return records, nil
+}
+
+// PurgeUserHistoryData removes payloads of history records of the user, event types and times are kept.
+func (r *Repository) PurgeUserHistoryData(ctx context.Context, userID int) error {
+	err := r.GetTx(ctx).
+		Model(&models.UserHistoryRecord{}).
+		Where("user_id = ?", userID).
+		Updates(map[string]any{"data": nil, "metadata": ""}).Error
+	if err != nil {
+		return fmt.Errorf("error purging user history: %w", err)
+	}
+	return nil
+}
+
+func (r *Repository) CreateJWTSecret(ctx context.Context, secret *models.JWTSecret) error {
+	err := r.GetTx(ctx).Create(secret).Error
+	if err != nil {
+		if r.IsDuplicateKeyError(err) {
+			return domain.ErrJWTSecretAlreadyRotated
+		}
+		return fmt.Errorf("error creating jwt secret: %w", err)
+	}
+	return nil
+}
+
+// ListJWTSecrets returns latest secrets ordered from oldest to newest.
+func (r *Repository) ListJWTSecrets(ctx context.Context, limit int) ([]*models.JWTSecret, error) {
+	var secrets []*models.JWTSecret
+	err := r.GetReadDB(ctx).
+		Order("generation DESC").
+		Limit(limit).
+		Find(&secrets).Error
+	if err != nil {
+		return nil, fmt.Errorf("error listing jwt secrets: %w", err)
+	}
+	slices.Reverse(secrets)
+	return secrets, nil
+}
+
+func (r *Repository) DeleteJWTSecretsBefore(ctx context.Context, generation int64) error {
+	err := r.GetTx(ctx).
+		Where("generation < ?", generation).
+		Delete(&models.JWTSecret{}).Error
+	if err != nil {
+		return fmt.Errorf("error deleting jwt secrets: %w", err)
+	}
+	return nil
+}
+
+func (r *Repository) CreateRefreshToken(ctx context.Context, token *models.RefreshToken) error {
+	err := r.GetTx(ctx).Create(token).Error
+	if err != nil {
+		return fmt.Errorf("error creating refresh token: %w", err)
+	}
+	return nil
+}
+
+// GetRefreshToken always reads from master to not miss recently consumed tokens.
+func (r *Repository) GetRefreshToken(ctx context.Context, jti string) (*models.RefreshToken, error) {
+	var token models.RefreshToken
+	err := r.GetTx(ctx).Where("jti = ?", jti).First(&token).Error
+	if r.IsNotFoundError(err) {
+		return nil, domain.ErrEntityNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("error retrieving refresh token: %w", err)
+	}
+	return &token, nil
+}
+
+// ConsumeRefreshToken marks token as used, if token was already
+// consumed or revoked, domain.ErrRefreshTokenReused is returned.
+func (r *Repository) ConsumeRefreshToken(ctx context.Context, id int) error {
+	result := r.GetTx(ctx).
+		Model(&models.RefreshToken{}).
+		Where("id = ?", id).
+		Where("consumed_at IS NULL").
+		Where("revoked_at IS NULL").
+		Update("consumed_at", time.Now())
+	if result.Error != nil {
+		return fmt.Errorf("error consuming refresh token: %w", result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return domain.ErrRefreshTokenReused
+	}
+	return nil
+}
+
+func (r *Repository) RevokeRefreshTokenFamily(ctx context.Context, familyID uuid.UUID) error {
+	err := r.GetTx(ctx).
+		Model(&models.RefreshToken{}).
+		Where("family_id = ?", familyID).
+		Where("revoked_at IS NULL").
+		Update("revoked_at", time.Now()).Error
+	if err != nil {
+		return fmt.Errorf("error revoking refresh token family: %w", err)
+	}
+	return nil
+}
+
+func (r *Repository) CreateSession(ctx context.Context, session *models.Session) error {
+	err := r.GetTx(ctx).Create(session).Error
+	if err != nil {
+		return fmt.Errorf("error creating session: %w", err)
+	}
+	return nil
+}
+
+func (r *Repository) UpdateSession(ctx context.Context, session *models.Session) error {
+	err := r.GetTx(ctx).Save(session).Error
+	if err != nil {
+		return fmt.Errorf("error updating session: %w", err)
+	}
+	return nil
+}
+
+func (r *Repository) GetSessionByUUID(ctx context.Context, uuid string) (*models.Session, error) {
+	var session models.Session
+	err := r.GetTx(ctx).Where("uuid = ?", uuid).First(&session).Error
+	if r.IsNotFoundError(err) {
+		return nil, domain.ErrEntityNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("error retrieving session: %w", err)
+	}
+	return &session, nil
+}
+
+func (r *Repository) GetSessionByFamilyID(ctx context.Context, familyID uuid.UUID) (*models.Session, error) {
+	var session models.Session
+	err := r.GetTx(ctx).Where("family_id = ?", familyID).First(&session).Error
+	if r.IsNotFoundError(err) {
+		return nil, domain.ErrEntityNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("error retrieving session: %w", err)
+	}
+	return &session, nil
+}
+
+// ListActiveSessions returns not revoked and not expired sessions of the user.
+func (r *Repository) ListActiveSessions(ctx context.Context, userID int) ([]*models.Session, error) {
+	var sessions []*models.Session
+	err := r.GetReadDB(ctx).
+		Where("user_id = ?", userID).
+		Where("revoked_at IS NULL").
+		Where("expires_at > ?", time.Now()).
+		Order("last_seen_at DESC").
+		Find(&sessions).Error
+	if err != nil {
+		return nil, fmt.Errorf("error listing sessions: %w", err)
+	}
+	return sessions, nil
+}
+
+func (r *Repository) RevokeSession(ctx context.Context, session *models.Session) error {
+	result := r.GetTx(ctx).
+		Model(session).
+		Where("revoked_at IS NULL").
+		Update("revoked_at", time.Now())
+	if result.Error != nil {
+		return fmt.Errorf("error revoking session: %w", result.Error)
+	}
+	return nil
+}
+
+// GetUserMFA always reads from master, last used step must be up to date.
+func (r *Repository) GetUserMFA(ctx context.Context, userID int) (*models.UserMFA, error) {
+	var mfa models.UserMFA
+	err := r.GetTx(ctx).Where("user_id = ?", userID).First(&mfa).Error
+	if r.IsNotFoundError(err) {
+		return nil, domain.ErrEntityNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("error retrieving user mfa: %w", err)
+	}
+	return &mfa, nil
+}
+
+// SaveUserMFA creates user mfa or replaces existing one.
+func (r *Repository) SaveUserMFA(ctx context.Context, mfa *models.UserMFA) error {
+	err := r.GetTx(ctx).
+		Clauses(clause.OnConflict{
+			Columns: []clause.Column{{Name: "user_id"}},
+			DoUpdates: clause.AssignmentColumns([]string{
+				"secret", "enabled_at", "last_used_step", "updated_at",
+			}),
+		}).
+		Create(mfa).Error
+	if err != nil {
+		return fmt.Errorf("error saving user mfa: %w", err)
+	}
+	return nil
+}
+
+// ConsumeMFAStep records step as used, if the same or later step
+// was already used, domain.ErrInvalidMFACode is returned.
+func (r *Repository) ConsumeMFAStep(ctx context.Context, userID int, step int64) error {
+	result := r.GetTx(ctx).
+		Model(&models.UserMFA{}).
+		Where("user_id = ?", userID).
+		Where("last_used_step < ?", step).
+		Updates(map[string]any{
+			"last_used_step": step,
+			"updated_at":     time.Now(),
+		})
+	if result.Error != nil {
+		return fmt.Errorf("error consuming mfa step: %w", result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return domain.ErrInvalidMFACode
+	}
+	return nil
+}
+
+// DeleteUserMFA deletes user mfa together with recovery codes.
+func (r *Repository) DeleteUserMFA(ctx context.Context, userID int) error {
+	err := r.GetTx(ctx).
+		Where("user_id = ?", userID).
+		Delete(&models.MFARecoveryCode{}).Error
+	if err != nil {
+		return fmt.Errorf("error deleting mfa recovery codes: %w", err)
+	}
+	err = r.GetTx(ctx).
+		Where("user_id = ?", userID).
+		Delete(&models.UserMFA{}).Error
+	if err != nil {
+		return fmt.Errorf("error deleting user mfa: %w", err)
+	}
+	return nil
+}
+
+// ReplaceMFARecoveryCodes deletes all recovery codes of the user and stores new ones.
+func (r *Repository) ReplaceMFARecoveryCodes(
+	ctx context.Context, userID int, codes []*models.MFARecoveryCode,
+) error {
+	err := r.GetTx(ctx).
+		Where("user_id = ?", userID).
+		Delete(&models.MFARecoveryCode{}).Error
+	if err != nil {
+		return fmt.Errorf("error deleting mfa recovery codes: %w", err)
+	}
+	if len(codes) == 0 {
+		return nil
+	}
+	if err := r.GetTx(ctx).Create(codes).Error; err != nil {
+		return fmt.Errorf("error creating mfa recovery codes: %w", err)
+	}
+	return nil
+}
+
+// UseMFARecoveryCode marks recovery code as used, if code does not exist
+// or was already used, domain.ErrInvalidMFACode is returned.
+func (r *Repository) UseMFARecoveryCode(ctx context.Context, userID int, codeHash string) error {
+	result := r.GetTx(ctx).
+		Model(&models.MFARecoveryCode{}).
+		Where("user_id = ?", userID).
+		Where("code_hash = ?", codeHash).
+		Where("used_at IS NULL").
+		Update("used_at", time.Now())
+	if result.Error != nil {
+		return fmt.Errorf("error using mfa recovery code: %w", result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return domain.ErrInvalidMFACode
+	}
+	return nil
+}
+
+// CountMFARecoveryCodes returns number of unused recovery codes of the user.
+func (r *Repository) CountMFARecoveryCodes(ctx context.Context, userID int) (int, error) {
+	var count int64
+	err := r.GetReadDB(ctx).
+		Model(&models.MFARecoveryCode{}).
+		Where("user_id = ?", userID).
+		Where("used_at IS NULL").
+		Count(&count).Error
+	if err != nil {
+		return 0, fmt.Errorf("error counting mfa recovery codes: %w", err)
+	}
+	return int(count), nil
+}
+
+func (r *Repository) ListOAuthClients(ctx context.Context) ([]*models.OAuthClient, error) {
+	var clients []*models.OAuthClient
+	err := r.GetReadDB(ctx).Order("created_at DESC").Find(&clients).Error
+	if err != nil {
+		return nil, fmt.Errorf("error listing oauth clients: %w", err)
+	}
+	return clients, nil
+}
+
+func (r *Repository) GetOAuthClient(ctx context.Context, clientID string) (*models.OAuthClient, error) {
+	var client models.OAuthClient
+	err := r.GetReadDB(ctx).Where("client_id = ?", clientID).First(&client).Error
+	if r.IsNotFoundError(err) {
+		return nil, domain.ErrEntityNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("error retrieving oauth client: %w", err)
+	}
+	return &client, nil
+}
+
+func (r *Repository) CreateOAuthClient(ctx context.Context, client *models.OAuthClient) error {
+	if err := r.GetTx(ctx).Create(client).Error; err != nil {
+		return fmt.Errorf("error creating oauth client: %w", err)
+	}
+	return nil
+}
+
+// DeleteOAuthClient deletes client, issued authorization codes are deleted by cascade.
+func (r *Repository) DeleteOAuthClient(ctx context.Context, client *models.OAuthClient) error {
+	if err := r.GetTx(ctx).Delete(client).Error; err != nil {
+		return fmt.Errorf("error deleting oauth client: %w", err)
+	}
+	return nil
+}
+
+func (r *Repository) CreateOAuthCode(ctx context.Context, code *models.OAuthCode) error {
+	if err := r.GetTx(ctx).Create(code).Error; err != nil {
+		return fmt.Errorf("error creating oauth code: %w", err)
+	}
+	return nil
+}
+
+// ConsumeOAuthCode marks code as used and returns it, if code does not exist,
+// expired or was already used, domain.ErrOAuthInvalidGrant is returned.
+// Should be called within transaction.
+func (r *Repository) ConsumeOAuthCode(ctx context.Context, codeHash string) (*models.OAuthCode, error) {
+	var code models.OAuthCode
+	err := r.GetTx(ctx).Where("code_hash = ?", codeHash).First(&code).Error
+	if r.IsNotFoundError(err) {
+		return nil, domain.ErrOAuthInvalidGrant
+	}
+	if err != nil {
+		return nil, fmt.Errorf("error retrieving oauth code: %w", err)
+	}
+	result := r.GetTx(ctx).
+		Model(&models.OAuthCode{}).
+		Where("id = ?", code.ID).
+		Where("used_at IS NULL").
+		Where("expires_at > ?", time.Now()).
+		Update("used_at", time.Now())
+	if result.Error != nil {
+		return nil, fmt.Errorf("error consuming oauth code: %w", result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return nil, domain.ErrOAuthInvalidGrant
+	}
+	return &code, nil
+}
+
+// DeleteExpiredOAuthCodes deletes expired and used codes of the user.
+func (r *Repository) DeleteExpiredOAuthCodes(ctx context.Context, userID int) error {
+	err := r.GetTx(ctx).
+		Where("user_id = ?", userID).
+		Where("expires_at < ? OR used_at IS NOT NULL", time.Now()).
+		Delete(&models.OAuthCode{}).Error
+	if err != nil {
+		return fmt.Errorf("error deleting expired oauth codes: %w", err)
+	}
+	return nil
+}
+
+func (r *Repository) GetUserIdentity(ctx context.Context, provider string, subject string) (*models.UserIdentity, error) {
+	var identity models.UserIdentity
+	err := r.GetReadDB(ctx).
+		Where("provider = ? AND subject = ?", provider, subject).
+		First(&identity).Error
+	if r.IsNotFoundError(err) {
+		return nil, domain.ErrEntityNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("error retrieving user identity: %w", err)
+	}
+	return &identity, nil
+}
+
+func (r *Repository) CreateUserIdentity(ctx context.Context, identity *models.UserIdentity) error {
+	if err := r.GetTx(ctx).Create(identity).Error; err != nil {
+		return fmt.Errorf("error creating user identity: %w", err)
+	}
+	return nil
+}
+
+func (r *Repository) UpdateUserIdentity(ctx context.Context, identity *models.UserIdentity) error {
+	err := r.GetTx(ctx).
+		Model(identity).
+		Updates(map[string]interface{}{
+			"email":         identity.Email,
+			"last_login_at": identity.LastLoginAt,
+		}).Error
+	if err != nil {
+		return fmt.Errorf("error updating user identity: %w", err)
+	}
+	return nil
+}
+
+// ListPasswordHistory returns most recent password hashes of the user, newest first.
+func (r *Repository) ListPasswordHistory(ctx context.Context, userID int, limit int) ([]*models.PasswordHistory, error) {
+	var history []*models.PasswordHistory
+	err := r.GetReadDB(ctx).
+		Where("user_id = ?", userID).
+		Order("id DESC").
+		Limit(limit).
+		Find(&history).Error
+	if err != nil {
+		return nil, fmt.Errorf("error listing password history: %w", err)
+	}
+	return history, nil
+}
+
+func (r *Repository) CreatePasswordHistory(ctx context.Context, entry *models.PasswordHistory) error {
+	if err := r.GetTx(ctx).Create(entry).Error; err != nil {
+		return fmt.Errorf("error creating password history: %w", err)
+	}
+	return nil
+}
+
+// DeletePasswordHistoryBefore deletes entries of the user older than entry with given id.
+func (r *Repository) DeletePasswordHistoryBefore(ctx context.Context, userID int, id int) error {
+	err := r.GetTx(ctx).
+		Where("user_id = ? AND id < ?", userID, id).
+		Delete(&models.PasswordHistory{}).Error
+	if err != nil {
+		return fmt.Errorf("error deleting password history: %w", err)
+	}
+	return nil
+}
+
+// ListOrganizations lists all organizations, or only active tenant.
+func (r *Repository) ListOrganizations(ctx context.Context) ([]*models.Organization, error) {
+	var organizations []*models.Organization
+	err := r.GetReadDB(ctx).
+		Scopes(r.TenantScope(ctx, "id")).
+		Order("id ASC").
+		Find(&organizations).Error
+	if err != nil {
+		return nil, fmt.Errorf("error listing organizations: %w", err)
+	}
+	return organizations, nil
+}
+
+// ListUserOrganizations lists organizations user is member of, regardless of active tenant.
+func (r *Repository) ListUserOrganizations(ctx context.Context, userID int) ([]*models.Organization, error) {
+	var organizations []*models.Organization
+	err := r.GetReadDB(ctx).
+		Joins("JOIN auth_organization_members ON auth_organization_members.organization_id = auth_organizations.id").
+		Where("auth_organization_members.user_id = ?", userID).
+		Order("auth_organizations.id ASC").
+		Find(&organizations).Error
+	if err != nil {
+		return nil, fmt.Errorf("error listing user organizations: %w", err)
+	}
+	return organizations, nil
+}
+
+// GetOrganizationByID is scoped to active tenant, same as GetOrganizationByUUID.
+func (r *Repository) GetOrganizationByID(ctx context.Context, id int) (*models.Organization, error) {
+	return r.getOrganization(ctx, "id", id)
+}
+
+// GetOrganizationByUUID finds organization, within active tenant only the tenant itself is found.
+func (r *Repository) GetOrganizationByUUID(ctx context.Context, uuid string) (*models.Organization, error) {
+	return r.getOrganization(ctx, "uuid", uuid)
+}
+
+func (r *Repository) getOrganization(ctx context.Context, column string, value any) (*models.Organization, error) {
+	var organization models.Organization
+	err := r.GetReadDB(ctx).
+		Scopes(r.TenantScope(ctx, "id")).
+		Where(clause.Eq{Column: clause.Column{Name: column}, Value: value}).
+		First(&organization).Error
+	if r.IsNotFoundError(err) {
+		return nil, domain.ErrEntityNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("error retrieving organization: %w", err)
+	}
+	return &organization, nil
+}
+
+func (r *Repository) CreateOrganization(ctx context.Context, organization *models.Organization) error {
+	err := r.GetTx(ctx).Create(organization).Error
+	if err != nil {
+		if r.IsDuplicateKeyError(err) {
+			return domain.ErrOrganizationExists
+		}
+		return fmt.Errorf("error creating organization: %w", err)
+	}
+	return nil
+}
+
+// DeleteOrganization removes organization together with memberships and their roles.
+// Should be called within transaction.
+func (r *Repository) DeleteOrganization(ctx context.Context, organization *models.Organization) error {
+	err := r.GetTx(ctx).
+		Where("organization_id = ?", organization.ID).
+		Delete(&models.OrganizationMemberRole{}).Error
+	if err != nil {
+		return fmt.Errorf("error deleting organization role assignments: %w", err)
+	}
+	err = r.GetTx(ctx).
+		Where("organization_id = ?", organization.ID).
+		Delete(&models.OrganizationMember{}).Error
+	if err != nil {
+		return fmt.Errorf("error deleting organization members: %w", err)
+	}
+	err = r.GetTx(ctx).
+		Model(&models.Session{}).
+		Where("organization_id = ?", organization.ID).
+		Update("organization_id", nil).Error
+	if err != nil {
+		return fmt.Errorf("error detaching organization sessions: %w", err)
+	}
+	result := r.GetTx(ctx).Delete(organization)
+	if result.Error != nil {
+		return fmt.Errorf("error deleting organization: %w", result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return domain.ErrEntityNotFound
+	}
+	return nil
+}
+
+// ListOrganizationMembers returns members together with users and roles within organization.
+func (r *Repository) ListOrganizationMembers(
+	ctx context.Context, organizationID int,
+) ([]*models.OrganizationMember, error) {
+	var members []*models.OrganizationMember
+	err := r.GetReadDB(ctx).
+		Scopes(r.TenantScope(ctx, "organization_id")).
+		Where("organization_id = ?", organizationID).
+		Order("user_id ASC").
+		Find(&members).Error
+	if err != nil {
+		return nil, fmt.Errorf("error listing organization members: %w", err)
+	}
+	if len(members) == 0 {
+		return members, nil
+	}
+
+	userIDs := make([]int, len(members))
+	for i, member := range members {
+		userIDs[i] = member.UserID
+	}
+	var users []*models.User
+	err = r.GetReadDB(ctx).Where("id IN ?", userIDs).Find(&users).Error
+	if err != nil {
+		return nil, fmt.Errorf("error fetching members: %w", err)
+	}
+	userMap := make(map[int]*models.User, len(users))
+	for _, user := range users {
+		userMap[user.ID] = user
+	}
+	for _, member := range members {
+		member.User = userMap[member.UserID]
+	}
+
+	if err := r.loadMemberRoles(ctx, organizationID, members...); err != nil {
+		return nil, err
+	}
+
+	return members, nil
+}
+
+// GetOrganizationMember returns membership with roles effective within organization.
+func (r *Repository) GetOrganizationMember(
+	ctx context.Context, organizationID int, userID int,
+) (*models.OrganizationMember, error) {
+	var member models.OrganizationMember
+	err := r.GetReadDB(ctx).
+		Scopes(r.TenantScope(ctx, "organization_id")).
+		Where("organization_id = ? AND user_id = ?", organizationID, userID).
+		First(&member).Error
+	if r.IsNotFoundError(err) {
+		return nil, domain.ErrEntityNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("error retrieving organization member: %w", err)
+	}
+	if err := r.loadMemberRoles(ctx, organizationID, &member); err != nil {
+		return nil, err
+	}
+	return &member, nil
+}
+
+// AddOrganizationMember is idempotent, adding existing member is not an error.
+func (r *Repository) AddOrganizationMember(ctx context.Context, member *models.OrganizationMember) error {
+	err := r.GetTx(ctx).
+		Clauses(clause.OnConflict{DoNothing: true}).
+		Create(member).Error
+	if err != nil {
+		return fmt.Errorf("error adding organization member: %w", err)
+	}
+	return nil
+}
+
+// RemoveOrganizationMember removes membership together with its roles.
+// Should be called within transaction.
+func (r *Repository) RemoveOrganizationMember(ctx context.Context, organizationID int, userID int) error {
+	err := r.GetTx(ctx).
+		Where("organization_id = ? AND user_id = ?", organizationID, userID).
+		Delete(&models.OrganizationMemberRole{}).Error
+	if err != nil {
+		return fmt.Errorf("error deleting member roles: %w", err)
+	}
+	result := r.GetTx(ctx).
+		Where("organization_id = ? AND user_id = ?", organizationID, userID).
+		Delete(&models.OrganizationMember{})
+	if result.Error != nil {
+		return fmt.Errorf("error removing organization member: %w", result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return domain.ErrEntityNotFound
+	}
+	return nil
+}
+
+// GrantOrganizationRole assigns role within organization, existing assignment is updated with new expiration.
+func (r *Repository) GrantOrganizationRole(ctx context.Context, memberRole *models.OrganizationMemberRole) error {
+	err := r.GetTx(ctx).
+		Clauses(clause.OnConflict{
+			Columns: []clause.Column{
+				{Name: "organization_id"}, {Name: "user_id"}, {Name: "role_id"},
+			},
+			DoUpdates: clause.AssignmentColumns([]string{"expires_at"}),
+		}).
+		Create(memberRole).Error
+	if err != nil {
+		return fmt.Errorf("error granting organization role: %w", err)
+	}
+	return nil
+}
+
+func (r *Repository) RevokeOrganizationRole(ctx context.Context, organizationID int, userID int, roleID int) error {
+	result := r.GetTx(ctx).
+		Where("organization_id = ? AND user_id = ? AND role_id = ?", organizationID, userID, roleID).
+		Delete(&models.OrganizationMemberRole{})
+	if result.Error != nil {
+		return fmt.Errorf("error revoking organization role: %w", result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return domain.ErrEntityNotFound
+	}
+	return nil
+}
+
+// loadMemberRoles loads not expired roles of members within organization.
+func (r *Repository) loadMemberRoles(
+	ctx context.Context, organizationID int, members ...*models.OrganizationMember,
+) error {
+	userIDs := make([]int, len(members))
+	for i, member := range members {
+		userIDs[i] = member.UserID
+	}
+
+	var memberRoles []struct {
+		UserID int
+		Role   models.Role `gorm:"embedded;embeddedPrefix:role_"`
+	}
+
+	err := r.GetReadDB(ctx).
+		Table("auth_organization_member_roles").
+		Select("auth_organization_member_roles.user_id, auth_roles.id as role_id, auth_roles.name as role_name, auth_roles.description as role_description, auth_roles.is_system as role_is_system, auth_roles.created_at as role_created_at, auth_roles.updated_at as role_updated_at").
+		Joins("JOIN auth_roles ON auth_roles.id = auth_organization_member_roles.role_id").
+		Where("auth_organization_member_roles.organization_id = ?", organizationID).
+		Where("auth_organization_member_roles.user_id IN ?", userIDs).
+		Where("auth_organization_member_roles.expires_at IS NULL OR auth_organization_member_roles.expires_at > ?", time.Now()).
+		Where("auth_roles.deleted_at IS NULL").
+		Order("auth_roles.id ASC").
+		Scan(&memberRoles).Error
+	if err != nil {
+		return fmt.Errorf("error fetching member roles: %w", err)
+	}
+
+	roleMap := make(map[int]*models.Role)
+	roles := make([]*models.Role, 0)
+	for _, mr := range memberRoles {
+		if _, exists := roleMap[mr.Role.ID]; !exists {
+			role := mr.Role
+			roleMap[role.ID] = &role
+			roles = append(roles, &role)
+		}
+	}
+	if err := r.loadRolePermissions(ctx, roles...); err != nil {
+		return err
+	}
+
+	memberMap := make(map[int]*models.OrganizationMember, len(members))
+	for _, member := range members {
+		member.Roles = nil
+		memberMap[member.UserID] = member
+	}
+	for _, mr := range memberRoles {
+		if member, exists := memberMap[mr.UserID]; exists {
+			member.Roles = append(member.Roles, *roleMap[mr.Role.ID])
+		}
+	}
+
+	return nil
+}
+
+func (r *Repository) CreateDataRequest(ctx context.Context, request *models.DataRequest) error {
+	if err := r.GetTx(ctx).Create(request).Error; err != nil {
+		return fmt.Errorf("error creating data request: %w", err)
+	}
+	return nil
+}
+
+func (r *Repository) GetDataRequestByID(ctx context.Context, id int) (*models.DataRequest, error) {
+	var request models.DataRequest
+	err := r.GetReadDB(ctx).
+		Omit("archive").
+		Where("id = ?", id).
+		First(&request).Error
+	if r.IsNotFoundError(err) {
+		return nil, domain.ErrEntityNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("error fetching data request: %w", err)
+	}
+	return &request, nil
+}
+
+// GetUserDataRequest returns data request of the user without its archive.
+// Erased users are soft deleted, their requests are still found.
+func (r *Repository) GetUserDataRequest(
+	ctx context.Context, userUUID string, requestUUID string,
+) (*models.DataRequest, error) {
+	var request models.DataRequest
+	err := r.GetReadDB(ctx).
+		Select(
+			"auth_data_requests.id", "auth_data_requests.uuid", "auth_data_requests.user_id",
+			"auth_data_requests.requested_by", "auth_data_requests.type", "auth_data_requests.status",
+			"auth_data_requests.error", "auth_data_requests.expires_at", "auth_data_requests.completed_at",
+			"auth_data_requests.created_at", "auth_data_requests.updated_at",
+		).
+		Joins("JOIN auth_users ON auth_users.id = auth_data_requests.user_id").
+		Where("auth_users.uuid = ?", userUUID).
+		Where("auth_data_requests.uuid = ?", requestUUID).
+		First(&request).Error
+	if r.IsNotFoundError(err) {
+		return nil, domain.ErrEntityNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("error fetching data request: %w", err)
+	}
+	return &request, nil
+}
+
+// GetDataRequestArchive returns archive of completed data request which has not expired yet.
+func (r *Repository) GetDataRequestArchive(ctx context.Context, id int) ([]byte, error) {
+	var request models.DataRequest
+	err := r.GetReadDB(ctx).
+		Select("archive").
+		Where("id = ?", id).
+		Where("status = ?", domain.DataRequestStatusCompleted).
+		Where("archive IS NOT NULL AND expires_at > ?", time.Now()).
+		First(&request).Error
+	if r.IsNotFoundError(err) {
+		return nil, domain.ErrEntityNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("error fetching data request archive: %w", err)
+	}
+	return request.Archive, nil
+}
+
+// ClaimDataRequest marks pending data request as being processed,
+// domain.ErrEntityNotFound is returned if request was already claimed.
+func (r *Repository) ClaimDataRequest(ctx context.Context, request *models.DataRequest) error {
+	result := r.GetTx(ctx).
+		Model(request).
+		Where("status = ?", domain.DataRequestStatusPending).
+		Update("status", domain.DataRequestStatusProcessing)
+	if result.Error != nil {
+		return fmt.Errorf("error claiming data request: %w", result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return domain.ErrEntityNotFound
+	}
+	return nil
+}
+
+// CompleteDataRequest saves result of processed data request.
+func (r *Repository) CompleteDataRequest(ctx context.Context, request *models.DataRequest) error {
+	columns := []string{"status", "error", "expires_at", "completed_at", "updated_at"}
+	if request.Archive != nil {
+		columns = append(columns, "archive")
+	}
+	err := r.GetTx(ctx).
+		Model(request).
+		Select(columns).
+		Updates(request).Error
+	if err != nil {
+		return fmt.Errorf("error completing data request: %w", err)
+	}
+	return nil
+}
+
+// DeleteExpiredDataArchives drops archives which expired before given time, requests themselves are kept.
+func (r *Repository) DeleteExpiredDataArchives(ctx context.Context, before time.Time) error {
+	err := r.GetTx(ctx).
+		Model(&models.DataRequest{}).
+		Where("archive IS NOT NULL AND expires_at <= ?", before).
+		Update("archive", nil).Error
+	if err != nil {
+		return fmt.Errorf("error deleting expired data archives: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,252 @@
+package auth
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/hasansino/go42/internal/auth/domain"
+	"github.com/hasansino/go42/internal/auth/models"
+	outboxDomain "github.com/hasansino/go42/internal/outbox/domain"
+)
+
+func (s *Service) ListRoles(ctx context.Context) ([]*models.Role, error) {
+	return s.repository.ListRoles(ctx)
+}
+
+// CreateRole creates global role, roles are not managed within tenant.
+func (s *Service) CreateRole(ctx context.Context, data *domain.CreateRoleData) (*models.Role, error) {
+	if err := requireNoTenant(ctx); err != nil {
+		return nil, err
+	}
+
+	permissions, err := s.getPermissions(ctx, data.Permissions)
+	if err != nil {
+		return nil, err
+	}
+
+	role := &models.Role{
+		Name:        data.Name,
+		Description: data.Description,
+		Permissions: permissions,
+	}
+
+	err = s.repository.WithTransaction(ctx, func(txCtx context.Context) error {
+		if err := s.repository.CreateRole(txCtx, role); err != nil {
+			return err
+		}
+		return s.sendRoleEvent(txCtx, domain.EventTypeRoleCreate, role.ID, map[string]any{
+			"role":        role.Name,
+			"permissions": role.PermissionList(),
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return role, nil
+}
+
+// DeleteRole deletes role and all its assignments, built-in roles can not be deleted.
+func (s *Service) DeleteRole(ctx context.Context, name string) error {
+	if err := requireNoTenant(ctx); err != nil {
+		return err
+	}
+
+	var users []*models.User
+
+	err := s.repository.WithTransaction(ctx, func(txCtx context.Context) error {
+		role, err := s.repository.GetRoleByName(txCtx, name)
+		if err != nil {
+			return fmt.Errorf("failed to get role: %w", err)
+		}
+		if role.IsSystem || slices.Contains(domain.RBACAllRoles, role.Name) {
+			return domain.ErrRoleIsBuiltIn
+		}
+		users, err = s.repository.ListRoleUsers(txCtx, role.ID)
+		if err != nil {
+			return fmt.Errorf("failed to list role users: %w", err)
+		}
+		if err := s.repository.DeleteRole(txCtx, role); err != nil {
+			return err
+		}
+		return s.sendRoleEvent(txCtx, domain.EventTypeRoleDelete, role.ID, map[string]any{
+			"role": role.Name,
+		})
+	})
+	if err != nil {
+		return err
+	}
+
+	s.repository.InvalidateUserCache(ctx, users...)
+
+	return nil
+}
+
+func (s *Service) AttachPermissionToRole(ctx context.Context, name string, permission string) error {
+	return s.changeRolePermission(
+		ctx, name, permission,
+		domain.EventTypeRolePermissionAttach, s.repository.AttachPermissionToRole,
+	)
+}
+
+func (s *Service) DetachPermissionFromRole(ctx context.Context, name string, permission string) error {
+	return s.changeRolePermission(
+		ctx, name, permission,
+		domain.EventTypeRolePermissionDetach, s.repository.DetachPermissionFromRole,
+	)
+}
+
+func (s *Service) changeRolePermission(
+	ctx context.Context, name string, permission string, eventType string,
+	change func(ctx context.Context, roleID int, permissionID int) error,
+) error {
+	if err := requireNoTenant(ctx); err != nil {
+		return err
+	}
+
+	permissions, err := s.getPermissions(ctx, []string{permission})
+	if err != nil {
+		return err
+	}
+
+	var users []*models.User
+
+	err = s.repository.WithTransaction(ctx, func(txCtx context.Context) error {
+		role, err := s.repository.GetRoleByName(txCtx, name)
+		if err != nil {
+			return fmt.Errorf("failed to get role: %w", err)
+		}
+		if err := change(txCtx, role.ID, permissions[0].ID); err != nil {
+			return err
+		}
+		users, err = s.repository.ListRoleUsers(txCtx, role.ID)
+		if err != nil {
+			return fmt.Errorf("failed to list role users: %w", err)
+		}
+		return s.sendRoleEvent(txCtx, eventType, role.ID, map[string]any{
+			"role":       role.Name,
+			"permission": permission,
+		})
+	})
+	if err != nil {
+		return err
+	}
+
+	s.repository.InvalidateUserCache(ctx, users...)
+
+	return nil
+}
+
+// GrantRoleToUser assigns role to user, optionally until given time.
+// Granting already assigned role updates its expiration.
+// Within tenant role is granted only within organization of the tenant.
+func (s *Service) GrantRoleToUser(ctx context.Context, userUUID string, name string, expiresAt *time.Time) error {
+	if _, tenantUUID, ok := activeTenant(ctx); ok {
+		return s.GrantOrganizationRole(ctx, tenantUUID, userUUID, name, expiresAt)
+	}
+
+	var user *models.User
+
+	err := s.repository.WithTransaction(ctx, func(txCtx context.Context) error {
+		var err error
+		user, err = s.repository.GetUserByUUID(txCtx, userUUID)
+		if err != nil {
+			return fmt.Errorf("failed to get user: %w", err)
+		}
+		role, err := s.repository.GetRoleByName(txCtx, name)
+		if err != nil {
+			return fmt.Errorf("failed to get role: %w", err)
+		}
+		userRole := &models.UserRole{
+			UserID: user.ID,
+			RoleID: role.ID,
+		}
+		payload := map[string]any{"role": role.Name}
+		if expiresAt != nil {
+			userRole.ExpiresAt = sql.Null[time.Time]{V: *expiresAt, Valid: true}
+			payload["expires_at"] = expiresAt
+		}
+		if err := s.repository.GrantRoleToUser(txCtx, userRole); err != nil {
+			return err
+		}
+		return s.sendRoleEvent(txCtx, domain.EventTypeUserRoleGrant, user.ID, payload)
+	})
+	if err != nil {
+		return err
+	}
+
+	s.repository.InvalidateUserCache(ctx, user)
+
+	return nil
+}
+
+// RevokeRoleFromUser revokes role, within tenant only role granted within organization of the tenant.
+func (s *Service) RevokeRoleFromUser(ctx context.Context, userUUID string, name string) error {
+	if _, tenantUUID, ok := activeTenant(ctx); ok {
+		return s.RevokeOrganizationRole(ctx, tenantUUID, userUUID, name)
+	}
+
+	var user *models.User
+
+	err := s.repository.WithTransaction(ctx, func(txCtx context.Context) error {
+		var err error
+		user, err = s.repository.GetUserByUUID(txCtx, userUUID)
+		if err != nil {
+			return fmt.Errorf("failed to get user: %w", err)
+		}
+		role, err := s.repository.GetRoleByName(txCtx, name)
+		if err != nil {
+			return fmt.Errorf("failed to get role: %w", err)
+		}
+		if err := s.repository.RevokeRoleFromUser(txCtx, user.ID, role.ID); err != nil {
+			return err
+		}
+		return s.sendRoleEvent(txCtx, domain.EventTypeUserRoleRevoke, user.ID, map[string]any{
+			"role": role.Name,
+		})
+	})
+	if err != nil {
+		return err
+	}
+
+	s.repository.InvalidateUserCache(ctx, user)
+
+	return nil
+}
+
+// getPermissions resolves permission names, all of them must exist.
+func (s *Service) getPermissions(ctx context.Context, names []string) ([]models.Permission, error) {
+	permissions, err := s.repository.GetPermissions(ctx, names)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get permissions: %w", err)
+	}
+	for _, name := range names {
+		found := slices.ContainsFunc(permissions, func(p models.Permission) bool {
+			return p.Resource+":"+p.Action == name
+		})
+		if !found {
+			return nil, fmt.Errorf("%w: permission %s", domain.ErrEntityNotFound, name)
+		}
+	}
+	return permissions, nil
+}
+
+func (s *Service) sendRoleEvent(ctx context.Context, eventType string, aggregateID int, data map[string]any) error {
+	payload, err := json.Marshal(data)
+	if err != nil {
+		return fmt.Errorf("failed to marshal event payload: %w", err)
+	}
+	event := outboxDomain.Message{
+		AggregateID:   aggregateID,
+		AggregateType: eventType,
+		Payload:       payload,
+	}
+	if err := s.sendEvent(ctx, domain.TopicNameAuthEvents, event); err != nil {
+		return fmt.Errorf("failed to send event: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,160 @@
+package auth
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/hasansino/go42/internal/auth/domain"
+	"github.com/hasansino/go42/internal/auth/models"
+	"github.com/hasansino/go42/internal/metrics"
+	outboxDomain "github.com/hasansino/go42/internal/outbox/domain"
+)
+
+const maxUserAgentLength = 512
+
+func (s *Service) ListUserSessions(ctx context.Context, userUUID string) ([]*models.Session, error) {
+	user, err := s.repository.GetUserByUUID(ctx, userUUID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get user: %w", err)
+	}
+	return s.repository.ListActiveSessions(ctx, user.ID)
+}
+
+// RevokeUserSession revokes single session, which must belong to given user.
+func (s *Service) RevokeUserSession(ctx context.Context, userUUID string, sessionUUID string) error {
+	user, err := s.repository.GetUserByUUID(ctx, userUUID)
+	if err != nil {
+		return fmt.Errorf("failed to get user: %w", err)
+	}
+
+	session, err := s.repository.GetSessionByUUID(ctx, sessionUUID)
+	if err != nil {
+		return fmt.Errorf("failed to get session: %w", err)
+	}
+	if session.UserID != user.ID || !session.IsActive() {
+		return domain.ErrEntityNotFound
+	}
+
+	err = s.repository.WithTransaction(ctx, func(txCtx context.Context) error {
+		return s.revokeSession(txCtx, session)
+	})
+	if err != nil {
+		return err
+	}
+
+	s.invalidateSessionAccessToken(ctx, session)
+
+	metrics.Counter("auth_sessions_revoked_total", map[string]interface{}{
+		"scope": "single",
+	}).Inc()
+
+	return nil
+}
+
+// RevokeUserSessions revokes all active sessions of the user.
+func (s *Service) RevokeUserSessions(ctx context.Context, userUUID string) error {
+	user, err := s.repository.GetUserByUUID(ctx, userUUID)
+	if err != nil {
+		return fmt.Errorf("failed to get user: %w", err)
+	}
+
+	sessions, err := s.repository.ListActiveSessions(ctx, user.ID)
+	if err != nil {
+		return fmt.Errorf("failed to list sessions: %w", err)
+	}
+
+	err = s.repository.WithTransaction(ctx, func(txCtx context.Context) error {
+		for _, session := range sessions {
+			if err := s.revokeSession(txCtx, session); err != nil {
+				return err
+			}
+		}
+		payload, err := json.Marshal(map[string]int{
+			"sessions": len(sessions),
+		})
+		if err != nil {
+			return fmt.Errorf("failed to marshal event payload: %w", err)
+		}
+		event := outboxDomain.Message{
+			AggregateID:   user.ID,
+			AggregateType: domain.EventTypeUserSessionsRevoke,
+			Payload:       payload,
+		}
+		if err := s.sendEvent(txCtx, domain.TopicNameAuthEvents, event); err != nil {
+			return fmt.Errorf("failed to send event: %w", err)
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	for _, session := range sessions {
+		s.invalidateSessionAccessToken(ctx, session)
+	}
+
+	metrics.Counter("auth_sessions_revoked_total", map[string]interface{}{
+		"scope": "all",
+	}).Inc()
+
+	return nil
+}
+
+// revokeTokenFamily revokes refresh token family and session started it, if any.
+// Should be called within transaction.
+func (s *Service) revokeTokenFamily(ctx context.Context, familyID uuid.UUID) (*models.Session, error) {
+	session, err := s.repository.GetSessionByFamilyID(ctx, familyID)
+	if errors.Is(err, domain.ErrEntityNotFound) {
+		if err := s.repository.RevokeRefreshTokenFamily(ctx, familyID); err != nil {
+			return nil, fmt.Errorf("failed to revoke refresh token family: %w", err)
+		}
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get session: %w", err)
+	}
+	return session, s.revokeSession(ctx, session)
+}
+
+// revokeSession revokes session together with its refresh token family.
+// Should be called within transaction.
+func (s *Service) revokeSession(ctx context.Context, session *models.Session) error {
+	if err := s.repository.RevokeSession(ctx, session); err != nil {
+		return fmt.Errorf("failed to revoke session: %w", err)
+	}
+	if err := s.repository.RevokeRefreshTokenFamily(ctx, session.FamilyID); err != nil {
+		return fmt.Errorf("failed to revoke refresh token family: %w", err)
+	}
+	return nil
+}
+
+// invalidateSessionAccessToken invalidates latest access token issued for session.
+// Failure is not critical, access tokens are short-lived.
+func (s *Service) invalidateSessionAccessToken(ctx context.Context, session *models.Session) {
+	expiresAt := session.LastSeenAt.Add(s.accessTokenTTL)
+	if expiresAt.Before(time.Now()) {
+		return
+	}
+	err := s.invalidateJWTTokenID(ctx, session.AccessTokenJTI.String(), expiresAt)
+	if err != nil {
+		s.logger.ErrorContext(
+			ctx, "failed to invalidate session access token",
+			slog.String("session_uuid", session.UUID.String()),
+			slog.Any("error", err),
+		)
+	}
+}
+
+func truncateString(str string, maxLength int) string {
+	runes := []rune(str)
+	if len(runes) <= maxLength {
+		return str
+	}
+	return string(runes[:maxLength])
+}
@@ -0,0 +1,304 @@
+package auth
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/hasansino/go42/internal/auth/domain"
+	"github.com/hasansino/go42/internal/auth/models"
+	"github.com/hasansino/go42/internal/metrics"
+	"github.com/hasansino/go42/internal/oidc"
+	outboxDomain "github.com/hasansino/go42/internal/outbox/domain"
+)
+
+const defaultSSOStateTTL = 10 * time.Minute
+
+type ssoProvider interface {
+	AuthCodeURL(ctx context.Context, state string, nonce string, codeChallenge string) (string, error)
+	Exchange(ctx context.Context, code string, codeVerifier string) (*oidc.Tokens, error)
+	VerifyIDToken(ctx context.Context, rawIDToken string, nonce string) (*oidc.Claims, error)
+}
+
+// SSOProvider is external identity provider users can sign in with.
+// Accounts are linked to existing users by verified email,
+// when AutoProvision is enabled, unknown users are created on first login.
+type SSOProvider struct {
+	Provider      ssoProvider
+	AutoProvision bool
+}
+
+// SSOProviders returns names of configured identity providers.
+func (s *Service) SSOProviders() []string {
+	names := make([]string, 0, len(s.ssoProviders))
+	for name := range s.ssoProviders {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+	return names
+}
+
+// StartSSO begins login with external identity provider.
+// State is a signed token bound to the verifier, which is also used as PKCE code verifier.
+func (s *Service) StartSSO(ctx context.Context, providerName string) (*domain.SSOAuthorization, error) {
+	provider, ok := s.ssoProviders[providerName]
+	if !ok {
+		return nil, domain.ErrEntityNotFound
+	}
+
+	verifier, err := generateAPIToken()
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate verifier: %w", err)
+	}
+
+	state, err := s.generateScopedToken(
+		providerName, domain.JWTScopeSSOState, strToSHA256(verifier), s.ssoStateTTL,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate state: %w", err)
+	}
+
+	authURL, err := provider.Provider.AuthCodeURL(ctx, state, ssoNonce(verifier), codeChallengeS256(verifier))
+	if err != nil {
+		s.logger.ErrorContext(
+			ctx, "failed to build sso authorization url",
+			slog.String("provider", providerName),
+			slog.Any("error", err),
+		)
+		return nil, domain.ErrSSOFailed
+	}
+
+	return &domain.SSOAuthorization{
+		AuthURL:   authURL,
+		Verifier:  verifier,
+		ExpiresAt: time.Now().Add(s.ssoStateTTL),
+	}, nil
+}
+
+// CompleteSSO handles callback of external identity provider.
+// Same as Login, challenge is returned instead of tokens when user has mfa enabled.
+func (s *Service) CompleteSSO(
+	ctx context.Context, providerName string, state string, code string, verifier string,
+	client domain.ClientInfo,
+) (*domain.Tokens, *domain.MFAChallenge, error) {
+	startTime := time.Now()
+	defer func() {
+		metrics.Histogram("auth_operation_duration_seconds", map[string]interface{}{
+			"operation": "sso_login",
+		}).Update(time.Since(startTime).Seconds())
+	}()
+
+	provider, ok := s.ssoProviders[providerName]
+	if !ok {
+		return nil, nil, domain.ErrEntityNotFound
+	}
+
+	claims, err := s.validateJWTToken(ctx, state, domain.JWTScopeSSOState)
+	if err != nil || claims.Subject != providerName || claims.Fingerprint != strToSHA256(verifier) {
+		s.countSSOLogin(providerName, "invalid_state")
+		return nil, nil, domain.ErrInvalidToken
+	}
+	if err := s.InvalidateJWTToken(ctx, state, claims.ExpiresAt.Time); err != nil {
+		s.logger.ErrorContext(ctx, "failed to invalidate sso state", slog.Any("error", err))
+	}
+
+	tokens, err := provider.Provider.Exchange(ctx, code, verifier)
+	if err != nil {
+		s.logger.WarnContext(
+			ctx, "sso code exchange failed",
+			slog.String("provider", providerName),
+			slog.Any("error", err),
+		)
+		s.countSSOLogin(providerName, "exchange_failed")
+		return nil, nil, domain.ErrSSOFailed
+	}
+
+	idClaims, err := provider.Provider.VerifyIDToken(ctx, tokens.IDToken, ssoNonce(verifier))
+	if err != nil {
+		s.logger.WarnContext(
+			ctx, "sso id token verification failed",
+			slog.String("provider", providerName),
+			slog.Any("error", err),
+		)
+		s.countSSOLogin(providerName, "invalid_id_token")
+		return nil, nil, domain.ErrSSOFailed
+	}
+
+	user, err := s.resolveSSOUser(ctx, providerName, provider, idClaims)
+	switch {
+	case errors.Is(err, domain.ErrUserNotProvisioned):
+		s.countSSOLogin(providerName, "not_provisioned")
+		return nil, nil, err
+	case errors.Is(err, domain.ErrSSOFailed):
+		s.countSSOLogin(providerName, "email_not_verified")
+		return nil, nil, err
+	case err != nil:
+		return nil, nil, err
+	}
+	if !user.IsActive() {
+		s.countSSOLogin(providerName, "user_inactive")
+		return nil, nil, domain.ErrInvalidCredentials
+	}
+
+	challenge, err := s.mfaChallenge(ctx, user)
+	if err != nil {
+		return nil, nil, err
+	}
+	if challenge != nil {
+		s.countSSOLogin(providerName, "mfa_required")
+		return nil, challenge, nil
+	}
+
+	result, err := s.completeLogin(ctx, user, client)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	s.countSSOLogin(providerName, "success")
+
+	return result, nil, nil
+}
+
+// resolveSSOUser finds user linked to external account. Unknown accounts are linked
+// to the user with the same email, or provisioned, if provider allows it.
+// Email must be verified by provider, otherwise it could be used to take over existing user.
+func (s *Service) resolveSSOUser(
+	ctx context.Context, providerName string, provider SSOProvider, claims *oidc.Claims,
+) (*models.User, error) {
+	email := strings.ToLower(strings.TrimSpace(claims.Email))
+
+	var (
+		user        *models.User
+		provisioned bool
+	)
+	err := s.repository.WithTransaction(ctx, func(txCtx context.Context) error {
+		identity, err := s.repository.GetUserIdentity(txCtx, providerName, claims.Subject)
+		if err != nil && !errors.Is(err, domain.ErrEntityNotFound) {
+			return fmt.Errorf("failed to get user identity: %w", err)
+		}
+		if identity != nil {
+			user, err = s.repository.GetUserByID(txCtx, identity.UserID)
+			if err != nil {
+				return fmt.Errorf("failed to get user: %w", err)
+			}
+			identity.LastLoginAt = time.Now()
+			if email != "" {
+				identity.Email = email
+			}
+			return s.repository.UpdateUserIdentity(txCtx, identity)
+		}
+
+		if email == "" || !claims.EmailVerified {
+			return fmt.Errorf("%w: verified email is required", domain.ErrSSOFailed)
+		}
+
+		user, err = s.repository.GetUserByEmail(txCtx, email)
+		switch {
+		case errors.Is(err, domain.ErrEntityNotFound):
+			if !provider.AutoProvision {
+				return domain.ErrUserNotProvisioned
+			}
+			user, err = s.provisionSSOUser(txCtx, email)
+			if err != nil {
+				return err
+			}
+			provisioned = true
+		case err != nil:
+			return fmt.Errorf("failed to get user: %w", err)
+		case !user.IsEmailVerified():
+			// provider confirmed ownership of the email
+			user.EmailVerifiedAt.V, user.EmailVerifiedAt.Valid = time.Now(), true
+			if user.Status == domain.UserStatusPending {
+				user.Status = domain.UserStatusActive
+			}
+			if err := s.repository.UpdateUser(txCtx, user); err != nil {
+				return fmt.Errorf("failed to update user: %w", err)
+			}
+		}
+
+		identity = &models.UserIdentity{
+			UserID:      user.ID,
+			Provider:    providerName,
+			Subject:     claims.Subject,
+			Email:       email,
+			LastLoginAt: time.Now(),
+		}
+		if err := s.repository.CreateUserIdentity(txCtx, identity); err != nil {
+			return err
+		}
+
+		payload, err := json.Marshal(map[string]string{
+			"provider": providerName,
+		})
+		if err != nil {
+			return fmt.Errorf("failed to marshal event payload: %w", err)
+		}
+		event := outboxDomain.Message{
+			AggregateID:   user.ID,
+			AggregateType: domain.EventTypeUserIdentityLink,
+			Payload:       payload,
+		}
+		if err := s.sendEvent(txCtx, domain.TopicNameAuthEvents, event); err != nil {
+			return fmt.Errorf("failed to send event: %w", err)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if provisioned {
+		metrics.Counter("auth_users_created_total", map[string]interface{}{
+			"method": "sso",
+		}).Inc()
+	} else {
+		s.repository.InvalidateUserCache(ctx, user)
+	}
+
+	return user, nil
+}
+
+// provisionSSOUser creates user without password, it can be set later with password reset.
+// Should be called within transaction.
+func (s *Service) provisionSSOUser(ctx context.Context, email string) (*models.User, error) {
+	user := &models.User{
+		UUID:   uuid.New(),
+		Email:  email,
+		Status: domain.UserStatusActive,
+	}
+	user.EmailVerifiedAt.V, user.EmailVerifiedAt.Valid = time.Now(), true
+
+	if err := s.repository.CreateUser(ctx, user); err != nil {
+		return nil, fmt.Errorf("failed to create user: %w", err)
+	}
+	if err := s.repository.AssignRoleToUser(ctx, user.ID, domain.RBACRoleUser); err != nil {
+		return nil, fmt.Errorf("failed to assign user role: %w", err)
+	}
+	event := outboxDomain.Message{
+		AggregateID:   user.ID,
+		AggregateType: domain.EventTypeAuthSignUp,
+	}
+	if err := s.sendEvent(ctx, domain.TopicNameAuthEvents, event); err != nil {
+		return nil, fmt.Errorf("failed to send event: %w", err)
+	}
+	return user, nil
+}
+
+func (s *Service) countSSOLogin(provider string, result string) {
+	metrics.Counter("auth_sso_logins_total", map[string]interface{}{
+		"provider": provider,
+		"result":   result,
+	}).Inc()
+}
+
+// ssoNonce derives nonce from verifier, so that it does not have to be stored.
+func ssoNonce(verifier string) string {
+	return strToSHA256("nonce:" + verifier)
+}
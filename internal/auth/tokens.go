@@ -0,0 +1,116 @@
+package auth
+
+import (
+	"context"
+	"crypto/rand"
+	"database/sql"
+	"encoding/base64"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/hasansino/go42/internal/auth/domain"
+	"github.com/hasansino/go42/internal/auth/models"
+	"github.com/hasansino/go42/internal/metrics"
+)
+
+const apiTokenLength = 32
+
+func (s *Service) ListAPITokens(ctx context.Context, userUUID string) ([]*models.Token, error) {
+	user, err := s.repository.GetUserByUUID(ctx, userUUID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get user: %w", err)
+	}
+	return s.repository.ListTokens(ctx, user.ID)
+}
+
+// CreateAPIToken creates new api token for the user.
+// Plaintext token is returned only once, only its hash is persisted.
+// Requested permissions must be held by the user, and, when request
+// itself is authenticated by api token or oauth client token, by that token as well.
+func (s *Service) CreateAPIToken(
+	ctx context.Context, userUUID string, data *domain.CreateAPITokenData,
+) (*models.Token, string, error) {
+	user, err := s.repository.GetUserByUUID(ctx, userUUID)
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to get user: %w", err)
+	}
+
+	userPermissions := user.PermissionList()
+	authInfo := RetrieveAuthFromContext(ctx)
+	for _, permission := range data.Permissions {
+		if !slices.Contains(userPermissions, permission) {
+			return nil, "", fmt.Errorf("%w: %s", domain.ErrPermissionDenied, permission)
+		}
+		if authInfo != nil && authInfo.Type != domain.AuthenticationTypeCredentials &&
+			!authInfo.HasPermission(permission) {
+			return nil, "", fmt.Errorf("%w: %s", domain.ErrPermissionDenied, permission)
+		}
+	}
+
+	permissions, err := s.repository.GetPermissions(ctx, data.Permissions)
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to get permissions: %w", err)
+	}
+
+	plainToken, err := generateAPIToken()
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to generate api token: %w", err)
+	}
+
+	apiToken := &models.Token{
+		UUID:        uuid.New(),
+		UserID:      user.ID,
+		Token:       strToSHA256(plainToken),
+		Name:        data.Name,
+		Permissions: permissions,
+	}
+	if data.ExpiresAt != nil {
+		apiToken.ExpiresAt = sql.Null[time.Time]{V: *data.ExpiresAt, Valid: true}
+	}
+
+	err = s.repository.WithTransaction(ctx, func(txCtx context.Context) error {
+		return s.repository.CreateToken(txCtx, apiToken)
+	})
+	if err != nil {
+		return nil, "", err
+	}
+
+	metrics.Counter("auth_api_tokens_created_total", nil).Inc()
+
+	return apiToken, plainToken, nil
+}
+
+// RevokeAPIToken revokes api token, which must belong to given user.
+func (s *Service) RevokeAPIToken(ctx context.Context, userUUID string, tokenUUID string) error {
+	user, err := s.repository.GetUserByUUID(ctx, userUUID)
+	if err != nil {
+		return fmt.Errorf("failed to get user: %w", err)
+	}
+
+	apiToken, err := s.repository.GetTokenByUUID(ctx, tokenUUID)
+	if err != nil {
+		return fmt.Errorf("failed to get api token: %w", err)
+	}
+	if apiToken.UserID != user.ID {
+		return domain.ErrEntityNotFound
+	}
+
+	if err := s.repository.DeleteToken(ctx, apiToken); err != nil {
+		return err
+	}
+
+	metrics.Counter("auth_api_tokens_revoked_total", nil).Inc()
+
+	return nil
+}
+
+func generateAPIToken() (string, error) {
+	b := make([]byte, apiTokenLength)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(b), nil
+}
@@ -0,0 +1,55 @@
+package auth
+
+import (
+	"testing"
+	"time"
+)
+
+// Test vectors from RFC 6238 appendix B, truncated to six digits.
+func TestTOTPCode(t *testing.T) {
+	secret := []byte("12345678901234567890")
+	tests := []struct {
+		unix int64
+		code string
+	}{
+		{59, "287082"},
+		{1111111109, "081804"},
+		{1111111111, "050471"},
+		{1234567890, "005924"},
+		{2000000000, "279037"},
+		{20000000000, "353130"},
+	}
+	for _, tt := range tests {
+		step := totpStep(time.Unix(tt.unix, 0))
+		if got := totpCode(secret, step); got != tt.code {
+			t.Errorf("totpCode(%d) = %s, want %s", tt.unix, got, tt.code)
+		}
+	}
+}
+
+func TestValidateTOTPCode(t *testing.T) {
+	secret, err := generateTOTPSecret()
+	if err != nil {
+		t.Fatal(err)
+	}
+	key, err := totpEncoding.DecodeString(secret)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	now := time.Now()
+	current := totpStep(now)
+
+	for _, offset := range []int64{-1, 0, 1} {
+		step, ok := validateTOTPCode(secret, totpCode(key, current+offset), now)
+		if !ok || step != current+offset {
+			t.Errorf("code of step offset %d rejected", offset)
+		}
+	}
+	if _, ok := validateTOTPCode(secret, totpCode(key, current+2), now); ok {
+		t.Error("code outside of allowed skew accepted")
+	}
+	if _, ok := validateTOTPCode(secret, "12345", now); ok {
+		t.Error("code of invalid length accepted")
+	}
+}
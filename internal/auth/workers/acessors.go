@@ -17,8 +17,13 @@ type repository interface {
 }
 
 type authService interface {
-	RotateJWTSecret(newSecret string)
+	RotateJWTSecret(ctx context.Context, newSecret string, generation int64, activeFrom time.Time) error
+	SyncJWTSecrets(ctx context.Context) error
+	JWTSecretGeneration() int64
 	RecentlyUsedTokensChan() <-chan domain.TokenWasUsed
+	SendAuthMail(ctx context.Context, userID int, mailType string) error
+	ProcessUserLifecycle(ctx context.Context) error
+	ProcessDataRequest(ctx context.Context, requestID int) error
 }
 
 type subscriber interface {
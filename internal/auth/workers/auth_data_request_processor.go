@@ -0,0 +1,86 @@
+package workers
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+
+	"github.com/hasansino/go42/internal/auth/domain"
+	"github.com/hasansino/go42/internal/metrics"
+	outboxDomain "github.com/hasansino/go42/internal/outbox/domain"
+)
+
+// AuthDataRequestProcessor processes data requests created by auth service through outbox.
+type AuthDataRequestProcessor struct {
+	logger  *slog.Logger
+	service authService
+}
+
+func NewAuthDataRequestProcessor(
+	service authService,
+	opts ...AuthDataRequestProcessorOption,
+) *AuthDataRequestProcessor {
+	processor := &AuthDataRequestProcessor{
+		service: service,
+	}
+	for _, o := range opts {
+		o(processor)
+	}
+	if processor.logger == nil {
+		processor.logger = slog.New(slog.DiscardHandler)
+	}
+	return processor
+}
+
+func (p *AuthDataRequestProcessor) Subscribe(ctx context.Context, subscriber subscriber) error {
+	return subscriber.Subscribe(ctx, domain.TopicNameAuthDataRequests, p.handleEvent)
+}
+
+func (p *AuthDataRequestProcessor) handleEvent(ctx context.Context, eventData []byte) error {
+	event := new(outboxDomain.Event)
+	err := json.Unmarshal(eventData, &event)
+	if err != nil {
+		p.logger.Error("failed to unmarshal event data")
+		metrics.Counter("application_errors", map[string]interface{}{
+			"type": "auth_data_request_processor_error",
+		}).Inc()
+		return fmt.Errorf("failed to unmarshal event: %w", err)
+	}
+
+	err = p.service.ProcessDataRequest(ctx, event.AggregateID)
+	if errors.Is(err, domain.ErrEntityNotFound) {
+		// request was deleted together with its user, nothing to retry
+		p.logger.Warn(
+			"queued data request not found",
+			slog.Int("request_id", event.AggregateID),
+			slog.String("type", event.AggregateType),
+		)
+		return nil
+	}
+	if err != nil {
+		p.logger.Error("failed to process data request", slog.Any("error", err))
+		metrics.Counter("application_errors", map[string]interface{}{
+			"type": "auth_data_request_processor_error",
+		}).Inc()
+		return fmt.Errorf("failed to process data request: %w", err)
+	}
+
+	p.logger.Debug(
+		"data request processed",
+		slog.Int("request_id", event.AggregateID),
+		slog.String("type", event.AggregateType),
+	)
+	metrics.Counter("application_auth_data_request_processor_processed", nil).Inc()
+
+	return nil
+}
+
+type AuthDataRequestProcessorOption func(*AuthDataRequestProcessor)
+
+func AuthDataRequestProcessorWithLogger(logger *slog.Logger) AuthDataRequestProcessorOption {
+	return func(o *AuthDataRequestProcessor) {
+		o.logger = logger
+	}
+}
@@ -50,6 +50,11 @@ func (s *AuthEventSubscriber) handleEvent(ctx context.Context, eventData []byte)
 
 	s.logger.Debug("received event", slog.Any("event", nil))
 
+	// erased users must not leave any history behind
+	if event.AggregateType == domain.EventTypeUserErase {
+		return nil
+	}
+
 	return s.repository.WithTransaction(ctx, func(txCtx context.Context) error {
 		eventLog := &models.UserHistoryRecord{
 			ID:         event.ID,
@@ -0,0 +1,86 @@
+package workers
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+
+	"github.com/hasansino/go42/internal/auth/domain"
+	"github.com/hasansino/go42/internal/metrics"
+	outboxDomain "github.com/hasansino/go42/internal/outbox/domain"
+)
+
+// AuthMailSender delivers mails requested by auth service through outbox.
+type AuthMailSender struct {
+	logger  *slog.Logger
+	service authService
+}
+
+func NewAuthMailSender(
+	service authService,
+	opts ...AuthMailSenderOption,
+) *AuthMailSender {
+	sender := &AuthMailSender{
+		service: service,
+	}
+	for _, o := range opts {
+		o(sender)
+	}
+	if sender.logger == nil {
+		sender.logger = slog.New(slog.DiscardHandler)
+	}
+	return sender
+}
+
+func (s *AuthMailSender) Subscribe(ctx context.Context, subscriber subscriber) error {
+	return subscriber.Subscribe(ctx, domain.TopicNameAuthMail, s.handleEvent)
+}
+
+func (s *AuthMailSender) handleEvent(ctx context.Context, eventData []byte) error {
+	event := new(outboxDomain.Event)
+	err := json.Unmarshal(eventData, &event)
+	if err != nil {
+		s.logger.Error("failed to unmarshal event data")
+		metrics.Counter("application_errors", map[string]interface{}{
+			"type": "auth_mail_sender_error",
+		}).Inc()
+		return fmt.Errorf("failed to unmarshal event: %w", err)
+	}
+
+	err = s.service.SendAuthMail(ctx, event.AggregateID, event.AggregateType)
+	if errors.Is(err, domain.ErrEntityNotFound) {
+		// user was deleted while mail was queued, nothing to retry
+		s.logger.Warn(
+			"user of queued mail not found",
+			slog.Int("user_id", event.AggregateID),
+			slog.String("type", event.AggregateType),
+		)
+		return nil
+	}
+	if err != nil {
+		s.logger.Error("failed to send mail", slog.Any("error", err))
+		metrics.Counter("application_errors", map[string]interface{}{
+			"type": "auth_mail_sender_error",
+		}).Inc()
+		return fmt.Errorf("failed to send mail: %w", err)
+	}
+
+	s.logger.Debug(
+		"mail sent",
+		slog.Int("user_id", event.AggregateID),
+		slog.String("type", event.AggregateType),
+	)
+	metrics.Counter("application_auth_mail_sender_processed", nil).Inc()
+
+	return nil
+}
+
+type AuthMailSenderOption func(*AuthMailSender)
+
+func AuthMailSenderWithLogger(logger *slog.Logger) AuthMailSenderOption {
+	return func(o *AuthMailSender) {
+		o.logger = logger
+	}
+}
@@ -2,19 +2,41 @@ package workers
 
 import (
 	"context"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
 	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
 	"encoding/base64"
+	"encoding/pem"
+	"errors"
 	"fmt"
 	"log/slog"
 	"time"
+
+	"github.com/hasansino/go42/internal/auth/domain"
 )
 
-const defaultSecretLength = 32 // 256 bits
+const (
+	defaultSecretLength   = 32 // 256 bits
+	defaultRotationPeriod = 24 * time.Hour
+	rsaKeyBits            = 2048
+)
 
+// SecretRotationWorker rotates JWT secrets shared between replicas.
+// Rotation periods are split into generations derived from wall clock,
+// each generation is rotated by one replica only, others pick up
+// new secret on next synchronisation.
+// Secret of next generation is created one period in advance and
+// becomes active when its generation starts, so every replica knows it
+// before first token is signed with it, as long as sync interval is shorter than period.
 type SecretRotationWorker struct {
-	logger       *slog.Logger
-	service      authService
-	secretLength int
+	logger         *slog.Logger
+	service        authService
+	secretLength   int
+	rotationPeriod time.Duration
+	algorithm      string
 }
 
 func NewSecretRotationWorker(
@@ -22,8 +44,10 @@ func NewSecretRotationWorker(
 	opts ...SecretRotationWorkerOption,
 ) *SecretRotationWorker {
 	r := &SecretRotationWorker{
-		service:      service,
-		secretLength: defaultSecretLength,
+		service:        service,
+		secretLength:   defaultSecretLength,
+		rotationPeriod: defaultRotationPeriod,
+		algorithm:      domain.JWTAlgorithmHS256,
 	}
 	for _, o := range opts {
 		o(r)
@@ -31,11 +55,16 @@ func NewSecretRotationWorker(
 	if r.logger == nil {
 		r.logger = slog.New(slog.DiscardHandler)
 	}
+	if r.rotationPeriod < time.Second {
+		r.rotationPeriod = time.Second
+	}
 	return r
 }
 
+// Run synchronises secrets with given interval, rotating them when current generation is due.
 func (w *SecretRotationWorker) Run(ctx context.Context, interval time.Duration) {
 	w.logger.InfoContext(ctx, "starting JWT secret rotation worker")
+	w.sync(ctx)
 	ticker := time.NewTicker(interval)
 	defer ticker.Stop()
 	for {
@@ -49,6 +78,17 @@ func (w *SecretRotationWorker) Run(ctx context.Context, interval time.Duration)
 }
 
 func (w *SecretRotationWorker) run(ctx context.Context) {
+	period := int64(w.rotationPeriod / time.Second)
+	current := time.Now().Unix() / period
+	// current generation is created only when it was not created in advance,
+	// e.g. on first start, and is used for signing right away
+	for generation := max(w.service.JWTSecretGeneration()+1, current); generation <= current+1; generation++ {
+		w.rotate(ctx, generation, time.Unix(generation*period, 0))
+	}
+	w.sync(ctx)
+}
+
+func (w *SecretRotationWorker) rotate(ctx context.Context, generation int64, activeFrom time.Time) {
 	newSecret, err := w.generateSecret()
 	if err != nil {
 		w.logger.ErrorContext(ctx, "failed to rotate JWT secret",
@@ -56,16 +96,63 @@ func (w *SecretRotationWorker) run(ctx context.Context) {
 		)
 		return
 	}
-	w.service.RotateJWTSecret(newSecret)
-	w.logger.InfoContext(ctx, "JWT secret rotated successfully")
+	err = w.service.RotateJWTSecret(ctx, newSecret, generation, activeFrom)
+	if errors.Is(err, domain.ErrJWTSecretAlreadyRotated) {
+		w.logger.DebugContext(ctx, "JWT secret already rotated by another instance",
+			slog.Int64("generation", generation),
+		)
+		return
+	}
+	if err != nil {
+		w.logger.ErrorContext(ctx, "failed to rotate JWT secret",
+			slog.Int64("generation", generation),
+			slog.Any("error", err),
+		)
+		return
+	}
+	w.logger.InfoContext(ctx, "JWT secret rotated successfully",
+		slog.Int64("generation", generation),
+		slog.Time("active_from", activeFrom),
+	)
 }
 
+func (w *SecretRotationWorker) sync(ctx context.Context) {
+	if err := w.service.SyncJWTSecrets(ctx); err != nil {
+		w.logger.ErrorContext(ctx, "failed to synchronise JWT secrets",
+			slog.Any("error", err),
+		)
+	}
+}
+
+// generateSecret returns random string for HMAC or PEM encoded private key
+// for asymmetric algorithms, secret length is ignored for the latter.
 func (w *SecretRotationWorker) generateSecret() (string, error) {
-	bytes := make([]byte, w.secretLength)
-	if _, err := rand.Read(bytes); err != nil {
-		return "", fmt.Errorf("failed to generate random bytes: %w", err)
+	var (
+		privateKey any
+		err        error
+	)
+	switch w.algorithm {
+	case domain.JWTAlgorithmRS256:
+		privateKey, err = rsa.GenerateKey(rand.Reader, rsaKeyBits)
+	case domain.JWTAlgorithmES256:
+		privateKey, err = ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	case domain.JWTAlgorithmEdDSA:
+		_, privateKey, err = ed25519.GenerateKey(rand.Reader)
+	default:
+		bytes := make([]byte, w.secretLength)
+		if _, err := rand.Read(bytes); err != nil {
+			return "", fmt.Errorf("failed to generate random bytes: %w", err)
+		}
+		return base64.URLEncoding.EncodeToString(bytes), nil
+	}
+	if err != nil {
+		return "", fmt.Errorf("failed to generate private key: %w", err)
+	}
+	der, err := x509.MarshalPKCS8PrivateKey(privateKey)
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal private key: %w", err)
 	}
-	return base64.URLEncoding.EncodeToString(bytes), nil
+	return string(pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: der})), nil
 }
 
 type SecretRotationWorkerOption func(*SecretRotationWorker)
@@ -81,3 +168,15 @@ func SecretRotationWorkerWithSecretLength(length int) SecretRotationWorkerOption
 		o.secretLength = length
 	}
 }
+
+func SecretRotationWorkerWithRotationPeriod(period time.Duration) SecretRotationWorkerOption {
+	return func(o *SecretRotationWorker) {
+		o.rotationPeriod = period
+	}
+}
+
+func SecretRotationWorkerWithAlgorithm(algorithm string) SecretRotationWorkerOption {
+	return func(o *SecretRotationWorker) {
+		o.algorithm = algorithm
+	}
+}
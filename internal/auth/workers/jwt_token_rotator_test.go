@@ -0,0 +1,129 @@
+package workers
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/pem"
+	"testing"
+	"time"
+
+	"go.uber.org/mock/gomock"
+
+	"github.com/hasansino/go42/internal/auth/domain"
+	"github.com/hasansino/go42/internal/auth/workers/mocks"
+)
+
+func TestSecretRotationWorker_run(t *testing.T) {
+	const period = time.Hour
+	current := time.Now().Unix() / int64(period/time.Second)
+	activeFrom := func(generation int64) time.Time {
+		return time.Unix(generation*int64(period/time.Second), 0)
+	}
+
+	t.Run("publishes next secret in advance", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		service := mocks.NewMockauthService(ctrl)
+		service.EXPECT().JWTSecretGeneration().Return(current)
+		service.EXPECT().RotateJWTSecret(gomock.Any(), gomock.Any(), current+1, activeFrom(current+1))
+		service.EXPECT().SyncJWTSecrets(gomock.Any())
+
+		worker := NewSecretRotationWorker(service, SecretRotationWorkerWithRotationPeriod(period))
+		worker.run(context.Background())
+	})
+
+	t.Run("creates current and next secret on first start", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		service := mocks.NewMockauthService(ctrl)
+		service.EXPECT().JWTSecretGeneration().Return(int64(0))
+		gomock.InOrder(
+			service.EXPECT().RotateJWTSecret(gomock.Any(), gomock.Any(), current, activeFrom(current)),
+			service.EXPECT().RotateJWTSecret(gomock.Any(), gomock.Any(), current+1, activeFrom(current+1)),
+		)
+		service.EXPECT().SyncJWTSecrets(gomock.Any())
+
+		worker := NewSecretRotationWorker(service, SecretRotationWorkerWithRotationPeriod(period))
+		worker.run(context.Background())
+	})
+
+	t.Run("does nothing when next secret exists", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		service := mocks.NewMockauthService(ctrl)
+		service.EXPECT().JWTSecretGeneration().Return(current + 1)
+		service.EXPECT().SyncJWTSecrets(gomock.Any())
+
+		worker := NewSecretRotationWorker(service, SecretRotationWorkerWithRotationPeriod(period))
+		worker.run(context.Background())
+	})
+
+	t.Run("ignores secret rotated by another instance", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		service := mocks.NewMockauthService(ctrl)
+		service.EXPECT().JWTSecretGeneration().Return(current)
+		service.EXPECT().RotateJWTSecret(gomock.Any(), gomock.Any(), current+1, gomock.Any()).
+			Return(domain.ErrJWTSecretAlreadyRotated)
+		service.EXPECT().SyncJWTSecrets(gomock.Any())
+
+		worker := NewSecretRotationWorker(service, SecretRotationWorkerWithRotationPeriod(period))
+		worker.run(context.Background())
+	})
+}
+
+func TestSecretRotationWorker_generateSecret(t *testing.T) {
+	t.Run(domain.JWTAlgorithmHS256, func(t *testing.T) {
+		worker := NewSecretRotationWorker(nil, SecretRotationWorkerWithSecretLength(48))
+		secret, err := worker.generateSecret()
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		raw, err := base64.URLEncoding.DecodeString(secret)
+		if err != nil {
+			t.Fatalf("secret is not base64: %v", err)
+		}
+		if len(raw) != 48 {
+			t.Errorf("secret length = %d, want 48", len(raw))
+		}
+		other, _ := worker.generateSecret()
+		if other == secret {
+			t.Errorf("generated secrets are equal")
+		}
+	})
+
+	tests := map[string]func(key any) bool{
+		domain.JWTAlgorithmRS256: func(key any) bool {
+			k, ok := key.(*rsa.PrivateKey)
+			return ok && k.N.BitLen() == rsaKeyBits
+		},
+		domain.JWTAlgorithmES256: func(key any) bool {
+			k, ok := key.(*ecdsa.PrivateKey)
+			return ok && k.Curve.Params().Name == "P-256"
+		},
+		domain.JWTAlgorithmEdDSA: func(key any) bool {
+			_, ok := key.(ed25519.PrivateKey)
+			return ok
+		},
+	}
+	for algorithm, check := range tests {
+		t.Run(algorithm, func(t *testing.T) {
+			worker := NewSecretRotationWorker(nil, SecretRotationWorkerWithAlgorithm(algorithm))
+			secret, err := worker.generateSecret()
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			block, _ := pem.Decode([]byte(secret))
+			if block == nil || block.Type != "PRIVATE KEY" {
+				t.Fatalf("secret is not pem encoded private key")
+			}
+			key, err := x509.ParsePKCS8PrivateKey(block.Bytes)
+			if err != nil {
+				t.Fatalf("failed to parse private key: %v", err)
+			}
+			if !check(key) {
+				t.Errorf("unexpected private key %T", key)
+			}
+		})
+	}
+}
@@ -109,6 +109,48 @@ func (m *MockauthService) EXPECT() *MockauthServiceMockRecorder {
 	return m.recorder
 }
 
+// JWTSecretGeneration mocks base method.
+func (m *MockauthService) JWTSecretGeneration() int64 {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "JWTSecretGeneration")
+	ret0, _ := ret[0].(int64)
+	return ret0
+}
+
+// JWTSecretGeneration indicates an expected call of JWTSecretGeneration.
+func (mr *MockauthServiceMockRecorder) JWTSecretGeneration() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "JWTSecretGeneration", reflect.TypeOf((*MockauthService)(nil).JWTSecretGeneration))
+}
+
+// ProcessDataRequest mocks base method.
+func (m *MockauthService) ProcessDataRequest(ctx context.Context, requestID int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ProcessDataRequest", ctx, requestID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ProcessDataRequest indicates an expected call of ProcessDataRequest.
+func (mr *MockauthServiceMockRecorder) ProcessDataRequest(ctx, requestID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ProcessDataRequest", reflect.TypeOf((*MockauthService)(nil).ProcessDataRequest), ctx, requestID)
+}
+
+// ProcessUserLifecycle mocks base method.
+func (m *MockauthService) ProcessUserLifecycle(ctx context.Context) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ProcessUserLifecycle", ctx)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ProcessUserLifecycle indicates an expected call of ProcessUserLifecycle.
+func (mr *MockauthServiceMockRecorder) ProcessUserLifecycle(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ProcessUserLifecycle", reflect.TypeOf((*MockauthService)(nil).ProcessUserLifecycle), ctx)
+}
+
 // RecentlyUsedTokensChan mocks base method.
 func (m *MockauthService) RecentlyUsedTokensChan() <-chan domain.TokenWasUsed {
 	m.ctrl.T.Helper()
@@ -124,15 +166,45 @@ func (mr *MockauthServiceMockRecorder) RecentlyUsedTokensChan() *gomock.Call {
 }
 
 // RotateJWTSecret mocks base method.
-func (m *MockauthService) RotateJWTSecret(newSecret string) {
+func (m *MockauthService) RotateJWTSecret(ctx context.Context, newSecret string, generation int64, activeFrom time.Time) error {
 	m.ctrl.T.Helper()
-	m.ctrl.Call(m, "RotateJWTSecret", newSecret)
+	ret := m.ctrl.Call(m, "RotateJWTSecret", ctx, newSecret, generation, activeFrom)
+	ret0, _ := ret[0].(error)
+	return ret0
 }
 
 // RotateJWTSecret indicates an expected call of RotateJWTSecret.
-func (mr *MockauthServiceMockRecorder) RotateJWTSecret(newSecret any) *gomock.Call {
+func (mr *MockauthServiceMockRecorder) RotateJWTSecret(ctx, newSecret, generation, activeFrom any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RotateJWTSecret", reflect.TypeOf((*MockauthService)(nil).RotateJWTSecret), ctx, newSecret, generation, activeFrom)
+}
+
+// SendAuthMail mocks base method.
+func (m *MockauthService) SendAuthMail(ctx context.Context, userID int, mailType string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SendAuthMail", ctx, userID, mailType)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SendAuthMail indicates an expected call of SendAuthMail.
+func (mr *MockauthServiceMockRecorder) SendAuthMail(ctx, userID, mailType any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SendAuthMail", reflect.TypeOf((*MockauthService)(nil).SendAuthMail), ctx, userID, mailType)
+}
+
+// SyncJWTSecrets mocks base method.
+func (m *MockauthService) SyncJWTSecrets(ctx context.Context) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SyncJWTSecrets", ctx)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SyncJWTSecrets indicates an expected call of SyncJWTSecrets.
+func (mr *MockauthServiceMockRecorder) SyncJWTSecrets(ctx any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RotateJWTSecret", reflect.TypeOf((*MockauthService)(nil).RotateJWTSecret), newSecret)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SyncJWTSecrets", reflect.TypeOf((*MockauthService)(nil).SyncJWTSecrets), ctx)
 }
 
 // Mocksubscriber is a mock of subscriber interface.
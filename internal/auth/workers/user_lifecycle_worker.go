@@ -0,0 +1,56 @@
+package workers
+
+import (
+	"context"
+	"log/slog"
+	"time"
+)
+
+// UserLifecycleWorker applies user lifecycle transitions which happen with time,
+// such as end of suspension or end of deletion grace period.
+type UserLifecycleWorker struct {
+	logger  *slog.Logger
+	service authService
+}
+
+func NewUserLifecycleWorker(
+	service authService,
+	opts ...UserLifecycleWorkerOption,
+) *UserLifecycleWorker {
+	w := &UserLifecycleWorker{
+		service: service,
+	}
+	for _, o := range opts {
+		o(w)
+	}
+	if w.logger == nil {
+		w.logger = slog.New(slog.DiscardHandler)
+	}
+	return w
+}
+
+func (w *UserLifecycleWorker) Run(ctx context.Context, interval time.Duration) {
+	w.logger.InfoContext(ctx, "starting user lifecycle worker")
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if err := w.service.ProcessUserLifecycle(ctx); err != nil {
+				w.logger.ErrorContext(ctx, "failed to process user lifecycle",
+					slog.Any("error", err),
+				)
+			}
+		}
+	}
+}
+
+type UserLifecycleWorkerOption func(*UserLifecycleWorker)
+
+func UserLifecycleWorkerWithLogger(logger *slog.Logger) UserLifecycleWorkerOption {
+	return func(o *UserLifecycleWorker) {
+		o.logger = logger
+	}
+}
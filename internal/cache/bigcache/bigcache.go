@@ -65,5 +65,9 @@ func (w *Wrapper) Set(_ context.Context, key string, value string, _ time.Durati
 }
 
 func (w *Wrapper) Invalidate(_ context.Context, key string) error {
-	return w.cache.Delete(key)
+	err := w.cache.Delete(key)
+	if errors.Is(err, bigcache.ErrEntryNotFound) {
+		return nil
+	}
+	return err
 }
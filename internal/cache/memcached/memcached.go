@@ -72,5 +72,9 @@ func (w *Wrapper) Set(_ context.Context, key string, value string, ttl time.Dura
 }
 
 func (w *Wrapper) Invalidate(_ context.Context, key string) error {
-	return w.client.Delete(key)
+	err := w.client.Delete(key)
+	if errors.Is(err, memcache.ErrCacheMiss) {
+		return nil
+	}
+	return err
 }
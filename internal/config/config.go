@@ -29,6 +29,7 @@ type Config struct {
 	Pprof    Pprof
 	Server   Server
 	Outbox   Outbox
+	Mailer   Mailer
 	Auth     Auth
 }
 
@@ -494,6 +495,56 @@ type GRPCRateLimiter struct {
 type Outbox struct {
 	WorkerRunInterval time.Duration `env:"OUTBOX_WORKER_INTERVAL"   default:"5s"`
 	WorkerBatchSize   int           `env:"OUTBOX_WORKER_BATCH_SIZE" default:"1000"`
+	MaxRetries        int           `env:"OUTBOX_MAX_RETRIES"       default:"3"   v:"min=1"`
+	RetryBaseDelay    time.Duration `env:"OUTBOX_RETRY_BASE_DELAY"  default:"1s"`
+	RetryMaxDelay     time.Duration `env:"OUTBOX_RETRY_MAX_DELAY"   default:"10m"`
+	Topics            []OutboxTopic `envPrefix:"OUTBOX_TOPICS_"                   v:"dive"`
+	ListenNotify      bool          `env:"OUTBOX_LISTEN_NOTIFY"     default:"true"`
+	Relay             string        `env:"OUTBOX_RELAY"             default:"poll" v:"oneof=poll wal"`
+	Cleanup           struct {
+		Enabled   bool          `env:"OUTBOX_CLEANUP_ENABLED"    default:"true"`
+		Interval  time.Duration `env:"OUTBOX_CLEANUP_INTERVAL"   default:"1m"`
+		Retention time.Duration `env:"OUTBOX_CLEANUP_RETENTION"  default:"168h"`
+		BatchSize int           `env:"OUTBOX_CLEANUP_BATCH_SIZE" default:"1000" v:"min=1"`
+		Archive   bool          `env:"OUTBOX_CLEANUP_ARCHIVE"    default:"false"`
+	}
+	WAL struct {
+		SlotName       string        `env:"OUTBOX_WAL_SLOT_NAME"       default:"go42_outbox" v:"required"`
+		StatusInterval time.Duration `env:"OUTBOX_WAL_STATUS_INTERVAL" default:"10s"`
+		ReconnectDelay time.Duration `env:"OUTBOX_WAL_RECONNECT_DELAY" default:"5s"`
+		FallbackDelay  time.Duration `env:"OUTBOX_WAL_FALLBACK_DELAY"  default:"1m"`
+	}
+}
+
+// OutboxTopic overrides outbox settings of the topic,
+// configured with indexed variables, e.g. OUTBOX_TOPICS_0_NAME.
+type OutboxTopic struct {
+	Name       string `env:"NAME"        v:"required"`
+	MaxRetries int    `env:"MAX_RETRIES" v:"min=1"`
+}
+
+// ╭──────────────────────────────╮
+// │            MAILER            │
+// ╰──────────────────────────────╯
+
+type Mailer struct {
+	Engine string `env:"MAILER_ENGINE" default:"log"               v:"oneof=none log file smtp"`
+	From   string `env:"MAILER_FROM"   default:"noreply@localhost"`
+	File   MailerFile
+	SMTP   MailerSMTP
+}
+
+type MailerFile struct {
+	Directory string `env:"MAILER_FILE_DIRECTORY" default:"mail"`
+}
+
+type MailerSMTP struct {
+	Host     string        `env:"MAILER_SMTP_HOST"     default:"localhost"`
+	Port     int           `env:"MAILER_SMTP_PORT"     default:"587"`
+	Username string        `env:"MAILER_SMTP_USERNAME" default:""`
+	Password string        `env:"MAILER_SMTP_PASSWORD" default:""`
+	TLS      bool          `env:"MAILER_SMTP_TLS"      default:"false"`
+	Timeout  time.Duration `env:"MAILER_SMTP_TIMEOUT"  default:"10s"`
 }
 
 // ╭──────────────────────────────╮
@@ -512,15 +563,91 @@ type Auth struct {
 	}
 	JWT struct {
 		InitialSecrets  []string      `env:"AUTH_JWT_SECRETS"               default:"0128899,9988210"`
+		EncryptionKey   string        `env:"AUTH_JWT_ENCRYPTION_KEY"        default:"0128899"         v:"required"`
 		AccessTokenTTL  time.Duration `env:"AUTH_JWT_ACCESS_TOKEN_TTL"      default:"15m"`
 		RefreshTokenTTL time.Duration `env:"AUTH_JWT_REFRESH_TOKEN_TTL"     default:"168h"`
 		Issuer          string        `env:"AUTH_JWT_ISSUER"                default:"go42"`
 		Audience        []string      `env:"AUTH_JWT_AUDIENCE"              default:"go42"`
 	}
 	Rotation struct {
-		Period       time.Duration `env:"AUTH_ROTATION_PERIOD"  default:"24h"`
+		Period       time.Duration `env:"AUTH_ROTATION_PERIOD"        default:"24h"`
+		SyncInterval time.Duration `env:"AUTH_ROTATION_SYNC_INTERVAL" default:"1m"    v:"ltfield=Period"`
 		SecretLength int           `env:"AUTH_ROTATION_SECRET_LENGTH" default:"32"`
+		Algorithm    string        `env:"AUTH_ROTATION_ALGORITHM"     default:"HS256" v:"oneof=HS256 RS256 ES256 EdDSA"`
 	}
+	Password struct {
+		Algorithm         string `env:"AUTH_PASSWORD_ALGORITHM"          default:"argon2id" v:"oneof=argon2id bcrypt"`
+		BcryptCost        int    `env:"AUTH_PASSWORD_BCRYPT_COST"        default:"10"       v:"min=4,max=31"`
+		Argon2Memory      uint32 `env:"AUTH_PASSWORD_ARGON2_MEMORY"      default:"19456"    v:"min=1024"`
+		Argon2Iterations  uint32 `env:"AUTH_PASSWORD_ARGON2_ITERATIONS"  default:"2"        v:"min=1,max=64"`
+		Argon2Parallelism uint8  `env:"AUTH_PASSWORD_ARGON2_PARALLELISM" default:"1"        v:"min=1"`
+		MinLength         int    `env:"AUTH_PASSWORD_MIN_LENGTH"         default:"8"        v:"min=1"`
+		MaxLength         int    `env:"AUTH_PASSWORD_MAX_LENGTH"         default:"24"       v:"gtefield=MinLength"`
+		BreachListPath    string `env:"AUTH_PASSWORD_BREACH_LIST_PATH"   default:""`
+		HistorySize       int    `env:"AUTH_PASSWORD_HISTORY_SIZE"       default:"5"        v:"min=0"`
+	}
+	Lockout struct {
+		IdentityMaxAttempts int           `env:"AUTH_LOCKOUT_IDENTITY_MAX_ATTEMPTS" default:"20" v:"min=0"`
+		ClientMaxAttempts   int           `env:"AUTH_LOCKOUT_CLIENT_MAX_ATTEMPTS"   default:"5"  v:"min=0"`
+		Window              time.Duration `env:"AUTH_LOCKOUT_WINDOW"                default:"15m"`
+		Duration            time.Duration `env:"AUTH_LOCKOUT_DURATION"              default:"1m"`
+		MaxDuration         time.Duration `env:"AUTH_LOCKOUT_MAX_DURATION"          default:"1h"`
+	}
+	MFA struct {
+		Issuer       string        `env:"AUTH_MFA_ISSUER"        default:"go42"`
+		ChallengeTTL time.Duration `env:"AUTH_MFA_CHALLENGE_TTL" default:"5m"`
+	}
+	Impersonation struct {
+		TokenTTL time.Duration `env:"AUTH_IMPERSONATION_TOKEN_TTL" default:"10m"`
+	}
+	Lifecycle struct {
+		DeletionGracePeriod time.Duration `env:"AUTH_LIFECYCLE_DELETION_GRACE_PERIOD" default:"720h"`
+		DeletionMode        string        `env:"AUTH_LIFECYCLE_DELETION_MODE"         default:"anonymize" v:"oneof=anonymize erase"`
+		WorkerInterval      time.Duration `env:"AUTH_LIFECYCLE_WORKER_INTERVAL"       default:"1m"`
+	}
+	DataRequests struct {
+		ArchiveTTL time.Duration `env:"AUTH_DATA_REQUESTS_ARCHIVE_TTL" default:"168h"`
+	}
+	Email struct {
+		VerificationRequired bool          `env:"AUTH_EMAIL_VERIFICATION_REQUIRED" default:"false"`
+		VerificationURL      string        `env:"AUTH_EMAIL_VERIFICATION_URL"      default:""`
+		VerificationTTL      time.Duration `env:"AUTH_EMAIL_VERIFICATION_TTL"      default:"24h"`
+		PasswordResetURL     string        `env:"AUTH_EMAIL_PASSWORD_RESET_URL"    default:""`
+		PasswordResetTTL     time.Duration `env:"AUTH_EMAIL_PASSWORD_RESET_TTL"    default:"1h"`
+	}
+	OAuth struct {
+		IssuerURL string        `env:"AUTH_OAUTH_ISSUER_URL" default:"http://localhost:8080" v:"required,url"`
+		LoginURL  string        `env:"AUTH_OAUTH_LOGIN_URL"  default:""                      v:"omitempty,url"`
+		CodeTTL   time.Duration `env:"AUTH_OAUTH_CODE_TTL"   default:"1m"`
+	}
+	SSO struct {
+		CallbackURL string        `env:"AUTH_SSO_CALLBACK_URL" default:"http://localhost:8080/api/v1/auth/sso" v:"required,url"`
+		StateTTL    time.Duration `env:"AUTH_SSO_STATE_TTL"    default:"10m"`
+		Providers   []SSOProvider `envPrefix:"AUTH_SSO_PROVIDERS_"                                       v:"dive"`
+	}
+	Policy struct {
+		Rules []PolicyRule `envPrefix:"AUTH_POLICY_RULES_" v:"dive"`
+	}
+}
+
+// SSOProvider is external OpenID Connect identity provider,
+// configured with indexed variables, e.g. AUTH_SSO_PROVIDERS_0_NAME.
+type SSOProvider struct {
+	Name          string   `env:"NAME"           v:"required,alphanum"`
+	IssuerURL     string   `env:"ISSUER_URL"     v:"required,url"`
+	ClientID      string   `env:"CLIENT_ID"      v:"required"`
+	ClientSecret  string   `env:"CLIENT_SECRET"`
+	Scopes        []string `env:"SCOPES"         default:"openid,email"`
+	AutoProvision bool     `env:"AUTO_PROVISION" default:"true"`
+}
+
+// PolicyRule is access policy rule with CEL condition,
+// configured with indexed variables, e.g. AUTH_POLICY_RULES_0_NAME.
+type PolicyRule struct {
+	Name      string `env:"NAME"                      v:"required"`
+	Action    string `env:"ACTION"                    v:"required"`
+	Effect    string `env:"EFFECT"    default:"allow" v:"oneof=allow deny"`
+	Condition string `env:"CONDITION"                 v:"required"`
 }
 
 // ---
@@ -0,0 +1,97 @@
+package pgsql
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+)
+
+const defaultListenerReconnectDelay = 5 * time.Second
+
+// Listener receives notifications sent with NOTIFY to the channel.
+// It holds dedicated connection, which is not a part of connection pool.
+type Listener struct {
+	logger         *slog.Logger
+	dsn            string
+	channel        string
+	reconnectDelay time.Duration
+}
+
+func NewListener(dsn string, channel string, opts ...ListenerOption) *Listener {
+	l := &Listener{
+		dsn:            dsn,
+		channel:        channel,
+		reconnectDelay: defaultListenerReconnectDelay,
+	}
+	for _, opt := range opts {
+		opt(l)
+	}
+	if l.logger == nil {
+		l.logger = slog.New(slog.DiscardHandler)
+	}
+	return l
+}
+
+// Listen calls handler with payload of every notification until context is cancelled.
+// Lost connection is re-established, notifications sent while disconnected are lost.
+func (l *Listener) Listen(ctx context.Context, handler func(payload string)) {
+	for {
+		err := l.listen(ctx, handler)
+		if ctx.Err() != nil {
+			return
+		}
+		l.logger.WarnContext(ctx, "listener connection lost, reconnecting...",
+			slog.String("channel", l.channel),
+			slog.Any("error", err),
+		)
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(l.reconnectDelay):
+		}
+	}
+}
+
+func (l *Listener) listen(ctx context.Context, handler func(payload string)) error {
+	conn, err := pgx.Connect(ctx, l.dsn)
+	if err != nil {
+		return fmt.Errorf("failed to connect: %w", err)
+	}
+	defer func() {
+		closeCtx, cancel := context.WithTimeout(context.Background(), time.Second)
+		defer cancel()
+		_ = conn.Close(closeCtx)
+	}()
+
+	_, err = conn.Exec(ctx, "listen "+pgx.Identifier{l.channel}.Sanitize())
+	if err != nil {
+		return fmt.Errorf("failed to listen: %w", err)
+	}
+
+	l.logger.InfoContext(ctx, "listening for notifications", slog.String("channel", l.channel))
+
+	for {
+		notification, err := conn.WaitForNotification(ctx)
+		if err != nil {
+			return fmt.Errorf("failed to wait for notification: %w", err)
+		}
+		handler(notification.Payload)
+	}
+}
+
+type ListenerOption func(l *Listener)
+
+func ListenerWithLogger(logger *slog.Logger) ListenerOption {
+	return func(l *Listener) {
+		l.logger = logger
+	}
+}
+
+func ListenerWithReconnectDelay(d time.Duration) ListenerOption {
+	return func(l *Listener) {
+		l.reconnectDelay = d
+	}
+}
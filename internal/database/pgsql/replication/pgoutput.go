@@ -0,0 +1,235 @@
+package replication
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"time"
+)
+
+// Decoder of pgoutput plugin messages, protocol version 1.
+// Only messages required to follow inserts are decoded, others are skipped.
+// @see https://www.postgresql.org/docs/current/protocol-logicalrep-message-formats.html
+
+const (
+	messageBegin    byte = 'B'
+	messageCommit   byte = 'C'
+	messageRelation byte = 'R'
+	messageInsert   byte = 'I'
+
+	tupleNull      byte = 'n'
+	tupleUnchanged byte = 'u'
+	tupleText      byte = 't'
+)
+
+var errMalformedMessage = errors.New("malformed pgoutput message")
+
+// postgresEpoch is start of time in replication protocol.
+var postgresEpoch = time.Date(2000, time.January, 1, 0, 0, 0, 0, time.UTC)
+
+// LSN is position in write-ahead log.
+type LSN uint64
+
+func (lsn LSN) String() string {
+	return fmt.Sprintf("%X/%X", uint32(lsn>>32), uint32(lsn))
+}
+
+// Transaction is committed transaction with inserted rows of published tables.
+type Transaction struct {
+	CommitLSN  LSN
+	EndLSN     LSN
+	CommitTime time.Time
+	Inserts    []Insert
+}
+
+// Insert is inserted row, values are in text format, NULL values are nil.
+type Insert struct {
+	Namespace string
+	Table     string
+	Values    map[string]*string
+}
+
+type relation struct {
+	namespace string
+	table     string
+	columns   []string
+}
+
+// decoder assembles transactions from pgoutput messages,
+// relations are sent once per session before first change of the table.
+type decoder struct {
+	relations map[uint32]relation
+	current   *Transaction
+}
+
+func newDecoder() *decoder {
+	return &decoder{relations: make(map[uint32]relation)}
+}
+
+// inTransaction reports whether begin of transaction is decoded, but its commit is not.
+func (d *decoder) inTransaction() bool {
+	return d.current != nil
+}
+
+// decode returns transaction when its commit message is decoded.
+func (d *decoder) decode(data []byte) (*Transaction, error) {
+	if len(data) == 0 {
+		return nil, errMalformedMessage
+	}
+	r := &reader{data: data[1:]}
+	switch data[0] {
+	case messageBegin:
+		r.uint64() // final lsn
+		commitTime := r.time()
+		r.uint32() // xid
+		if r.err != nil {
+			return nil, r.err
+		}
+		d.current = &Transaction{CommitTime: commitTime}
+	case messageCommit:
+		r.byte() // flags
+		commitLSN := LSN(r.uint64())
+		endLSN := LSN(r.uint64())
+		if r.err != nil {
+			return nil, r.err
+		}
+		if d.current == nil {
+			return nil, fmt.Errorf("%w: commit without begin", errMalformedMessage)
+		}
+		tx := d.current
+		tx.CommitLSN, tx.EndLSN = commitLSN, endLSN
+		d.current = nil
+		return tx, nil
+	case messageRelation:
+		id := r.uint32()
+		rel := relation{
+			namespace: r.string(),
+			table:     r.string(),
+		}
+		r.byte() // replica identity
+		columns := int(r.uint16())
+		for i := 0; i < columns && r.err == nil; i++ {
+			r.byte() // flags
+			rel.columns = append(rel.columns, r.string())
+			r.uint32() // type oid
+			r.uint32() // type modifier
+		}
+		if r.err != nil {
+			return nil, r.err
+		}
+		d.relations[id] = rel
+	case messageInsert:
+		id := r.uint32()
+		if r.byte() != 'N' {
+			return nil, fmt.Errorf("%w: insert without new tuple", errMalformedMessage)
+		}
+		rel, ok := d.relations[id]
+		if !ok {
+			return nil, fmt.Errorf("%w: unknown relation %d", errMalformedMessage, id)
+		}
+		values, err := r.tuple(rel.columns)
+		if err != nil {
+			return nil, err
+		}
+		if d.current == nil {
+			return nil, fmt.Errorf("%w: insert without begin", errMalformedMessage)
+		}
+		d.current.Inserts = append(d.current.Inserts, Insert{
+			Namespace: rel.namespace,
+			Table:     rel.table,
+			Values:    values,
+		})
+	}
+	return nil, nil
+}
+
+// reader reads big endian values, first error is kept and stops reading.
+type reader struct {
+	data []byte
+	err  error
+}
+
+func (r *reader) next(n int) []byte {
+	if r.err != nil {
+		return nil
+	}
+	if len(r.data) < n {
+		r.err = errMalformedMessage
+		return nil
+	}
+	b := r.data[:n]
+	r.data = r.data[n:]
+	return b
+}
+
+func (r *reader) byte() byte {
+	if b := r.next(1); b != nil {
+		return b[0]
+	}
+	return 0
+}
+
+func (r *reader) uint16() uint16 {
+	if b := r.next(2); b != nil {
+		return binary.BigEndian.Uint16(b)
+	}
+	return 0
+}
+
+func (r *reader) uint32() uint32 {
+	if b := r.next(4); b != nil {
+		return binary.BigEndian.Uint32(b)
+	}
+	return 0
+}
+
+func (r *reader) uint64() uint64 {
+	if b := r.next(8); b != nil {
+		return binary.BigEndian.Uint64(b)
+	}
+	return 0
+}
+
+// time reads microseconds since postgres epoch.
+func (r *reader) time() time.Time {
+	return postgresEpoch.Add(time.Duration(int64(r.uint64())) * time.Microsecond)
+}
+
+// string reads null terminated string.
+func (r *reader) string() string {
+	if r.err != nil {
+		return ""
+	}
+	i := bytes.IndexByte(r.data, 0)
+	if i < 0 {
+		r.err = errMalformedMessage
+		return ""
+	}
+	s := string(r.data[:i])
+	r.data = r.data[i+1:]
+	return s
+}
+
+func (r *reader) tuple(columns []string) (map[string]*string, error) {
+	count := int(r.uint16())
+	if r.err == nil && count != len(columns) {
+		return nil, fmt.Errorf("%w: tuple has %d columns, relation has %d", errMalformedMessage, count, len(columns))
+	}
+	values := make(map[string]*string, count)
+	for i := 0; i < count && r.err == nil; i++ {
+		switch kind := r.byte(); kind {
+		case tupleNull, tupleUnchanged:
+			values[columns[i]] = nil
+		case tupleText:
+			value := string(r.next(int(r.uint32())))
+			values[columns[i]] = &value
+		default:
+			return nil, fmt.Errorf("%w: unsupported tuple data %q", errMalformedMessage, kind)
+		}
+	}
+	if r.err != nil {
+		return nil, r.err
+	}
+	return values, nil
+}
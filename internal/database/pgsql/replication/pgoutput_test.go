@@ -0,0 +1,126 @@
+package replication
+
+import (
+	"encoding/binary"
+	"errors"
+	"testing"
+	"time"
+)
+
+type message []byte
+
+func (m message) byte(b byte) message     { return append(m, b) }
+func (m message) uint16(v uint16) message { return binary.BigEndian.AppendUint16(m, v) }
+func (m message) uint32(v uint32) message { return binary.BigEndian.AppendUint32(m, v) }
+func (m message) uint64(v uint64) message { return binary.BigEndian.AppendUint64(m, v) }
+func (m message) string(s string) message { return append(append(m, s...), 0) }
+
+func (m message) text(s string) message {
+	return append(m.byte(tupleText).uint32(uint32(len(s))), s...)
+}
+
+func beginMessage(commitTime time.Time) message {
+	return message{messageBegin}.
+		uint64(0x100).
+		uint64(uint64(commitTime.Sub(postgresEpoch).Microseconds())).
+		uint32(42)
+}
+
+func relationMessage(id uint32, table string, columns ...string) message {
+	m := message{messageRelation}.uint32(id).string("public").string(table).byte('d').uint16(uint16(len(columns)))
+	for _, column := range columns {
+		m = m.byte(1).string(column).uint32(25).uint32(0xFFFFFFFF)
+	}
+	return m
+}
+
+func commitMessage(commitLSN, endLSN uint64) message {
+	return message{messageCommit}.byte(0).uint64(commitLSN).uint64(endLSN).uint64(0)
+}
+
+func TestDecoder_Decode(t *testing.T) {
+	commitTime := time.Date(2026, time.October, 17, 10, 0, 0, 0, time.UTC)
+
+	t.Run("assembles transaction", func(t *testing.T) {
+		dec := newDecoder()
+		insert := message{messageInsert}.uint32(1).byte('N').uint16(2).text("hello").byte(tupleNull)
+		for _, m := range []message{
+			beginMessage(commitTime),
+			relationMessage(1, "transactional_outbox", "topic", "metadata"),
+			insert,
+			message{'T'}, // truncate is skipped
+		} {
+			tx, err := dec.decode(m)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if tx != nil {
+				t.Fatalf("transaction returned before commit")
+			}
+		}
+
+		tx, err := dec.decode(commitMessage(0x100, 0x1A0))
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if tx == nil {
+			t.Fatalf("transaction is not returned on commit")
+		}
+		if tx.CommitLSN != 0x100 || tx.EndLSN != 0x1A0 {
+			t.Errorf("lsn = %s/%s, want 0/100 and 0/1A0", tx.CommitLSN, tx.EndLSN)
+		}
+		if !tx.CommitTime.Equal(commitTime) {
+			t.Errorf("commit time = %s, want %s", tx.CommitTime, commitTime)
+		}
+		if len(tx.Inserts) != 1 {
+			t.Fatalf("inserts = %d, want 1", len(tx.Inserts))
+		}
+		insertRow := tx.Inserts[0]
+		if insertRow.Namespace != "public" || insertRow.Table != "transactional_outbox" {
+			t.Errorf("table = %s.%s", insertRow.Namespace, insertRow.Table)
+		}
+		if v := insertRow.Values["topic"]; v == nil || *v != "hello" {
+			t.Errorf("topic = %v, want hello", v)
+		}
+		if v, ok := insertRow.Values["metadata"]; !ok || v != nil {
+			t.Errorf("metadata = %v, want nil", v)
+		}
+	})
+
+	t.Run("rejects malformed messages", func(t *testing.T) {
+		tests := map[string][]message{
+			"empty":            {{}},
+			"short begin":      {{messageBegin, 0, 1}},
+			"commit no begin":  {commitMessage(1, 2)},
+			"unknown relation": {beginMessage(commitTime), message{messageInsert}.uint32(7).byte('N').uint16(0)},
+			"column mismatch": {
+				beginMessage(commitTime),
+				relationMessage(1, "transactional_outbox", "topic"),
+				message{messageInsert}.uint32(1).byte('N').uint16(2).text("a").text("b"),
+			},
+		}
+		for name, messages := range tests {
+			t.Run(name, func(t *testing.T) {
+				dec := newDecoder()
+				var err error
+				for _, m := range messages {
+					if _, err = dec.decode(m); err != nil {
+						break
+					}
+				}
+				if !errors.Is(err, errMalformedMessage) {
+					t.Errorf("error = %v, want %v", err, errMalformedMessage)
+				}
+			})
+		}
+	})
+}
+
+func TestLSN_String(t *testing.T) {
+	if s := LSN(0x16B3748).String(); s != "0/16B3748" {
+		t.Errorf("lsn = %s, want 0/16B3748", s)
+	}
+	if s := LSN(0x1_0000_00FF).String(); s != "1/FF" {
+		t.Errorf("lsn = %s, want 1/FF", s)
+	}
+}
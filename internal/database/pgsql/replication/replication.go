@@ -0,0 +1,258 @@
+// Package replication streams committed inserts from pgsql logical replication slot.
+// Replication position is confirmed to the slot after transaction is handled,
+// so stream started again continues after last handled transaction.
+// While no transaction is open, position reported by server keepalive is confirmed as well.
+package replication
+
+import (
+	"context"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgproto3"
+)
+
+const (
+	outputPlugin          = "pgoutput"
+	defaultStatusInterval = 10 * time.Second
+
+	// pg error code of existing replication slot
+	codeDuplicateObject = "42710"
+
+	messageXLogData         byte = 'w'
+	messagePrimaryKeepalive byte = 'k'
+	messageStandbyStatus    byte = 'r'
+)
+
+// Stream reads transactions of the publication through the replication slot,
+// slot is created on first start. Only one stream can use the slot at a time.
+type Stream struct {
+	logger         *slog.Logger
+	dsn            string
+	slot           string
+	publication    string
+	statusInterval time.Duration
+}
+
+func NewStream(dsn string, slot string, publication string, opts ...Option) *Stream {
+	s := &Stream{
+		dsn:            dsn,
+		slot:           slot,
+		publication:    publication,
+		statusInterval: defaultStatusInterval,
+	}
+	for _, opt := range opts {
+		opt(s)
+	}
+	if s.logger == nil {
+		s.logger = slog.New(slog.DiscardHandler)
+	}
+	return s
+}
+
+// Run calls handler for every committed transaction until context is cancelled
+// or handler returns error. Transaction is confirmed only if handler returns nil,
+// not confirmed transactions are received again by next run.
+func (s *Stream) Run(ctx context.Context, handler func(ctx context.Context, tx *Transaction) error) error {
+	dsn, err := replicationDSN(s.dsn)
+	if err != nil {
+		return err
+	}
+
+	conn, err := pgconn.Connect(ctx, dsn)
+	if err != nil {
+		return fmt.Errorf("failed to connect: %w", err)
+	}
+	defer func() {
+		closeCtx, cancel := context.WithTimeout(context.Background(), time.Second)
+		defer cancel()
+		_ = conn.Close(closeCtx)
+	}()
+
+	if err := s.createSlot(ctx, conn); err != nil {
+		return err
+	}
+	if err := s.startReplication(ctx, conn); err != nil {
+		return err
+	}
+
+	s.logger.InfoContext(ctx, "replication started",
+		slog.String("slot", s.slot),
+		slog.String("publication", s.publication),
+	)
+
+	var (
+		dec        = newDecoder()
+		confirmed  LSN
+		nextStatus = time.Now().Add(s.statusInterval)
+	)
+
+	for {
+		if time.Now().After(nextStatus) {
+			if err := sendStandbyStatus(conn, confirmed); err != nil {
+				return err
+			}
+			nextStatus = time.Now().Add(s.statusInterval)
+		}
+
+		receiveCtx, cancel := context.WithDeadline(ctx, nextStatus)
+		msg, err := conn.ReceiveMessage(receiveCtx)
+		cancel()
+		if err != nil {
+			if ctx.Err() != nil {
+				// position is confirmed to make restart faster, it is not required for correctness
+				_ = sendStandbyStatus(conn, confirmed)
+				return nil
+			}
+			if pgconn.Timeout(err) {
+				continue
+			}
+			return fmt.Errorf("failed to receive message: %w", err)
+		}
+
+		var data []byte
+		switch msg := msg.(type) {
+		case *pgproto3.CopyData:
+			data = msg.Data
+		case *pgproto3.ErrorResponse:
+			return fmt.Errorf("replication error: %w", pgconn.ErrorResponseToPgError(msg))
+		default:
+			continue
+		}
+
+		if len(data) == 0 {
+			continue
+		}
+
+		switch data[0] {
+		case messagePrimaryKeepalive:
+			confirmed = keepalivePosition(data, confirmed, dec.inTransaction())
+			// reply is requested if server waits for status too long
+			if len(data) >= 18 && data[17] == 1 {
+				nextStatus = time.Now()
+			}
+		case messageXLogData:
+			// header: wal start, wal end, send time
+			if len(data) < 25 {
+				return fmt.Errorf("%w: short xlog data", errMalformedMessage)
+			}
+			tx, err := dec.decode(data[25:])
+			if err != nil {
+				return fmt.Errorf("failed to decode message: %w", err)
+			}
+			if tx == nil {
+				continue
+			}
+			if err := handler(ctx, tx); err != nil {
+				return fmt.Errorf("failed to handle transaction %s: %w", tx.CommitLSN, err)
+			}
+			confirmed = tx.EndLSN
+		}
+	}
+}
+
+func (s *Stream) createSlot(ctx context.Context, conn *pgconn.PgConn) error {
+	query := fmt.Sprintf("CREATE_REPLICATION_SLOT %s LOGICAL %s", quoteIdentifier(s.slot), outputPlugin)
+	_, err := conn.Exec(ctx, query).ReadAll()
+	var pgErr *pgconn.PgError
+	if errors.As(err, &pgErr) && pgErr.Code == codeDuplicateObject {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("failed to create replication slot: %w", err)
+	}
+	s.logger.InfoContext(ctx, "replication slot created", slog.String("slot", s.slot))
+	return nil
+}
+
+// startReplication continues from position confirmed to the slot.
+func (s *Stream) startReplication(ctx context.Context, conn *pgconn.PgConn) error {
+	query := fmt.Sprintf(
+		`START_REPLICATION SLOT %s LOGICAL 0/0 ("proto_version" '1', "publication_names" %s)`,
+		quoteIdentifier(s.slot), quoteLiteral(s.publication),
+	)
+	conn.Frontend().SendQuery(&pgproto3.Query{String: query})
+	if err := conn.Frontend().Flush(); err != nil {
+		return fmt.Errorf("failed to start replication: %w", err)
+	}
+	for {
+		msg, err := conn.ReceiveMessage(ctx)
+		if err != nil {
+			return fmt.Errorf("failed to start replication: %w", err)
+		}
+		switch msg := msg.(type) {
+		case *pgproto3.CopyBothResponse:
+			return nil
+		case *pgproto3.ErrorResponse:
+			return fmt.Errorf("failed to start replication: %w", pgconn.ErrorResponseToPgError(msg))
+		}
+	}
+}
+
+// keepalivePosition returns position to confirm after keepalive message.
+// All wal up to the end reported by server is received, it is confirmed unless transaction
+// is received partially, otherwise slot keeps wal of other tables while publication is idle.
+func keepalivePosition(data []byte, confirmed LSN, inTransaction bool) LSN {
+	// header: wal end, send time, reply requested
+	if len(data) < 18 || inTransaction {
+		return confirmed
+	}
+	return max(confirmed, LSN(binary.BigEndian.Uint64(data[1:9])))
+}
+
+// sendStandbyStatus reports position up to which wal is processed and can be recycled.
+func sendStandbyStatus(conn *pgconn.PgConn, lsn LSN) error {
+	data := make([]byte, 0, 34)
+	data = append(data, messageStandbyStatus)
+	data = binary.BigEndian.AppendUint64(data, uint64(lsn)) // written
+	data = binary.BigEndian.AppendUint64(data, uint64(lsn)) // flushed
+	data = binary.BigEndian.AppendUint64(data, uint64(lsn)) // applied
+	data = binary.BigEndian.AppendUint64(data, uint64(time.Since(postgresEpoch).Microseconds()))
+	data = append(data, 0) // reply is not requested
+	conn.Frontend().Send(&pgproto3.CopyData{Data: data})
+	if err := conn.Frontend().Flush(); err != nil {
+		return fmt.Errorf("failed to send standby status: %w", err)
+	}
+	return nil
+}
+
+// replicationDSN enables replication protocol of the connection.
+func replicationDSN(dsn string) (string, error) {
+	u, err := url.Parse(dsn)
+	if err != nil {
+		return "", fmt.Errorf("failed to parse dsn: %w", err)
+	}
+	query := u.Query()
+	query.Set("replication", "database")
+	u.RawQuery = query.Encode()
+	return u.String(), nil
+}
+
+func quoteIdentifier(s string) string {
+	return `"` + strings.ReplaceAll(s, `"`, `""`) + `"`
+}
+
+func quoteLiteral(s string) string {
+	return `'` + strings.ReplaceAll(s, `'`, `''`) + `'`
+}
+
+type Option func(s *Stream)
+
+func WithLogger(logger *slog.Logger) Option {
+	return func(s *Stream) {
+		s.logger = logger
+	}
+}
+
+// WithStatusInterval sets how often position is confirmed to the server.
+func WithStatusInterval(d time.Duration) Option {
+	return func(s *Stream) {
+		s.statusInterval = d
+	}
+}
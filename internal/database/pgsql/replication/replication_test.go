@@ -0,0 +1,51 @@
+package replication
+
+import (
+	"testing"
+	"time"
+)
+
+func keepaliveMessage(walEnd uint64) message {
+	return message{messagePrimaryKeepalive}.
+		uint64(walEnd).
+		uint64(uint64(time.Since(postgresEpoch).Microseconds())).
+		byte(0)
+}
+
+func TestKeepalivePosition(t *testing.T) {
+	tests := []struct {
+		name          string
+		data          message
+		confirmed     LSN
+		inTransaction bool
+		want          LSN
+	}{
+		{"confirms wal end while idle", keepaliveMessage(0x200), 0x100, false, 0x200},
+		{"keeps open transaction unconfirmed", keepaliveMessage(0x200), 0x100, true, 0x100},
+		{"never moves position back", keepaliveMessage(0x80), 0x100, false, 0x100},
+		{"ignores short message", message{messagePrimaryKeepalive}.uint64(0x200), 0x100, false, 0x100},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := keepalivePosition(tt.data, tt.confirmed, tt.inTransaction); got != tt.want {
+				t.Errorf("keepalivePosition() = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDecoder_InTransaction(t *testing.T) {
+	dec := newDecoder()
+	if _, err := dec.decode(beginMessage(time.Now())); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !dec.inTransaction() {
+		t.Errorf("transaction should be open after begin")
+	}
+	if _, err := dec.decode(commitMessage(0x100, 0x108)); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if dec.inTransaction() {
+		t.Errorf("transaction should be closed after commit")
+	}
+}
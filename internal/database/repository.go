@@ -6,8 +6,10 @@ import (
 	"errors"
 	"fmt"
 	"log/slog"
+	"sync"
 
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 type BaseRepository struct {
@@ -20,7 +22,28 @@ func NewBaseRepository(db Database) *BaseRepository {
 
 type ctxKey string
 
-var ctxKeyTx ctxKey = "transaction"
+var (
+	ctxKeyTx          ctxKey = "transaction"
+	ctxKeyTenant      ctxKey = "tenant"
+	ctxKeyAfterCommit ctxKey = "after_commit"
+)
+
+// afterCommitHooks are collected during transaction and run after its commit.
+type afterCommitHooks struct {
+	sync.Mutex
+	hooks []func()
+}
+
+// WithTenant returns context in which queries using TenantScope are restricted to given tenant.
+func WithTenant(ctx context.Context, tenantID int) context.Context {
+	return context.WithValue(ctx, ctxKeyTenant, tenantID)
+}
+
+// TenantFromContext returns tenant set by WithTenant.
+func TenantFromContext(ctx context.Context) (int, bool) {
+	tenantID, ok := ctx.Value(ctxKeyTenant).(int)
+	return tenantID, ok
+}
 
 func (r *BaseRepository) GetTx(ctx context.Context) *gorm.DB {
 	if tx, ok := ctx.Value(ctxKeyTx).(*gorm.DB); ok {
@@ -36,6 +59,19 @@ func (r *BaseRepository) GetReadDB(ctx context.Context) *gorm.DB {
 	return r.db.Slave().WithContext(ctx)
 }
 
+// TenantScope restricts query to tenant of the context by given column,
+// query is not restricted when context has no tenant.
+// Usage: db.Scopes(r.TenantScope(ctx, "organization_id")).Find(&rows)
+func (r *BaseRepository) TenantScope(ctx context.Context, column string) func(*gorm.DB) *gorm.DB {
+	return func(db *gorm.DB) *gorm.DB {
+		tenantID, ok := TenantFromContext(ctx)
+		if !ok {
+			return db
+		}
+		return db.Where(clause.Eq{Column: clause.Column{Name: column}, Value: tenantID})
+	}
+}
+
 func (r *BaseRepository) Begin(
 	ctx context.Context, isolationLvl sql.IsolationLevel,
 ) (context.Context, error) {
@@ -46,6 +82,7 @@ func (r *BaseRepository) Begin(
 	if tx.Error != nil {
 		return ctx, fmt.Errorf("failed to begin transaction: %w", tx.Error)
 	}
+	ctx = context.WithValue(ctx, ctxKeyAfterCommit, new(afterCommitHooks))
 	return context.WithValue(ctx, ctxKeyTx, tx), nil
 }
 
@@ -57,9 +94,30 @@ func (r *BaseRepository) Commit(ctx context.Context) error {
 	if err := tx.Commit().Error; err != nil {
 		return fmt.Errorf("failed to commit transaction: %w", err)
 	}
+	if hooks, ok := ctx.Value(ctxKeyAfterCommit).(*afterCommitHooks); ok {
+		hooks.Lock()
+		defer hooks.Unlock()
+		for _, hook := range hooks.hooks {
+			hook()
+		}
+		hooks.hooks = nil
+	}
 	return nil
 }
 
+// AfterCommit runs hook after transaction of the context is committed,
+// or right away if context has no transaction. Hook is dropped if transaction is rolled back.
+func (r *BaseRepository) AfterCommit(ctx context.Context, hook func()) {
+	hooks, ok := ctx.Value(ctxKeyAfterCommit).(*afterCommitHooks)
+	if !ok {
+		hook()
+		return
+	}
+	hooks.Lock()
+	defer hooks.Unlock()
+	hooks.hooks = append(hooks.hooks, hook)
+}
+
 func (r *BaseRepository) Rollback(ctx context.Context) error {
 	tx, ok := ctx.Value(ctxKeyTx).(*gorm.DB)
 	if !ok {
@@ -4,9 +4,15 @@ import (
 	"context"
 )
 
+// MetadataOrderingKey is metadata of the message which holds its ordering key.
+const MetadataOrderingKey = "ordering_key"
+
 // Publisher publishes event to the topic in async fashion.
+// Events published with the same key are delivered in order of publishing,
+// where underlying engine supports it, the key is passed in MetadataOrderingKey.
 type Publisher interface {
 	Publish(topic string, event []byte) error
+	PublishWithKey(topic string, key string, event []byte) error
 }
 
 // Subscriber subscribes a handler for given topic in async fashion.
@@ -38,6 +44,10 @@ func (e *NoopEngine) Publish(_ string, _ []byte) error {
 	return nil
 }
 
+func (e *NoopEngine) PublishWithKey(_ string, _ string, _ []byte) error {
+	return nil
+}
+
 func (e *NoopEngine) Subscribe(
 	_ context.Context, _ string,
 	_ func(_ context.Context, _ []byte) error,
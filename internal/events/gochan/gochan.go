@@ -8,6 +8,8 @@ import (
 	"github.com/ThreeDotsLabs/watermill"
 	"github.com/ThreeDotsLabs/watermill/message"
 	"github.com/ThreeDotsLabs/watermill/pubsub/gochannel"
+
+	"github.com/hasansino/go42/internal/events"
 )
 
 type GoChan struct {
@@ -46,6 +48,12 @@ func (g *GoChan) Publish(topic string, event []byte) error {
 	return g.channel.Publish(topic, msg)
 }
 
+func (g *GoChan) PublishWithKey(topic string, key string, event []byte) error {
+	msg := message.NewMessage(watermill.NewUUID(), event)
+	msg.Metadata.Set(events.MetadataOrderingKey, key)
+	return g.channel.Publish(topic, msg)
+}
+
 func (g *GoChan) Subscribe(
 	ctx context.Context, topic string,
 	handler func(ctx context.Context, event []byte) error,
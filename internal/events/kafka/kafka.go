@@ -7,9 +7,12 @@ import (
 	"log/slog"
 	"sync"
 
+	"github.com/IBM/sarama"
 	"github.com/ThreeDotsLabs/watermill"
 	wkafka "github.com/ThreeDotsLabs/watermill-kafka/v3/pkg/kafka"
 	"github.com/ThreeDotsLabs/watermill/message"
+
+	"github.com/hasansino/go42/internal/events"
 )
 
 type Kafka struct {
@@ -37,7 +40,7 @@ func New(brokers []string, group string, opts ...Option) (*Kafka, error) {
 	publisher, err := wkafka.NewPublisher(
 		wkafka.PublisherConfig{
 			Brokers:               brokers,
-			Marshaler:             wkafka.DefaultMarshaler{},
+			Marshaler:             orderingMarshaler{},
 			OverwriteSaramaConfig: pubCfg,
 		},
 		watermill.NewSlogLogger(engine.logger),
@@ -70,6 +73,12 @@ func (k *Kafka) Publish(topic string, event []byte) error {
 	return k.publisher.Publish(topic, msg)
 }
 
+func (k *Kafka) PublishWithKey(topic string, key string, event []byte) error {
+	msg := message.NewMessage(watermill.NewUUID(), event)
+	msg.Metadata.Set(events.MetadataOrderingKey, key)
+	return k.publisher.Publish(topic, msg)
+}
+
 func (k *Kafka) Subscribe(
 	ctx context.Context, topic string,
 	handler func(ctx context.Context, event []byte) error,
@@ -113,3 +122,20 @@ func (k *Kafka) Shutdown(ctx context.Context) error {
 		return err
 	}
 }
+
+// orderingMarshaler sends ordering key as key of kafka message,
+// messages with the same key are written to the same partition by hash partitioner.
+type orderingMarshaler struct {
+	wkafka.DefaultMarshaler
+}
+
+func (m orderingMarshaler) Marshal(topic string, msg *message.Message) (*sarama.ProducerMessage, error) {
+	kafkaMsg, err := m.DefaultMarshaler.Marshal(topic, msg)
+	if err != nil {
+		return nil, err
+	}
+	if key := msg.Metadata.Get(events.MetadataOrderingKey); key != "" {
+		kafkaMsg.Key = sarama.StringEncoder(key)
+	}
+	return kafkaMsg, nil
+}
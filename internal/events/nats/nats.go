@@ -17,6 +17,8 @@ import (
 	wnats "github.com/ThreeDotsLabs/watermill-nats/v2/pkg/nats"
 	"github.com/ThreeDotsLabs/watermill/message"
 	natsgo "github.com/nats-io/nats.go"
+
+	"github.com/hasansino/go42/internal/events"
 )
 
 type NATS struct {
@@ -73,6 +75,14 @@ func (n *NATS) Publish(topic string, event []byte) error {
 	return n.publisher.Publish(topic, msg)
 }
 
+// PublishWithKey relies on nats delivering messages of one connection in order,
+// key is passed to subscribers in message metadata.
+func (n *NATS) PublishWithKey(topic string, key string, event []byte) error {
+	msg := message.NewMessage(watermill.NewUUID(), event)
+	msg.Metadata.Set(events.MetadataOrderingKey, key)
+	return n.publisher.Publish(topic, msg)
+}
+
 func (n *NATS) Subscribe(
 	ctx context.Context, topic string,
 	handler func(ctx context.Context, event []byte) error,
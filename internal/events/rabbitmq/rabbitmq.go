@@ -10,6 +10,8 @@ import (
 	"github.com/ThreeDotsLabs/watermill"
 	"github.com/ThreeDotsLabs/watermill-amqp/v3/pkg/amqp"
 	"github.com/ThreeDotsLabs/watermill/message"
+
+	"github.com/hasansino/go42/internal/events"
 )
 
 type AMQP struct {
@@ -60,6 +62,12 @@ func (rmq *AMQP) Publish(topic string, event []byte) error {
 	return rmq.publisher.Publish(topic, msg)
 }
 
+func (rmq *AMQP) PublishWithKey(topic string, key string, event []byte) error {
+	msg := message.NewMessage(watermill.NewUUID(), event)
+	msg.Metadata.Set(events.MetadataOrderingKey, key)
+	return rmq.publisher.Publish(topic, msg)
+}
+
 func (rmq *AMQP) Subscribe(
 	ctx context.Context, topic string,
 	handler func(ctx context.Context, event []byte) error,
@@ -0,0 +1,38 @@
+package file
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"time"
+
+	"github.com/hasansino/go42/internal/mailer"
+)
+
+// Mailer writes messages as .eml files to a directory instead of sending them.
+type Mailer struct {
+	directory string
+	from      string
+}
+
+func New(directory string, from string) (*Mailer, error) {
+	if err := os.MkdirAll(directory, 0o750); err != nil {
+		return nil, fmt.Errorf("failed to create directory: %w", err)
+	}
+	return &Mailer{directory: directory, from: from}, nil
+}
+
+func (m *Mailer) Send(_ context.Context, msg mailer.Message) error {
+	data, err := mailer.Compose(m.from, msg)
+	if err != nil {
+		return err
+	}
+	name := strconv.FormatInt(time.Now().UnixNano(), 10) + ".eml"
+	err = os.WriteFile(filepath.Join(m.directory, name), data, 0o600)
+	if err != nil {
+		return fmt.Errorf("failed to write message: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,63 @@
+package mailer_test
+
+import (
+	"bytes"
+	"io"
+	"mime"
+	"mime/quotedprintable"
+	"net/mail"
+	"strings"
+	"testing"
+
+	"github.com/hasansino/go42/internal/mailer"
+)
+
+func TestCompose(t *testing.T) {
+	msg := mailer.Message{
+		To:      "user@example.com",
+		Subject: "Vérifiez votre email",
+		Body:    "Please verify your email address:\n\nhttps://example.com/verify?token=abc&x=1\n",
+	}
+
+	data, err := mailer.Compose("Go42 <noreply@example.com>", msg)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	parsed, err := mail.ReadMessage(bytes.NewReader(data))
+	if err != nil {
+		t.Fatalf("failed to parse composed message: %v", err)
+	}
+
+	if v := parsed.Header.Get("To"); v != "<user@example.com>" {
+		t.Errorf("unexpected To header: %q", v)
+	}
+	if v := parsed.Header.Get("From"); v != `"Go42" <noreply@example.com>` {
+		t.Errorf("unexpected From header: %q", v)
+	}
+	subject, err := new(mime.WordDecoder).DecodeHeader(parsed.Header.Get("Subject"))
+	if err != nil || subject != msg.Subject {
+		t.Errorf("unexpected Subject header: %q", subject)
+	}
+	if v := parsed.Header.Get("Message-ID"); v == "" {
+		t.Error("expected Message-ID header")
+	}
+
+	body, err := io.ReadAll(quotedprintable.NewReader(parsed.Body))
+	if err != nil {
+		t.Fatalf("failed to decode body: %v", err)
+	}
+	// line breaks are converted to CRLF as required by RFC 5322
+	if string(body) != strings.ReplaceAll(msg.Body, "\n", "\r\n") {
+		t.Errorf("unexpected body: %q", body)
+	}
+}
+
+func TestCompose_InvalidAddress(t *testing.T) {
+	if _, err := mailer.Compose("noreply@example.com", mailer.Message{To: "invalid"}); err == nil {
+		t.Error("expected error for invalid recipient")
+	}
+	if _, err := mailer.Compose("invalid", mailer.Message{To: "user@example.com"}); err == nil {
+		t.Error("expected error for invalid sender")
+	}
+}
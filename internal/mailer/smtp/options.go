@@ -0,0 +1,27 @@
+package smtp
+
+import "time"
+
+type Option func(*Mailer)
+
+func WithCredentials(username string, password string) Option {
+	return func(m *Mailer) {
+		m.username = username
+		m.password = password
+	}
+}
+
+// WithImplicitTLS enables TLS from the start of connection, usually on port 465.
+func WithImplicitTLS(enabled bool) Option {
+	return func(m *Mailer) {
+		m.implicitTLS = enabled
+	}
+}
+
+func WithTimeout(timeout time.Duration) Option {
+	return func(m *Mailer) {
+		if timeout > 0 {
+			m.timeout = timeout
+		}
+	}
+}
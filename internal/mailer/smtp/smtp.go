@@ -0,0 +1,115 @@
+package smtp
+
+import (
+	"context"
+	"crypto/tls"
+	"fmt"
+	"net"
+	"net/mail"
+	"net/smtp"
+	"strconv"
+	"time"
+
+	"github.com/hasansino/go42/internal/mailer"
+)
+
+const defaultTimeout = 10 * time.Second
+
+// Mailer sends messages using SMTP server.
+// Connection is upgraded with STARTTLS when server supports it,
+// or established over TLS from the start when implicit TLS is enabled.
+type Mailer struct {
+	host        string
+	port        int
+	from        string
+	username    string
+	password    string
+	implicitTLS bool
+	timeout     time.Duration
+}
+
+func New(host string, port int, from string, opts ...Option) (*Mailer, error) {
+	if _, err := mail.ParseAddress(from); err != nil {
+		return nil, fmt.Errorf("invalid sender address: %w", err)
+	}
+	m := &Mailer{
+		host:    host,
+		port:    port,
+		from:    from,
+		timeout: defaultTimeout,
+	}
+	for _, opt := range opts {
+		opt(m)
+	}
+	return m, nil
+}
+
+func (m *Mailer) Send(ctx context.Context, msg mailer.Message) error {
+	data, err := mailer.Compose(m.from, msg)
+	if err != nil {
+		return err
+	}
+
+	fromAddr, _ := mail.ParseAddress(m.from)
+	toAddr, _ := mail.ParseAddress(msg.To)
+
+	ctx, cancel := context.WithTimeout(ctx, m.timeout)
+	defer cancel()
+
+	conn, err := m.dial(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to connect: %w", err)
+	}
+	if deadline, ok := ctx.Deadline(); ok {
+		_ = conn.SetDeadline(deadline)
+	}
+
+	client, err := smtp.NewClient(conn, m.host)
+	if err != nil {
+		_ = conn.Close()
+		return fmt.Errorf("failed to create client: %w", err)
+	}
+	defer client.Close()
+
+	if ok, _ := client.Extension("STARTTLS"); ok && !m.implicitTLS {
+		if err := client.StartTLS(&tls.Config{ServerName: m.host, MinVersion: tls.VersionTLS12}); err != nil {
+			return fmt.Errorf("failed to start tls: %w", err)
+		}
+	}
+	if m.username != "" {
+		// PlainAuth refuses to send credentials over unencrypted connection
+		auth := smtp.PlainAuth("", m.username, m.password, m.host)
+		if err := client.Auth(auth); err != nil {
+			return fmt.Errorf("failed to authenticate: %w", err)
+		}
+	}
+
+	if err := client.Mail(fromAddr.Address); err != nil {
+		return fmt.Errorf("failed to set sender: %w", err)
+	}
+	if err := client.Rcpt(toAddr.Address); err != nil {
+		return fmt.Errorf("failed to set recipient: %w", err)
+	}
+	w, err := client.Data()
+	if err != nil {
+		return fmt.Errorf("failed to start data: %w", err)
+	}
+	if _, err := w.Write(data); err != nil {
+		return fmt.Errorf("failed to write data: %w", err)
+	}
+	if err := w.Close(); err != nil {
+		return fmt.Errorf("failed to send data: %w", err)
+	}
+
+	return client.Quit()
+}
+
+func (m *Mailer) dial(ctx context.Context) (net.Conn, error) {
+	addr := net.JoinHostPort(m.host, strconv.Itoa(m.port))
+	if m.implicitTLS {
+		dialer := &tls.Dialer{Config: &tls.Config{ServerName: m.host, MinVersion: tls.VersionTLS12}}
+		return dialer.DialContext(ctx, "tcp", addr)
+	}
+	dialer := &net.Dialer{}
+	return dialer.DialContext(ctx, "tcp", addr)
+}
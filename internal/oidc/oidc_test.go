@@ -0,0 +1,234 @@
+package oidc
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+const (
+	testClientID     = "client"
+	testClientSecret = "secret"
+	testRedirectURL  = "http://localhost/callback"
+	testCode         = "code"
+)
+
+// mockIdP is minimal identity provider which issues id token for single authorization code.
+type mockIdP struct {
+	server    *httptest.Server
+	key       *rsa.PrivateKey
+	issuer    string
+	challenge string
+	claims    func() jwt.Claims
+}
+
+func newMockIdP(t *testing.T) *mockIdP {
+	t.Helper()
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+	idp := &mockIdP{key: key}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /.well-known/openid-configuration", func(w http.ResponseWriter, _ *http.Request) {
+		writeJSON(w, map[string]string{
+			"issuer":                 idp.issuer,
+			"authorization_endpoint": idp.server.URL + "/authorize",
+			"token_endpoint":         idp.server.URL + "/token",
+			"jwks_uri":               idp.server.URL + "/jwks",
+		})
+	})
+	mux.HandleFunc("GET /jwks", func(w http.ResponseWriter, _ *http.Request) {
+		writeJSON(w, map[string]any{
+			"keys": []map[string]string{{
+				"kty": "RSA",
+				"kid": "k1",
+				"use": "sig",
+				"n":   base64.RawURLEncoding.EncodeToString(key.N.Bytes()),
+				"e":   base64.RawURLEncoding.EncodeToString(big.NewInt(int64(key.E)).Bytes()),
+			}},
+		})
+	})
+	mux.HandleFunc("POST /token", func(w http.ResponseWriter, r *http.Request) {
+		clientID, clientSecret, _ := r.BasicAuth()
+		sum := sha256.Sum256([]byte(r.PostFormValue("code_verifier")))
+		if clientID != testClientID || clientSecret != testClientSecret ||
+			r.PostFormValue("code") != testCode ||
+			r.PostFormValue("redirect_uri") != testRedirectURL ||
+			base64.RawURLEncoding.EncodeToString(sum[:]) != idp.challenge {
+			w.WriteHeader(http.StatusBadRequest)
+			writeJSON(w, map[string]string{"error": "invalid_grant"})
+			return
+		}
+		token := jwt.NewWithClaims(jwt.SigningMethodRS256, idp.claims())
+		token.Header["kid"] = "k1"
+		idToken, err := token.SignedString(key)
+		if err != nil {
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+		writeJSON(w, map[string]any{
+			"access_token": "access",
+			"token_type":   "Bearer",
+			"id_token":     idToken,
+		})
+	})
+
+	idp.server = httptest.NewServer(mux)
+	idp.issuer = idp.server.URL
+	t.Cleanup(idp.server.Close)
+	return idp
+}
+
+func (idp *mockIdP) provider() *Provider {
+	return New(Config{
+		IssuerURL:    idp.server.URL,
+		ClientID:     testClientID,
+		ClientSecret: testClientSecret,
+		RedirectURL:  testRedirectURL,
+		Scopes:       []string{"openid", "email"},
+	})
+}
+
+func writeJSON(w http.ResponseWriter, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(v)
+}
+
+func idTokenClaims(issuer string, audience string, nonce string) jwt.Claims {
+	return Claims{
+		RegisteredClaims: jwt.RegisteredClaims{
+			Issuer:    issuer,
+			Subject:   "external-user",
+			Audience:  jwt.ClaimStrings{audience},
+			IssuedAt:  jwt.NewNumericDate(time.Now()),
+			ExpiresAt: jwt.NewNumericDate(time.Now().Add(time.Minute)),
+		},
+		Nonce:         nonce,
+		Email:         "user@example.com",
+		EmailVerified: true,
+	}
+}
+
+func TestProvider(t *testing.T) {
+	idp := newMockIdP(t)
+	provider := idp.provider()
+	ctx := context.Background()
+
+	verifier := "dBjftJeZ4CVP-mB92K27uhbUJU1p1r_wW1gFWFOEjXk"
+	idp.challenge = "E9Melhoa2OwvFrEMTJguCHaoeK1t8URWbuGJSstw-cM"
+	idp.claims = func() jwt.Claims { return idTokenClaims(idp.issuer, testClientID, "nonce") }
+
+	authURL, err := provider.AuthCodeURL(ctx, "state", "nonce", idp.challenge)
+	if err != nil {
+		t.Fatal(err)
+	}
+	u, err := url.Parse(authURL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	query := u.Query()
+	if u.Path != "/authorize" || query.Get("client_id") != testClientID ||
+		query.Get("redirect_uri") != testRedirectURL || query.Get("state") != "state" ||
+		query.Get("nonce") != "nonce" || query.Get("code_challenge") != idp.challenge ||
+		query.Get("code_challenge_method") != "S256" || query.Get("scope") != "openid email" {
+		t.Errorf("unexpected authorization url: %s", authURL)
+	}
+
+	tokens, err := provider.Exchange(ctx, testCode, verifier)
+	if err != nil {
+		t.Fatal(err)
+	}
+	claims, err := provider.VerifyIDToken(ctx, tokens.IDToken, "nonce")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if claims.Subject != "external-user" || claims.Email != "user@example.com" || !claims.EmailVerified {
+		t.Errorf("unexpected claims: %+v", claims)
+	}
+
+	if _, err := provider.VerifyIDToken(ctx, tokens.IDToken, "other"); !errors.Is(err, ErrInvalidIDToken) {
+		t.Errorf("nonce mismatch: got %v, want %v", err, ErrInvalidIDToken)
+	}
+	if _, err := provider.Exchange(ctx, testCode, verifier+"x"); !errors.Is(err, ErrExchangeFailed) {
+		t.Errorf("invalid verifier: got %v, want %v", err, ErrExchangeFailed)
+	}
+}
+
+func TestProvider_InvalidIDToken(t *testing.T) {
+	idp := newMockIdP(t)
+	provider := idp.provider()
+	ctx := context.Background()
+
+	sign := func(claims jwt.Claims) string {
+		token := jwt.NewWithClaims(jwt.SigningMethodRS256, claims)
+		token.Header["kid"] = "k1"
+		raw, err := token.SignedString(idp.key)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return raw
+	}
+
+	otherKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+	forged := jwt.NewWithClaims(jwt.SigningMethodRS256, idTokenClaims(idp.issuer, testClientID, "nonce"))
+	forged.Header["kid"] = "k1"
+	forgedToken, err := forged.SignedString(otherKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	symmetric := jwt.NewWithClaims(jwt.SigningMethodHS256, idTokenClaims(idp.issuer, testClientID, "nonce"))
+	symmetric.Header["kid"] = "k1"
+	symmetricToken, err := symmetric.SignedString([]byte(testClientSecret))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expired := idTokenClaims(idp.issuer, testClientID, "nonce").(Claims)
+	expired.ExpiresAt = jwt.NewNumericDate(time.Now().Add(-time.Hour))
+
+	tests := []struct {
+		name  string
+		token string
+	}{
+		{"WrongAudience", sign(idTokenClaims(idp.issuer, "other", "nonce"))},
+		{"WrongIssuer", sign(idTokenClaims("https://other.example.com", testClientID, "nonce"))},
+		{"Expired", sign(expired)},
+		{"WrongSignature", forgedToken},
+		{"SymmetricAlgorithm", symmetricToken},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := provider.VerifyIDToken(ctx, tt.token, "nonce"); !errors.Is(err, ErrInvalidIDToken) {
+				t.Errorf("got %v, want %v", err, ErrInvalidIDToken)
+			}
+		})
+	}
+}
+
+func TestProvider_IssuerMismatch(t *testing.T) {
+	idp := newMockIdP(t)
+	idp.issuer = "https://other.example.com"
+
+	_, err := idp.provider().AuthCodeURL(context.Background(), "state", "nonce", "challenge")
+	if err == nil {
+		t.Error("provider with mismatching issuer accepted")
+	}
+}
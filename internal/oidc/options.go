@@ -0,0 +1,13 @@
+package oidc
+
+import "net/http"
+
+type Option func(*Provider)
+
+func WithHTTPClient(client *http.Client) Option {
+	return func(p *Provider) {
+		if client != nil {
+			p.httpClient = client
+		}
+	}
+}
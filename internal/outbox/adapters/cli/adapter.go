@@ -0,0 +1,132 @@
+package adapter
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"text/tabwriter"
+	"time"
+
+	"github.com/hasansino/go42/internal/outbox/domain"
+	"github.com/hasansino/go42/internal/outbox/models"
+)
+
+//go:generate mockgen -source $GOFILE -package mocks -destination mocks/mocks.go
+
+type serviceAccessor interface {
+	ListFailedMessages(ctx context.Context, filter *domain.FailedMessagesFilter) ([]*models.Message, error)
+	GetMessage(ctx context.Context, id string) (*models.Message, error)
+	RequeueMessage(ctx context.Context, id string) error
+	DiscardMessage(ctx context.Context, id string) error
+}
+
+const usage = `usage: outbox <command> [arguments]
+
+commands:
+  list [-topic name] [-limit n] [-offset n]  list failed messages
+  show <id>                                  show message
+  requeue <id>                               publish failed message again
+  discard <id>                               delete failed message
+`
+
+var errUsage = errors.New("invalid arguments")
+
+// Adapter administers outbox messages from command line.
+type Adapter struct {
+	service serviceAccessor
+	out     io.Writer
+}
+
+func New(service serviceAccessor, out io.Writer) *Adapter {
+	return &Adapter{
+		service: service,
+		out:     out,
+	}
+}
+
+// Run executes command, args do not include name of the command group.
+func (a *Adapter) Run(ctx context.Context, args []string) error {
+	if len(args) == 0 {
+		_, _ = fmt.Fprint(a.out, usage)
+		return errUsage
+	}
+	var err error
+	switch args[0] {
+	case "list":
+		err = a.list(ctx, args[1:])
+	case "show":
+		err = a.withID(args[1:], func(id string) error { return a.show(ctx, id) })
+	case "requeue":
+		err = a.withID(args[1:], func(id string) error { return a.service.RequeueMessage(ctx, id) })
+	case "discard":
+		err = a.withID(args[1:], func(id string) error { return a.service.DiscardMessage(ctx, id) })
+	default:
+		err = errUsage
+	}
+	if errors.Is(err, errUsage) {
+		_, _ = fmt.Fprint(a.out, usage)
+	}
+	return err
+}
+
+func (a *Adapter) withID(args []string, fn func(id string) error) error {
+	if len(args) != 1 {
+		return errUsage
+	}
+	return fn(args[0])
+}
+
+func (a *Adapter) list(ctx context.Context, args []string) error {
+	filter := new(domain.FailedMessagesFilter)
+
+	flags := flag.NewFlagSet("list", flag.ContinueOnError)
+	flags.SetOutput(io.Discard)
+	flags.StringVar(&filter.Topic, "topic", "", "")
+	flags.IntVar(&filter.Limit, "limit", 0, "")
+	flags.IntVar(&filter.Offset, "offset", 0, "")
+	if err := flags.Parse(args); err != nil || flags.NArg() > 0 {
+		return errUsage
+	}
+
+	messages, err := a.service.ListFailedMessages(ctx, filter)
+	if err != nil {
+		return err
+	}
+
+	w := tabwriter.NewWriter(a.out, 0, 0, 2, ' ', 0)
+	_, _ = fmt.Fprintln(w, "ID\tTOPIC\tRETRIES\tCREATED AT\tLAST ERROR")
+	for _, message := range messages {
+		_, _ = fmt.Fprintf(w, "%s\t%s\t%d/%d\t%s\t%s\n",
+			message.ID, message.Topic, message.RetryCount, message.MaxRetries,
+			message.CreatedAt.Format(time.DateTime), message.LastError,
+		)
+	}
+	return w.Flush()
+}
+
+func (a *Adapter) show(ctx context.Context, id string) error {
+	message, err := a.service.GetMessage(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	w := tabwriter.NewWriter(a.out, 0, 0, 2, ' ', 0)
+	_, _ = fmt.Fprintf(w, "id:\t%s\n", message.ID)
+	_, _ = fmt.Fprintf(w, "aggregate:\t%s/%d\n", message.AggregateType, message.AggregateID)
+	_, _ = fmt.Fprintf(w, "topic:\t%s\n", message.Topic)
+	_, _ = fmt.Fprintf(w, "status:\t%s\n", message.Status)
+	_, _ = fmt.Fprintf(w, "retries:\t%d/%d\n", message.RetryCount, message.MaxRetries)
+	_, _ = fmt.Fprintf(w, "created at:\t%s\n", message.CreatedAt.Format(time.DateTime))
+	if message.ProcessedAt.Valid {
+		_, _ = fmt.Fprintf(w, "processed at:\t%s\n", message.ProcessedAt.Time.Format(time.DateTime))
+	}
+	if message.NextAttemptAt.Valid {
+		_, _ = fmt.Fprintf(w, "next attempt at:\t%s\n", message.NextAttemptAt.Time.Format(time.DateTime))
+	}
+	_, _ = fmt.Fprintf(w, "last error:\t%s\n", message.LastError)
+	_, _ = fmt.Fprintf(w, "metadata:\t%s\n", message.Metadata)
+	_, _ = fmt.Fprintf(w, "payload:\t%s\n", message.Payload)
+	return w.Flush()
+}
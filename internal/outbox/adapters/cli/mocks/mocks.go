@@ -0,0 +1,101 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: adapter.go
+//
+// Generated by this command:
+//
+//	mockgen -source adapter.go -package mocks -destination mocks/mocks.go
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+
+	domain "github.com/hasansino/go42/internal/outbox/domain"
+	models "github.com/hasansino/go42/internal/outbox/models"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockserviceAccessor is a mock of serviceAccessor interface.
+type MockserviceAccessor struct {
+	ctrl     *gomock.Controller
+	recorder *MockserviceAccessorMockRecorder
+	isgomock struct{}
+}
+
+// MockserviceAccessorMockRecorder is the mock recorder for MockserviceAccessor.
+type MockserviceAccessorMockRecorder struct {
+	mock *MockserviceAccessor
+}
+
+// NewMockserviceAccessor creates a new mock instance.
+func NewMockserviceAccessor(ctrl *gomock.Controller) *MockserviceAccessor {
+	mock := &MockserviceAccessor{ctrl: ctrl}
+	mock.recorder = &MockserviceAccessorMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockserviceAccessor) EXPECT() *MockserviceAccessorMockRecorder {
+	return m.recorder
+}
+
+// DiscardMessage mocks base method.
+func (m *MockserviceAccessor) DiscardMessage(ctx context.Context, id string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DiscardMessage", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DiscardMessage indicates an expected call of DiscardMessage.
+func (mr *MockserviceAccessorMockRecorder) DiscardMessage(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DiscardMessage", reflect.TypeOf((*MockserviceAccessor)(nil).DiscardMessage), ctx, id)
+}
+
+// GetMessage mocks base method.
+func (m *MockserviceAccessor) GetMessage(ctx context.Context, id string) (*models.Message, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetMessage", ctx, id)
+	ret0, _ := ret[0].(*models.Message)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetMessage indicates an expected call of GetMessage.
+func (mr *MockserviceAccessorMockRecorder) GetMessage(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetMessage", reflect.TypeOf((*MockserviceAccessor)(nil).GetMessage), ctx, id)
+}
+
+// ListFailedMessages mocks base method.
+func (m *MockserviceAccessor) ListFailedMessages(ctx context.Context, filter *domain.FailedMessagesFilter) ([]*models.Message, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListFailedMessages", ctx, filter)
+	ret0, _ := ret[0].([]*models.Message)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListFailedMessages indicates an expected call of ListFailedMessages.
+func (mr *MockserviceAccessorMockRecorder) ListFailedMessages(ctx, filter any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListFailedMessages", reflect.TypeOf((*MockserviceAccessor)(nil).ListFailedMessages), ctx, filter)
+}
+
+// RequeueMessage mocks base method.
+func (m *MockserviceAccessor) RequeueMessage(ctx context.Context, id string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RequeueMessage", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RequeueMessage indicates an expected call of RequeueMessage.
+func (mr *MockserviceAccessorMockRecorder) RequeueMessage(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RequeueMessage", reflect.TypeOf((*MockserviceAccessor)(nil).RequeueMessage), ctx, id)
+}
@@ -0,0 +1,114 @@
+package adapter
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/labstack/echo/v4"
+
+	authDomain "github.com/hasansino/go42/internal/auth/domain"
+	authMiddleware "github.com/hasansino/go42/internal/auth/middleware"
+	authModels "github.com/hasansino/go42/internal/auth/models"
+	"github.com/hasansino/go42/internal/outbox/domain"
+	"github.com/hasansino/go42/internal/outbox/models"
+)
+
+//go:generate mockgen -source $GOFILE -package mocks -destination mocks/mocks.go
+
+type serviceAccessor interface {
+	ListFailedMessages(ctx context.Context, filter *domain.FailedMessagesFilter) ([]*models.Message, error)
+	GetMessage(ctx context.Context, id string) (*models.Message, error)
+	RequeueMessage(ctx context.Context, id string) error
+	DiscardMessage(ctx context.Context, id string) error
+}
+
+// authAccessor authenticates and authorises requests to outbox endpoints.
+type authAccessor interface {
+	Logout(ctx context.Context, accessToken, refreshToken string) error
+	GetUserByID(ctx context.Context, id int) (*authModels.User, error)
+	GetUserByUUID(ctx context.Context, uuid string) (*authModels.User, error)
+	ValidateJWTToken(ctx context.Context, token string) (*authDomain.JWTClaims, error)
+	InvalidateJWTToken(ctx context.Context, token string, until time.Time) error
+	ValidateAPIToken(ctx context.Context, token string) (*authModels.Token, error)
+	GetOrganizationMember(
+		ctx context.Context, organizationUUID string, userID int,
+	) (*authModels.OrganizationMember, error)
+	RecordImpersonatedRequest(ctx context.Context, operation string, ipAddress string) error
+	CheckAccess(ctx context.Context, req *authDomain.AccessRequest) *authDomain.AccessDecision
+}
+
+// Adapter serves administration of outbox messages.
+type Adapter struct {
+	service serviceAccessor
+	auth    authAccessor
+}
+
+func New(service serviceAccessor, auth authAccessor) *Adapter {
+	return &Adapter{
+		service: service,
+		auth:    auth,
+	}
+}
+
+func (a *Adapter) Register(g *echo.Group) {
+	outboxGroup := g.Group("/outbox", authMiddleware.NewAuthMiddleware(a.auth))
+
+	outboxGroup.GET("/messages/failed", a.listFailedMessages,
+		authMiddleware.NewAccessMiddleware(a.auth, authDomain.RBACPermissionOutboxRead))
+	outboxGroup.GET("/messages/:id", a.readMessage,
+		authMiddleware.NewAccessMiddleware(a.auth, authDomain.RBACPermissionOutboxRead))
+	outboxGroup.POST("/messages/:id/requeue", a.requeueMessage,
+		authMiddleware.NewAccessMiddleware(a.auth, authDomain.RBACPermissionOutboxManage))
+	outboxGroup.DELETE("/messages/:id", a.discardMessage,
+		authMiddleware.NewAccessMiddleware(a.auth, authDomain.RBACPermissionOutboxManage))
+}
+
+// listFailedMessages accepts topic, limit and offset.
+func (a *Adapter) listFailedMessages(ctx echo.Context) error {
+	filter := &domain.FailedMessagesFilter{
+		Topic: ctx.QueryParam("topic"),
+	}
+	limit, err := strconv.Atoi(ctx.QueryParam("limit"))
+	if err == nil && limit > 0 {
+		filter.Limit = limit
+	}
+	offset, err := strconv.Atoi(ctx.QueryParam("offset"))
+	if err == nil && offset > 0 {
+		filter.Offset = offset
+	}
+
+	messages, err := a.service.ListFailedMessages(ctx.Request().Context(), filter)
+	if err != nil {
+		return a.processError(ctx, err)
+	}
+
+	resp := make([]MessageResponse, len(messages))
+	for i, message := range messages {
+		resp[i] = MessageResponseFromModel(message)
+	}
+	return ctx.JSON(http.StatusOK, resp)
+}
+
+func (a *Adapter) readMessage(ctx echo.Context) error {
+	message, err := a.service.GetMessage(ctx.Request().Context(), ctx.Param("id"))
+	if err != nil {
+		return a.processError(ctx, err)
+	}
+	return ctx.JSON(http.StatusOK, MessageResponseFromModel(message))
+}
+
+func (a *Adapter) requeueMessage(ctx echo.Context) error {
+	if err := a.service.RequeueMessage(ctx.Request().Context(), ctx.Param("id")); err != nil {
+		return a.processError(ctx, err)
+	}
+	return ctx.NoContent(http.StatusOK)
+}
+
+func (a *Adapter) discardMessage(ctx echo.Context) error {
+	if err := a.service.DiscardMessage(ctx.Request().Context(), ctx.Param("id")); err != nil {
+		return a.processError(ctx, err)
+	}
+	return ctx.NoContent(http.StatusOK)
+}
@@ -0,0 +1,25 @@
+package adapter
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+
+	httpAPI "github.com/hasansino/go42/internal/api/http"
+	"github.com/hasansino/go42/internal/outbox/domain"
+)
+
+func (a *Adapter) processError(ctx echo.Context, err error) error {
+	switch {
+	case errors.Is(err, domain.ErrMessageNotFound):
+		return httpAPI.SendJSONError(ctx,
+			http.StatusNotFound, http.StatusText(http.StatusNotFound))
+	case errors.Is(err, domain.ErrMessageNotFailed):
+		return httpAPI.SendJSONError(ctx,
+			http.StatusConflict, http.StatusText(http.StatusConflict))
+	default:
+		return httpAPI.SendJSONError(ctx,
+			http.StatusInternalServerError, err.Error())
+	}
+}
@@ -0,0 +1,259 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: adapter.go
+//
+// Generated by this command:
+//
+//	mockgen -source adapter.go -package mocks -destination mocks/mocks.go
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+	time "time"
+
+	domain "github.com/hasansino/go42/internal/auth/domain"
+	models "github.com/hasansino/go42/internal/auth/models"
+	domain0 "github.com/hasansino/go42/internal/outbox/domain"
+	models0 "github.com/hasansino/go42/internal/outbox/models"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockserviceAccessor is a mock of serviceAccessor interface.
+type MockserviceAccessor struct {
+	ctrl     *gomock.Controller
+	recorder *MockserviceAccessorMockRecorder
+	isgomock struct{}
+}
+
+// MockserviceAccessorMockRecorder is the mock recorder for MockserviceAccessor.
+type MockserviceAccessorMockRecorder struct {
+	mock *MockserviceAccessor
+}
+
+// NewMockserviceAccessor creates a new mock instance.
+func NewMockserviceAccessor(ctrl *gomock.Controller) *MockserviceAccessor {
+	mock := &MockserviceAccessor{ctrl: ctrl}
+	mock.recorder = &MockserviceAccessorMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockserviceAccessor) EXPECT() *MockserviceAccessorMockRecorder {
+	return m.recorder
+}
+
+// DiscardMessage mocks base method.
+func (m *MockserviceAccessor) DiscardMessage(ctx context.Context, id string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DiscardMessage", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DiscardMessage indicates an expected call of DiscardMessage.
+func (mr *MockserviceAccessorMockRecorder) DiscardMessage(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DiscardMessage", reflect.TypeOf((*MockserviceAccessor)(nil).DiscardMessage), ctx, id)
+}
+
+// GetMessage mocks base method.
+func (m *MockserviceAccessor) GetMessage(ctx context.Context, id string) (*models0.Message, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetMessage", ctx, id)
+	ret0, _ := ret[0].(*models0.Message)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetMessage indicates an expected call of GetMessage.
+func (mr *MockserviceAccessorMockRecorder) GetMessage(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetMessage", reflect.TypeOf((*MockserviceAccessor)(nil).GetMessage), ctx, id)
+}
+
+// ListFailedMessages mocks base method.
+func (m *MockserviceAccessor) ListFailedMessages(ctx context.Context, filter *domain0.FailedMessagesFilter) ([]*models0.Message, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListFailedMessages", ctx, filter)
+	ret0, _ := ret[0].([]*models0.Message)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListFailedMessages indicates an expected call of ListFailedMessages.
+func (mr *MockserviceAccessorMockRecorder) ListFailedMessages(ctx, filter any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListFailedMessages", reflect.TypeOf((*MockserviceAccessor)(nil).ListFailedMessages), ctx, filter)
+}
+
+// RequeueMessage mocks base method.
+func (m *MockserviceAccessor) RequeueMessage(ctx context.Context, id string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RequeueMessage", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RequeueMessage indicates an expected call of RequeueMessage.
+func (mr *MockserviceAccessorMockRecorder) RequeueMessage(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RequeueMessage", reflect.TypeOf((*MockserviceAccessor)(nil).RequeueMessage), ctx, id)
+}
+
+// MockauthAccessor is a mock of authAccessor interface.
+type MockauthAccessor struct {
+	ctrl     *gomock.Controller
+	recorder *MockauthAccessorMockRecorder
+	isgomock struct{}
+}
+
+// MockauthAccessorMockRecorder is the mock recorder for MockauthAccessor.
+type MockauthAccessorMockRecorder struct {
+	mock *MockauthAccessor
+}
+
+// NewMockauthAccessor creates a new mock instance.
+func NewMockauthAccessor(ctrl *gomock.Controller) *MockauthAccessor {
+	mock := &MockauthAccessor{ctrl: ctrl}
+	mock.recorder = &MockauthAccessorMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockauthAccessor) EXPECT() *MockauthAccessorMockRecorder {
+	return m.recorder
+}
+
+// CheckAccess mocks base method.
+func (m *MockauthAccessor) CheckAccess(ctx context.Context, req *domain.AccessRequest) *domain.AccessDecision {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CheckAccess", ctx, req)
+	ret0, _ := ret[0].(*domain.AccessDecision)
+	return ret0
+}
+
+// CheckAccess indicates an expected call of CheckAccess.
+func (mr *MockauthAccessorMockRecorder) CheckAccess(ctx, req any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CheckAccess", reflect.TypeOf((*MockauthAccessor)(nil).CheckAccess), ctx, req)
+}
+
+// GetOrganizationMember mocks base method.
+func (m *MockauthAccessor) GetOrganizationMember(ctx context.Context, organizationUUID string, userID int) (*models.OrganizationMember, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetOrganizationMember", ctx, organizationUUID, userID)
+	ret0, _ := ret[0].(*models.OrganizationMember)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetOrganizationMember indicates an expected call of GetOrganizationMember.
+func (mr *MockauthAccessorMockRecorder) GetOrganizationMember(ctx, organizationUUID, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetOrganizationMember", reflect.TypeOf((*MockauthAccessor)(nil).GetOrganizationMember), ctx, organizationUUID, userID)
+}
+
+// GetUserByID mocks base method.
+func (m *MockauthAccessor) GetUserByID(ctx context.Context, id int) (*models.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUserByID", ctx, id)
+	ret0, _ := ret[0].(*models.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUserByID indicates an expected call of GetUserByID.
+func (mr *MockauthAccessorMockRecorder) GetUserByID(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserByID", reflect.TypeOf((*MockauthAccessor)(nil).GetUserByID), ctx, id)
+}
+
+// GetUserByUUID mocks base method.
+func (m *MockauthAccessor) GetUserByUUID(ctx context.Context, uuid string) (*models.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUserByUUID", ctx, uuid)
+	ret0, _ := ret[0].(*models.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUserByUUID indicates an expected call of GetUserByUUID.
+func (mr *MockauthAccessorMockRecorder) GetUserByUUID(ctx, uuid any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserByUUID", reflect.TypeOf((*MockauthAccessor)(nil).GetUserByUUID), ctx, uuid)
+}
+
+// InvalidateJWTToken mocks base method.
+func (m *MockauthAccessor) InvalidateJWTToken(ctx context.Context, token string, until time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "InvalidateJWTToken", ctx, token, until)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// InvalidateJWTToken indicates an expected call of InvalidateJWTToken.
+func (mr *MockauthAccessorMockRecorder) InvalidateJWTToken(ctx, token, until any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InvalidateJWTToken", reflect.TypeOf((*MockauthAccessor)(nil).InvalidateJWTToken), ctx, token, until)
+}
+
+// Logout mocks base method.
+func (m *MockauthAccessor) Logout(ctx context.Context, accessToken, refreshToken string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Logout", ctx, accessToken, refreshToken)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Logout indicates an expected call of Logout.
+func (mr *MockauthAccessorMockRecorder) Logout(ctx, accessToken, refreshToken any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Logout", reflect.TypeOf((*MockauthAccessor)(nil).Logout), ctx, accessToken, refreshToken)
+}
+
+// RecordImpersonatedRequest mocks base method.
+func (m *MockauthAccessor) RecordImpersonatedRequest(ctx context.Context, operation, ipAddress string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RecordImpersonatedRequest", ctx, operation, ipAddress)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RecordImpersonatedRequest indicates an expected call of RecordImpersonatedRequest.
+func (mr *MockauthAccessorMockRecorder) RecordImpersonatedRequest(ctx, operation, ipAddress any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RecordImpersonatedRequest", reflect.TypeOf((*MockauthAccessor)(nil).RecordImpersonatedRequest), ctx, operation, ipAddress)
+}
+
+// ValidateAPIToken mocks base method.
+func (m *MockauthAccessor) ValidateAPIToken(ctx context.Context, token string) (*models.Token, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ValidateAPIToken", ctx, token)
+	ret0, _ := ret[0].(*models.Token)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ValidateAPIToken indicates an expected call of ValidateAPIToken.
+func (mr *MockauthAccessorMockRecorder) ValidateAPIToken(ctx, token any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ValidateAPIToken", reflect.TypeOf((*MockauthAccessor)(nil).ValidateAPIToken), ctx, token)
+}
+
+// ValidateJWTToken mocks base method.
+func (m *MockauthAccessor) ValidateJWTToken(ctx context.Context, token string) (*domain.JWTClaims, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ValidateJWTToken", ctx, token)
+	ret0, _ := ret[0].(*domain.JWTClaims)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ValidateJWTToken indicates an expected call of ValidateJWTToken.
+func (mr *MockauthAccessorMockRecorder) ValidateJWTToken(ctx, token any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ValidateJWTToken", reflect.TypeOf((*MockauthAccessor)(nil).ValidateJWTToken), ctx, token)
+}
@@ -0,0 +1,56 @@
+package adapter
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/hasansino/go42/internal/outbox/models"
+)
+
+type MessageResponse struct {
+	ID            string          `json:"id"`
+	AggregateID   int             `json:"aggregate_id"`
+	AggregateType string          `json:"aggregate_type"`
+	Topic         string          `json:"topic"`
+	Status        string          `json:"status"`
+	RetryCount    int             `json:"retry_count"`
+	MaxRetries    int             `json:"max_retries"`
+	LastError     *string         `json:"last_error"`
+	Payload       json.RawMessage `json:"payload"`
+	Metadata      json.RawMessage `json:"metadata"`
+	CreatedAt     string          `json:"created_at"`
+	ProcessedAt   *string         `json:"processed_at"`
+	NextAttemptAt *string         `json:"next_attempt_at"`
+}
+
+func MessageResponseFromModel(message *models.Message) MessageResponse {
+	resp := MessageResponse{
+		ID:            message.ID.String(),
+		AggregateID:   message.AggregateID,
+		AggregateType: message.AggregateType,
+		Topic:         message.Topic,
+		Status:        message.Status,
+		RetryCount:    message.RetryCount,
+		MaxRetries:    message.MaxRetries,
+		CreatedAt:     message.CreatedAt.Format(time.DateTime),
+	}
+	if message.LastError != "" {
+		resp.LastError = &message.LastError
+	}
+	// payload is published as is, it is not required to be json
+	if json.Valid(message.Payload) {
+		resp.Payload = message.Payload
+	}
+	if message.Metadata != "" && json.Valid([]byte(message.Metadata)) {
+		resp.Metadata = json.RawMessage(message.Metadata)
+	}
+	if message.ProcessedAt.Valid {
+		processedAt := message.ProcessedAt.Time.Format(time.DateTime)
+		resp.ProcessedAt = &processedAt
+	}
+	if message.NextAttemptAt.Valid {
+		nextAttemptAt := message.NextAttemptAt.Time.Format(time.DateTime)
+		resp.NextAttemptAt = &nextAttemptAt
+	}
+	return resp
+}
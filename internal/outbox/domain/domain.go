@@ -1,18 +1,35 @@
 package domain
 
 import (
+	"errors"
 	"time"
 
 	"github.com/google/uuid"
 )
 
-const MaxRetries = 3
+// DefaultMaxRetries is used for topics without configured number of retries.
+const DefaultMaxRetries = 3
+
+// NotificationChannel is pgsql channel notified by trigger on new messages.
+const NotificationChannel = "transactional_outbox"
+
+// RelayPublication is pgsql publication of messages read by wal relay.
+const RelayPublication = "transactional_outbox_relay"
+
+var (
+	ErrMessageNotFound  = errors.New("message not found")
+	ErrMessageNotFailed = errors.New("message is not failed")
+)
 
 type Message struct {
 	AggregateID   int    `v:"required,gte=1"`
 	AggregateType string `v:"required,min=3,max=100"`
-	Payload       []byte `v:"omitzero,min=2"`
-	Metadata      string `v:"omitzero,max=1000"`
+	// AggregateKey identifies aggregate when messages of different types belong to it,
+	// e.g. "user:1". Messages of the same key are published in order.
+	// Aggregate type and id are used if empty.
+	AggregateKey string `v:"omitzero,max=255"`
+	Payload      []byte `v:"omitzero,min=2"`
+	Metadata     string `v:"omitzero,max=1000"`
 }
 
 type Event struct {
@@ -23,3 +40,10 @@ type Event struct {
 	Payload       []byte    `json:"payload"`
 	Metadata      string    `json:"metadata"`
 }
+
+// FailedMessagesFilter selects failed messages, optionally of single topic.
+type FailedMessagesFilter struct {
+	Topic  string
+	Limit  int
+	Offset int
+}
@@ -13,6 +13,8 @@ import (
 	context "context"
 	reflect "reflect"
 
+	uuid "github.com/google/uuid"
+	domain "github.com/hasansino/go42/internal/outbox/domain"
 	models "github.com/hasansino/go42/internal/outbox/models"
 	gomock "go.uber.org/mock/gomock"
 )
@@ -41,6 +43,62 @@ func (m *Mockrepository) EXPECT() *MockrepositoryMockRecorder {
 	return m.recorder
 }
 
+// AfterCommit mocks base method.
+func (m *Mockrepository) AfterCommit(ctx context.Context, hook func()) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "AfterCommit", ctx, hook)
+}
+
+// AfterCommit indicates an expected call of AfterCommit.
+func (mr *MockrepositoryMockRecorder) AfterCommit(ctx, hook any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AfterCommit", reflect.TypeOf((*Mockrepository)(nil).AfterCommit), ctx, hook)
+}
+
+// DeleteFailedMessage mocks base method.
+func (m *Mockrepository) DeleteFailedMessage(ctx context.Context, id uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteFailedMessage", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteFailedMessage indicates an expected call of DeleteFailedMessage.
+func (mr *MockrepositoryMockRecorder) DeleteFailedMessage(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteFailedMessage", reflect.TypeOf((*Mockrepository)(nil).DeleteFailedMessage), ctx, id)
+}
+
+// GetMessageByID mocks base method.
+func (m *Mockrepository) GetMessageByID(ctx context.Context, id uuid.UUID) (*models.Message, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetMessageByID", ctx, id)
+	ret0, _ := ret[0].(*models.Message)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetMessageByID indicates an expected call of GetMessageByID.
+func (mr *MockrepositoryMockRecorder) GetMessageByID(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetMessageByID", reflect.TypeOf((*Mockrepository)(nil).GetMessageByID), ctx, id)
+}
+
+// ListFailedMessages mocks base method.
+func (m *Mockrepository) ListFailedMessages(ctx context.Context, filter *domain.FailedMessagesFilter) ([]*models.Message, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListFailedMessages", ctx, filter)
+	ret0, _ := ret[0].([]*models.Message)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListFailedMessages indicates an expected call of ListFailedMessages.
+func (mr *MockrepositoryMockRecorder) ListFailedMessages(ctx, filter any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListFailedMessages", reflect.TypeOf((*Mockrepository)(nil).ListFailedMessages), ctx, filter)
+}
+
 // NewOutboxMessage mocks base method.
 func (m *Mockrepository) NewOutboxMessage(ctx context.Context, msg *models.Message) error {
 	m.ctrl.T.Helper()
@@ -54,3 +112,17 @@ func (mr *MockrepositoryMockRecorder) NewOutboxMessage(ctx, msg any) *gomock.Cal
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "NewOutboxMessage", reflect.TypeOf((*Mockrepository)(nil).NewOutboxMessage), ctx, msg)
 }
+
+// RequeueFailedMessage mocks base method.
+func (m *Mockrepository) RequeueFailedMessage(ctx context.Context, id uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RequeueFailedMessage", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RequeueFailedMessage indicates an expected call of RequeueFailedMessage.
+func (mr *MockrepositoryMockRecorder) RequeueFailedMessage(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RequeueFailedMessage", reflect.TypeOf((*Mockrepository)(nil).RequeueFailedMessage), ctx, id)
+}
@@ -2,6 +2,7 @@ package models
 
 import (
 	"database/sql"
+	"strconv"
 	"time"
 
 	"github.com/google/uuid"
@@ -17,10 +18,12 @@ type Message struct {
 	ID            uuid.UUID
 	AggregateID   int
 	AggregateType string
+	AggregateKey  string
 	Topic         string
 	Payload       []byte
 	CreatedAt     time.Time
 	ProcessedAt   sql.NullTime
+	NextAttemptAt sql.NullTime
 	Status        string
 	RetryCount    int
 	MaxRetries    int
@@ -31,3 +34,38 @@ type Message struct {
 func (m *Message) TableName() string {
 	return "transactional_outbox"
 }
+
+// OrderingKey identifies aggregate, messages of the same aggregate are published in order.
+func (m *Message) OrderingKey() string {
+	if m.AggregateKey != "" {
+		return m.AggregateKey
+	}
+	return DefaultAggregateKey(m.AggregateType, m.AggregateID)
+}
+
+// DefaultAggregateKey is used for messages created without aggregate key.
+func DefaultAggregateKey(aggregateType string, aggregateID int) string {
+	return aggregateType + ":" + strconv.Itoa(aggregateID)
+}
+
+// ArchivedMessage is processed message moved out of outbox by cleanup worker.
+// Status and next attempt time are not kept, they are meaningless for processed message.
+type ArchivedMessage struct {
+	ID            uuid.UUID
+	AggregateID   int
+	AggregateType string
+	AggregateKey  string
+	Topic         string
+	Payload       []byte
+	CreatedAt     time.Time
+	ProcessedAt   sql.NullTime
+	ArchivedAt    time.Time
+	RetryCount    int
+	MaxRetries    int
+	LastError     string
+	Metadata      string
+}
+
+func (m *ArchivedMessage) TableName() string {
+	return "transactional_outbox_archive"
+}
@@ -9,3 +9,24 @@ func WithLogger(logger *slog.Logger) Option {
 		s.logger = logger
 	}
 }
+
+// WithMaxRetries sets number of publish attempts for topics without own limit.
+func WithMaxRetries(maxRetries int) Option {
+	return func(s *Service) {
+		s.maxRetries = maxRetries
+	}
+}
+
+// WithTopicMaxRetries sets number of publish attempts for the topic.
+func WithTopicMaxRetries(topic string, maxRetries int) Option {
+	return func(s *Service) {
+		s.topicMaxRetries[topic] = maxRetries
+	}
+}
+
+// WithWakeup sets function called after message is committed, e.g. to wake up publisher.
+func WithWakeup(wakeup func()) Option {
+	return func(s *Service) {
+		s.wakeup = wakeup
+	}
+}
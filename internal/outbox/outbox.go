@@ -5,10 +5,12 @@ package outbox
 
 import (
 	"context"
+	"fmt"
 	"log/slog"
 
 	"github.com/google/uuid"
 
+	"github.com/hasansino/go42/internal/metrics"
 	"github.com/hasansino/go42/internal/outbox/domain"
 	"github.com/hasansino/go42/internal/outbox/models"
 	"github.com/hasansino/go42/internal/tools"
@@ -17,17 +19,27 @@ import (
 //go:generate mockgen -source $GOFILE -package mocks -destination mocks/mocks.go
 
 type repository interface {
+	AfterCommit(ctx context.Context, hook func())
 	NewOutboxMessage(ctx context.Context, msg *models.Message) error
+	ListFailedMessages(ctx context.Context, filter *domain.FailedMessagesFilter) ([]*models.Message, error)
+	GetMessageByID(ctx context.Context, id uuid.UUID) (*models.Message, error)
+	RequeueFailedMessage(ctx context.Context, id uuid.UUID) error
+	DeleteFailedMessage(ctx context.Context, id uuid.UUID) error
 }
 
 type Service struct {
-	logger     *slog.Logger
-	repository repository
+	logger          *slog.Logger
+	repository      repository
+	maxRetries      int
+	topicMaxRetries map[string]int
+	wakeup          func()
 }
 
 func NewService(repository repository, opts ...Option) *Service {
 	svc := &Service{
-		repository: repository,
+		repository:      repository,
+		maxRetries:      domain.DefaultMaxRetries,
+		topicMaxRetries: make(map[string]int),
 	}
 	for _, opt := range opts {
 		opt(svc)
@@ -44,16 +56,115 @@ func (s *Service) NewOutboxMessage(ctx context.Context, topic string, msg *domai
 		return err
 	}
 
+	// time ordered id keeps order of messages created within the same second
+	id, err := uuid.NewV7()
+	if err != nil {
+		return fmt.Errorf("failed to generate message id: %w", err)
+	}
+
 	var outboxMsg models.Message
 
-	outboxMsg.ID = uuid.New()
+	outboxMsg.ID = id
 	outboxMsg.AggregateID = msg.AggregateID
 	outboxMsg.AggregateType = msg.AggregateType
+	outboxMsg.AggregateKey = msg.AggregateKey
+	if outboxMsg.AggregateKey == "" {
+		outboxMsg.AggregateKey = models.DefaultAggregateKey(msg.AggregateType, msg.AggregateID)
+	}
 	outboxMsg.Topic = topic
 	outboxMsg.Payload = msg.Payload
 	outboxMsg.Status = models.MessageStatusPending
-	outboxMsg.MaxRetries = domain.MaxRetries
+	outboxMsg.MaxRetries = s.topicMaxRetriesOrDefault(topic)
 	outboxMsg.Metadata = msg.Metadata
 
-	return s.repository.NewOutboxMessage(ctx, &outboxMsg)
+	if err := s.repository.NewOutboxMessage(ctx, &outboxMsg); err != nil {
+		return err
+	}
+
+	// message is visible to publisher only after transaction is committed
+	if s.wakeup != nil {
+		s.repository.AfterCommit(ctx, s.wakeup)
+	}
+
+	return nil
+}
+
+func (s *Service) topicMaxRetriesOrDefault(topic string) int {
+	if maxRetries, ok := s.topicMaxRetries[topic]; ok {
+		return maxRetries
+	}
+	return s.maxRetries
+}
+
+const (
+	defaultFailedMessagesPageSize = 20
+	maxFailedMessagesPageSize     = 100
+)
+
+// ListFailedMessages returns failed messages, oldest first.
+// Messages which exhausted their retries are failed, they stay in outbox until requeued or discarded.
+func (s *Service) ListFailedMessages(
+	ctx context.Context, filter *domain.FailedMessagesFilter,
+) ([]*models.Message, error) {
+	if filter.Limit <= 0 {
+		filter.Limit = defaultFailedMessagesPageSize
+	}
+	filter.Limit = min(filter.Limit, maxFailedMessagesPageSize)
+	filter.Offset = max(filter.Offset, 0)
+	return s.repository.ListFailedMessages(ctx, filter)
+}
+
+// GetMessage returns message of any status.
+func (s *Service) GetMessage(ctx context.Context, id string) (*models.Message, error) {
+	messageID, err := uuid.Parse(id)
+	if err != nil {
+		return nil, domain.ErrMessageNotFound
+	}
+	return s.repository.GetMessageByID(ctx, messageID)
+}
+
+// RequeueMessage returns failed message to pending with reset retries,
+// it is published by next run of publisher worker.
+func (s *Service) RequeueMessage(ctx context.Context, id string) error {
+	message, err := s.failedMessage(ctx, id)
+	if err != nil {
+		return err
+	}
+	if err := s.repository.RequeueFailedMessage(ctx, message.ID); err != nil {
+		return err
+	}
+	s.logger.InfoContext(ctx, "failed message requeued",
+		slog.String("id", message.ID.String()),
+		slog.String("topic", message.Topic),
+	)
+	metrics.Counter("application_outbox_messages_requeued", nil).Inc()
+	return nil
+}
+
+// DiscardMessage deletes failed message, it is never published.
+func (s *Service) DiscardMessage(ctx context.Context, id string) error {
+	message, err := s.failedMessage(ctx, id)
+	if err != nil {
+		return err
+	}
+	if err := s.repository.DeleteFailedMessage(ctx, message.ID); err != nil {
+		return err
+	}
+	s.logger.InfoContext(ctx, "failed message discarded",
+		slog.String("id", message.ID.String()),
+		slog.String("topic", message.Topic),
+	)
+	metrics.Counter("application_outbox_messages_discarded", nil).Inc()
+	return nil
+}
+
+func (s *Service) failedMessage(ctx context.Context, id string) (*models.Message, error) {
+	message, err := s.GetMessage(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if message.Status != models.MessageStatusFailed {
+		return nil, domain.ErrMessageNotFailed
+	}
+	return message, nil
 }
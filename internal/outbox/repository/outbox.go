@@ -9,6 +9,7 @@ import (
 	"gorm.io/gorm/clause"
 
 	"github.com/hasansino/go42/internal/database"
+	"github.com/hasansino/go42/internal/outbox/domain"
 	"github.com/hasansino/go42/internal/outbox/models"
 )
 
@@ -28,12 +29,32 @@ func (r *Repository) NewOutboxMessage(ctx context.Context, msg *models.Message)
 	return nil
 }
 
-func (r *Repository) GetUnprocessedMessages(ctx context.Context, limit int) ([]models.Message, error) {
+// GetUnprocessedMessages returns only the oldest pending message of every aggregate,
+// message is not returned while older message of the same aggregate key and topic is pending or failed.
+// Messages are ordered by creation, id breaks ties because it is time ordered.
+// Messages created after createdBefore are not returned, unless it is zero.
+func (r *Repository) GetUnprocessedMessages(
+	ctx context.Context, limit int, createdBefore time.Time,
+) ([]models.Message, error) {
 	var messages []models.Message
-	result := r.
+	query := r.
 		GetTx(ctx).
 		Clauses(clause.Locking{Strength: clause.LockingStrengthUpdate, Options: clause.LockingOptionsSkipLocked}).
 		Where("status = ?", models.MessageStatusPending).
+		Where("next_attempt_at IS NULL OR next_attempt_at <= ?", time.Now())
+	if !createdBefore.IsZero() {
+		query = query.Where("created_at <= ?", createdBefore)
+	}
+	result := query.
+		Where(`NOT EXISTS (
+			SELECT 1 FROM transactional_outbox AS older
+			WHERE older.topic = transactional_outbox.topic
+			AND older.aggregate_key = transactional_outbox.aggregate_key
+			AND older.status IN ?
+			AND (older.created_at < transactional_outbox.created_at
+				OR (older.created_at = transactional_outbox.created_at AND older.id < transactional_outbox.id))
+		)`, []string{models.MessageStatusPending, models.MessageStatusFailed}).
+		Order("created_at, id").
 		Limit(limit).Find(&messages)
 	if result.Error != nil {
 		return nil, fmt.Errorf("error fetching messages: %w", result.Error)
@@ -41,6 +62,30 @@ func (r *Repository) GetUnprocessedMessages(ctx context.Context, limit int) ([]m
 	return messages, nil
 }
 
+// GetBlockedMessageIDs returns ids of given messages which wait for older message of the same aggregate,
+// older message is pending or failed and it is not one of given messages.
+func (r *Repository) GetBlockedMessageIDs(ctx context.Context, ids []uuid.UUID) ([]uuid.UUID, error) {
+	var blocked []uuid.UUID
+	err := r.
+		GetTx(ctx).
+		Model(&models.Message{}).
+		Where("id IN ?", ids).
+		Where(`EXISTS (
+			SELECT 1 FROM transactional_outbox AS older
+			WHERE older.topic = transactional_outbox.topic
+			AND older.aggregate_key = transactional_outbox.aggregate_key
+			AND older.status IN ?
+			AND older.id NOT IN ?
+			AND (older.created_at < transactional_outbox.created_at
+				OR (older.created_at = transactional_outbox.created_at AND older.id < transactional_outbox.id))
+		)`, []string{models.MessageStatusPending, models.MessageStatusFailed}, ids).
+		Pluck("id", &blocked).Error
+	if err != nil {
+		return nil, fmt.Errorf("error fetching blocked messages: %w", err)
+	}
+	return blocked, nil
+}
+
 func (r *Repository) SaveProcessedMessages(ctx context.Context, messages []models.Message) error {
 	var ids []uuid.UUID
 	for _, message := range messages {
@@ -60,12 +105,180 @@ func (r *Repository) SaveProcessedMessages(ctx context.Context, messages []model
 	return nil
 }
 
+// SaveFailedMessages records failed attempt of messages which are still pending,
+// messages processed or purged meanwhile are left as they are.
 func (r *Repository) SaveFailedMessages(ctx context.Context, messages []models.Message) error {
 	for _, message := range messages {
-		result := r.GetTx(ctx).Save(&message)
+		result := r.GetTx(ctx).
+			Model(&models.Message{}).
+			Where("id = ? AND status = ?", message.ID, models.MessageStatusPending).
+			Updates(map[string]interface{}{
+				"status":          message.Status,
+				"retry_count":     message.RetryCount,
+				"next_attempt_at": message.NextAttemptAt,
+				"last_error":      message.LastError,
+			})
 		if result.Error != nil {
-			return fmt.Errorf("error saving message with ID %d: %w", message.ID, result.Error)
+			return fmt.Errorf("error saving message with ID %s: %w", message.ID, result.Error)
+		}
+	}
+	return nil
+}
+
+// LockPendingMessages locks given messages which are still pending,
+// messages locked by other transaction or not pending anymore are skipped.
+func (r *Repository) LockPendingMessages(ctx context.Context, ids []uuid.UUID) ([]models.Message, error) {
+	var messages []models.Message
+	err := r.
+		GetTx(ctx).
+		Clauses(clause.Locking{Strength: clause.LockingStrengthUpdate, Options: clause.LockingOptionsSkipLocked}).
+		Where("id IN ? AND status = ?", ids, models.MessageStatusPending).
+		Order("created_at, id").
+		Find(&messages).Error
+	if err != nil {
+		return nil, fmt.Errorf("error locking messages: %w", err)
+	}
+	return messages, nil
+}
+
+func (r *Repository) ListFailedMessages(
+	ctx context.Context, filter *domain.FailedMessagesFilter,
+) ([]*models.Message, error) {
+	var messages []*models.Message
+	query := r.GetReadDB(ctx).
+		Where("status = ?", models.MessageStatusFailed)
+	if filter.Topic != "" {
+		query = query.Where("topic = ?", filter.Topic)
+	}
+	err := query.
+		Order("created_at, id").
+		Limit(filter.Limit).
+		Offset(filter.Offset).
+		Find(&messages).Error
+	if err != nil {
+		return nil, fmt.Errorf("error fetching failed messages: %w", err)
+	}
+	return messages, nil
+}
+
+func (r *Repository) GetMessageByID(ctx context.Context, id uuid.UUID) (*models.Message, error) {
+	message := new(models.Message)
+	err := r.GetReadDB(ctx).Where("id = ?", id).First(message).Error
+	if err != nil {
+		if r.IsNotFoundError(err) {
+			return nil, domain.ErrMessageNotFound
 		}
+		return nil, fmt.Errorf("error fetching message: %w", err)
+	}
+	return message, nil
+}
+
+// RequeueFailedMessage returns domain.ErrMessageNotFailed if message is not failed anymore.
+func (r *Repository) RequeueFailedMessage(ctx context.Context, id uuid.UUID) error {
+	result := r.GetTx(ctx).
+		Model(&models.Message{}).
+		Where("id = ? AND status = ?", id, models.MessageStatusFailed).
+		Updates(map[string]interface{}{
+			"status":          models.MessageStatusPending,
+			"retry_count":     0,
+			"next_attempt_at": nil,
+		})
+	if result.Error != nil {
+		return fmt.Errorf("error requeueing message: %w", result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return domain.ErrMessageNotFailed
+	}
+	return nil
+}
+
+// DeleteFailedMessage returns domain.ErrMessageNotFailed if message is not failed anymore.
+func (r *Repository) DeleteFailedMessage(ctx context.Context, id uuid.UUID) error {
+	result := r.GetTx(ctx).
+		Where("id = ? AND status = ?", id, models.MessageStatusFailed).
+		Delete(&models.Message{})
+	if result.Error != nil {
+		return fmt.Errorf("error deleting message: %w", result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return domain.ErrMessageNotFailed
 	}
 	return nil
 }
+
+// PurgeProcessedMessages deletes up to limit messages processed before given time,
+// archived copies are saved before deletion if archive is true.
+// Messages are selected first, so batch size is bounded in every database engine.
+func (r *Repository) PurgeProcessedMessages(
+	ctx context.Context, processedBefore time.Time, limit int, archive bool,
+) (int64, error) {
+	var messages []models.Message
+	err := r.
+		GetTx(ctx).
+		Clauses(clause.Locking{Strength: clause.LockingStrengthUpdate, Options: clause.LockingOptionsSkipLocked}).
+		Where("status = ? AND processed_at < ?", models.MessageStatusProcessed, processedBefore).
+		Order("processed_at").
+		Limit(limit).
+		Find(&messages).Error
+	if err != nil {
+		return 0, fmt.Errorf("error fetching processed messages: %w", err)
+	}
+	if len(messages) == 0 {
+		return 0, nil
+	}
+
+	ids := make([]uuid.UUID, len(messages))
+	for i, message := range messages {
+		ids[i] = message.ID
+	}
+
+	if archive {
+		now := time.Now()
+		archived := make([]models.ArchivedMessage, len(messages))
+		for i, message := range messages {
+			archived[i] = models.ArchivedMessage{
+				ID:            message.ID,
+				AggregateID:   message.AggregateID,
+				AggregateType: message.AggregateType,
+				AggregateKey:  message.AggregateKey,
+				Topic:         message.Topic,
+				Payload:       message.Payload,
+				CreatedAt:     message.CreatedAt,
+				ProcessedAt:   message.ProcessedAt,
+				ArchivedAt:    now,
+				RetryCount:    message.RetryCount,
+				MaxRetries:    message.MaxRetries,
+				LastError:     message.LastError,
+				Metadata:      message.Metadata,
+			}
+		}
+		// messages could be archived by interrupted run, which deleted nothing
+		err = r.GetTx(ctx).
+			Clauses(clause.OnConflict{DoNothing: true}).
+			Create(&archived).Error
+		if err != nil {
+			return 0, fmt.Errorf("error archiving messages: %w", err)
+		}
+	}
+
+	result := r.GetTx(ctx).Where("id IN ?", ids).Delete(&models.Message{})
+	if result.Error != nil {
+		return 0, fmt.Errorf("error deleting processed messages: %w", result.Error)
+	}
+	return result.RowsAffected, nil
+}
+
+// GetOldestProcessedTime returns zero time if there are no processed messages.
+func (r *Repository) GetOldestProcessedTime(ctx context.Context) (time.Time, error) {
+	var message models.Message
+	err := r.GetReadDB(ctx).
+		Select("processed_at").
+		Where("status = ?", models.MessageStatusProcessed).
+		Order("processed_at").
+		Limit(1).
+		Find(&message).Error
+	if err != nil {
+		return time.Time{}, fmt.Errorf("error fetching oldest processed message: %w", err)
+	}
+	return message.ProcessedAt.Time, nil
+}
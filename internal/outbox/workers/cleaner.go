@@ -0,0 +1,136 @@
+package workers
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"github.com/hasansino/go42/internal/metrics"
+)
+
+const (
+	defaultCleanupRetention = 7 * 24 * time.Hour
+	defaultCleanupBatchSize = 1000
+)
+
+// OutboxCleaner deletes processed messages older than retention,
+// optionally moving them to archive table.
+// Every batch is deleted in its own transaction to keep locks short.
+type OutboxCleaner struct {
+	logger     *slog.Logger
+	repository repository
+	retention  time.Duration
+	batchSize  int
+	archive    bool
+}
+
+func NewOutboxCleaner(
+	repository repository,
+	opts ...OutboxCleanerOption,
+) *OutboxCleaner {
+	c := &OutboxCleaner{
+		repository: repository,
+		retention:  defaultCleanupRetention,
+		batchSize:  defaultCleanupBatchSize,
+	}
+	for _, opt := range opts {
+		opt(c)
+	}
+	if c.logger == nil {
+		c.logger = slog.New(slog.DiscardHandler)
+	}
+	return c
+}
+
+func (c *OutboxCleaner) Run(ctx context.Context, interval time.Duration) {
+	c.logger.InfoContext(ctx, "starting outbox cleaner",
+		slog.Duration("retention", c.retention),
+		slog.Bool("archive", c.archive),
+	)
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			c.run(ctx)
+		}
+	}
+}
+
+func (c *OutboxCleaner) run(ctx context.Context) {
+	processedBefore := time.Now().Add(-c.retention)
+
+	var total int64
+	for ctx.Err() == nil {
+		var purged int64
+		err := c.repository.WithTransaction(ctx, func(txCtx context.Context) error {
+			var err error
+			purged, err = c.repository.PurgeProcessedMessages(txCtx, processedBefore, c.batchSize, c.archive)
+			return err
+		})
+		if err != nil {
+			c.logger.ErrorContext(ctx, "failed to purge processed messages", slog.Any("error", err))
+			metrics.Counter("application_errors", map[string]interface{}{
+				"type": "outbox_cleaner_error",
+			}).Inc()
+			break
+		}
+		total += purged
+		metrics.Counter("application_outbox_cleaner_purged", map[string]interface{}{
+			"archived": c.archive,
+		}).Add(int(purged))
+		if purged < int64(c.batchSize) {
+			break
+		}
+	}
+
+	if total > 0 {
+		c.logger.DebugContext(ctx, "processed messages purged", slog.Int64("count", total))
+	}
+
+	c.observeLag(ctx, processedBefore)
+}
+
+// observeLag reports how long ago oldest processed message was due for cleanup.
+func (c *OutboxCleaner) observeLag(ctx context.Context, processedBefore time.Time) {
+	oldest, err := c.repository.GetOldestProcessedTime(ctx)
+	if err != nil {
+		c.logger.ErrorContext(ctx, "failed to get oldest processed message", slog.Any("error", err))
+		return
+	}
+	var lag time.Duration
+	if !oldest.IsZero() && oldest.Before(processedBefore) {
+		lag = processedBefore.Sub(oldest)
+	}
+	metrics.Gauge("application_outbox_cleaner_lag_seconds", nil).Set(lag.Seconds())
+}
+
+type OutboxCleanerOption func(*OutboxCleaner)
+
+func OutboxCleanerWithLogger(logger *slog.Logger) OutboxCleanerOption {
+	return func(o *OutboxCleaner) {
+		o.logger = logger
+	}
+}
+
+// OutboxCleanerWithRetention sets how long processed messages are kept.
+func OutboxCleanerWithRetention(retention time.Duration) OutboxCleanerOption {
+	return func(o *OutboxCleaner) {
+		o.retention = retention
+	}
+}
+
+func OutboxCleanerWithBatchSize(batchSize int) OutboxCleanerOption {
+	return func(o *OutboxCleaner) {
+		o.batchSize = batchSize
+	}
+}
+
+// OutboxCleanerWithArchive moves messages to archive table instead of deleting them.
+func OutboxCleanerWithArchive(archive bool) OutboxCleanerOption {
+	return func(o *OutboxCleaner) {
+		o.archive = archive
+	}
+}
@@ -0,0 +1,63 @@
+package workers
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"go.uber.org/mock/gomock"
+
+	"github.com/hasansino/go42/internal/outbox/workers/mocks"
+)
+
+func TestOutboxCleaner_Run(t *testing.T) {
+	withTransaction := func(ctx context.Context, fn func(txCtx context.Context) error) error {
+		return fn(ctx)
+	}
+
+	t.Run("purges batches until last incomplete batch", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		repo := mocks.NewMockrepository(ctrl)
+		repo.EXPECT().WithTransaction(gomock.Any(), gomock.Any()).DoAndReturn(withTransaction).Times(3)
+		gomock.InOrder(
+			repo.EXPECT().PurgeProcessedMessages(gomock.Any(), gomock.Any(), 10, true).Return(int64(10), nil),
+			repo.EXPECT().PurgeProcessedMessages(gomock.Any(), gomock.Any(), 10, true).Return(int64(10), nil),
+			repo.EXPECT().PurgeProcessedMessages(gomock.Any(), gomock.Any(), 10, true).Return(int64(3), nil),
+		)
+		repo.EXPECT().GetOldestProcessedTime(gomock.Any()).Return(time.Time{}, nil)
+
+		cleaner := NewOutboxCleaner(repo, OutboxCleanerWithBatchSize(10), OutboxCleanerWithArchive(true))
+		cleaner.run(context.Background())
+	})
+
+	t.Run("stops on error", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		repo := mocks.NewMockrepository(ctrl)
+		repo.EXPECT().WithTransaction(gomock.Any(), gomock.Any()).DoAndReturn(withTransaction)
+		repo.EXPECT().PurgeProcessedMessages(gomock.Any(), gomock.Any(), 10, false).
+			Return(int64(0), errors.New("database is gone"))
+		repo.EXPECT().GetOldestProcessedTime(gomock.Any()).Return(time.Time{}, nil)
+
+		cleaner := NewOutboxCleaner(repo, OutboxCleanerWithBatchSize(10))
+		cleaner.run(context.Background())
+	})
+
+	t.Run("purges messages older than retention", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		repo := mocks.NewMockrepository(ctrl)
+		repo.EXPECT().WithTransaction(gomock.Any(), gomock.Any()).DoAndReturn(withTransaction)
+		repo.EXPECT().PurgeProcessedMessages(gomock.Any(), gomock.Any(), defaultCleanupBatchSize, false).
+			DoAndReturn(func(_ context.Context, processedBefore time.Time, _ int, _ bool) (int64, error) {
+				age := time.Since(processedBefore)
+				if age < time.Hour || age > time.Hour+time.Minute {
+					t.Errorf("processed before %s ago, want 1h", age)
+				}
+				return 0, nil
+			})
+		repo.EXPECT().GetOldestProcessedTime(gomock.Any()).Return(time.Time{}, nil)
+
+		cleaner := NewOutboxCleaner(repo, OutboxCleanerWithRetention(time.Hour))
+		cleaner.run(context.Background())
+	})
+}
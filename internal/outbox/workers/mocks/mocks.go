@@ -12,7 +12,9 @@ package mocks
 import (
 	context "context"
 	reflect "reflect"
+	time "time"
 
+	uuid "github.com/google/uuid"
 	models "github.com/hasansino/go42/internal/outbox/models"
 	gomock "go.uber.org/mock/gomock"
 )
@@ -41,19 +43,79 @@ func (m *Mockrepository) EXPECT() *MockrepositoryMockRecorder {
 	return m.recorder
 }
 
+// GetBlockedMessageIDs mocks base method.
+func (m *Mockrepository) GetBlockedMessageIDs(ctx context.Context, ids []uuid.UUID) ([]uuid.UUID, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetBlockedMessageIDs", ctx, ids)
+	ret0, _ := ret[0].([]uuid.UUID)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetBlockedMessageIDs indicates an expected call of GetBlockedMessageIDs.
+func (mr *MockrepositoryMockRecorder) GetBlockedMessageIDs(ctx, ids any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBlockedMessageIDs", reflect.TypeOf((*Mockrepository)(nil).GetBlockedMessageIDs), ctx, ids)
+}
+
+// GetOldestProcessedTime mocks base method.
+func (m *Mockrepository) GetOldestProcessedTime(ctx context.Context) (time.Time, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetOldestProcessedTime", ctx)
+	ret0, _ := ret[0].(time.Time)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetOldestProcessedTime indicates an expected call of GetOldestProcessedTime.
+func (mr *MockrepositoryMockRecorder) GetOldestProcessedTime(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetOldestProcessedTime", reflect.TypeOf((*Mockrepository)(nil).GetOldestProcessedTime), ctx)
+}
+
 // GetUnprocessedMessages mocks base method.
-func (m *Mockrepository) GetUnprocessedMessages(ctx context.Context, limit int) ([]models.Message, error) {
+func (m *Mockrepository) GetUnprocessedMessages(ctx context.Context, limit int, createdBefore time.Time) ([]models.Message, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetUnprocessedMessages", ctx, limit)
+	ret := m.ctrl.Call(m, "GetUnprocessedMessages", ctx, limit, createdBefore)
 	ret0, _ := ret[0].([]models.Message)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // GetUnprocessedMessages indicates an expected call of GetUnprocessedMessages.
-func (mr *MockrepositoryMockRecorder) GetUnprocessedMessages(ctx, limit any) *gomock.Call {
+func (mr *MockrepositoryMockRecorder) GetUnprocessedMessages(ctx, limit, createdBefore any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUnprocessedMessages", reflect.TypeOf((*Mockrepository)(nil).GetUnprocessedMessages), ctx, limit, createdBefore)
+}
+
+// LockPendingMessages mocks base method.
+func (m *Mockrepository) LockPendingMessages(ctx context.Context, ids []uuid.UUID) ([]models.Message, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "LockPendingMessages", ctx, ids)
+	ret0, _ := ret[0].([]models.Message)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// LockPendingMessages indicates an expected call of LockPendingMessages.
+func (mr *MockrepositoryMockRecorder) LockPendingMessages(ctx, ids any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LockPendingMessages", reflect.TypeOf((*Mockrepository)(nil).LockPendingMessages), ctx, ids)
+}
+
+// PurgeProcessedMessages mocks base method.
+func (m *Mockrepository) PurgeProcessedMessages(ctx context.Context, processedBefore time.Time, limit int, archive bool) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PurgeProcessedMessages", ctx, processedBefore, limit, archive)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// PurgeProcessedMessages indicates an expected call of PurgeProcessedMessages.
+func (mr *MockrepositoryMockRecorder) PurgeProcessedMessages(ctx, processedBefore, limit, archive any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUnprocessedMessages", reflect.TypeOf((*Mockrepository)(nil).GetUnprocessedMessages), ctx, limit)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PurgeProcessedMessages", reflect.TypeOf((*Mockrepository)(nil).PurgeProcessedMessages), ctx, processedBefore, limit, archive)
 }
 
 // SaveFailedMessages mocks base method.
@@ -122,16 +184,16 @@ func (m *Mockpublisher) EXPECT() *MockpublisherMockRecorder {
 	return m.recorder
 }
 
-// Publish mocks base method.
-func (m *Mockpublisher) Publish(topic string, event []byte) error {
+// PublishWithKey mocks base method.
+func (m *Mockpublisher) PublishWithKey(topic, key string, event []byte) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "Publish", topic, event)
+	ret := m.ctrl.Call(m, "PublishWithKey", topic, key, event)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
-// Publish indicates an expected call of Publish.
-func (mr *MockpublisherMockRecorder) Publish(topic, event any) *gomock.Call {
+// PublishWithKey indicates an expected call of PublishWithKey.
+func (mr *MockpublisherMockRecorder) PublishWithKey(topic, key, event any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Publish", reflect.TypeOf((*Mockpublisher)(nil).Publish), topic, event)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PublishWithKey", reflect.TypeOf((*Mockpublisher)(nil).PublishWithKey), topic, key, event)
 }
@@ -2,11 +2,15 @@ package workers
 
 import (
 	"context"
+	"database/sql"
 	"encoding/json"
 	"fmt"
 	"log/slog"
+	"math/rand/v2"
 	"time"
 
+	"github.com/google/uuid"
+
 	"github.com/hasansino/go42/internal/metrics"
 	"github.com/hasansino/go42/internal/outbox/domain"
 	"github.com/hasansino/go42/internal/outbox/models"
@@ -16,19 +20,32 @@ import (
 
 type repository interface {
 	WithTransaction(ctx context.Context, fn func(txCtx context.Context) error) error
-	GetUnprocessedMessages(ctx context.Context, limit int) ([]models.Message, error)
+	GetUnprocessedMessages(ctx context.Context, limit int, createdBefore time.Time) ([]models.Message, error)
+	GetBlockedMessageIDs(ctx context.Context, ids []uuid.UUID) ([]uuid.UUID, error)
+	LockPendingMessages(ctx context.Context, ids []uuid.UUID) ([]models.Message, error)
 	SaveProcessedMessages(ctx context.Context, messages []models.Message) error
 	SaveFailedMessages(ctx context.Context, messages []models.Message) error
+	PurgeProcessedMessages(ctx context.Context, processedBefore time.Time, limit int, archive bool) (int64, error)
+	GetOldestProcessedTime(ctx context.Context) (time.Time, error)
 }
 
 type publisher interface {
-	Publish(topic string, event []byte) error
+	PublishWithKey(topic string, key string, event []byte) error
 }
 
+const (
+	defaultRetryBaseDelay = time.Second
+	defaultRetryMaxDelay  = 10 * time.Minute
+)
+
 type OutboxMessagePublisher struct {
-	logger     *slog.Logger
-	repository repository
-	publisher  publisher
+	logger         *slog.Logger
+	repository     repository
+	publisher      publisher
+	retryBaseDelay time.Duration
+	retryMaxDelay  time.Duration
+	minAge         time.Duration
+	wakeup         chan struct{}
 }
 
 func NewOutboxMessagePublisher(
@@ -37,8 +54,11 @@ func NewOutboxMessagePublisher(
 	opts ...OutboxMessagePublisherOption,
 ) *OutboxMessagePublisher {
 	pub := &OutboxMessagePublisher{
-		repository: repository,
-		publisher:  publisher,
+		repository:     repository,
+		publisher:      publisher,
+		retryBaseDelay: defaultRetryBaseDelay,
+		retryMaxDelay:  defaultRetryMaxDelay,
+		wakeup:         make(chan struct{}, 1),
 	}
 	for _, opt := range opts {
 		opt(pub)
@@ -49,6 +69,8 @@ func NewOutboxMessagePublisher(
 	return pub
 }
 
+// Run publishes messages on every tick and on every wakeup,
+// interval is a fallback for wakeups which were missed.
 func (p *OutboxMessagePublisher) Run(
 	ctx context.Context, interval time.Duration, batchSize int,
 ) {
@@ -59,16 +81,39 @@ func (p *OutboxMessagePublisher) Run(
 		case <-ctx.Done():
 			return
 		case <-ticker.C:
-			p.run(ctx, batchSize)
+		case <-p.wakeup:
 		}
+		// batch contains single message of every aggregate,
+		// messages which were waiting for published ones are published right away
+		for ctx.Err() == nil {
+			if p.run(ctx, batchSize) == 0 {
+				break
+			}
+		}
+	}
+}
+
+// Wakeup makes publisher run without waiting for next tick, it never blocks.
+// Wakeups received during the run are merged into single next run.
+func (p *OutboxMessagePublisher) Wakeup() {
+	select {
+	case p.wakeup <- struct{}{}:
+	default:
 	}
 }
 
-func (p *OutboxMessagePublisher) run(ctx context.Context, batchSize int) {
+// run returns number of published messages.
+func (p *OutboxMessagePublisher) run(ctx context.Context, batchSize int) int {
+	var published int
 	err := p.repository.WithTransaction(ctx, func(txCtx context.Context) error {
 		p.logger.Debug("running outbox publisher job")
 
-		messages, err := p.repository.GetUnprocessedMessages(txCtx, batchSize)
+		var createdBefore time.Time
+		if p.minAge > 0 {
+			createdBefore = time.Now().Add(-p.minAge)
+		}
+
+		messages, err := p.repository.GetUnprocessedMessages(txCtx, batchSize, createdBefore)
 		if err != nil {
 			return fmt.Errorf("failed to get unprocessed messages: %w", err)
 		}
@@ -91,13 +136,9 @@ func (p *OutboxMessagePublisher) run(ctx context.Context, batchSize int) {
 			if err != nil {
 				return fmt.Errorf("failed to marshal event: %w", err)
 			}
-			err = p.publisher.Publish(message.Topic, jsonBytes)
+			err = p.publisher.PublishWithKey(message.Topic, message.OrderingKey(), jsonBytes)
 			if err != nil {
-				message.RetryCount++
-				message.LastError = err.Error()
-				if message.RetryCount == message.MaxRetries {
-					message.Status = models.MessageStatusFailed
-				}
+				retryLater(&message, err, p.retryBaseDelay, p.retryMaxDelay)
 				failed = append(failed, message)
 				p.logger.Error("failed to publish message", slog.Any("error", err))
 				metrics.Counter("application_errors", map[string]interface{}{
@@ -125,6 +166,8 @@ func (p *OutboxMessagePublisher) run(ctx context.Context, batchSize int) {
 			}
 		}
 
+		published = len(processed)
+
 		return nil
 	})
 	if err != nil {
@@ -132,9 +175,45 @@ func (p *OutboxMessagePublisher) run(ctx context.Context, batchSize int) {
 		metrics.Counter("application_errors", map[string]interface{}{
 			"type": "outbox_publisher_error",
 		}).Inc()
+		return 0
+	}
+	return published
+}
+
+// retryLater counts failed attempt to publish the message.
+// Message without retries left is failed, otherwise it is published again after delay.
+func retryLater(message *models.Message, err error, baseDelay, maxDelay time.Duration) {
+	message.RetryCount++
+	message.LastError = err.Error()
+	if message.RetryCount >= message.MaxRetries {
+		message.Status = models.MessageStatusFailed
+		message.NextAttemptAt = sql.NullTime{}
+		metrics.Counter("application_outbox_worker_dead_lettered", map[string]interface{}{
+			"topic": message.Topic,
+		}).Inc()
+		return
+	}
+	message.NextAttemptAt = sql.NullTime{
+		Time:  time.Now().Add(retryDelay(baseDelay, maxDelay, message.RetryCount)),
+		Valid: true,
 	}
 }
 
+// retryDelay doubles base delay with every attempt up to max delay.
+// Half of the delay is random, so messages failed together are not retried together.
+func retryDelay(base, maxDelay time.Duration, attempt int) time.Duration {
+	delay := base
+	for i := 1; i < attempt && delay < maxDelay; i++ {
+		delay *= 2
+	}
+	delay = min(delay, maxDelay)
+	if delay <= 0 {
+		return 0
+	}
+	half := delay / 2
+	return half + rand.N(delay-half+1)
+}
+
 type OutboxMessagePublisherOption func(*OutboxMessagePublisher)
 
 func OutboxMessagePublisherWithLogger(logger *slog.Logger) OutboxMessagePublisherOption {
@@ -142,3 +221,19 @@ func OutboxMessagePublisherWithLogger(logger *slog.Logger) OutboxMessagePublishe
 		o.logger = logger
 	}
 }
+
+// OutboxMessagePublisherWithRetryDelay sets delay before first retry and limit of the delay.
+func OutboxMessagePublisherWithRetryDelay(base, maxDelay time.Duration) OutboxMessagePublisherOption {
+	return func(o *OutboxMessagePublisher) {
+		o.retryBaseDelay = base
+		o.retryMaxDelay = maxDelay
+	}
+}
+
+// OutboxMessagePublisherWithMinAge makes publisher skip messages younger than given age,
+// it is used as fallback of wal relay, which publishes new messages itself.
+func OutboxMessagePublisherWithMinAge(age time.Duration) OutboxMessagePublisherOption {
+	return func(o *OutboxMessagePublisher) {
+		o.minAge = age
+	}
+}
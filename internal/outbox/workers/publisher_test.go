@@ -0,0 +1,132 @@
+package workers
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"go.uber.org/mock/gomock"
+
+	"github.com/hasansino/go42/internal/outbox/models"
+	"github.com/hasansino/go42/internal/outbox/workers/mocks"
+)
+
+func TestRetryDelay(t *testing.T) {
+	tests := []struct {
+		attempt int
+		want    time.Duration
+	}{
+		{1, time.Second},
+		{2, 2 * time.Second},
+		{5, 16 * time.Second},
+		{10, time.Minute},
+		{1000, time.Minute},
+	}
+	for _, tt := range tests {
+		for range 100 {
+			got := retryDelay(time.Second, time.Minute, tt.attempt)
+			if got < tt.want/2 || got > tt.want {
+				t.Fatalf("retryDelay(%d) = %s, want between %s and %s", tt.attempt, got, tt.want/2, tt.want)
+			}
+		}
+	}
+}
+
+func TestOutboxMessagePublisher_run(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	repo := mocks.NewMockrepository(ctrl)
+	pub := mocks.NewMockpublisher(ctrl)
+
+	messages := []models.Message{
+		{ID: uuid.New(), AggregateID: 1, AggregateType: "user", Topic: "user.update", MaxRetries: 3},
+		{ID: uuid.New(), AggregateID: 2, AggregateType: "user", Topic: "user.update", MaxRetries: 3},
+	}
+
+	repo.EXPECT().WithTransaction(gomock.Any(), gomock.Any()).DoAndReturn(
+		func(ctx context.Context, fn func(txCtx context.Context) error) error {
+			return fn(ctx)
+		},
+	)
+	repo.EXPECT().GetUnprocessedMessages(gomock.Any(), 10, time.Time{}).Return(messages, nil)
+	pub.EXPECT().PublishWithKey("user.update", "user:1", gomock.Any()).Return(nil)
+	pub.EXPECT().PublishWithKey("user.update", "user:2", gomock.Any()).Return(errors.New("broker is gone"))
+	repo.EXPECT().SaveProcessedMessages(gomock.Any(), gomock.Len(1)).Return(nil)
+	repo.EXPECT().SaveFailedMessages(gomock.Any(), gomock.Any()).DoAndReturn(
+		func(_ context.Context, failed []models.Message) error {
+			if len(failed) != 1 || failed[0].AggregateID != 2 {
+				t.Fatalf("unexpected failed messages: %+v", failed)
+			}
+			if failed[0].Status == models.MessageStatusFailed || !failed[0].NextAttemptAt.Valid {
+				t.Fatalf("message should be retried later: %+v", failed[0])
+			}
+			return nil
+		},
+	)
+
+	publisher := NewOutboxMessagePublisher(repo, pub)
+	if published := publisher.run(context.Background(), 10); published != 1 {
+		t.Fatalf("run() = %d, want 1", published)
+	}
+}
+
+func TestOutboxMessagePublisher_Wakeup(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	repo := mocks.NewMockrepository(ctrl)
+	pub := mocks.NewMockpublisher(ctrl)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	ran := make(chan struct{})
+	repo.EXPECT().WithTransaction(gomock.Any(), gomock.Any()).DoAndReturn(
+		func(ctx context.Context, fn func(txCtx context.Context) error) error {
+			return fn(ctx)
+		},
+	)
+	repo.EXPECT().GetUnprocessedMessages(gomock.Any(), 10, time.Time{}).DoAndReturn(
+		func(_ context.Context, _ int, _ time.Time) ([]models.Message, error) {
+			close(ran)
+			return nil, nil
+		},
+	)
+
+	publisher := NewOutboxMessagePublisher(repo, pub)
+	// wakeups are merged while publisher is not running
+	publisher.Wakeup()
+	publisher.Wakeup()
+	go publisher.Run(ctx, time.Hour, 10)
+
+	select {
+	case <-ran:
+	case <-time.After(time.Second):
+		t.Fatal("publisher did not run after wakeup")
+	}
+}
+
+func TestOutboxMessagePublisher_MinAge(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	repo := mocks.NewMockrepository(ctrl)
+
+	repo.EXPECT().WithTransaction(gomock.Any(), gomock.Any()).DoAndReturn(
+		func(ctx context.Context, fn func(txCtx context.Context) error) error {
+			return fn(ctx)
+		},
+	)
+	repo.EXPECT().GetUnprocessedMessages(gomock.Any(), 10, gomock.Any()).DoAndReturn(
+		func(_ context.Context, _ int, createdBefore time.Time) ([]models.Message, error) {
+			// messages younger than min age are left to wal relay
+			if age := time.Since(createdBefore); age < time.Minute || age > 2*time.Minute {
+				t.Errorf("created before is %s ago, want a minute ago", age)
+			}
+			return nil, nil
+		},
+	)
+
+	publisher := NewOutboxMessagePublisher(repo, mocks.NewMockpublisher(ctrl),
+		OutboxMessagePublisherWithMinAge(time.Minute))
+	if published := publisher.run(context.Background(), 10); published != 0 {
+		t.Fatalf("run() = %d, want 0", published)
+	}
+}
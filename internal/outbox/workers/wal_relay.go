@@ -0,0 +1,230 @@
+package workers
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"slices"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/hasansino/go42/internal/database/pgsql/replication"
+	"github.com/hasansino/go42/internal/metrics"
+	"github.com/hasansino/go42/internal/outbox/domain"
+	"github.com/hasansino/go42/internal/outbox/models"
+)
+
+type walStream interface {
+	Run(ctx context.Context, handler func(ctx context.Context, tx *replication.Transaction) error) error
+}
+
+const defaultRelayReconnectDelay = 5 * time.Second
+
+// OutboxWALRelay publishes messages inserted to outbox as they are read from
+// pgsql logical replication slot, instead of polling the table.
+// Transaction is confirmed to the slot only after all of its pending messages are published or
+// handed over to poll publisher, on error stream is restarted and messages are published again (at-least-once).
+// Messages are published in commit order, so order of aggregate is kept.
+// Message which failed to publish is retried by poll publisher with the same rules as polled messages,
+// messages of its aggregate wait until it is published, so they are left to poll publisher as well.
+type OutboxWALRelay struct {
+	logger         *slog.Logger
+	repository     repository
+	publisher      publisher
+	stream         walStream
+	reconnectDelay time.Duration
+	retryBaseDelay time.Duration
+	retryMaxDelay  time.Duration
+}
+
+func NewOutboxWALRelay(
+	repository repository,
+	publisher publisher,
+	stream walStream,
+	opts ...OutboxWALRelayOption,
+) *OutboxWALRelay {
+	r := &OutboxWALRelay{
+		repository:     repository,
+		publisher:      publisher,
+		stream:         stream,
+		reconnectDelay: defaultRelayReconnectDelay,
+		retryBaseDelay: defaultRetryBaseDelay,
+		retryMaxDelay:  defaultRetryMaxDelay,
+	}
+	for _, opt := range opts {
+		opt(r)
+	}
+	if r.logger == nil {
+		r.logger = slog.New(slog.DiscardHandler)
+	}
+	return r
+}
+
+// Run reads replication stream until context is cancelled, stream is restarted on error.
+// Only one relay reads the slot at a time, others retry until slot is released.
+func (r *OutboxWALRelay) Run(ctx context.Context) {
+	r.logger.InfoContext(ctx, "starting outbox wal relay")
+	for {
+		err := r.stream.Run(ctx, r.handle)
+		if ctx.Err() != nil {
+			return
+		}
+		if err != nil {
+			r.logger.ErrorContext(ctx, "outbox wal relay stopped", slog.Any("error", err))
+			metrics.Counter("application_errors", map[string]interface{}{
+				"type": "outbox_relay_error",
+			}).Inc()
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(r.reconnectDelay):
+		}
+	}
+}
+
+// handle publishes messages of the transaction which are still pending.
+// Messages are locked and read again, because poll publisher could publish or fail them
+// while relay was lagging, and cleanup worker could purge them.
+func (r *OutboxWALRelay) handle(ctx context.Context, tx *replication.Transaction) error {
+	var ids []uuid.UUID
+	for _, insert := range tx.Inserts {
+		if insert.Table != (&models.Message{}).TableName() {
+			continue
+		}
+		id, err := messageIDFromInsert(insert)
+		if err != nil {
+			return err
+		}
+		ids = append(ids, id)
+	}
+	if len(ids) == 0 {
+		return nil
+	}
+
+	err := r.repository.WithTransaction(ctx, func(txCtx context.Context) error {
+		messages, err := r.repository.LockPendingMessages(txCtx, ids)
+		if err != nil {
+			return fmt.Errorf("failed to lock messages: %w", err)
+		}
+		if len(messages) == 0 {
+			return nil
+		}
+
+		lockedIDs := make([]uuid.UUID, len(messages))
+		for i, message := range messages {
+			lockedIDs[i] = message.ID
+		}
+		blockedIDs, err := r.repository.GetBlockedMessageIDs(txCtx, lockedIDs)
+		if err != nil {
+			return fmt.Errorf("failed to get blocked messages: %w", err)
+		}
+
+		// aggregates which have unpublished message, their messages are left to poll publisher
+		blocked := make(map[string]struct{})
+		for _, message := range messages {
+			if slices.Contains(blockedIDs, message.ID) {
+				blocked[message.Topic+" "+message.OrderingKey()] = struct{}{}
+			}
+		}
+
+		var (
+			processed []models.Message
+			failed    []models.Message
+		)
+
+		for _, message := range messages {
+			aggregate := message.Topic + " " + message.OrderingKey()
+			if _, ok := blocked[aggregate]; ok {
+				metrics.Counter("application_outbox_relay_deferred", nil).Inc()
+				continue
+			}
+			event := domain.Event{
+				ID:            message.ID,
+				CreatedAt:     message.CreatedAt,
+				AggregateID:   message.AggregateID,
+				AggregateType: message.AggregateType,
+				Payload:       message.Payload,
+				Metadata:      message.Metadata,
+			}
+			jsonBytes, err := json.Marshal(event)
+			if err != nil {
+				return fmt.Errorf("failed to marshal event: %w", err)
+			}
+			err = r.publisher.PublishWithKey(message.Topic, message.OrderingKey(), jsonBytes)
+			if err != nil {
+				retryLater(&message, err, r.retryBaseDelay, r.retryMaxDelay)
+				failed = append(failed, message)
+				blocked[aggregate] = struct{}{}
+				r.logger.Error("failed to publish message", slog.Any("error", err))
+				metrics.Counter("application_errors", map[string]interface{}{
+					"type": "outbox_relay_error",
+				}).Inc()
+				metrics.Counter("application_outbox_relay_failed", nil).Inc()
+				continue
+			}
+			processed = append(processed, message)
+			r.logger.Debug("published message", slog.Any("message", message))
+			metrics.Counter("application_outbox_relay_processed", nil).Inc()
+		}
+
+		// status is kept for cleanup worker and administration
+		if len(processed) > 0 {
+			if err := r.repository.SaveProcessedMessages(txCtx, processed); err != nil {
+				return fmt.Errorf("failed to save processed messages: %w", err)
+			}
+		}
+
+		if len(failed) > 0 {
+			if err := r.repository.SaveFailedMessages(txCtx, failed); err != nil {
+				return fmt.Errorf("failed to save failed messages: %w", err)
+			}
+		}
+
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	metrics.Gauge("application_outbox_relay_lag_seconds", nil).Set(time.Since(tx.CommitTime).Seconds())
+
+	return nil
+}
+
+func messageIDFromInsert(insert replication.Insert) (uuid.UUID, error) {
+	var value string
+	if v := insert.Values["id"]; v != nil {
+		value = *v
+	}
+	id, err := uuid.Parse(value)
+	if err != nil {
+		return uuid.Nil, fmt.Errorf("failed to parse message id: %w", err)
+	}
+	return id, nil
+}
+
+type OutboxWALRelayOption func(*OutboxWALRelay)
+
+func OutboxWALRelayWithLogger(logger *slog.Logger) OutboxWALRelayOption {
+	return func(r *OutboxWALRelay) {
+		r.logger = logger
+	}
+}
+
+// OutboxWALRelayWithReconnectDelay sets delay before stream is restarted after error.
+func OutboxWALRelayWithReconnectDelay(d time.Duration) OutboxWALRelayOption {
+	return func(r *OutboxWALRelay) {
+		r.reconnectDelay = d
+	}
+}
+
+// OutboxWALRelayWithRetryDelay sets delay before first retry and limit of the delay.
+func OutboxWALRelayWithRetryDelay(base, maxDelay time.Duration) OutboxWALRelayOption {
+	return func(r *OutboxWALRelay) {
+		r.retryBaseDelay = base
+		r.retryMaxDelay = maxDelay
+	}
+}
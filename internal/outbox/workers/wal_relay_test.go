@@ -0,0 +1,253 @@
+package workers
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/google/uuid"
+	"go.uber.org/mock/gomock"
+
+	"github.com/hasansino/go42/internal/database/pgsql/replication"
+	"github.com/hasansino/go42/internal/outbox/domain"
+	"github.com/hasansino/go42/internal/outbox/models"
+	"github.com/hasansino/go42/internal/outbox/workers/mocks"
+)
+
+func outboxInsert(id string) replication.Insert {
+	return replication.Insert{
+		Namespace: "public",
+		Table:     "transactional_outbox",
+		Values:    map[string]*string{"id": &id, "metadata": nil},
+	}
+}
+
+func outboxMessage(id uuid.UUID, aggregateID int) models.Message {
+	return models.Message{
+		ID:            id,
+		AggregateID:   aggregateID,
+		AggregateType: "user",
+		Topic:         "users",
+		Payload:       []byte(`{"name":"john"}`),
+		Status:        models.MessageStatusPending,
+		MaxRetries:    3,
+	}
+}
+
+func newRelayRepository(ctrl *gomock.Controller) *mocks.Mockrepository {
+	repo := mocks.NewMockrepository(ctrl)
+	repo.EXPECT().WithTransaction(gomock.Any(), gomock.Any()).DoAndReturn(
+		func(ctx context.Context, fn func(txCtx context.Context) error) error {
+			return fn(ctx)
+		},
+	).AnyTimes()
+	return repo
+}
+
+func TestOutboxWALRelay_handle(t *testing.T) {
+	first, second := uuid.New(), uuid.New()
+
+	t.Run("publishes pending outbox messages in order", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		repo := newRelayRepository(ctrl)
+		pub := mocks.NewMockpublisher(ctrl)
+
+		var published []uuid.UUID
+		pub.EXPECT().PublishWithKey("users", "user:1", gomock.Any()).
+			DoAndReturn(func(_ string, _ string, data []byte) error {
+				var event domain.Event
+				if err := json.Unmarshal(data, &event); err != nil {
+					t.Fatalf("failed to unmarshal event: %v", err)
+				}
+				if string(event.Payload) != `{"name":"john"}` {
+					t.Errorf("payload = %s", event.Payload)
+				}
+				published = append(published, event.ID)
+				return nil
+			}).Times(2)
+		repo.EXPECT().LockPendingMessages(gomock.Any(), []uuid.UUID{first, second}).
+			Return([]models.Message{outboxMessage(first, 1), outboxMessage(second, 1)}, nil)
+		repo.EXPECT().GetBlockedMessageIDs(gomock.Any(), []uuid.UUID{first, second}).Return(nil, nil)
+		repo.EXPECT().SaveProcessedMessages(gomock.Any(), gomock.Len(2)).Return(nil)
+
+		relay := NewOutboxWALRelay(repo, pub, nil)
+		err := relay.handle(context.Background(), &replication.Transaction{
+			Inserts: []replication.Insert{
+				outboxInsert(first.String()),
+				{Table: "users"},
+				outboxInsert(second.String()),
+			},
+		})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if len(published) != 2 || published[0] != first || published[1] != second {
+			t.Errorf("published = %v, want [%s %s]", published, first, second)
+		}
+	})
+
+	t.Run("partitions different types of the same aggregate by aggregate key", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		repo := newRelayRepository(ctrl)
+		pub := mocks.NewMockpublisher(ctrl)
+
+		create, update := outboxMessage(first, 1), outboxMessage(second, 1)
+		create.AggregateType, update.AggregateType = "user.create", "user.update"
+		create.AggregateKey, update.AggregateKey = "user:1", "user:1"
+
+		pub.EXPECT().PublishWithKey("users", "user:1", gomock.Any()).Return(nil).Times(2)
+		repo.EXPECT().LockPendingMessages(gomock.Any(), gomock.Any()).Return([]models.Message{create, update}, nil)
+		repo.EXPECT().GetBlockedMessageIDs(gomock.Any(), gomock.Any()).Return(nil, nil)
+		repo.EXPECT().SaveProcessedMessages(gomock.Any(), gomock.Len(2)).Return(nil)
+
+		relay := NewOutboxWALRelay(repo, pub, nil)
+		err := relay.handle(context.Background(), &replication.Transaction{
+			Inserts: []replication.Insert{outboxInsert(first.String()), outboxInsert(second.String())},
+		})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	})
+
+	t.Run("skips transactions without outbox inserts", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		relay := NewOutboxWALRelay(mocks.NewMockrepository(ctrl), mocks.NewMockpublisher(ctrl), nil)
+		err := relay.handle(context.Background(), &replication.Transaction{
+			Inserts: []replication.Insert{{Table: "users"}},
+		})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	})
+
+	t.Run("skips message already processed by poll publisher", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		repo := newRelayRepository(ctrl)
+		// processed message is not pending anymore, so it is not locked
+		repo.EXPECT().LockPendingMessages(gomock.Any(), []uuid.UUID{first}).Return(nil, nil)
+
+		relay := NewOutboxWALRelay(repo, mocks.NewMockpublisher(ctrl), nil)
+		err := relay.handle(context.Background(), &replication.Transaction{
+			Inserts: []replication.Insert{outboxInsert(first.String())},
+		})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	})
+
+	t.Run("retries failed message later and defers its aggregate", func(t *testing.T) {
+		third := uuid.New()
+		ctrl := gomock.NewController(t)
+		repo := newRelayRepository(ctrl)
+		pub := mocks.NewMockpublisher(ctrl)
+		repo.EXPECT().LockPendingMessages(gomock.Any(), gomock.Len(3)).Return([]models.Message{
+			outboxMessage(first, 1), outboxMessage(second, 1), outboxMessage(third, 2),
+		}, nil)
+		repo.EXPECT().GetBlockedMessageIDs(gomock.Any(), gomock.Len(3)).Return(nil, nil)
+		pub.EXPECT().PublishWithKey("users", "user:1", gomock.Any()).Return(errors.New("broker is gone"))
+		pub.EXPECT().PublishWithKey("users", "user:2", gomock.Any()).Return(nil)
+		repo.EXPECT().SaveProcessedMessages(gomock.Any(), gomock.Any()).DoAndReturn(
+			func(_ context.Context, processed []models.Message) error {
+				if len(processed) != 1 || processed[0].ID != third {
+					t.Errorf("processed = %+v, want only %s", processed, third)
+				}
+				return nil
+			},
+		)
+		repo.EXPECT().SaveFailedMessages(gomock.Any(), gomock.Any()).DoAndReturn(
+			func(_ context.Context, failed []models.Message) error {
+				if len(failed) != 1 || failed[0].ID != first {
+					t.Fatalf("failed = %+v, want only %s", failed, first)
+				}
+				if failed[0].Status != models.MessageStatusPending || !failed[0].NextAttemptAt.Valid {
+					t.Errorf("message should be retried later: %+v", failed[0])
+				}
+				if failed[0].RetryCount != 1 || failed[0].LastError != "broker is gone" {
+					t.Errorf("retry count = %d, last error = %q", failed[0].RetryCount, failed[0].LastError)
+				}
+				return nil
+			},
+		)
+
+		relay := NewOutboxWALRelay(repo, pub, nil)
+		err := relay.handle(context.Background(), &replication.Transaction{
+			Inserts: []replication.Insert{
+				outboxInsert(first.String()),
+				outboxInsert(second.String()),
+				outboxInsert(third.String()),
+			},
+		})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	})
+
+	t.Run("fails message without retries left", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		repo := newRelayRepository(ctrl)
+		pub := mocks.NewMockpublisher(ctrl)
+		message := outboxMessage(first, 1)
+		message.MaxRetries = 1
+		repo.EXPECT().LockPendingMessages(gomock.Any(), gomock.Any()).Return([]models.Message{message}, nil)
+		repo.EXPECT().GetBlockedMessageIDs(gomock.Any(), gomock.Any()).Return(nil, nil)
+		pub.EXPECT().PublishWithKey(gomock.Any(), gomock.Any(), gomock.Any()).Return(errors.New("broker is gone"))
+		repo.EXPECT().SaveFailedMessages(gomock.Any(), gomock.Any()).DoAndReturn(
+			func(_ context.Context, failed []models.Message) error {
+				if len(failed) != 1 || failed[0].Status != models.MessageStatusFailed || failed[0].NextAttemptAt.Valid {
+					t.Errorf("message should be failed: %+v", failed)
+				}
+				return nil
+			},
+		)
+
+		relay := NewOutboxWALRelay(repo, pub, nil)
+		err := relay.handle(context.Background(), &replication.Transaction{
+			Inserts: []replication.Insert{outboxInsert(first.String())},
+		})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	})
+
+	t.Run("leaves messages waiting for older message to poll publisher", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		repo := newRelayRepository(ctrl)
+		repo.EXPECT().LockPendingMessages(gomock.Any(), gomock.Any()).
+			Return([]models.Message{outboxMessage(first, 1), outboxMessage(second, 1)}, nil)
+		repo.EXPECT().GetBlockedMessageIDs(gomock.Any(), gomock.Any()).Return([]uuid.UUID{first, second}, nil)
+
+		relay := NewOutboxWALRelay(repo, mocks.NewMockpublisher(ctrl), nil)
+		err := relay.handle(context.Background(), &replication.Transaction{
+			Inserts: []replication.Insert{outboxInsert(first.String()), outboxInsert(second.String())},
+		})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	})
+
+	t.Run("does not confirm transaction on repository error", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		repo := newRelayRepository(ctrl)
+		repo.EXPECT().LockPendingMessages(gomock.Any(), gomock.Any()).Return(nil, errors.New("database is gone"))
+
+		relay := NewOutboxWALRelay(repo, mocks.NewMockpublisher(ctrl), nil)
+		err := relay.handle(context.Background(), &replication.Transaction{
+			Inserts: []replication.Insert{outboxInsert(first.String())},
+		})
+		if err == nil {
+			t.Fatalf("expected error")
+		}
+	})
+
+	t.Run("rejects malformed insert", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		relay := NewOutboxWALRelay(mocks.NewMockrepository(ctrl), mocks.NewMockpublisher(ctrl), nil)
+		err := relay.handle(context.Background(), &replication.Transaction{
+			Inserts: []replication.Insert{outboxInsert("not an id")},
+		})
+		if err == nil {
+			t.Fatalf("expected error")
+		}
+	})
+}
@@ -0,0 +1,94 @@
+package password
+
+import (
+	"bufio"
+	"context"
+	"crypto/sha1" //nolint:gosec // breach corpora are indexed by sha1
+	"encoding/hex"
+	"fmt"
+	"io"
+	"os"
+	"slices"
+	"strings"
+)
+
+const (
+	// length of hash prefix disclosed to breach source, same as in pwnedpasswords range api
+	breachPrefixLength = 5
+	sha1HexLength      = 40
+)
+
+// BreachSource returns hash suffixes of breached passwords sharing given prefix of sha1 hash.
+// Only prefix leaves the caller (k-anonymity), so source may as well be remote service.
+type BreachSource interface {
+	Range(ctx context.Context, prefix string) ([]string, error)
+}
+
+// IsBreached reports whether password is known to source.
+func IsBreached(ctx context.Context, source BreachSource, password string) (bool, error) {
+	sum := sha1.Sum([]byte(password)) //nolint:gosec // breach corpora are indexed by sha1
+	hash := strings.ToUpper(hex.EncodeToString(sum[:]))
+	suffixes, err := source.Range(ctx, hash[:breachPrefixLength])
+	if err != nil {
+		return false, err
+	}
+	return slices.Contains(suffixes, hash[breachPrefixLength:]), nil
+}
+
+// BreachList is breach corpus kept in memory, indexed by hash prefix.
+type BreachList struct {
+	ranges map[string][]string
+}
+
+// LoadBreachList reads file with one uppercase or lowercase sha1 hash per line,
+// optionally followed by occurrence count, e.g. pwnedpasswords ordered-by-hash dump.
+func LoadBreachList(path string) (*BreachList, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	return ReadBreachList(f)
+}
+
+func ReadBreachList(r io.Reader) (*BreachList, error) {
+	list := &BreachList{ranges: make(map[string][]string)}
+
+	scanner := bufio.NewScanner(r)
+	line := 0
+	for scanner.Scan() {
+		line++
+		text := strings.TrimSpace(scanner.Text())
+		if text == "" || strings.HasPrefix(text, "#") {
+			continue
+		}
+		hash, _, _ := strings.Cut(text, ":")
+		hash = strings.ToUpper(hash)
+		if len(hash) != sha1HexLength {
+			return nil, fmt.Errorf("line %d: invalid sha1 hash", line)
+		}
+		if _, err := hex.DecodeString(hash); err != nil {
+			return nil, fmt.Errorf("line %d: invalid sha1 hash: %w", line, err)
+		}
+		prefix := hash[:breachPrefixLength]
+		list.ranges[prefix] = append(list.ranges[prefix], hash[breachPrefixLength:])
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	return list, nil
+}
+
+func (l *BreachList) Range(_ context.Context, prefix string) ([]string, error) {
+	return l.ranges[strings.ToUpper(prefix)], nil
+}
+
+// Len returns number of hashes in the list.
+func (l *BreachList) Len() int {
+	n := 0
+	for _, suffixes := range l.ranges {
+		n += len(suffixes)
+	}
+	return n
+}
@@ -0,0 +1,59 @@
+package password
+
+import (
+	"errors"
+)
+
+var (
+	ErrMismatch         = errors.New("password does not match")
+	ErrUnknownAlgorithm = errors.New("unknown hash algorithm")
+	ErrMalformedHash    = errors.New("malformed hash")
+)
+
+// Algorithm is single password hashing algorithm.
+// Hashes are self-describing, they carry algorithm identifier and parameters.
+type Algorithm interface {
+	// Hash returns encoded hash of the password with random salt.
+	Hash(password string) (string, error)
+	// Verify returns ErrMismatch when password does not match the hash.
+	Verify(hash string, password string) error
+	// Recognizes reports whether hash was produced by this algorithm.
+	Recognizes(hash string) bool
+	// Outdated reports whether hash was produced with different parameters.
+	Outdated(hash string) bool
+}
+
+// Hasher hashes passwords with preferred algorithm and verifies hashes
+// of any known algorithm, so that algorithms can be migrated without password resets.
+type Hasher struct {
+	preferred  Algorithm
+	algorithms []Algorithm
+}
+
+// NewHasher creates hasher which produces hashes with preferred algorithm,
+// legacy algorithms are used only for verification.
+func NewHasher(preferred Algorithm, legacy ...Algorithm) *Hasher {
+	return &Hasher{
+		preferred:  preferred,
+		algorithms: append([]Algorithm{preferred}, legacy...),
+	}
+}
+
+func (h *Hasher) Hash(password string) (string, error) {
+	return h.preferred.Hash(password)
+}
+
+// Verify checks password against the hash. Hash should be replaced with
+// new one when rehash is true, it is produced by legacy algorithm or with outdated parameters.
+func (h *Hasher) Verify(hash string, password string) (rehash bool, err error) {
+	for _, algorithm := range h.algorithms {
+		if !algorithm.Recognizes(hash) {
+			continue
+		}
+		if err := algorithm.Verify(hash, password); err != nil {
+			return false, err
+		}
+		return algorithm != h.preferred || algorithm.Outdated(hash), nil
+	}
+	return false, ErrUnknownAlgorithm
+}
@@ -0,0 +1,117 @@
+package password
+
+import (
+	"errors"
+	"testing"
+
+	"golang.org/x/crypto/bcrypt"
+)
+
+func TestAlgorithms(t *testing.T) {
+	tests := []struct {
+		name      string
+		algorithm Algorithm
+	}{
+		{"Argon2id", NewArgon2id(Argon2Params{Memory: 1024, Iterations: 1})},
+		{"Bcrypt", NewBcrypt(bcrypt.MinCost)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			hash, err := tt.algorithm.Hash("secret")
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !tt.algorithm.Recognizes(hash) {
+				t.Errorf("hash %s is not recognized", hash)
+			}
+			if tt.algorithm.Outdated(hash) {
+				t.Errorf("fresh hash %s is outdated", hash)
+			}
+			if err := tt.algorithm.Verify(hash, "secret"); err != nil {
+				t.Errorf("valid password rejected: %v", err)
+			}
+			if err := tt.algorithm.Verify(hash, "other"); !errors.Is(err, ErrMismatch) {
+				t.Errorf("invalid password: got %v, want %v", err, ErrMismatch)
+			}
+			other, err := tt.algorithm.Hash("secret")
+			if err != nil {
+				t.Fatal(err)
+			}
+			if other == hash {
+				t.Error("hashes of the same password are equal")
+			}
+		})
+	}
+}
+
+// Test vector produced by reference implementation (argon2 CLI).
+func TestArgon2id_Reference(t *testing.T) {
+	hash := "$argon2id$v=19$m=65536,t=2,p=1$c29tZXNhbHQ$CTFhFdXPJO1aFaMaO6Mm5c8y7cJHAph8ArZWb2GRPPc"
+	if err := NewArgon2id(Argon2Params{}).Verify(hash, "password"); err != nil {
+		t.Errorf("reference hash rejected: %v", err)
+	}
+}
+
+func TestArgon2id_Malformed(t *testing.T) {
+	algorithm := NewArgon2id(Argon2Params{})
+	hashes := []string{
+		"$argon2id$",
+		"$argon2id$v=18$m=1024,t=1,p=1$c29tZXNhbHQ$c29tZWtleQ",
+		"$argon2id$v=19$m=1024,t=1$c29tZXNhbHQ$c29tZWtleQ",
+		"$argon2id$v=19$m=1024,t=0,p=1$c29tZXNhbHQ$c29tZWtleQ",
+		"$argon2id$v=19$m=99999999,t=1,p=1$c29tZXNhbHQ$c29tZWtleQ",
+		"$argon2id$v=19$m=1024,t=1,p=1$!!!$c29tZWtleQ",
+	}
+	for _, hash := range hashes {
+		if err := algorithm.Verify(hash, "password"); !errors.Is(err, ErrMalformedHash) {
+			t.Errorf("Verify(%s) = %v, want %v", hash, err, ErrMalformedHash)
+		}
+	}
+}
+
+func TestHasher(t *testing.T) {
+	preferred := NewArgon2id(Argon2Params{Memory: 1024, Iterations: 1})
+	legacy := NewBcrypt(bcrypt.MinCost)
+	hasher := NewHasher(preferred, legacy)
+
+	legacyHash, err := legacy.Hash("secret")
+	if err != nil {
+		t.Fatal(err)
+	}
+	outdatedHash, err := NewArgon2id(Argon2Params{Memory: 2048, Iterations: 1}).Hash("secret")
+	if err != nil {
+		t.Fatal(err)
+	}
+	currentHash, err := hasher.Hash("secret")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name   string
+		hash   string
+		rehash bool
+	}{
+		{"Current", currentHash, false},
+		{"LegacyAlgorithm", legacyHash, true},
+		{"OutdatedParameters", outdatedHash, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rehash, err := hasher.Verify(tt.hash, "secret")
+			if err != nil {
+				t.Fatal(err)
+			}
+			if rehash != tt.rehash {
+				t.Errorf("rehash = %v, want %v", rehash, tt.rehash)
+			}
+			if _, err := hasher.Verify(tt.hash, "other"); !errors.Is(err, ErrMismatch) {
+				t.Errorf("invalid password: got %v, want %v", err, ErrMismatch)
+			}
+		})
+	}
+
+	if _, err := hasher.Verify("$1$md5crypt", "secret"); !errors.Is(err, ErrUnknownAlgorithm) {
+		t.Errorf("unknown algorithm: got %v, want %v", err, ErrUnknownAlgorithm)
+	}
+}
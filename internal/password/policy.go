@@ -0,0 +1,114 @@
+package password
+
+import (
+	"context"
+	"fmt"
+	"unicode/utf8"
+
+	passwordvalidator "github.com/wagslane/go-password-validator"
+)
+
+// Reasons of policy violations.
+const (
+	ReasonTooShort   = "too_short"
+	ReasonTooLong    = "too_long"
+	ReasonLowEntropy = "low_entropy"
+	ReasonBreached   = "breached"
+	ReasonReused     = "reused"
+)
+
+// Violation is returned when password does not satisfy the policy.
+type Violation struct {
+	Reason  string
+	Message string
+}
+
+func (v *Violation) Error() string {
+	return v.Message
+}
+
+// Rule is single password requirement, it returns *Violation when password does not satisfy it.
+type Rule interface {
+	Check(ctx context.Context, password string) error
+}
+
+type RuleFunc func(ctx context.Context, password string) error
+
+func (f RuleFunc) Check(ctx context.Context, password string) error {
+	return f(ctx, password)
+}
+
+// Policy is set of rules password must satisfy, rules are checked in order.
+type Policy struct {
+	rules []Rule
+}
+
+func NewPolicy(rules ...Rule) *Policy {
+	return &Policy{rules: rules}
+}
+
+// Check returns first violated rule.
+func (p *Policy) Check(ctx context.Context, password string) error {
+	for _, rule := range p.rules {
+		if err := rule.Check(ctx, password); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// MinLength requires at least n characters.
+func MinLength(n int) Rule {
+	return RuleFunc(func(_ context.Context, password string) error {
+		if utf8.RuneCountInString(password) < n {
+			return &Violation{
+				Reason:  ReasonTooShort,
+				Message: fmt.Sprintf("password must be at least %d characters long", n),
+			}
+		}
+		return nil
+	})
+}
+
+// MaxLength allows at most n characters.
+func MaxLength(n int) Rule {
+	return RuleFunc(func(_ context.Context, password string) error {
+		if utf8.RuneCountInString(password) > n {
+			return &Violation{
+				Reason:  ReasonTooLong,
+				Message: fmt.Sprintf("password must be at most %d characters long", n),
+			}
+		}
+		return nil
+	})
+}
+
+// MinEntropy requires estimated entropy of at least given number of bits.
+func MinEntropy(bits int) Rule {
+	return RuleFunc(func(_ context.Context, password string) error {
+		if err := passwordvalidator.Validate(password, float64(bits)); err != nil {
+			return &Violation{
+				Reason:  ReasonLowEntropy,
+				Message: err.Error(),
+			}
+		}
+		return nil
+	})
+}
+
+// NotBreached rejects passwords found in breach corpus.
+func NotBreached(source BreachSource) Rule {
+	return RuleFunc(func(ctx context.Context, password string) error {
+		breached, err := IsBreached(ctx, source, password)
+		if err != nil {
+			return fmt.Errorf("failed to check breached passwords: %w", err)
+		}
+		if breached {
+			return &Violation{
+				Reason:  ReasonBreached,
+				Message: "password was found in data breach",
+			}
+		}
+		return nil
+	})
+}
@@ -0,0 +1,73 @@
+package password
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+)
+
+// sha1 of "password" and "123456"
+const testBreachList = `
+# comment
+5BAA61E4C9B93F3F0682250B6CF8331B7EE68FD8:9545824
+7c4a8d09ca3762af61e59520943dc26494f8941b
+`
+
+func TestPolicy(t *testing.T) {
+	breachList, err := ReadBreachList(strings.NewReader(testBreachList))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if breachList.Len() != 2 {
+		t.Fatalf("got %d hashes, want 2", breachList.Len())
+	}
+
+	policy := NewPolicy(
+		MinLength(8),
+		MaxLength(24),
+		NotBreached(breachList),
+		MinEntropy(50),
+	)
+
+	tests := []struct {
+		password string
+		reason   string
+	}{
+		{"Xy7!kqLm3#pRt9zw", ""},
+		{"short", ReasonTooShort},
+		{"Xy7!kqLm3#pRt9zwXy7!kqLm3#pRt9zw", ReasonTooLong},
+		{"password", ReasonBreached},
+		{"aaaaaaaaaaaa", ReasonLowEntropy},
+	}
+	for _, tt := range tests {
+		t.Run(tt.password, func(t *testing.T) {
+			err := policy.Check(context.Background(), tt.password)
+			if tt.reason == "" {
+				if err != nil {
+					t.Errorf("got %v, want nil", err)
+				}
+				return
+			}
+			var violation *Violation
+			if !errors.As(err, &violation) {
+				t.Fatalf("got %v, want violation", err)
+			}
+			if violation.Reason != tt.reason {
+				t.Errorf("reason = %s, want %s", violation.Reason, tt.reason)
+			}
+		})
+	}
+}
+
+func TestReadBreachList_Invalid(t *testing.T) {
+	inputs := []string{
+		"5BAA61E4C9B93F3F0682250B6CF8331B7EE68FD",
+		"ZBAA61E4C9B93F3F0682250B6CF8331B7EE68FD8",
+	}
+	for _, input := range inputs {
+		if _, err := ReadBreachList(strings.NewReader(input)); err == nil {
+			t.Errorf("invalid list %q accepted", input)
+		}
+	}
+}
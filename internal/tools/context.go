@@ -4,7 +4,10 @@ import (
 	"context"
 )
 
-const contextKeyRequestID contextKey = "request_id"
+const (
+	contextKeyRequestID  contextKey = "request_id"
+	contextKeyClientInfo contextKey = "client_info"
+)
 
 type contextKey string
 
@@ -19,3 +22,21 @@ func GetRequestIDFromContext(ctx context.Context) string {
 func SetRequestIDToContext(ctx context.Context, requestID string) context.Context {
 	return context.WithValue(ctx, contextKeyRequestID, requestID)
 }
+
+// ClientInfo describes origin of the request.
+type ClientInfo struct {
+	IPAddress string
+	UserAgent string
+}
+
+func GetClientInfoFromContext(ctx context.Context) ClientInfo {
+	val, ok := ctx.Value(contextKeyClientInfo).(ClientInfo)
+	if ok {
+		return val
+	}
+	return ClientInfo{}
+}
+
+func SetClientInfoToContext(ctx context.Context, info ClientInfo) context.Context {
+	return context.WithValue(ctx, contextKeyClientInfo, info)
+}
@@ -0,0 +1,15 @@
+-- +goose Up
+
+create table if not exists auth_jwt_secrets (
+    id bigint unsigned not null auto_increment primary key,
+    kid varchar(64) not null,
+    secret varchar(255) not null,
+    generation bigint not null,
+    created_at timestamp not null default current_timestamp,
+    unique key idx_auth_jwt_secrets_kid (kid),
+    unique key idx_auth_jwt_secrets_generation (generation)
+) engine = innodb default charset = utf8mb4 collate = utf8mb4_unicode_ci;
+
+-- +goose Down
+
+drop table if exists auth_jwt_secrets;
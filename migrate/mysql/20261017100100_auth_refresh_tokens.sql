@@ -0,0 +1,22 @@
+-- +goose Up
+
+create table if not exists auth_refresh_tokens (
+    id bigint unsigned not null auto_increment primary key,
+    jti char(36) not null,
+    family_id char(36) not null,
+    user_id bigint unsigned not null,
+    expires_at timestamp not null,
+    consumed_at timestamp null default null,
+    revoked_at timestamp null default null,
+    created_at timestamp not null default current_timestamp,
+    unique key idx_auth_refresh_tokens_jti (jti),
+    key idx_auth_refresh_tokens_family_id (family_id),
+    key idx_auth_refresh_tokens_user_id (user_id),
+    constraint fk_auth_refresh_tokens_user_id foreign key (
+        user_id
+    ) references auth_users (id) on delete cascade
+) engine = innodb default charset = utf8mb4 collate = utf8mb4_unicode_ci;
+
+-- +goose Down
+
+drop table if exists auth_refresh_tokens;
@@ -0,0 +1,26 @@
+-- +goose Up
+
+create table if not exists auth_sessions (
+    id bigint unsigned not null auto_increment primary key,
+    uuid char(36) not null,
+    user_id bigint unsigned not null,
+    family_id char(36) not null,
+    access_token_jti char(36) not null,
+    refresh_token_jti char(36) not null,
+    user_agent varchar(512) not null default '',
+    ip_address varchar(45) not null default '',
+    created_at timestamp not null default current_timestamp,
+    last_seen_at timestamp not null default current_timestamp,
+    expires_at timestamp not null,
+    revoked_at timestamp null default null,
+    unique key idx_auth_sessions_uuid (uuid),
+    unique key idx_auth_sessions_family_id (family_id),
+    key idx_auth_sessions_user_id (user_id),
+    constraint fk_auth_sessions_user_id foreign key (
+        user_id
+    ) references auth_users (id) on delete cascade
+) engine = innodb default charset = utf8mb4 collate = utf8mb4_unicode_ci;
+
+-- +goose Down
+
+drop table if exists auth_sessions;
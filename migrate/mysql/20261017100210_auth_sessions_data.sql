@@ -0,0 +1,38 @@
+-- +goose Up
+
+insert ignore into auth_permissions (resource, action) values
+('sessions', 'read_self'),
+('sessions', 'revoke_self'),
+('sessions', 'revoke_others');
+
+-- admins have all permissions
+insert ignore into auth_role_permissions (role_id, permission_id)
+select
+    (
+        select auth_roles.id
+        from auth_roles
+        where auth_roles.name = 'admin'
+    ) as role_id,
+    auth_permissions.id as permission_id
+from
+    auth_permissions
+where auth_permissions.resource = 'sessions';
+
+-- users can list & revoke own sessions
+insert ignore into auth_role_permissions (role_id, permission_id)
+select
+    (
+        select auth_roles.id
+        from auth_roles
+        where auth_roles.name = 'user'
+    ) as role_id,
+    auth_permissions.id as permission_id
+from
+    auth_permissions
+where
+    auth_permissions.resource = 'sessions'
+    and auth_permissions.action in ('read_self', 'revoke_self');
+
+-- +goose Down
+
+delete from auth_permissions where resource = 'sessions';
@@ -0,0 +1,9 @@
+-- +goose Up
+
+-- user_id is already indexed by foreign key
+alter table auth_api_tokens
+add column name varchar(100) not null default '' after token;
+
+-- +goose Down
+
+alter table auth_api_tokens drop column name;
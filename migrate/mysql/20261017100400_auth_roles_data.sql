@@ -0,0 +1,26 @@
+-- +goose Up
+
+insert ignore into auth_permissions (resource, action) values
+('roles', 'list'),
+('roles', 'create'),
+('roles', 'update'),
+('roles', 'delete'),
+('roles', 'grant'),
+('roles', 'revoke');
+
+-- admins have all permissions
+insert ignore into auth_role_permissions (role_id, permission_id)
+select
+    (
+        select auth_roles.id
+        from auth_roles
+        where auth_roles.name = 'admin'
+    ) as role_id,
+    auth_permissions.id as permission_id
+from
+    auth_permissions
+where auth_permissions.resource = 'roles';
+
+-- +goose Down
+
+delete from auth_permissions where resource = 'roles';
@@ -0,0 +1,23 @@
+-- +goose Up
+
+insert ignore into auth_permissions (resource, action) values
+('users', 'unlock');
+
+-- admins have all permissions
+insert ignore into auth_role_permissions (role_id, permission_id)
+select
+    (
+        select auth_roles.id
+        from auth_roles
+        where auth_roles.name = 'admin'
+    ) as role_id,
+    auth_permissions.id as permission_id
+from
+    auth_permissions
+where
+    auth_permissions.resource = 'users'
+    and auth_permissions.action = 'unlock';
+
+-- +goose Down
+
+delete from auth_permissions where resource = 'users' and action = 'unlock';
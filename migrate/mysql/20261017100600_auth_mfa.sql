@@ -0,0 +1,30 @@
+-- +goose Up
+
+create table if not exists auth_user_mfa (
+    user_id bigint unsigned not null primary key,
+    secret varchar(64) not null,
+    enabled_at timestamp null default null,
+    last_used_step bigint not null default 0,
+    created_at timestamp not null default current_timestamp,
+    updated_at timestamp not null default current_timestamp,
+    constraint fk_auth_user_mfa_user_id foreign key (
+        user_id
+    ) references auth_users (id) on delete cascade
+) engine = innodb default charset = utf8mb4 collate = utf8mb4_unicode_ci;
+
+create table if not exists auth_user_mfa_recovery_codes (
+    id bigint unsigned not null auto_increment primary key,
+    user_id bigint unsigned not null,
+    code_hash varchar(64) not null,
+    used_at timestamp null default null,
+    created_at timestamp not null default current_timestamp,
+    key idx_auth_user_mfa_recovery_codes_user_id (user_id),
+    constraint fk_auth_user_mfa_recovery_codes_user_id foreign key (
+        user_id
+    ) references auth_users (id) on delete cascade
+) engine = innodb default charset = utf8mb4 collate = utf8mb4_unicode_ci;
+
+-- +goose Down
+
+drop table if exists auth_user_mfa_recovery_codes;
+drop table if exists auth_user_mfa;
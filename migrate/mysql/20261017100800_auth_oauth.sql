@@ -0,0 +1,46 @@
+-- +goose Up
+
+create table if not exists auth_oauth_clients (
+    id bigint unsigned not null auto_increment primary key,
+    client_id varchar(64) not null,
+    secret_hash varchar(64) not null default '',
+    name varchar(100) not null,
+    redirect_uris text not null,
+    grant_types text not null,
+    permissions text not null,
+    user_id bigint unsigned not null,
+    created_at timestamp not null default current_timestamp,
+    updated_at timestamp not null default current_timestamp,
+    unique key uq_auth_oauth_clients_client_id (client_id),
+    key idx_auth_oauth_clients_user_id (user_id),
+    constraint fk_auth_oauth_clients_user_id foreign key (
+        user_id
+    ) references auth_users (id) on delete cascade
+) engine = innodb default charset = utf8mb4 collate = utf8mb4_unicode_ci;
+
+create table if not exists auth_oauth_codes (
+    id bigint unsigned not null auto_increment primary key,
+    code_hash varchar(64) not null,
+    client_id bigint unsigned not null,
+    user_id bigint unsigned not null,
+    redirect_uri text not null,
+    scope varchar(255) not null default '',
+    nonce varchar(255) not null default '',
+    code_challenge varchar(128) not null default '',
+    expires_at timestamp not null,
+    used_at timestamp null default null,
+    created_at timestamp not null default current_timestamp,
+    unique key uq_auth_oauth_codes_code_hash (code_hash),
+    key idx_auth_oauth_codes_expires_at (expires_at),
+    constraint fk_auth_oauth_codes_client_id foreign key (
+        client_id
+    ) references auth_oauth_clients (id) on delete cascade,
+    constraint fk_auth_oauth_codes_user_id foreign key (
+        user_id
+    ) references auth_users (id) on delete cascade
+) engine = innodb default charset = utf8mb4 collate = utf8mb4_unicode_ci;
+
+-- +goose Down
+
+drop table if exists auth_oauth_codes;
+drop table if exists auth_oauth_clients;
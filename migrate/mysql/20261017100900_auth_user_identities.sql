@@ -0,0 +1,20 @@
+-- +goose Up
+
+create table if not exists auth_user_identities (
+    id bigint unsigned not null auto_increment primary key,
+    user_id bigint unsigned not null,
+    provider varchar(50) not null,
+    subject varchar(255) not null,
+    email varchar(255) not null default '',
+    created_at timestamp not null default current_timestamp,
+    last_login_at timestamp not null default current_timestamp,
+    unique key uq_auth_user_identities_provider_subject (provider, subject),
+    key idx_auth_user_identities_user_id (user_id),
+    constraint fk_auth_user_identities_user_id foreign key (
+        user_id
+    ) references auth_users (id) on delete cascade
+) engine = innodb default charset = utf8mb4 collate = utf8mb4_unicode_ci;
+
+-- +goose Down
+
+drop table if exists auth_user_identities;
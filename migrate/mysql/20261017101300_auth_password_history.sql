@@ -0,0 +1,16 @@
+-- +goose Up
+
+create table if not exists auth_password_history (
+    id bigint unsigned not null auto_increment primary key,
+    user_id bigint unsigned not null,
+    password varchar(255) not null,
+    created_at timestamp not null default current_timestamp,
+    key idx_auth_password_history_user_id (user_id),
+    constraint fk_auth_password_history_user_id foreign key (
+        user_id
+    ) references auth_users (id) on delete cascade
+) engine = innodb default charset = utf8mb4 collate = utf8mb4_unicode_ci;
+
+-- +goose Down
+
+drop table if exists auth_password_history;
@@ -0,0 +1,55 @@
+-- +goose Up
+
+create table if not exists auth_organizations (
+    id bigint unsigned not null auto_increment primary key,
+    uuid char(36) not null,
+    name varchar(255) not null,
+    slug varchar(64) not null,
+    created_at timestamp not null default current_timestamp,
+    updated_at timestamp not null default current_timestamp on update current_timestamp,
+    unique key uq_auth_organizations_uuid (uuid),
+    unique key uq_auth_organizations_slug (slug)
+) engine = innodb default charset = utf8mb4 collate = utf8mb4_unicode_ci;
+
+create table if not exists auth_organization_members (
+    organization_id bigint unsigned not null,
+    user_id bigint unsigned not null,
+    created_at timestamp not null default current_timestamp,
+    primary key (organization_id, user_id),
+    key idx_auth_organization_members_user_id (user_id),
+    constraint fk_auth_organization_members_organization_id foreign key (
+        organization_id
+    ) references auth_organizations (id) on delete cascade,
+    constraint fk_auth_organization_members_user_id foreign key (
+        user_id
+    ) references auth_users (id) on delete cascade
+) engine = innodb default charset = utf8mb4 collate = utf8mb4_unicode_ci;
+
+create table if not exists auth_organization_member_roles (
+    organization_id bigint unsigned not null,
+    user_id bigint unsigned not null,
+    role_id bigint unsigned not null,
+    created_at timestamp not null default current_timestamp,
+    expires_at timestamp null default null,
+    primary key (organization_id, user_id, role_id),
+    key idx_auth_organization_member_roles_role_id (role_id),
+    constraint fk_auth_organization_member_roles_member foreign key (
+        organization_id, user_id
+    ) references auth_organization_members (organization_id, user_id) on delete cascade,
+    constraint fk_auth_organization_member_roles_role_id foreign key (
+        role_id
+    ) references auth_roles (id) on delete cascade
+) engine = innodb default charset = utf8mb4 collate = utf8mb4_unicode_ci;
+
+alter table auth_sessions add column organization_id bigint unsigned null default null after user_id,
+add constraint fk_auth_sessions_organization_id foreign key (
+    organization_id
+) references auth_organizations (id) on delete set null;
+
+-- +goose Down
+
+alter table auth_sessions drop foreign key fk_auth_sessions_organization_id;
+alter table auth_sessions drop column organization_id;
+drop table if exists auth_organization_member_roles;
+drop table if exists auth_organization_members;
+drop table if exists auth_organizations;
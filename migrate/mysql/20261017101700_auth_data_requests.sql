@@ -0,0 +1,30 @@
+-- +goose Up
+
+-- subject access requests, archive holds exported data until it expires
+create table if not exists auth_data_requests (
+    id bigint unsigned not null auto_increment primary key,
+    uuid char(36) not null,
+    user_id bigint unsigned not null,
+    requested_by bigint unsigned null,
+    type varchar(32) not null,
+    status varchar(32) not null,
+    error varchar(255) null,
+    archive longblob null,
+    expires_at timestamp null,
+    completed_at timestamp null,
+    created_at timestamp not null default current_timestamp,
+    updated_at timestamp not null default current_timestamp on update current_timestamp,
+    unique key uq_auth_data_requests_uuid (uuid),
+    key idx_auth_data_requests_user_id (user_id),
+    key idx_auth_data_requests_expires_at (expires_at),
+    constraint fk_auth_data_requests_user_id foreign key (
+        user_id
+    ) references auth_users (id) on delete cascade,
+    constraint fk_auth_data_requests_requested_by foreign key (
+        requested_by
+    ) references auth_users (id) on delete set null
+) engine = innodb default charset = utf8mb4 collate = utf8mb4_unicode_ci;
+
+-- +goose Down
+
+drop table if exists auth_data_requests;
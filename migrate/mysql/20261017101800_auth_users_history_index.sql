@@ -0,0 +1,8 @@
+-- +goose Up
+
+-- history is read per user, newest first
+create index idx_auth_users_history_user_id_occurred_at on auth_users_history (user_id, occurred_at);
+
+-- +goose Down
+
+drop index idx_auth_users_history_user_id_occurred_at on auth_users_history;
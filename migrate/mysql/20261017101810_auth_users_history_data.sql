@@ -0,0 +1,37 @@
+-- +goose Up
+
+insert ignore into auth_permissions (resource, action) values
+('history', 'read_self'),
+('history', 'read_others');
+
+-- admins have all permissions
+insert ignore into auth_role_permissions (role_id, permission_id)
+select
+    (
+        select auth_roles.id
+        from auth_roles
+        where auth_roles.name = 'admin'
+    ) as role_id,
+    auth_permissions.id as permission_id
+from
+    auth_permissions
+where auth_permissions.resource = 'history';
+
+-- users can read own history
+insert ignore into auth_role_permissions (role_id, permission_id)
+select
+    (
+        select auth_roles.id
+        from auth_roles
+        where auth_roles.name = 'user'
+    ) as role_id,
+    auth_permissions.id as permission_id
+from
+    auth_permissions
+where
+    auth_permissions.resource = 'history'
+    and auth_permissions.action = 'read_self';
+
+-- +goose Down
+
+delete from auth_permissions where resource = 'history';
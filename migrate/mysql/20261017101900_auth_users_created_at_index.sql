@@ -0,0 +1,8 @@
+-- +goose Up
+
+-- users are paginated by keyset of sort column and id
+create index idx_auth_users_created_at on auth_users (created_at, id);
+
+-- +goose Down
+
+drop index idx_auth_users_created_at on auth_users;
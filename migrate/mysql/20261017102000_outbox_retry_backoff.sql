@@ -0,0 +1,12 @@
+-- +goose Up
+
+-- failed messages are not published again before this time
+alter table transactional_outbox add column next_attempt_at timestamp null default null after processed_at;
+
+create index idx_transactional_outbox_next_attempt on transactional_outbox (status, next_attempt_at);
+
+-- +goose Down
+
+drop index idx_transactional_outbox_next_attempt on transactional_outbox;
+
+alter table transactional_outbox drop column next_attempt_at;
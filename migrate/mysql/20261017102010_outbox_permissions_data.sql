@@ -0,0 +1,22 @@
+-- +goose Up
+
+insert ignore into auth_permissions (resource, action) values
+('outbox', 'read'),
+('outbox', 'manage');
+
+-- admins have all permissions
+insert ignore into auth_role_permissions (role_id, permission_id)
+select
+    (
+        select auth_roles.id
+        from auth_roles
+        where auth_roles.name = 'admin'
+    ) as role_id,
+    auth_permissions.id as permission_id
+from
+    auth_permissions
+where auth_permissions.resource = 'outbox';
+
+-- +goose Down
+
+delete from auth_permissions where resource = 'outbox';
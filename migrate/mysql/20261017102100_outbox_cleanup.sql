@@ -0,0 +1,26 @@
+-- +goose Up
+
+-- processed messages are deleted by cleanup worker after retention
+create index idx_transactional_outbox_processed on transactional_outbox (status, processed_at);
+
+create table if not exists transactional_outbox_archive (
+    id char(36) primary key,
+    aggregate_id int not null,
+    aggregate_type varchar(100) not null,
+    topic varchar(255) not null,
+    payload text null,
+    created_at timestamp not null,
+    processed_at timestamp null,
+    archived_at timestamp not null default current_timestamp,
+    retry_count int not null,
+    max_retries int not null,
+    last_error text not null,
+    metadata text null,
+    key idx_transactional_outbox_archive_aggregate (aggregate_type, aggregate_id)
+);
+
+-- +goose Down
+
+drop table if exists transactional_outbox_archive;
+
+drop index idx_transactional_outbox_processed on transactional_outbox;
@@ -0,0 +1,10 @@
+-- +goose Up
+
+-- publisher looks for older unpublished messages of the same aggregate
+create index idx_transactional_outbox_aggregate on transactional_outbox (
+    aggregate_type, aggregate_id, created_at
+);
+
+-- +goose Down
+
+drop index idx_transactional_outbox_aggregate on transactional_outbox;
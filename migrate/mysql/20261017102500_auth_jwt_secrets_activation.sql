@@ -0,0 +1,8 @@
+-- +goose Up
+
+-- secrets are published for validation before they are used for signing
+alter table auth_jwt_secrets add column active_from timestamp not null default current_timestamp after generation;
+
+-- +goose Down
+
+alter table auth_jwt_secrets drop column active_from;
@@ -0,0 +1,8 @@
+-- +goose Up
+
+-- encrypted private keys do not fit into varchar
+alter table auth_jwt_secrets modify column secret text not null;
+
+-- +goose Down
+
+alter table auth_jwt_secrets modify column secret varchar(255) not null;
@@ -0,0 +1,8 @@
+-- +goose Up
+
+-- sessions started by oauth clients are refreshed only by the same client
+alter table auth_sessions add column oauth_client_id varchar(64) not null default '' after organization_id;
+
+-- +goose Down
+
+alter table auth_sessions drop column oauth_client_id;
@@ -0,0 +1,12 @@
+-- +goose Up
+
+-- status user had before deletion was scheduled, it is restored when deletion is cancelled
+alter table auth_users add column previous_status varchar(255) null default null after status_until;
+alter table auth_users add column previous_status_reason varchar(255) null default null after previous_status;
+alter table auth_users add column previous_status_until timestamp null default null after previous_status_reason;
+
+-- +goose Down
+
+alter table auth_users drop column previous_status_until;
+alter table auth_users drop column previous_status_reason;
+alter table auth_users drop column previous_status;
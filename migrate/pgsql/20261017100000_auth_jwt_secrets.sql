@@ -0,0 +1,18 @@
+-- +goose Up
+
+create table if not exists auth_jwt_secrets (
+    id bigserial primary key,
+    kid varchar(64) not null,
+    secret varchar(255) not null,
+    generation bigint not null,
+    created_at timestamp not null default current_timestamp
+);
+
+create unique index if not exists idx_auth_jwt_secrets_kid on auth_jwt_secrets (kid);
+create unique index if not exists idx_auth_jwt_secrets_generation on auth_jwt_secrets (
+    generation
+);
+
+-- +goose Down
+
+drop table if exists auth_jwt_secrets;
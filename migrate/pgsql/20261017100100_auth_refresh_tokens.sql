@@ -0,0 +1,27 @@
+-- +goose Up
+
+create table if not exists auth_refresh_tokens (
+    id bigserial primary key,
+    jti uuid not null,
+    family_id uuid not null,
+    user_id bigint not null,
+    expires_at timestamp not null,
+    consumed_at timestamp null,
+    revoked_at timestamp null,
+    created_at timestamp not null default current_timestamp,
+    constraint fk_auth_refresh_tokens_user_id foreign key (
+        user_id
+    ) references auth_users (id) on delete cascade
+);
+
+create unique index if not exists idx_auth_refresh_tokens_jti on auth_refresh_tokens (jti);
+create index if not exists idx_auth_refresh_tokens_family_id on auth_refresh_tokens (
+    family_id
+);
+create index if not exists idx_auth_refresh_tokens_user_id on auth_refresh_tokens (
+    user_id
+);
+
+-- +goose Down
+
+drop table if exists auth_refresh_tokens;
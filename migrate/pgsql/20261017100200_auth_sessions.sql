@@ -0,0 +1,29 @@
+-- +goose Up
+
+create table if not exists auth_sessions (
+    id bigserial primary key,
+    uuid uuid not null,
+    user_id bigint not null,
+    family_id uuid not null,
+    access_token_jti uuid not null,
+    refresh_token_jti uuid not null,
+    user_agent varchar(512) not null default '',
+    ip_address varchar(45) not null default '',
+    created_at timestamp not null default current_timestamp,
+    last_seen_at timestamp not null default current_timestamp,
+    expires_at timestamp not null,
+    revoked_at timestamp null,
+    constraint fk_auth_sessions_user_id foreign key (
+        user_id
+    ) references auth_users (id) on delete cascade
+);
+
+create unique index if not exists idx_auth_sessions_uuid on auth_sessions (uuid);
+create unique index if not exists idx_auth_sessions_family_id on auth_sessions (
+    family_id
+);
+create index if not exists idx_auth_sessions_user_id on auth_sessions (user_id);
+
+-- +goose Down
+
+drop table if exists auth_sessions;
@@ -0,0 +1,42 @@
+-- +goose Up
+
+insert into auth_permissions (resource, action) values
+('api_tokens', 'read_self'),
+('api_tokens', 'create_self'),
+('api_tokens', 'revoke_self'),
+('api_tokens', 'manage_others')
+on conflict do nothing;
+
+-- admins have all permissions
+insert into auth_role_permissions (role_id, permission_id)
+select
+    (
+        select auth_roles.id
+        from auth_roles
+        where auth_roles.name = 'admin'
+    ) as role_id,
+    auth_permissions.id as permission_id
+from
+    auth_permissions
+where auth_permissions.resource = 'api_tokens'
+on conflict do nothing;
+
+-- users can manage own api tokens
+insert into auth_role_permissions (role_id, permission_id)
+select
+    (
+        select auth_roles.id
+        from auth_roles
+        where auth_roles.name = 'user'
+    ) as role_id,
+    auth_permissions.id as permission_id
+from
+    auth_permissions
+where
+    auth_permissions.resource = 'api_tokens'
+    and auth_permissions.action in ('read_self', 'create_self', 'revoke_self')
+on conflict do nothing;
+
+-- +goose Down
+
+delete from auth_permissions where resource = 'api_tokens';
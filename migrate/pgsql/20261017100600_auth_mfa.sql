@@ -0,0 +1,33 @@
+-- +goose Up
+
+create table if not exists auth_user_mfa (
+    user_id bigint primary key,
+    secret varchar(64) not null,
+    enabled_at timestamp null,
+    last_used_step bigint not null default 0,
+    created_at timestamp not null default current_timestamp,
+    updated_at timestamp not null default current_timestamp,
+    constraint fk_auth_user_mfa_user_id foreign key (
+        user_id
+    ) references auth_users (id) on delete cascade
+);
+
+create table if not exists auth_user_mfa_recovery_codes (
+    id bigserial primary key,
+    user_id bigint not null,
+    code_hash varchar(64) not null,
+    used_at timestamp null,
+    created_at timestamp not null default current_timestamp,
+    constraint fk_auth_user_mfa_recovery_codes_user_id foreign key (
+        user_id
+    ) references auth_users (id) on delete cascade
+);
+
+create index if not exists idx_auth_user_mfa_recovery_codes_user_id on auth_user_mfa_recovery_codes (
+    user_id
+);
+
+-- +goose Down
+
+drop table if exists auth_user_mfa_recovery_codes;
+drop table if exists auth_user_mfa;
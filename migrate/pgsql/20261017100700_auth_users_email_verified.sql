@@ -0,0 +1,7 @@
+-- +goose Up
+
+alter table auth_users add column if not exists email_verified_at timestamp null;
+
+-- +goose Down
+
+alter table auth_users drop column if exists email_verified_at;
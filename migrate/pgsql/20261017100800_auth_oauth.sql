@@ -0,0 +1,50 @@
+-- +goose Up
+
+create table if not exists auth_oauth_clients (
+    id bigserial primary key,
+    client_id varchar(64) not null unique,
+    secret_hash varchar(64) not null default '',
+    name varchar(100) not null,
+    redirect_uris text not null,
+    grant_types text not null,
+    permissions text not null,
+    user_id bigint not null,
+    created_at timestamp not null default current_timestamp,
+    updated_at timestamp not null default current_timestamp,
+    constraint fk_auth_oauth_clients_user_id foreign key (
+        user_id
+    ) references auth_users (id) on delete cascade
+);
+
+create index if not exists idx_auth_oauth_clients_user_id on auth_oauth_clients (
+    user_id
+);
+
+create table if not exists auth_oauth_codes (
+    id bigserial primary key,
+    code_hash varchar(64) not null unique,
+    client_id bigint not null,
+    user_id bigint not null,
+    redirect_uri text not null,
+    scope varchar(255) not null default '',
+    nonce varchar(255) not null default '',
+    code_challenge varchar(128) not null default '',
+    expires_at timestamp not null,
+    used_at timestamp null,
+    created_at timestamp not null default current_timestamp,
+    constraint fk_auth_oauth_codes_client_id foreign key (
+        client_id
+    ) references auth_oauth_clients (id) on delete cascade,
+    constraint fk_auth_oauth_codes_user_id foreign key (
+        user_id
+    ) references auth_users (id) on delete cascade
+);
+
+create index if not exists idx_auth_oauth_codes_expires_at on auth_oauth_codes (
+    expires_at
+);
+
+-- +goose Down
+
+drop table if exists auth_oauth_codes;
+drop table if exists auth_oauth_clients;
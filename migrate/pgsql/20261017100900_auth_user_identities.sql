@@ -0,0 +1,23 @@
+-- +goose Up
+
+create table if not exists auth_user_identities (
+    id bigserial primary key,
+    user_id bigint not null,
+    provider varchar(50) not null,
+    subject varchar(255) not null,
+    email varchar(255) not null default '',
+    created_at timestamp not null default current_timestamp,
+    last_login_at timestamp not null default current_timestamp,
+    constraint uq_auth_user_identities_provider_subject unique (provider, subject),
+    constraint fk_auth_user_identities_user_id foreign key (
+        user_id
+    ) references auth_users (id) on delete cascade
+);
+
+create index if not exists idx_auth_user_identities_user_id on auth_user_identities (
+    user_id
+);
+
+-- +goose Down
+
+drop table if exists auth_user_identities;
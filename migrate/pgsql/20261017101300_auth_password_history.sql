@@ -0,0 +1,19 @@
+-- +goose Up
+
+create table if not exists auth_password_history (
+    id bigserial primary key,
+    user_id bigint not null,
+    password varchar(255) not null,
+    created_at timestamp not null default current_timestamp,
+    constraint fk_auth_password_history_user_id foreign key (
+        user_id
+    ) references auth_users (id) on delete cascade
+);
+
+create index if not exists idx_auth_password_history_user_id on auth_password_history (
+    user_id
+);
+
+-- +goose Down
+
+drop table if exists auth_password_history;
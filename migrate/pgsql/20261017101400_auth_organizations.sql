@@ -0,0 +1,62 @@
+-- +goose Up
+
+create table if not exists auth_organizations (
+    id bigserial primary key,
+    uuid uuid not null,
+    name varchar(255) not null,
+    slug varchar(64) not null,
+    created_at timestamp not null default current_timestamp,
+    updated_at timestamp not null default current_timestamp
+);
+
+create unique index if not exists idx_auth_organizations_uuid on auth_organizations (uuid);
+create unique index if not exists idx_auth_organizations_slug on auth_organizations (slug);
+
+create table if not exists auth_organization_members (
+    organization_id bigint not null,
+    user_id bigint not null,
+    created_at timestamp not null default current_timestamp,
+    primary key (organization_id, user_id),
+    constraint fk_auth_organization_members_organization_id foreign key (
+        organization_id
+    ) references auth_organizations (id) on delete cascade,
+    constraint fk_auth_organization_members_user_id foreign key (
+        user_id
+    ) references auth_users (id) on delete cascade
+);
+
+create index if not exists idx_auth_organization_members_user_id on auth_organization_members (
+    user_id
+);
+
+create table if not exists auth_organization_member_roles (
+    organization_id bigint not null,
+    user_id bigint not null,
+    role_id bigint not null,
+    created_at timestamp not null default current_timestamp,
+    expires_at timestamp null,
+    primary key (organization_id, user_id, role_id),
+    constraint fk_auth_organization_member_roles_member foreign key (
+        organization_id, user_id
+    ) references auth_organization_members (organization_id, user_id) on delete cascade,
+    constraint fk_auth_organization_member_roles_role_id foreign key (
+        role_id
+    ) references auth_roles (id) on delete cascade
+);
+
+create index if not exists idx_auth_organization_member_roles_role_id on auth_organization_member_roles (
+    role_id
+);
+
+alter table auth_sessions add column if not exists organization_id bigint null;
+alter table auth_sessions add constraint fk_auth_sessions_organization_id foreign key (
+    organization_id
+) references auth_organizations (id) on delete set null;
+
+-- +goose Down
+
+alter table auth_sessions drop constraint if exists fk_auth_sessions_organization_id;
+alter table auth_sessions drop column if exists organization_id;
+drop table if exists auth_organization_member_roles;
+drop table if exists auth_organization_members;
+drop table if exists auth_organizations;
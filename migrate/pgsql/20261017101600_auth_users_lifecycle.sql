@@ -0,0 +1,14 @@
+-- +goose Up
+
+-- reason and end of suspension, or end of deletion grace period
+alter table auth_users add column if not exists status_reason varchar(255) null;
+alter table auth_users add column if not exists status_until timestamp null;
+
+create index if not exists idx_auth_users_status_until on auth_users (status, status_until);
+
+-- +goose Down
+
+drop index if exists idx_auth_users_status_until;
+
+alter table auth_users drop column if exists status_until;
+alter table auth_users drop column if exists status_reason;
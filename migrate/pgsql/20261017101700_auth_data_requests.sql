@@ -0,0 +1,31 @@
+-- +goose Up
+
+-- subject access requests, archive holds exported data until it expires
+create table if not exists auth_data_requests (
+    id bigserial primary key,
+    uuid uuid not null,
+    user_id bigint not null,
+    requested_by bigint null,
+    type varchar(32) not null,
+    status varchar(32) not null,
+    error varchar(255) null,
+    archive bytea null,
+    expires_at timestamp null,
+    completed_at timestamp null,
+    created_at timestamp not null default current_timestamp,
+    updated_at timestamp not null default current_timestamp,
+    constraint fk_auth_data_requests_user_id foreign key (
+        user_id
+    ) references auth_users (id) on delete cascade,
+    constraint fk_auth_data_requests_requested_by foreign key (
+        requested_by
+    ) references auth_users (id) on delete set null
+);
+
+create unique index if not exists idx_auth_data_requests_uuid on auth_data_requests (uuid);
+create index if not exists idx_auth_data_requests_user_id on auth_data_requests (user_id);
+create index if not exists idx_auth_data_requests_expires_at on auth_data_requests (expires_at);
+
+-- +goose Down
+
+drop table if exists auth_data_requests;
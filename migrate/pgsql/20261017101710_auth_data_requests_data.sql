@@ -0,0 +1,25 @@
+-- +goose Up
+
+insert into auth_permissions (resource, action) values
+('users', 'data_requests')
+on conflict do nothing;
+
+-- admins have all permissions
+insert into auth_role_permissions (role_id, permission_id)
+select
+    (
+        select auth_roles.id
+        from auth_roles
+        where auth_roles.name = 'admin'
+    ) as role_id,
+    auth_permissions.id as permission_id
+from
+    auth_permissions
+where
+    auth_permissions.resource = 'users'
+    and auth_permissions.action = 'data_requests'
+on conflict do nothing;
+
+-- +goose Down
+
+delete from auth_permissions where resource = 'users' and action = 'data_requests';
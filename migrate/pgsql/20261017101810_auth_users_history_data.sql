@@ -0,0 +1,40 @@
+-- +goose Up
+
+insert into auth_permissions (resource, action) values
+('history', 'read_self'),
+('history', 'read_others')
+on conflict do nothing;
+
+-- admins have all permissions
+insert into auth_role_permissions (role_id, permission_id)
+select
+    (
+        select auth_roles.id
+        from auth_roles
+        where auth_roles.name = 'admin'
+    ) as role_id,
+    auth_permissions.id as permission_id
+from
+    auth_permissions
+where auth_permissions.resource = 'history'
+on conflict do nothing;
+
+-- users can read own history
+insert into auth_role_permissions (role_id, permission_id)
+select
+    (
+        select auth_roles.id
+        from auth_roles
+        where auth_roles.name = 'user'
+    ) as role_id,
+    auth_permissions.id as permission_id
+from
+    auth_permissions
+where
+    auth_permissions.resource = 'history'
+    and auth_permissions.action = 'read_self'
+on conflict do nothing;
+
+-- +goose Down
+
+delete from auth_permissions where resource = 'history';
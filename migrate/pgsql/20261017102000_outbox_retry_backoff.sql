@@ -0,0 +1,12 @@
+-- +goose Up
+
+-- failed messages are not published again before this time
+alter table transactional_outbox add column if not exists next_attempt_at timestamp null;
+
+create index if not exists idx_transactional_outbox_next_attempt on transactional_outbox (status, next_attempt_at);
+
+-- +goose Down
+
+drop index if exists idx_transactional_outbox_next_attempt;
+
+alter table transactional_outbox drop column if exists next_attempt_at;
@@ -0,0 +1,24 @@
+-- +goose Up
+
+insert into auth_permissions (resource, action) values
+('outbox', 'read'),
+('outbox', 'manage')
+on conflict do nothing;
+
+-- admins have all permissions
+insert into auth_role_permissions (role_id, permission_id)
+select
+    (
+        select auth_roles.id
+        from auth_roles
+        where auth_roles.name = 'admin'
+    ) as role_id,
+    auth_permissions.id as permission_id
+from
+    auth_permissions
+where auth_permissions.resource = 'outbox'
+on conflict do nothing;
+
+-- +goose Down
+
+delete from auth_permissions where resource = 'outbox';
@@ -0,0 +1,22 @@
+-- +goose Up
+
+-- publishers listen to this channel to publish new messages without waiting for next poll,
+-- notifications are delivered on commit and merged within transaction
+-- +goose StatementBegin
+create or replace function transactional_outbox_notify() returns trigger as $$
+begin
+    perform pg_notify('transactional_outbox', '');
+    return null;
+end;
+$$ language plpgsql;
+-- +goose StatementEnd
+
+create trigger transactional_outbox_notify
+after insert on transactional_outbox
+for each statement execute function transactional_outbox_notify();
+
+-- +goose Down
+
+drop trigger if exists transactional_outbox_notify on transactional_outbox;
+
+drop function if exists transactional_outbox_notify();
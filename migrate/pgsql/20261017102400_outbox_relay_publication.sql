@@ -0,0 +1,9 @@
+-- +goose Up
+
+-- wal relay reads inserted messages through replication slot of this publication,
+-- it requires wal_level = logical
+create publication transactional_outbox_relay for table transactional_outbox with (publish = 'insert');
+
+-- +goose Down
+
+drop publication if exists transactional_outbox_relay;
@@ -0,0 +1,8 @@
+-- +goose Up
+
+-- secrets are published for validation before they are used for signing
+alter table auth_jwt_secrets add column if not exists active_from timestamp not null default current_timestamp;
+
+-- +goose Down
+
+alter table auth_jwt_secrets drop column if exists active_from;
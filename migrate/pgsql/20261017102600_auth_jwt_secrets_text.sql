@@ -0,0 +1,8 @@
+-- +goose Up
+
+-- encrypted private keys do not fit into varchar
+alter table auth_jwt_secrets alter column secret type text;
+
+-- +goose Down
+
+alter table auth_jwt_secrets alter column secret type varchar(255);
@@ -0,0 +1,12 @@
+-- +goose Up
+
+-- status user had before deletion was scheduled, it is restored when deletion is cancelled
+alter table auth_users add column if not exists previous_status varchar(255) null;
+alter table auth_users add column if not exists previous_status_reason varchar(255) null;
+alter table auth_users add column if not exists previous_status_until timestamp null;
+
+-- +goose Down
+
+alter table auth_users drop column if exists previous_status_until;
+alter table auth_users drop column if exists previous_status_reason;
+alter table auth_users drop column if exists previous_status;
@@ -0,0 +1,29 @@
+-- +goose Up
+
+-- aggregate key identifies aggregate regardless of message type, messages of the same key are published in order
+alter table transactional_outbox add column if not exists aggregate_key varchar(255) not null default '';
+
+update transactional_outbox set aggregate_key = aggregate_type || ':' || aggregate_id where aggregate_key = '';
+
+drop index if exists idx_transactional_outbox_aggregate;
+
+create index if not exists idx_transactional_outbox_aggregate_key on transactional_outbox (
+    topic, aggregate_key, created_at
+);
+
+-- archived messages keep the key they were published with
+alter table transactional_outbox_archive add column if not exists aggregate_key varchar(255) not null default '';
+
+update transactional_outbox_archive set aggregate_key = aggregate_type || ':' || aggregate_id where aggregate_key = '';
+
+-- +goose Down
+
+alter table transactional_outbox_archive drop column if exists aggregate_key;
+
+drop index if exists idx_transactional_outbox_aggregate_key;
+
+create index if not exists idx_transactional_outbox_aggregate on transactional_outbox (
+    aggregate_type, aggregate_id, created_at
+);
+
+alter table transactional_outbox drop column if exists aggregate_key;
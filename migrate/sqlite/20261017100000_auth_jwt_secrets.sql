@@ -0,0 +1,13 @@
+-- +goose Up
+
+create table if not exists auth_jwt_secrets (
+    id integer primary key autoincrement,
+    kid text not null unique,
+    secret text not null,
+    generation integer not null unique,
+    created_at datetime not null default current_timestamp
+);
+
+-- +goose Down
+
+drop table if exists auth_jwt_secrets;
@@ -0,0 +1,24 @@
+-- +goose Up
+
+create table if not exists auth_refresh_tokens (
+    id integer primary key autoincrement,
+    jti text not null unique,
+    family_id text not null,
+    user_id integer not null,
+    expires_at datetime not null,
+    consumed_at datetime,
+    revoked_at datetime,
+    created_at datetime not null default current_timestamp,
+    foreign key (user_id) references auth_users (id) on delete cascade
+);
+
+create index if not exists idx_auth_refresh_tokens_family_id on auth_refresh_tokens (
+    family_id
+);
+create index if not exists idx_auth_refresh_tokens_user_id on auth_refresh_tokens (
+    user_id
+);
+
+-- +goose Down
+
+drop table if exists auth_refresh_tokens;
@@ -0,0 +1,23 @@
+-- +goose Up
+
+create table if not exists auth_sessions (
+    id integer primary key autoincrement,
+    uuid text not null unique,
+    user_id integer not null,
+    family_id text not null unique,
+    access_token_jti text not null,
+    refresh_token_jti text not null,
+    user_agent text not null default '',
+    ip_address text not null default '',
+    created_at datetime not null default current_timestamp,
+    last_seen_at datetime not null default current_timestamp,
+    expires_at datetime not null,
+    revoked_at datetime,
+    foreign key (user_id) references auth_users (id) on delete cascade
+);
+
+create index if not exists idx_auth_sessions_user_id on auth_sessions (user_id);
+
+-- +goose Down
+
+drop table if exists auth_sessions;
@@ -0,0 +1,9 @@
+-- +goose Up
+
+create index if not exists idx_auth_api_tokens_user_id on auth_api_tokens (
+    user_id
+);
+
+-- +goose Down
+
+drop index if exists idx_auth_api_tokens_user_id;
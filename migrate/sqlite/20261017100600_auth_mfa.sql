@@ -0,0 +1,29 @@
+-- +goose Up
+
+create table if not exists auth_user_mfa (
+    user_id integer primary key,
+    secret text not null,
+    enabled_at datetime,
+    last_used_step integer not null default 0,
+    created_at datetime not null default current_timestamp,
+    updated_at datetime not null default current_timestamp,
+    foreign key (user_id) references auth_users (id) on delete cascade
+);
+
+create table if not exists auth_user_mfa_recovery_codes (
+    id integer primary key autoincrement,
+    user_id integer not null,
+    code_hash text not null,
+    used_at datetime,
+    created_at datetime not null default current_timestamp,
+    foreign key (user_id) references auth_users (id) on delete cascade
+);
+
+create index if not exists idx_auth_user_mfa_recovery_codes_user_id on auth_user_mfa_recovery_codes (
+    user_id
+);
+
+-- +goose Down
+
+drop table if exists auth_user_mfa_recovery_codes;
+drop table if exists auth_user_mfa;
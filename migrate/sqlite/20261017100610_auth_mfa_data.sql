@@ -0,0 +1,37 @@
+-- +goose Up
+
+insert or ignore into auth_permissions (resource, action) values
+('mfa', 'manage_self'),
+('mfa', 'reset_others');
+
+-- admins have all permissions
+insert or ignore into auth_role_permissions (role_id, permission_id)
+select
+    (
+        select auth_roles.id
+        from auth_roles
+        where auth_roles.name = 'admin'
+    ) as role_id,
+    auth_permissions.id as permission_id
+from
+    auth_permissions
+where auth_permissions.resource = 'mfa';
+
+-- users can manage own mfa
+insert or ignore into auth_role_permissions (role_id, permission_id)
+select
+    (
+        select auth_roles.id
+        from auth_roles
+        where auth_roles.name = 'user'
+    ) as role_id,
+    auth_permissions.id as permission_id
+from
+    auth_permissions
+where
+    auth_permissions.resource = 'mfa'
+    and auth_permissions.action = 'manage_self';
+
+-- +goose Down
+
+delete from auth_permissions where resource = 'mfa';
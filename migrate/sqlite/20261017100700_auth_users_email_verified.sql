@@ -0,0 +1,7 @@
+-- +goose Up
+
+alter table auth_users add column email_verified_at datetime;
+
+-- +goose Down
+
+alter table auth_users drop column email_verified_at;
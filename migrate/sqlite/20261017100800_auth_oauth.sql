@@ -0,0 +1,44 @@
+-- +goose Up
+
+create table if not exists auth_oauth_clients (
+    id integer primary key autoincrement,
+    client_id text not null unique,
+    secret_hash text not null default '',
+    name text not null,
+    redirect_uris text not null,
+    grant_types text not null,
+    permissions text not null,
+    user_id integer not null,
+    created_at datetime not null default current_timestamp,
+    updated_at datetime not null default current_timestamp,
+    foreign key (user_id) references auth_users (id) on delete cascade
+);
+
+create index if not exists idx_auth_oauth_clients_user_id on auth_oauth_clients (
+    user_id
+);
+
+create table if not exists auth_oauth_codes (
+    id integer primary key autoincrement,
+    code_hash text not null unique,
+    client_id integer not null,
+    user_id integer not null,
+    redirect_uri text not null,
+    scope text not null default '',
+    nonce text not null default '',
+    code_challenge text not null default '',
+    expires_at datetime not null,
+    used_at datetime,
+    created_at datetime not null default current_timestamp,
+    foreign key (client_id) references auth_oauth_clients (id) on delete cascade,
+    foreign key (user_id) references auth_users (id) on delete cascade
+);
+
+create index if not exists idx_auth_oauth_codes_expires_at on auth_oauth_codes (
+    expires_at
+);
+
+-- +goose Down
+
+drop table if exists auth_oauth_codes;
+drop table if exists auth_oauth_clients;
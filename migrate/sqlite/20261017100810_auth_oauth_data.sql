@@ -0,0 +1,23 @@
+-- +goose Up
+
+insert or ignore into auth_permissions (resource, action) values
+('oauth_clients', 'list'),
+('oauth_clients', 'create'),
+('oauth_clients', 'delete');
+
+-- admins have all permissions
+insert or ignore into auth_role_permissions (role_id, permission_id)
+select
+    (
+        select auth_roles.id
+        from auth_roles
+        where auth_roles.name = 'admin'
+    ) as role_id,
+    auth_permissions.id as permission_id
+from
+    auth_permissions
+where auth_permissions.resource = 'oauth_clients';
+
+-- +goose Down
+
+delete from auth_permissions where resource = 'oauth_clients';
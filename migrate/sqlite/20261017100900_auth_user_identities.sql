@@ -0,0 +1,21 @@
+-- +goose Up
+
+create table if not exists auth_user_identities (
+    id integer primary key autoincrement,
+    user_id integer not null,
+    provider text not null,
+    subject text not null,
+    email text not null default '',
+    created_at datetime not null default current_timestamp,
+    last_login_at datetime not null default current_timestamp,
+    unique (provider, subject),
+    foreign key (user_id) references auth_users (id) on delete cascade
+);
+
+create index if not exists idx_auth_user_identities_user_id on auth_user_identities (
+    user_id
+);
+
+-- +goose Down
+
+drop table if exists auth_user_identities;
@@ -0,0 +1,17 @@
+-- +goose Up
+
+create table if not exists auth_password_history (
+    id integer primary key autoincrement,
+    user_id integer not null,
+    password text not null,
+    created_at datetime not null default current_timestamp,
+    foreign key (user_id) references auth_users (id) on delete cascade
+);
+
+create index if not exists idx_auth_password_history_user_id on auth_password_history (
+    user_id
+);
+
+-- +goose Down
+
+drop table if exists auth_password_history;
@@ -0,0 +1,51 @@
+-- +goose Up
+
+create table if not exists auth_organizations (
+    id integer primary key autoincrement,
+    uuid text not null unique,
+    name text not null,
+    slug text not null unique,
+    created_at datetime not null default current_timestamp,
+    updated_at datetime not null default current_timestamp
+);
+
+create table if not exists auth_organization_members (
+    organization_id integer not null,
+    user_id integer not null,
+    created_at datetime not null default current_timestamp,
+    primary key (organization_id, user_id),
+    foreign key (organization_id) references auth_organizations (id) on delete cascade,
+    foreign key (user_id) references auth_users (id) on delete cascade
+);
+
+create index if not exists idx_auth_organization_members_user_id on auth_organization_members (
+    user_id
+);
+
+create table if not exists auth_organization_member_roles (
+    organization_id integer not null,
+    user_id integer not null,
+    role_id integer not null,
+    created_at datetime not null default current_timestamp,
+    expires_at datetime,
+    primary key (organization_id, user_id, role_id),
+    foreign key (organization_id, user_id) references auth_organization_members (
+        organization_id, user_id
+    ) on delete cascade,
+    foreign key (role_id) references auth_roles (id) on delete cascade
+);
+
+create index if not exists idx_auth_organization_member_roles_role_id on auth_organization_member_roles (
+    role_id
+);
+
+-- column referenced by foreign key can not be dropped in sqlite,
+-- sessions of deleted organizations are handled by service
+alter table auth_sessions add column organization_id integer;
+
+-- +goose Down
+
+alter table auth_sessions drop column organization_id;
+drop table if exists auth_organization_member_roles;
+drop table if exists auth_organization_members;
+drop table if exists auth_organizations;
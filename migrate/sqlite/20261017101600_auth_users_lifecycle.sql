@@ -0,0 +1,14 @@
+-- +goose Up
+
+-- reason and end of suspension, or end of deletion grace period
+alter table auth_users add column status_reason text;
+alter table auth_users add column status_until datetime;
+
+create index if not exists idx_auth_users_status_until on auth_users (status, status_until);
+
+-- +goose Down
+
+drop index if exists idx_auth_users_status_until;
+
+alter table auth_users drop column status_until;
+alter table auth_users drop column status_reason;
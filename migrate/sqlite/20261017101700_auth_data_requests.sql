@@ -0,0 +1,30 @@
+-- +goose Up
+
+-- subject access requests, archive holds exported data until it expires
+create table if not exists auth_data_requests (
+    id integer primary key autoincrement,
+    uuid text not null unique,
+    user_id integer not null,
+    requested_by integer null,
+    type text not null,
+    status text not null,
+    error text null,
+    archive blob null,
+    expires_at datetime null,
+    completed_at datetime null,
+    created_at datetime not null default current_timestamp,
+    updated_at datetime not null default current_timestamp,
+    foreign key (user_id) references auth_users (id) on delete cascade,
+    foreign key (requested_by) references auth_users (id) on delete set null
+);
+
+create index if not exists idx_auth_data_requests_user_id on auth_data_requests (
+    user_id
+);
+create index if not exists idx_auth_data_requests_expires_at on auth_data_requests (
+    expires_at
+);
+
+-- +goose Down
+
+drop table if exists auth_data_requests;
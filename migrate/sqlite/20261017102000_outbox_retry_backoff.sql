@@ -0,0 +1,12 @@
+-- +goose Up
+
+-- failed messages are not published again before this time
+alter table transactional_outbox add column next_attempt_at datetime;
+
+create index if not exists idx_transactional_outbox_next_attempt on transactional_outbox (status, next_attempt_at);
+
+-- +goose Down
+
+drop index if exists idx_transactional_outbox_next_attempt;
+
+alter table transactional_outbox drop column next_attempt_at;
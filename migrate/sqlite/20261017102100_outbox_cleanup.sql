@@ -0,0 +1,29 @@
+-- +goose Up
+
+-- processed messages are deleted by cleanup worker after retention
+create index if not exists idx_transactional_outbox_processed on transactional_outbox (status, processed_at);
+
+create table if not exists transactional_outbox_archive (
+    id text primary key,
+    aggregate_id integer not null,
+    aggregate_type text not null,
+    topic text not null,
+    payload text null,
+    created_at datetime not null,
+    processed_at datetime null,
+    archived_at datetime not null default current_timestamp,
+    retry_count integer not null,
+    max_retries integer not null,
+    last_error text not null,
+    metadata text null
+);
+
+create index if not exists idx_transactional_outbox_archive_aggregate on transactional_outbox_archive (
+    aggregate_type, aggregate_id
+);
+
+-- +goose Down
+
+drop table if exists transactional_outbox_archive;
+
+drop index if exists idx_transactional_outbox_processed;
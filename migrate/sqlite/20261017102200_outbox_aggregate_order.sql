@@ -0,0 +1,10 @@
+-- +goose Up
+
+-- publisher looks for older unpublished messages of the same aggregate
+create index if not exists idx_transactional_outbox_aggregate on transactional_outbox (
+    aggregate_type, aggregate_id, created_at
+);
+
+-- +goose Down
+
+drop index if exists idx_transactional_outbox_aggregate;
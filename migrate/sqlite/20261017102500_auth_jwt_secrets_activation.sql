@@ -0,0 +1,9 @@
+-- +goose Up
+
+-- secrets are published for validation before they are used for signing,
+-- existing secrets are already active
+alter table auth_jwt_secrets add column active_from datetime not null default '2000-01-01 00:00:00';
+
+-- +goose Down
+
+alter table auth_jwt_secrets drop column active_from;
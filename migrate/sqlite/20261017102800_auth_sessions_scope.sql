@@ -0,0 +1,8 @@
+-- +goose Up
+
+-- scope granted to oauth client limits tokens of its session
+alter table auth_sessions add column scope text not null default '';
+
+-- +goose Down
+
+alter table auth_sessions drop column scope;
@@ -0,0 +1,12 @@
+-- +goose Up
+
+-- status user had before deletion was scheduled, it is restored when deletion is cancelled
+alter table auth_users add column previous_status text;
+alter table auth_users add column previous_status_reason text;
+alter table auth_users add column previous_status_until datetime;
+
+-- +goose Down
+
+alter table auth_users drop column previous_status_until;
+alter table auth_users drop column previous_status_reason;
+alter table auth_users drop column previous_status;
@@ -5,11 +5,14 @@ import (
 	"context"
 	"fmt"
 	"testing"
+	"time"
 
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/credentials/insecure"
 	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
 
 	pb "github.com/hasansino/go42/api/gen/sdk/grpc/auth/v1"
 	"github.com/hasansino/go42/tests/integration"
@@ -46,8 +49,7 @@ var _ = Describe("Auth gRPC Integration Tests", func() {
 		Describe("ListUsers", func() {
 			It("should list users", func() {
 				req := &pb.ListUsersRequest{
-					Limit:  10,
-					Offset: 0,
+					Limit: 10,
 				}
 
 				resp, err := client.ListUsers(ctx, req)
@@ -55,6 +57,75 @@ var _ = Describe("Auth gRPC Integration Tests", func() {
 				Expect(resp).NotTo(BeNil())
 				Expect(resp.Users).NotTo(BeNil())
 			})
+
+			It("should page filtered users with cursor", func() {
+				prefix := fmt.Sprintf("list-test-%s", integration.GenerateRandomString("user"))
+				for i := 0; i < 3; i++ {
+					_, err := client.CreateUser(ctx, &pb.CreateUserRequest{
+						Email:    fmt.Sprintf("%s-%d@example.com", prefix, i),
+						Password: "TestPass123!",
+					})
+					if err != nil {
+						Skip("Could not create test user")
+					}
+				}
+
+				var emails []string
+				req := &pb.ListUsersRequest{
+					Limit:       2,
+					EmailPrefix: prefix,
+					Status:      pb.UserStatus_USER_STATUS_ACTIVE,
+					SortBy:      pb.UserSortField_USER_SORT_FIELD_EMAIL,
+					SortDesc:    true,
+				}
+				resp, err := client.ListUsers(ctx, req)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(resp.Users).To(HaveLen(2))
+				Expect(resp.NextCursor).NotTo(BeEmpty())
+				for _, user := range resp.Users {
+					emails = append(emails, user.Email)
+				}
+
+				req.Cursor = resp.NextCursor
+				resp, err = client.ListUsers(ctx, req)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(resp.Users).To(HaveLen(1))
+				Expect(resp.NextCursor).To(BeEmpty())
+				emails = append(emails, resp.Users[0].Email)
+
+				Expect(emails).To(Equal([]string{
+					prefix + "-2@example.com",
+					prefix + "-1@example.com",
+					prefix + "-0@example.com",
+				}))
+			})
+
+			It("should return InvalidArgument for cursor of another sort", func() {
+				resp, err := client.ListUsers(ctx, &pb.ListUsersRequest{Limit: 1})
+				Expect(err).NotTo(HaveOccurred())
+				if resp.NextCursor == "" {
+					Skip("Not enough users to get cursor")
+				}
+
+				_, err = client.ListUsers(ctx, &pb.ListUsersRequest{
+					Cursor: resp.NextCursor,
+					SortBy: pb.UserSortField_USER_SORT_FIELD_EMAIL,
+				})
+				Expect(err).To(HaveOccurred())
+
+				st, ok := status.FromError(err)
+				Expect(ok).To(BeTrue())
+				Expect(st.Code()).To(Equal(codes.InvalidArgument))
+			})
+
+			It("should return InvalidArgument for malformed cursor", func() {
+				_, err := client.ListUsers(ctx, &pb.ListUsersRequest{Cursor: "not a cursor"})
+				Expect(err).To(HaveOccurred())
+
+				st, ok := status.FromError(err)
+				Expect(ok).To(BeTrue())
+				Expect(st.Code()).To(Equal(codes.InvalidArgument))
+			})
 		})
 
 		Describe("GetUserByUUID", func() {
@@ -186,6 +257,41 @@ var _ = Describe("Auth gRPC Integration Tests", func() {
 				Expect(resp).NotTo(BeNil())
 			})
 
+			It("should reject reuse of recent passwords", func() {
+				if createdUserUUID == "" {
+					Skip("Could not create test user")
+				}
+
+				expectReused := func(password string) {
+					_, err := client.UpdateUser(ctx, &pb.UpdateUserRequest{
+						Uuid:     createdUserUUID,
+						Password: &password,
+					})
+					Expect(err).To(HaveOccurred())
+
+					st, ok := status.FromError(err)
+					Expect(ok).To(BeTrue())
+					Expect(st.Code()).To(Equal(codes.InvalidArgument))
+					Expect(st.Details()).To(HaveLen(1))
+					badRequest, ok := st.Details()[0].(*errdetails.BadRequest)
+					Expect(ok).To(BeTrue())
+					Expect(badRequest.FieldViolations).To(HaveLen(1))
+					Expect(badRequest.FieldViolations[0].Field).To(Equal("password"))
+					Expect(badRequest.FieldViolations[0].Reason).To(Equal("reused"))
+				}
+
+				expectReused("TestPass123!")
+
+				newPassword := "NewTestPass456!"
+				_, err := client.UpdateUser(ctx, &pb.UpdateUserRequest{
+					Uuid:     createdUserUUID,
+					Password: &newPassword,
+				})
+				Expect(err).NotTo(HaveOccurred())
+
+				expectReused("TestPass123!")
+			})
+
 			It("should return InvalidArgument for invalid UUID", func() {
 				newEmail := fmt.Sprintf("updated-%s@example.com", integration.GenerateRandomString("user"))
 				req := &pb.UpdateUserRequest{
@@ -222,7 +328,7 @@ var _ = Describe("Auth gRPC Integration Tests", func() {
 		})
 
 		Describe("DeleteUser", func() {
-			It("should delete an existing user", func() {
+			It("should schedule an existing user for deletion", func() {
 				// Create a user to delete
 				newEmail := fmt.Sprintf("delete-test-%s@example.com", integration.GenerateRandomString("user"))
 				createReq := &pb.CreateUserRequest{
@@ -244,16 +350,13 @@ var _ = Describe("Auth gRPC Integration Tests", func() {
 				Expect(err).NotTo(HaveOccurred())
 				Expect(resp).NotTo(BeNil())
 
-				// Verify deletion
+				// Verify user is scheduled for deletion
 				getReq := &pb.GetUserByUUIDRequest{
 					Uuid: createResp.User.Uuid,
 				}
-				_, err = client.GetUserByUUID(ctx, getReq)
-				Expect(err).To(HaveOccurred())
-
-				st, ok := status.FromError(err)
-				Expect(ok).To(BeTrue())
-				Expect(st.Code()).To(Equal(codes.NotFound))
+				getResp, err := client.GetUserByUUID(ctx, getReq)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(getResp.User.Status).To(Equal(pb.UserStatus_USER_STATUS_DELETION_SCHEDULED))
 			})
 
 			It("should return InvalidArgument for invalid UUID", func() {
@@ -282,6 +385,480 @@ var _ = Describe("Auth gRPC Integration Tests", func() {
 				Expect(st.Code()).To(Equal(codes.NotFound))
 			})
 		})
+
+		Describe("UnlockUser", func() {
+			It("should unlock an existing user", func() {
+				newEmail := fmt.Sprintf("unlock-test-%s@example.com", integration.GenerateRandomString("user"))
+				createResp, err := client.CreateUser(ctx, &pb.CreateUserRequest{
+					Email:    newEmail,
+					Password: "TestPass123!",
+				})
+				if err != nil {
+					Skip("Could not create test user")
+				}
+
+				resp, err := client.UnlockUser(ctx, &pb.UnlockUserRequest{
+					Uuid: createResp.User.Uuid,
+				})
+				Expect(err).NotTo(HaveOccurred())
+				Expect(resp).NotTo(BeNil())
+			})
+
+			It("should return NotFound for non-existent user", func() {
+				_, err := client.UnlockUser(ctx, &pb.UnlockUserRequest{
+					Uuid: "123e4567-e89b-12d3-a456-426614174000",
+				})
+				Expect(err).To(HaveOccurred())
+
+				st, ok := status.FromError(err)
+				Expect(ok).To(BeTrue())
+				Expect(st.Code()).To(Equal(codes.NotFound))
+			})
+		})
+
+		Describe("SuspendUser", func() {
+			It("should suspend and reinstate an existing user", func() {
+				newEmail := fmt.Sprintf("suspend-test-%s@example.com", integration.GenerateRandomString("user"))
+				createResp, err := client.CreateUser(ctx, &pb.CreateUserRequest{
+					Email:    newEmail,
+					Password: "TestPass123!",
+				})
+				if err != nil {
+					Skip("Could not create test user")
+				}
+
+				_, err = client.SuspendUser(ctx, &pb.SuspendUserRequest{
+					Uuid:   createResp.User.Uuid,
+					Reason: "integration test",
+				})
+				Expect(err).NotTo(HaveOccurred())
+
+				userResp, err := client.GetUserByUUID(ctx, &pb.GetUserByUUIDRequest{Uuid: createResp.User.Uuid})
+				Expect(err).NotTo(HaveOccurred())
+				Expect(userResp.User.Status).To(Equal(pb.UserStatus_USER_STATUS_SUSPENDED))
+				Expect(userResp.User.GetStatusReason()).To(Equal("integration test"))
+
+				_, err = client.ReinstateUser(ctx, &pb.ReinstateUserRequest{Uuid: createResp.User.Uuid})
+				Expect(err).NotTo(HaveOccurred())
+
+				_, err = client.ReinstateUser(ctx, &pb.ReinstateUserRequest{Uuid: createResp.User.Uuid})
+				Expect(err).To(HaveOccurred())
+
+				st, ok := status.FromError(err)
+				Expect(ok).To(BeTrue())
+				Expect(st.Code()).To(Equal(codes.FailedPrecondition))
+			})
+		})
+
+		Describe("ListUserHistory", func() {
+			It("should list filtered history with request metadata", func() {
+				newEmail := fmt.Sprintf("history-test-%s@example.com", integration.GenerateRandomString("user"))
+				createResp, err := client.CreateUser(ctx, &pb.CreateUserRequest{
+					Email:    newEmail,
+					Password: "TestPass123!",
+				})
+				if err != nil {
+					Skip("Could not create test user")
+				}
+
+				_, err = client.SuspendUser(ctx, &pb.SuspendUserRequest{
+					Uuid:   createResp.User.Uuid,
+					Reason: "integration test",
+				})
+				Expect(err).NotTo(HaveOccurred())
+
+				// history is written asynchronously through outbox
+				var records []*pb.UserHistoryRecord
+				Eventually(func() []*pb.UserHistoryRecord {
+					resp, err := client.ListUserHistory(ctx, &pb.ListUserHistoryRequest{
+						Uuid:       createResp.User.Uuid,
+						EventTypes: []string{"user.suspend"},
+					})
+					Expect(err).NotTo(HaveOccurred())
+					records = resp.Records
+					return records
+				}, 15*time.Second, 250*time.Millisecond).Should(HaveLen(1))
+
+				Expect(records[0].EventType).To(Equal("user.suspend"))
+				Expect(records[0].GetData()).To(ContainSubstring("integration test"))
+				Expect(records[0].GetMetadata()).To(ContainSubstring("request_id"))
+			})
+
+			It("should return InvalidArgument for empty time range", func() {
+				now := timestamppb.Now()
+				_, err := client.ListUserHistory(ctx, &pb.ListUserHistoryRequest{
+					Uuid: "00000000-0000-0000-0000-000000000001",
+					From: now,
+					To:   now,
+				})
+				Expect(err).To(HaveOccurred())
+
+				st, ok := status.FromError(err)
+				Expect(ok).To(BeTrue())
+				Expect(st.Code()).To(Equal(codes.InvalidArgument))
+			})
+		})
+
+		Describe("RestoreUser", func() {
+			It("should restore user scheduled for deletion", func() {
+				newEmail := fmt.Sprintf("restore-test-%s@example.com", integration.GenerateRandomString("user"))
+				createResp, err := client.CreateUser(ctx, &pb.CreateUserRequest{
+					Email:    newEmail,
+					Password: "TestPass123!",
+				})
+				if err != nil {
+					Skip("Could not create test user")
+				}
+
+				_, err = client.DeleteUser(ctx, &pb.DeleteUserRequest{Uuid: createResp.User.Uuid})
+				Expect(err).NotTo(HaveOccurred())
+
+				userResp, err := client.GetUserByUUID(ctx, &pb.GetUserByUUIDRequest{Uuid: createResp.User.Uuid})
+				Expect(err).NotTo(HaveOccurred())
+				Expect(userResp.User.Status).To(Equal(pb.UserStatus_USER_STATUS_DELETION_SCHEDULED))
+				Expect(userResp.User.StatusUntil).NotTo(BeNil())
+
+				_, err = client.RestoreUser(ctx, &pb.RestoreUserRequest{Uuid: createResp.User.Uuid})
+				Expect(err).NotTo(HaveOccurred())
+
+				userResp, err = client.GetUserByUUID(ctx, &pb.GetUserByUUIDRequest{Uuid: createResp.User.Uuid})
+				Expect(err).NotTo(HaveOccurred())
+				Expect(userResp.User.Status).To(Equal(pb.UserStatus_USER_STATUS_ACTIVE))
+			})
+
+			It("should return FailedPrecondition for user not scheduled for deletion", func() {
+				newEmail := fmt.Sprintf("restore-test-%s@example.com", integration.GenerateRandomString("user"))
+				createResp, err := client.CreateUser(ctx, &pb.CreateUserRequest{
+					Email:    newEmail,
+					Password: "TestPass123!",
+				})
+				if err != nil {
+					Skip("Could not create test user")
+				}
+
+				_, err = client.RestoreUser(ctx, &pb.RestoreUserRequest{Uuid: createResp.User.Uuid})
+				Expect(err).To(HaveOccurred())
+
+				st, ok := status.FromError(err)
+				Expect(ok).To(BeTrue())
+				Expect(st.Code()).To(Equal(codes.FailedPrecondition))
+			})
+		})
+
+		Describe("ResetUserMFA", func() {
+			It("should return FailedPrecondition for user without mfa", func() {
+				newEmail := fmt.Sprintf("mfa-test-%s@example.com", integration.GenerateRandomString("user"))
+				createResp, err := client.CreateUser(ctx, &pb.CreateUserRequest{
+					Email:    newEmail,
+					Password: "TestPass123!",
+				})
+				if err != nil {
+					Skip("Could not create test user")
+				}
+
+				_, err = client.ResetUserMFA(ctx, &pb.ResetUserMFARequest{
+					Uuid: createResp.User.Uuid,
+				})
+				Expect(err).To(HaveOccurred())
+
+				st, ok := status.FromError(err)
+				Expect(ok).To(BeTrue())
+				Expect(st.Code()).To(Equal(codes.FailedPrecondition))
+			})
+
+			It("should return NotFound for non-existent user", func() {
+				_, err := client.ResetUserMFA(ctx, &pb.ResetUserMFARequest{
+					Uuid: "123e4567-e89b-12d3-a456-426614174000",
+				})
+				Expect(err).To(HaveOccurred())
+
+				st, ok := status.FromError(err)
+				Expect(ok).To(BeTrue())
+				Expect(st.Code()).To(Equal(codes.NotFound))
+			})
+		})
+
+		Describe("RevokeUserSessions", func() {
+			It("should revoke sessions of an existing user", func() {
+				newEmail := fmt.Sprintf("sessions-test-%s@example.com", integration.GenerateRandomString("user"))
+				createResp, err := client.CreateUser(ctx, &pb.CreateUserRequest{
+					Email:    newEmail,
+					Password: "TestPass123!",
+				})
+				if err != nil {
+					Skip("Could not create test user")
+				}
+
+				resp, err := client.RevokeUserSessions(ctx, &pb.RevokeUserSessionsRequest{
+					Uuid: createResp.User.Uuid,
+				})
+				Expect(err).NotTo(HaveOccurred())
+				Expect(resp).NotTo(BeNil())
+			})
+
+			It("should return NotFound for non-existent user", func() {
+				req := &pb.RevokeUserSessionsRequest{
+					Uuid: "123e4567-e89b-12d3-a456-426614174000",
+				}
+
+				_, err := client.RevokeUserSessions(ctx, req)
+				Expect(err).To(HaveOccurred())
+
+				st, ok := status.FromError(err)
+				Expect(ok).To(BeTrue())
+				Expect(st.Code()).To(Equal(codes.NotFound))
+			})
+		})
+
+		Describe("API tokens", func() {
+			It("should create, list and revoke token of an existing user", func() {
+				newEmail := fmt.Sprintf("tokens-test-%s@example.com", integration.GenerateRandomString("user"))
+				createResp, err := client.CreateUser(ctx, &pb.CreateUserRequest{
+					Email:    newEmail,
+					Password: "TestPass123!",
+				})
+				if err != nil {
+					Skip("Could not create test user")
+				}
+
+				tokenResp, err := client.CreateAPIToken(ctx, &pb.CreateAPITokenRequest{
+					UserUuid:    createResp.User.Uuid,
+					Name:        "ci",
+					Permissions: []string{"users:read_self"},
+				})
+				Expect(err).NotTo(HaveOccurred())
+				Expect(tokenResp.PlainToken).NotTo(BeEmpty())
+				Expect(tokenResp.Token.Permissions).To(ConsistOf("users:read_self"))
+
+				listResp, err := client.ListAPITokens(ctx, &pb.ListAPITokensRequest{
+					UserUuid: createResp.User.Uuid,
+				})
+				Expect(err).NotTo(HaveOccurred())
+				Expect(listResp.Tokens).To(HaveLen(1))
+				Expect(listResp.Tokens[0].Uuid).To(Equal(tokenResp.Token.Uuid))
+
+				_, err = client.RevokeAPIToken(ctx, &pb.RevokeAPITokenRequest{
+					UserUuid: createResp.User.Uuid,
+					Uuid:     tokenResp.Token.Uuid,
+				})
+				Expect(err).NotTo(HaveOccurred())
+
+				listResp, err = client.ListAPITokens(ctx, &pb.ListAPITokensRequest{
+					UserUuid: createResp.User.Uuid,
+				})
+				Expect(err).NotTo(HaveOccurred())
+				Expect(listResp.Tokens).To(BeEmpty())
+			})
+
+			It("should return PermissionDenied for permissions user does not have", func() {
+				newEmail := fmt.Sprintf("tokens-test-%s@example.com", integration.GenerateRandomString("user"))
+				createResp, err := client.CreateUser(ctx, &pb.CreateUserRequest{
+					Email:    newEmail,
+					Password: "TestPass123!",
+				})
+				if err != nil {
+					Skip("Could not create test user")
+				}
+
+				_, err = client.CreateAPIToken(ctx, &pb.CreateAPITokenRequest{
+					UserUuid:    createResp.User.Uuid,
+					Name:        "ci",
+					Permissions: []string{"users:delete"},
+				})
+				Expect(err).To(HaveOccurred())
+
+				st, ok := status.FromError(err)
+				Expect(ok).To(BeTrue())
+				Expect(st.Code()).To(Equal(codes.PermissionDenied))
+			})
+		})
+
+		Describe("Roles", func() {
+			It("should manage role and its assignment", func() {
+				roleName := "role-" + integration.GenerateRandomString("role")
+				roleResp, err := client.CreateRole(ctx, &pb.CreateRoleRequest{
+					Name:        roleName,
+					Description: "integration test role",
+					Permissions: []string{"users:list"},
+				})
+				Expect(err).NotTo(HaveOccurred())
+				Expect(roleResp.Role.Name).To(Equal(roleName))
+				Expect(roleResp.Role.Permissions).To(ConsistOf("users:list"))
+
+				_, err = client.CreateRole(ctx, &pb.CreateRoleRequest{Name: roleName})
+				st, ok := status.FromError(err)
+				Expect(ok).To(BeTrue())
+				Expect(st.Code()).To(Equal(codes.AlreadyExists))
+
+				newEmail := fmt.Sprintf("roles-test-%s@example.com", integration.GenerateRandomString("user"))
+				createResp, err := client.CreateUser(ctx, &pb.CreateUserRequest{
+					Email:    newEmail,
+					Password: "TestPass123!",
+				})
+				if err != nil {
+					Skip("Could not create test user")
+				}
+
+				_, err = client.GrantRoleToUser(ctx, &pb.GrantRoleToUserRequest{
+					UserUuid: createResp.User.Uuid,
+					Role:     roleName,
+				})
+				Expect(err).NotTo(HaveOccurred())
+
+				_, err = client.AttachPermissionToRole(ctx, &pb.AttachPermissionToRoleRequest{
+					Name:       roleName,
+					Permission: "users:read_others",
+				})
+				Expect(err).NotTo(HaveOccurred())
+
+				userResp, err := client.GetUserByUUID(ctx, &pb.GetUserByUUIDRequest{Uuid: createResp.User.Uuid})
+				Expect(err).NotTo(HaveOccurred())
+				Expect(userResp.User.Roles).To(ContainElement(roleName))
+				Expect(userResp.User.Permissions).To(ContainElements("users:list", "users:read_others"))
+
+				_, err = client.DetachPermissionFromRole(ctx, &pb.DetachPermissionFromRoleRequest{
+					Name:       roleName,
+					Permission: "users:list",
+				})
+				Expect(err).NotTo(HaveOccurred())
+
+				listResp, err := client.ListRoles(ctx, &pb.ListRolesRequest{})
+				Expect(err).NotTo(HaveOccurred())
+				var found *pb.Role
+				for _, role := range listResp.Roles {
+					if role.Name == roleName {
+						found = role
+					}
+				}
+				Expect(found).NotTo(BeNil())
+				Expect(found.Permissions).To(ConsistOf("users:read_others"))
+
+				_, err = client.RevokeRoleFromUser(ctx, &pb.RevokeRoleFromUserRequest{
+					UserUuid: createResp.User.Uuid,
+					Role:     roleName,
+				})
+				Expect(err).NotTo(HaveOccurred())
+
+				userResp, err = client.GetUserByUUID(ctx, &pb.GetUserByUUIDRequest{Uuid: createResp.User.Uuid})
+				Expect(err).NotTo(HaveOccurred())
+				Expect(userResp.User.Roles).NotTo(ContainElement(roleName))
+
+				_, err = client.DeleteRole(ctx, &pb.DeleteRoleRequest{Name: roleName})
+				Expect(err).NotTo(HaveOccurred())
+			})
+
+			It("should not delete built-in role", func() {
+				_, err := client.DeleteRole(ctx, &pb.DeleteRoleRequest{Name: "user"})
+				Expect(err).To(HaveOccurred())
+
+				st, ok := status.FromError(err)
+				Expect(ok).To(BeTrue())
+				Expect(st.Code()).To(Equal(codes.FailedPrecondition))
+			})
+
+			It("should return NotFound for unknown permission", func() {
+				_, err := client.CreateRole(ctx, &pb.CreateRoleRequest{
+					Name:        "role-" + integration.GenerateRandomString("role"),
+					Permissions: []string{"unknown:permission"},
+				})
+				Expect(err).To(HaveOccurred())
+
+				st, ok := status.FromError(err)
+				Expect(ok).To(BeTrue())
+				Expect(st.Code()).To(Equal(codes.NotFound))
+			})
+		})
+
+		Describe("OAuth clients", func() {
+			It("should create, list and delete client", func() {
+				newEmail := fmt.Sprintf("oauth-test-%s@example.com", integration.GenerateRandomString("user"))
+				createResp, err := client.CreateUser(ctx, &pb.CreateUserRequest{
+					Email:    newEmail,
+					Password: "TestPass123!",
+				})
+				if err != nil {
+					Skip("Could not create test user")
+				}
+
+				clientResp, err := client.CreateOAuthClient(ctx, &pb.CreateOAuthClientRequest{
+					UserUuid:     createResp.User.Uuid,
+					Name:         "spa",
+					RedirectUris: []string{"https://example.com/callback"},
+					GrantTypes:   []string{"authorization_code"},
+					Public:       true,
+				})
+				Expect(err).NotTo(HaveOccurred())
+				Expect(clientResp.ClientSecret).To(BeEmpty())
+				Expect(clientResp.Client.Public).To(BeTrue())
+				Expect(clientResp.Client.RedirectUris).To(ConsistOf("https://example.com/callback"))
+
+				listResp, err := client.ListOAuthClients(ctx, &pb.ListOAuthClientsRequest{})
+				Expect(err).NotTo(HaveOccurred())
+				clientIDs := make([]string, 0, len(listResp.Clients))
+				for _, c := range listResp.Clients {
+					clientIDs = append(clientIDs, c.ClientId)
+				}
+				Expect(clientIDs).To(ContainElement(clientResp.Client.ClientId))
+
+				_, err = client.DeleteOAuthClient(ctx, &pb.DeleteOAuthClientRequest{
+					ClientId: clientResp.Client.ClientId,
+				})
+				Expect(err).NotTo(HaveOccurred())
+
+				_, err = client.DeleteOAuthClient(ctx, &pb.DeleteOAuthClientRequest{
+					ClientId: clientResp.Client.ClientId,
+				})
+				st, ok := status.FromError(err)
+				Expect(ok).To(BeTrue())
+				Expect(st.Code()).To(Equal(codes.NotFound))
+			})
+
+			It("should return InvalidArgument for insecure redirect uri", func() {
+				newEmail := fmt.Sprintf("oauth-test-%s@example.com", integration.GenerateRandomString("user"))
+				createResp, err := client.CreateUser(ctx, &pb.CreateUserRequest{
+					Email:    newEmail,
+					Password: "TestPass123!",
+				})
+				if err != nil {
+					Skip("Could not create test user")
+				}
+
+				_, err = client.CreateOAuthClient(ctx, &pb.CreateOAuthClientRequest{
+					UserUuid:     createResp.User.Uuid,
+					Name:         "web",
+					RedirectUris: []string{"http://example.com/callback"},
+					GrantTypes:   []string{"authorization_code"},
+				})
+				Expect(err).To(HaveOccurred())
+
+				st, ok := status.FromError(err)
+				Expect(ok).To(BeTrue())
+				Expect(st.Code()).To(Equal(codes.InvalidArgument))
+			})
+
+			It("should return PermissionDenied for permissions owner does not have", func() {
+				newEmail := fmt.Sprintf("oauth-test-%s@example.com", integration.GenerateRandomString("user"))
+				createResp, err := client.CreateUser(ctx, &pb.CreateUserRequest{
+					Email:    newEmail,
+					Password: "TestPass123!",
+				})
+				if err != nil {
+					Skip("Could not create test user")
+				}
+
+				_, err = client.CreateOAuthClient(ctx, &pb.CreateOAuthClientRequest{
+					UserUuid:    createResp.User.Uuid,
+					Name:        "service",
+					GrantTypes:  []string{"client_credentials"},
+					Permissions: []string{"users:delete"},
+				})
+				Expect(err).To(HaveOccurred())
+
+				st, ok := status.FromError(err)
+				Expect(ok).To(BeTrue())
+				Expect(st.Code()).To(Equal(codes.PermissionDenied))
+			})
+		})
 	})
 })
 
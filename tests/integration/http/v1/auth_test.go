@@ -2,13 +2,28 @@
 package test
 
 import (
+	"archive/zip"
 	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha1"
+	"crypto/sha256"
+	"encoding/base32"
+	"encoding/base64"
+	"encoding/binary"
 	"encoding/json"
 	"fmt"
+	"io"
 	"net/http"
+	"net/url"
+	"strings"
 	"testing"
 	"time"
 
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+
+	pb "github.com/hasansino/go42/api/gen/sdk/grpc/auth/v1"
 	"github.com/hasansino/go42/tests/integration"
 
 	. "github.com/onsi/ginkgo/v2"
@@ -49,12 +64,43 @@ type UpdateUserRequest struct {
 	Password string `json:"password,omitempty"`
 }
 
-type User struct {
-	UUID        string   `json:"uuid"`
-	Email       string   `json:"email"`
-	CreatedAt   string   `json:"created_at"`
-	Roles       []string `json:"roles"`
+type Session struct {
+	ID         string `json:"id"`
+	UserAgent  string `json:"user_agent"`
+	IPAddress  string `json:"ip_address"`
+	CreatedAt  string `json:"created_at"`
+	LastSeenAt string `json:"last_seen_at"`
+	ExpiresAt  string `json:"expires_at"`
+}
+
+type CreateAPITokenRequest struct {
+	Name        string     `json:"name"`
+	Permissions []string   `json:"permissions"`
+	ExpiresAt   *time.Time `json:"expires_at,omitempty"`
+}
+
+type APIToken struct {
+	ID          string   `json:"id"`
+	Name        string   `json:"name"`
 	Permissions []string `json:"permissions"`
+	CreatedAt   string   `json:"created_at"`
+	LastUsedAt  *string  `json:"last_used_at"`
+	ExpiresAt   *string  `json:"expires_at"`
+	Token       string   `json:"token"`
+}
+
+type User struct {
+	UUID          string   `json:"uuid"`
+	Email         string   `json:"email"`
+	EmailVerified bool     `json:"email_verified"`
+	CreatedAt     string   `json:"created_at"`
+	Roles         []string `json:"roles"`
+	Permissions   []string `json:"permissions"`
+}
+
+type UserList struct {
+	Users      []User  `json:"users"`
+	NextCursor *string `json:"next_cursor"`
 }
 
 type Tokens struct {
@@ -63,6 +109,126 @@ type Tokens struct {
 	ExpiresIn    int    `json:"expires_in"`
 }
 
+type MFAChallenge struct {
+	ChallengeToken string `json:"challenge_token"`
+	ExpiresIn      int    `json:"expires_in"`
+}
+
+type VerifyMFARequest struct {
+	ChallengeToken string `json:"challenge_token"`
+	Code           string `json:"code"`
+}
+
+type TokenRequest struct {
+	Token string `json:"token"`
+}
+
+type EmailRequest struct {
+	Email string `json:"email"`
+}
+
+type ConfirmPasswordResetRequest struct {
+	Token    string `json:"token"`
+	Password string `json:"password"`
+}
+
+type MFACodeRequest struct {
+	Code string `json:"code"`
+}
+
+type MFAStatus struct {
+	Enabled           bool    `json:"enabled"`
+	EnabledAt         *string `json:"enabled_at"`
+	RecoveryCodesLeft int     `json:"recovery_codes_left"`
+}
+
+type MFAEnrollment struct {
+	Secret          string `json:"secret"`
+	ProvisioningURI string `json:"provisioning_uri"`
+}
+
+type MFARecoveryCodes struct {
+	RecoveryCodes []string `json:"recovery_codes"`
+}
+
+type AuthorizeRequest struct {
+	ResponseType        string `json:"response_type"`
+	ClientID            string `json:"client_id"`
+	RedirectURI         string `json:"redirect_uri"`
+	Scope               string `json:"scope"`
+	State               string `json:"state"`
+	Nonce               string `json:"nonce"`
+	CodeChallenge       string `json:"code_challenge"`
+	CodeChallengeMethod string `json:"code_challenge_method"`
+}
+
+type AuthorizeResponse struct {
+	RedirectTo string `json:"redirect_to"`
+}
+
+type OAuthTokens struct {
+	AccessToken  string `json:"access_token"`
+	TokenType    string `json:"token_type"`
+	ExpiresIn    int    `json:"expires_in"`
+	RefreshToken string `json:"refresh_token"`
+	IDToken      string `json:"id_token"`
+	Scope        string `json:"scope"`
+}
+
+type OAuthError struct {
+	Error            string `json:"error"`
+	ErrorDescription string `json:"error_description"`
+}
+
+type UserInfo struct {
+	Subject       string `json:"sub"`
+	Email         string `json:"email"`
+	EmailVerified bool   `json:"email_verified"`
+}
+
+type SwitchTenantRequest struct {
+	Token            string `json:"token"`
+	OrganizationUUID string `json:"organization_uuid"`
+}
+
+type Organization struct {
+	UUID string `json:"uuid"`
+	Name string `json:"name"`
+	Slug string `json:"slug"`
+}
+
+type HistoryRecord struct {
+	ID        string            `json:"id"`
+	EventType string            `json:"event_type"`
+	Metadata  map[string]string `json:"metadata"`
+}
+
+type DataRequestRequest struct {
+	Type string `json:"type"`
+}
+
+type DataRequest struct {
+	ID        string  `json:"id"`
+	Type      string  `json:"type"`
+	Status    string  `json:"status"`
+	Error     *string `json:"error"`
+	ExpiresAt *string `json:"expires_at"`
+}
+
+// totpCode generates RFC 6238 code with default parameters.
+func totpCode(secret string, t time.Time) string {
+	key, err := base32.StdEncoding.WithPadding(base32.NoPadding).DecodeString(secret)
+	Expect(err).ToNot(HaveOccurred())
+	var msg [8]byte
+	binary.BigEndian.PutUint64(msg[:], uint64(t.Unix()/30))
+	mac := hmac.New(sha1.New, key)
+	mac.Write(msg[:])
+	sum := mac.Sum(nil)
+	offset := sum[len(sum)-1] & 0x0f
+	value := binary.BigEndian.Uint32(sum[offset:offset+4]) & 0x7fffffff
+	return fmt.Sprintf("%06d", value%1000000)
+}
+
 var _ = Describe("Auth API Integration Tests", func() {
 	var client *http.Client
 
@@ -173,6 +339,37 @@ var _ = Describe("Auth API Integration Tests", func() {
 
 				Expect(resp.StatusCode).To(Equal(http.StatusBadRequest))
 			})
+
+			It("should report reason of rejected password", func() {
+				reqBody := SignupRequest{
+					Email:    testEmail,
+					Password: "aaaaaaaaaaaa",
+				}
+				bodyBytes, err := json.Marshal(reqBody)
+				Expect(err).ToNot(HaveOccurred())
+
+				resp, err := client.Post(
+					integration.HTTPServerAddress()+"/api/v1/auth/signup",
+					"application/json",
+					bytes.NewReader(bodyBytes),
+				)
+				Expect(err).ToNot(HaveOccurred())
+				defer resp.Body.Close()
+
+				Expect(resp.StatusCode).To(Equal(http.StatusBadRequest))
+
+				var body struct {
+					Errors []struct {
+						Pointer string `json:"pointer"`
+						Code    string `json:"code"`
+					} `json:"errors"`
+				}
+				err = json.NewDecoder(resp.Body).Decode(&body)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(body.Errors).To(HaveLen(1))
+				Expect(body.Errors[0].Pointer).To(Equal("/password"))
+				Expect(body.Errors[0].Code).To(Equal("low_entropy"))
+			})
 		})
 
 		Describe("POST /auth/login", func() {
@@ -243,6 +440,42 @@ var _ = Describe("Auth API Integration Tests", func() {
 				Expect(resp.StatusCode).To(Equal(http.StatusBadRequest))
 			})
 
+			It("should throttle repeated failed logins", func() {
+				wrongBytes, err := json.Marshal(LoginRequest{
+					Email:    testEmail,
+					Password: "WrongPass123!",
+				})
+				Expect(err).ToNot(HaveOccurred())
+
+				for i := 0; i < 5; i++ {
+					resp, err := client.Post(
+						integration.HTTPServerAddress()+"/api/v1/auth/login",
+						"application/json",
+						bytes.NewReader(wrongBytes),
+					)
+					Expect(err).ToNot(HaveOccurred())
+					resp.Body.Close()
+					Expect(resp.StatusCode).To(Equal(http.StatusBadRequest))
+				}
+
+				loginBytes, err := json.Marshal(LoginRequest{
+					Email:    testEmail,
+					Password: testPassword,
+				})
+				Expect(err).ToNot(HaveOccurred())
+
+				resp, err := client.Post(
+					integration.HTTPServerAddress()+"/api/v1/auth/login",
+					"application/json",
+					bytes.NewReader(loginBytes),
+				)
+				Expect(err).ToNot(HaveOccurred())
+				defer resp.Body.Close()
+
+				// lockout is tracked in cache, without cache engine login is not throttled
+				Expect(resp.StatusCode).To(BeElementOf(http.StatusTooManyRequests, http.StatusOK))
+			})
+
 			It("should return 400 for non-existent user", func() {
 				reqBody := LoginRequest{
 					Email:    "nonexistent@example.com",
@@ -346,6 +579,39 @@ var _ = Describe("Auth API Integration Tests", func() {
 
 				Expect(resp.StatusCode).To(Equal(http.StatusUnauthorized))
 			})
+
+			It("should revoke token family when consumed refresh token is reused", func() {
+				refresh := func(token string) (*http.Response, error) {
+					bodyBytes, err := json.Marshal(RefreshTokenRequest{Token: token})
+					Expect(err).ToNot(HaveOccurred())
+					return client.Post(
+						integration.HTTPServerAddress()+"/api/v1/auth/refresh",
+						"application/json",
+						bytes.NewReader(bodyBytes),
+					)
+				}
+
+				resp, err := refresh(refreshToken)
+				Expect(err).ToNot(HaveOccurred())
+				defer resp.Body.Close()
+				Expect(resp.StatusCode).To(Equal(http.StatusOK))
+
+				var tokens Tokens
+				err = json.NewDecoder(resp.Body).Decode(&tokens)
+				Expect(err).ToNot(HaveOccurred())
+
+				// replay of consumed token
+				resp2, err := refresh(refreshToken)
+				Expect(err).ToNot(HaveOccurred())
+				defer resp2.Body.Close()
+				Expect(resp2.StatusCode).To(Equal(http.StatusUnauthorized))
+
+				// whole family is revoked, including latest token
+				resp3, err := refresh(tokens.RefreshToken)
+				Expect(err).ToNot(HaveOccurred())
+				defer resp3.Body.Close()
+				Expect(resp3.StatusCode).To(Equal(http.StatusUnauthorized))
+			})
 		})
 
 		Describe("POST /auth/logout", func() {
@@ -412,6 +678,142 @@ var _ = Describe("Auth API Integration Tests", func() {
 			})
 		})
 
+		Describe("Email verification and password reset", func() {
+			var signedUpUser User
+			var validAccessToken string
+
+			post := func(path string, body any) *http.Response {
+				bodyBytes, err := json.Marshal(body)
+				Expect(err).ToNot(HaveOccurred())
+				resp, err := client.Post(
+					integration.HTTPServerAddress()+"/api/v1"+path,
+					"application/json",
+					bytes.NewReader(bodyBytes),
+				)
+				Expect(err).ToNot(HaveOccurred())
+				return resp
+			}
+
+			BeforeEach(func() {
+				resp := post("/auth/signup", SignupRequest{
+					Email:    testEmail,
+					Password: testPassword,
+				})
+				defer resp.Body.Close()
+				Expect(resp.StatusCode).To(Equal(http.StatusCreated))
+				err := json.NewDecoder(resp.Body).Decode(&signedUpUser)
+				Expect(err).ToNot(HaveOccurred())
+
+				loginResp := post("/auth/login", LoginRequest{
+					Email:    testEmail,
+					Password: testPassword,
+				})
+				defer loginResp.Body.Close()
+				Expect(loginResp.StatusCode).To(Equal(http.StatusOK))
+				var tokens Tokens
+				err = json.NewDecoder(loginResp.Body).Decode(&tokens)
+				Expect(err).ToNot(HaveOccurred())
+				validAccessToken = tokens.AccessToken
+			})
+
+			It("should create user with unverified email", func() {
+				Expect(signedUpUser.EmailVerified).To(BeFalse())
+			})
+
+			It("should reject invalid verification token", func() {
+				resp := post("/auth/verify", TokenRequest{Token: "invalid.token.here"})
+				defer resp.Body.Close()
+				Expect(resp.StatusCode).To(Equal(http.StatusUnauthorized))
+			})
+
+			It("should not accept access token as verification token", func() {
+				resp := post("/auth/verify", TokenRequest{Token: validAccessToken})
+				defer resp.Body.Close()
+				Expect(resp.StatusCode).To(Equal(http.StatusUnauthorized))
+			})
+
+			It("should fail verification without token", func() {
+				resp := post("/auth/verify", TokenRequest{})
+				defer resp.Body.Close()
+				Expect(resp.StatusCode).To(Equal(http.StatusBadRequest))
+			})
+
+			It("should accept verification resend for registered and unknown emails", func() {
+				resp := post("/auth/verify/resend", EmailRequest{Email: testEmail})
+				defer resp.Body.Close()
+				Expect(resp.StatusCode).To(Equal(http.StatusAccepted))
+
+				unknownResp := post("/auth/verify/resend", EmailRequest{Email: "unknown-" + testEmail})
+				defer unknownResp.Body.Close()
+				Expect(unknownResp.StatusCode).To(Equal(http.StatusAccepted))
+			})
+
+			It("should accept password reset for registered and unknown emails", func() {
+				resp := post("/auth/password-reset", EmailRequest{Email: testEmail})
+				defer resp.Body.Close()
+				Expect(resp.StatusCode).To(Equal(http.StatusAccepted))
+
+				unknownResp := post("/auth/password-reset", EmailRequest{Email: "unknown-" + testEmail})
+				defer unknownResp.Body.Close()
+				Expect(unknownResp.StatusCode).To(Equal(http.StatusAccepted))
+			})
+
+			It("should fail password reset with invalid email", func() {
+				resp := post("/auth/password-reset", EmailRequest{Email: "invalid-email"})
+				defer resp.Body.Close()
+				Expect(resp.StatusCode).To(Equal(http.StatusBadRequest))
+			})
+
+			It("should not accept access token as password reset token", func() {
+				resp := post("/auth/password-reset/confirm", ConfirmPasswordResetRequest{
+					Token:    validAccessToken,
+					Password: "NewTestPass456!",
+				})
+				defer resp.Body.Close()
+				Expect(resp.StatusCode).To(Equal(http.StatusUnauthorized))
+
+				// password is unchanged
+				loginResp := post("/auth/login", LoginRequest{
+					Email:    testEmail,
+					Password: testPassword,
+				})
+				defer loginResp.Body.Close()
+				Expect(loginResp.StatusCode).To(Equal(http.StatusOK))
+			})
+		})
+
+		Describe("External identity providers", func() {
+			It("should list configured providers", func() {
+				resp, err := client.Get(integration.HTTPServerAddress() + "/api/v1/auth/sso")
+				Expect(err).ToNot(HaveOccurred())
+				defer resp.Body.Close()
+				Expect(resp.StatusCode).To(Equal(http.StatusOK))
+
+				var body struct {
+					Providers []string `json:"providers"`
+				}
+				err = json.NewDecoder(resp.Body).Decode(&body)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(body.Providers).ToNot(BeNil())
+			})
+
+			It("should return 404 for unknown provider", func() {
+				resp, err := client.Get(integration.HTTPServerAddress() + "/api/v1/auth/sso/unknown/start")
+				Expect(err).ToNot(HaveOccurred())
+				defer resp.Body.Close()
+				Expect(resp.StatusCode).To(Equal(http.StatusNotFound))
+			})
+
+			It("should reject callback without verifier", func() {
+				resp, err := client.Get(
+					integration.HTTPServerAddress() + "/api/v1/auth/sso/unknown/callback?code=code&state=state",
+				)
+				Expect(err).ToNot(HaveOccurred())
+				defer resp.Body.Close()
+				Expect(resp.StatusCode).To(Equal(http.StatusUnauthorized))
+			})
+		})
+
 		Describe("User Management Endpoints", func() {
 			var adminAccessToken string
 			var createdUserUUID string
@@ -495,85 +897,555 @@ var _ = Describe("Auth API Integration Tests", func() {
 				})
 			})
 
-			Describe("PUT /users/me", func() {
-				It("should update current user email", func() {
-					newEmail := fmt.Sprintf("updated-%s@example.com", integration.GenerateRandomString("email"))
-					reqBody := UpdateSelfRequest{
-						Email: newEmail,
-					}
-					bodyBytes, err := json.Marshal(reqBody)
-					Expect(err).ToNot(HaveOccurred())
-
+			Describe("GET /users/me/sessions", func() {
+				It("should list current session", func() {
 					req, err := http.NewRequest(
-						http.MethodPut,
-						integration.HTTPServerAddress()+"/api/v1/users/me",
-						bytes.NewReader(bodyBytes),
+						http.MethodGet,
+						integration.HTTPServerAddress()+"/api/v1/users/me/sessions",
+						nil,
 					)
 					Expect(err).ToNot(HaveOccurred())
 					req.Header.Set("Authorization", "Bearer "+adminAccessToken)
-					req.Header.Set("Content-Type", "application/json")
 
 					resp, err := client.Do(req)
 					Expect(err).ToNot(HaveOccurred())
 					defer resp.Body.Close()
 
 					Expect(resp.StatusCode).To(Equal(http.StatusOK))
+
+					var sessions []Session
+					err = json.NewDecoder(resp.Body).Decode(&sessions)
+					Expect(err).ToNot(HaveOccurred())
+					Expect(sessions).To(HaveLen(1))
+					Expect(sessions[0].ID).ToNot(BeEmpty())
+					Expect(sessions[0].UserAgent).ToNot(BeEmpty())
 				})
+			})
 
-				It("should update current user password", func() {
-					reqBody := UpdateSelfRequest{
-						Password: "NewPassword123!",
-					}
-					bodyBytes, err := json.Marshal(reqBody)
+			Describe("DELETE /users/me/sessions/{id}", func() {
+				It("should revoke session and its refresh token", func() {
+					loginBytes, err := json.Marshal(LoginRequest{
+						Email:    testEmail,
+						Password: testPassword,
+					})
 					Expect(err).ToNot(HaveOccurred())
 
-					req, err := http.NewRequest(
-						http.MethodPut,
-						integration.HTTPServerAddress()+"/api/v1/users/me",
-						bytes.NewReader(bodyBytes),
+					signupResp, err := client.Post(
+						integration.HTTPServerAddress()+"/api/v1/auth/signup",
+						"application/json",
+						bytes.NewReader(loginBytes),
 					)
 					Expect(err).ToNot(HaveOccurred())
-					req.Header.Set("Authorization", "Bearer "+adminAccessToken)
-					req.Header.Set("Content-Type", "application/json")
+					signupResp.Body.Close()
 
-					resp, err := client.Do(req)
+					loginResp, err := client.Post(
+						integration.HTTPServerAddress()+"/api/v1/auth/login",
+						"application/json",
+						bytes.NewReader(loginBytes),
+					)
 					Expect(err).ToNot(HaveOccurred())
-					defer resp.Body.Close()
+					defer loginResp.Body.Close()
+					Expect(loginResp.StatusCode).To(Equal(http.StatusOK))
 
-					Expect(resp.StatusCode).To(Equal(http.StatusOK))
-				})
-			})
+					var tokens Tokens
+					err = json.NewDecoder(loginResp.Body).Decode(&tokens)
+					Expect(err).ToNot(HaveOccurred())
 
-			Describe("GET /users", func() {
-				It("should list users", func() {
 					req, err := http.NewRequest(
 						http.MethodGet,
-						integration.HTTPServerAddress()+"/api/v1/users?limit=10&offset=0",
+						integration.HTTPServerAddress()+"/api/v1/users/me/sessions",
 						nil,
 					)
 					Expect(err).ToNot(HaveOccurred())
-					req.Header.Set("Authorization", "Bearer "+adminAccessToken)
+					req.Header.Set("Authorization", "Bearer "+tokens.AccessToken)
 
 					resp, err := client.Do(req)
 					Expect(err).ToNot(HaveOccurred())
 					defer resp.Body.Close()
+					Expect(resp.StatusCode).To(Equal(http.StatusOK))
 
-					// The endpoint might not exist (404) or require permissions (403)
-					if resp.StatusCode == http.StatusOK {
-						var users []User
-						err = json.NewDecoder(resp.Body).Decode(&users)
-						Expect(err).ToNot(HaveOccurred())
-						Expect(len(users)).To(BeNumerically(">=", 0))
-					} else {
-						Expect(resp.StatusCode).To(BeElementOf(http.StatusForbidden, http.StatusNotFound))
-					}
-				})
-			})
+					var sessions []Session
+					err = json.NewDecoder(resp.Body).Decode(&sessions)
+					Expect(err).ToNot(HaveOccurred())
+					Expect(sessions).To(HaveLen(1))
 
-			Describe("POST /users", func() {
-				It("should create a new user", func() {
-					newUserEmail := fmt.Sprintf("newuser-%s@example.com", integration.GenerateRandomString("user"))
-					reqBody := CreateUserRequest{
+					req, err = http.NewRequest(
+						http.MethodDelete,
+						integration.HTTPServerAddress()+"/api/v1/users/me/sessions/"+sessions[0].ID,
+						nil,
+					)
+					Expect(err).ToNot(HaveOccurred())
+					req.Header.Set("Authorization", "Bearer "+tokens.AccessToken)
+
+					resp2, err := client.Do(req)
+					Expect(err).ToNot(HaveOccurred())
+					defer resp2.Body.Close()
+					Expect(resp2.StatusCode).To(Equal(http.StatusOK))
+
+					refreshBytes, err := json.Marshal(RefreshTokenRequest{Token: tokens.RefreshToken})
+					Expect(err).ToNot(HaveOccurred())
+
+					resp3, err := client.Post(
+						integration.HTTPServerAddress()+"/api/v1/auth/refresh",
+						"application/json",
+						bytes.NewReader(refreshBytes),
+					)
+					Expect(err).ToNot(HaveOccurred())
+					defer resp3.Body.Close()
+					Expect(resp3.StatusCode).To(Equal(http.StatusUnauthorized))
+				})
+
+				It("should return 404 for unknown session", func() {
+					req, err := http.NewRequest(
+						http.MethodDelete,
+						integration.HTTPServerAddress()+"/api/v1/users/me/sessions/123e4567-e89b-12d3-a456-426614174000",
+						nil,
+					)
+					Expect(err).ToNot(HaveOccurred())
+					req.Header.Set("Authorization", "Bearer "+adminAccessToken)
+
+					resp, err := client.Do(req)
+					Expect(err).ToNot(HaveOccurred())
+					defer resp.Body.Close()
+					Expect(resp.StatusCode).To(Equal(http.StatusNotFound))
+				})
+			})
+
+			Describe("/users/me/tokens", func() {
+				createToken := func(reqBody CreateAPITokenRequest) *http.Response {
+					bodyBytes, err := json.Marshal(reqBody)
+					Expect(err).ToNot(HaveOccurred())
+
+					req, err := http.NewRequest(
+						http.MethodPost,
+						integration.HTTPServerAddress()+"/api/v1/users/me/tokens",
+						bytes.NewReader(bodyBytes),
+					)
+					Expect(err).ToNot(HaveOccurred())
+					req.Header.Set("Content-Type", "application/json")
+					req.Header.Set("Authorization", "Bearer "+adminAccessToken)
+
+					resp, err := client.Do(req)
+					Expect(err).ToNot(HaveOccurred())
+					return resp
+				}
+
+				It("should create token usable for authentication", func() {
+					expiresAt := time.Now().Add(time.Hour)
+					resp := createToken(CreateAPITokenRequest{
+						Name:        "ci",
+						Permissions: []string{"api_tokens:read_self"},
+						ExpiresAt:   &expiresAt,
+					})
+					defer resp.Body.Close()
+					Expect(resp.StatusCode).To(Equal(http.StatusCreated))
+
+					var created APIToken
+					err := json.NewDecoder(resp.Body).Decode(&created)
+					Expect(err).ToNot(HaveOccurred())
+					Expect(created.ID).ToNot(BeEmpty())
+					Expect(created.Token).ToNot(BeEmpty())
+					Expect(created.Name).To(Equal("ci"))
+					Expect(created.Permissions).To(ConsistOf("api_tokens:read_self"))
+					Expect(created.ExpiresAt).ToNot(BeNil())
+
+					req, err := http.NewRequest(
+						http.MethodGet,
+						integration.HTTPServerAddress()+"/api/v1/users/me/tokens",
+						nil,
+					)
+					Expect(err).ToNot(HaveOccurred())
+					req.Header.Set("x-api-key", created.Token)
+
+					resp2, err := client.Do(req)
+					Expect(err).ToNot(HaveOccurred())
+					defer resp2.Body.Close()
+					Expect(resp2.StatusCode).To(Equal(http.StatusOK))
+
+					var tokens []APIToken
+					err = json.NewDecoder(resp2.Body).Decode(&tokens)
+					Expect(err).ToNot(HaveOccurred())
+					Expect(tokens).To(HaveLen(1))
+					Expect(tokens[0].ID).To(Equal(created.ID))
+					Expect(tokens[0].Token).To(BeEmpty())
+
+					// token has no permission to read user
+					req, err = http.NewRequest(
+						http.MethodGet,
+						integration.HTTPServerAddress()+"/api/v1/users/me",
+						nil,
+					)
+					Expect(err).ToNot(HaveOccurred())
+					req.Header.Set("x-api-key", created.Token)
+
+					resp3, err := client.Do(req)
+					Expect(err).ToNot(HaveOccurred())
+					defer resp3.Body.Close()
+					Expect(resp3.StatusCode).To(Equal(http.StatusForbidden))
+				})
+
+				It("should return 403 for permissions user does not have", func() {
+					resp := createToken(CreateAPITokenRequest{
+						Name:        "ci",
+						Permissions: []string{"users:delete"},
+					})
+					defer resp.Body.Close()
+					Expect(resp.StatusCode).To(Equal(http.StatusForbidden))
+				})
+
+				It("should return 400 for expiry in the past", func() {
+					expiresAt := time.Now().Add(-time.Hour)
+					resp := createToken(CreateAPITokenRequest{
+						Name:        "ci",
+						Permissions: []string{"users:read_self"},
+						ExpiresAt:   &expiresAt,
+					})
+					defer resp.Body.Close()
+					Expect(resp.StatusCode).To(Equal(http.StatusBadRequest))
+				})
+
+				It("should revoke token", func() {
+					resp := createToken(CreateAPITokenRequest{
+						Name:        "ci",
+						Permissions: []string{"users:read_self"},
+					})
+					defer resp.Body.Close()
+					Expect(resp.StatusCode).To(Equal(http.StatusCreated))
+
+					var created APIToken
+					err := json.NewDecoder(resp.Body).Decode(&created)
+					Expect(err).ToNot(HaveOccurred())
+
+					req, err := http.NewRequest(
+						http.MethodDelete,
+						integration.HTTPServerAddress()+"/api/v1/users/me/tokens/"+created.ID,
+						nil,
+					)
+					Expect(err).ToNot(HaveOccurred())
+					req.Header.Set("Authorization", "Bearer "+adminAccessToken)
+
+					resp2, err := client.Do(req)
+					Expect(err).ToNot(HaveOccurred())
+					defer resp2.Body.Close()
+					Expect(resp2.StatusCode).To(Equal(http.StatusOK))
+
+					req, err = http.NewRequest(
+						http.MethodGet,
+						integration.HTTPServerAddress()+"/api/v1/users/me",
+						nil,
+					)
+					Expect(err).ToNot(HaveOccurred())
+					req.Header.Set("x-api-key", created.Token)
+
+					resp3, err := client.Do(req)
+					Expect(err).ToNot(HaveOccurred())
+					defer resp3.Body.Close()
+					Expect(resp3.StatusCode).To(Equal(http.StatusUnauthorized))
+				})
+			})
+
+			Describe("/users/me/mfa", func() {
+				var userEmail string
+				var userAccessToken string
+
+				BeforeEach(func() {
+					userEmail = fmt.Sprintf("mfa-%s@example.com", integration.GenerateRandomString("mfa"))
+					bodyBytes, err := json.Marshal(SignupRequest{Email: userEmail, Password: testPassword})
+					Expect(err).ToNot(HaveOccurred())
+					resp, err := client.Post(
+						integration.HTTPServerAddress()+"/api/v1/auth/signup",
+						"application/json",
+						bytes.NewReader(bodyBytes),
+					)
+					Expect(err).ToNot(HaveOccurred())
+					resp.Body.Close()
+
+					bodyBytes, err = json.Marshal(LoginRequest{Email: userEmail, Password: testPassword})
+					Expect(err).ToNot(HaveOccurred())
+					resp, err = client.Post(
+						integration.HTTPServerAddress()+"/api/v1/auth/login",
+						"application/json",
+						bytes.NewReader(bodyBytes),
+					)
+					Expect(err).ToNot(HaveOccurred())
+					defer resp.Body.Close()
+					Expect(resp.StatusCode).To(Equal(http.StatusOK))
+
+					var tokens Tokens
+					err = json.NewDecoder(resp.Body).Decode(&tokens)
+					Expect(err).ToNot(HaveOccurred())
+					userAccessToken = tokens.AccessToken
+				})
+
+				doRequest := func(method string, path string, token string, body any) *http.Response {
+					var reader *bytes.Reader
+					if body != nil {
+						bodyBytes, err := json.Marshal(body)
+						Expect(err).ToNot(HaveOccurred())
+						reader = bytes.NewReader(bodyBytes)
+					} else {
+						reader = bytes.NewReader(nil)
+					}
+					req, err := http.NewRequest(method, integration.HTTPServerAddress()+path, reader)
+					Expect(err).ToNot(HaveOccurred())
+					req.Header.Set("Content-Type", "application/json")
+					if token != "" {
+						req.Header.Set("Authorization", "Bearer "+token)
+					}
+					resp, err := client.Do(req)
+					Expect(err).ToNot(HaveOccurred())
+					return resp
+				}
+
+				login := func() *http.Response {
+					return doRequest(http.MethodPost, "/api/v1/auth/login", "",
+						LoginRequest{Email: userEmail, Password: testPassword})
+				}
+
+				enable := func() []string {
+					resp := doRequest(http.MethodPost, "/api/v1/users/me/mfa", userAccessToken, nil)
+					defer resp.Body.Close()
+					Expect(resp.StatusCode).To(Equal(http.StatusOK))
+
+					var enrollment MFAEnrollment
+					err := json.NewDecoder(resp.Body).Decode(&enrollment)
+					Expect(err).ToNot(HaveOccurred())
+					Expect(enrollment.Secret).ToNot(BeEmpty())
+					Expect(enrollment.ProvisioningURI).To(HavePrefix("otpauth://totp/"))
+
+					resp2 := doRequest(http.MethodPost, "/api/v1/users/me/mfa/confirm", userAccessToken,
+						MFACodeRequest{Code: totpCode(enrollment.Secret, time.Now())})
+					defer resp2.Body.Close()
+					Expect(resp2.StatusCode).To(Equal(http.StatusOK))
+
+					var codes MFARecoveryCodes
+					err = json.NewDecoder(resp2.Body).Decode(&codes)
+					Expect(err).ToNot(HaveOccurred())
+					Expect(codes.RecoveryCodes).To(HaveLen(10))
+					return codes.RecoveryCodes
+				}
+
+				It("should not be enabled by default", func() {
+					resp := doRequest(http.MethodGet, "/api/v1/users/me/mfa", userAccessToken, nil)
+					defer resp.Body.Close()
+					Expect(resp.StatusCode).To(Equal(http.StatusOK))
+
+					var status MFAStatus
+					err := json.NewDecoder(resp.Body).Decode(&status)
+					Expect(err).ToNot(HaveOccurred())
+					Expect(status.Enabled).To(BeFalse())
+				})
+
+				It("should reject confirmation with invalid code", func() {
+					resp := doRequest(http.MethodPost, "/api/v1/users/me/mfa", userAccessToken, nil)
+					resp.Body.Close()
+					Expect(resp.StatusCode).To(Equal(http.StatusOK))
+
+					resp2 := doRequest(http.MethodPost, "/api/v1/users/me/mfa/confirm", userAccessToken,
+						MFACodeRequest{Code: "000000x"})
+					defer resp2.Body.Close()
+					Expect(resp2.StatusCode).To(Equal(http.StatusBadRequest))
+				})
+
+				It("should require second factor to login", func() {
+					recoveryCodes := enable()
+
+					resp := login()
+					defer resp.Body.Close()
+					Expect(resp.StatusCode).To(Equal(http.StatusAccepted))
+
+					var challenge MFAChallenge
+					err := json.NewDecoder(resp.Body).Decode(&challenge)
+					Expect(err).ToNot(HaveOccurred())
+					Expect(challenge.ChallengeToken).ToNot(BeEmpty())
+
+					// challenge token is not an access token
+					resp2 := doRequest(http.MethodGet, "/api/v1/users/me", challenge.ChallengeToken, nil)
+					defer resp2.Body.Close()
+					Expect(resp2.StatusCode).To(Equal(http.StatusUnauthorized))
+
+					resp3 := doRequest(http.MethodPost, "/api/v1/auth/mfa/verify", "",
+						VerifyMFARequest{ChallengeToken: challenge.ChallengeToken, Code: "invalid-code"})
+					defer resp3.Body.Close()
+					Expect(resp3.StatusCode).To(Equal(http.StatusBadRequest))
+
+					resp4 := doRequest(http.MethodPost, "/api/v1/auth/mfa/verify", "",
+						VerifyMFARequest{ChallengeToken: challenge.ChallengeToken, Code: recoveryCodes[0]})
+					defer resp4.Body.Close()
+					Expect(resp4.StatusCode).To(Equal(http.StatusOK))
+
+					var tokens Tokens
+					err = json.NewDecoder(resp4.Body).Decode(&tokens)
+					Expect(err).ToNot(HaveOccurred())
+					Expect(tokens.AccessToken).ToNot(BeEmpty())
+
+					// recovery code is single use
+					resp5 := login()
+					defer resp5.Body.Close()
+					Expect(resp5.StatusCode).To(Equal(http.StatusAccepted))
+					err = json.NewDecoder(resp5.Body).Decode(&challenge)
+					Expect(err).ToNot(HaveOccurred())
+
+					resp6 := doRequest(http.MethodPost, "/api/v1/auth/mfa/verify", "",
+						VerifyMFARequest{ChallengeToken: challenge.ChallengeToken, Code: recoveryCodes[0]})
+					defer resp6.Body.Close()
+					Expect(resp6.StatusCode).To(Equal(http.StatusBadRequest))
+
+					resp7 := doRequest(http.MethodGet, "/api/v1/users/me/mfa", tokens.AccessToken, nil)
+					defer resp7.Body.Close()
+					Expect(resp7.StatusCode).To(Equal(http.StatusOK))
+
+					var status MFAStatus
+					err = json.NewDecoder(resp7.Body).Decode(&status)
+					Expect(err).ToNot(HaveOccurred())
+					Expect(status.Enabled).To(BeTrue())
+					Expect(status.EnabledAt).ToNot(BeNil())
+					Expect(status.RecoveryCodesLeft).To(Equal(9))
+				})
+
+				It("should disable mfa", func() {
+					recoveryCodes := enable()
+
+					resp := doRequest(http.MethodPost, "/api/v1/users/me/mfa", userAccessToken, nil)
+					defer resp.Body.Close()
+					Expect(resp.StatusCode).To(Equal(http.StatusConflict))
+
+					resp2 := doRequest(http.MethodPost, "/api/v1/users/me/mfa/disable", userAccessToken,
+						MFACodeRequest{Code: recoveryCodes[1]})
+					defer resp2.Body.Close()
+					Expect(resp2.StatusCode).To(Equal(http.StatusOK))
+
+					resp3 := login()
+					defer resp3.Body.Close()
+					Expect(resp3.StatusCode).To(Equal(http.StatusOK))
+
+					resp4 := doRequest(http.MethodPost, "/api/v1/users/me/mfa/disable", userAccessToken,
+						MFACodeRequest{Code: recoveryCodes[2]})
+					defer resp4.Body.Close()
+					Expect(resp4.StatusCode).To(Equal(http.StatusConflict))
+				})
+			})
+
+			Describe("GET /roles", func() {
+				It("should return 403 without roles permissions", func() {
+					req, err := http.NewRequest(
+						http.MethodGet,
+						integration.HTTPServerAddress()+"/api/v1/roles",
+						nil,
+					)
+					Expect(err).ToNot(HaveOccurred())
+					req.Header.Set("Authorization", "Bearer "+adminAccessToken)
+
+					resp, err := client.Do(req)
+					Expect(err).ToNot(HaveOccurred())
+					defer resp.Body.Close()
+					Expect(resp.StatusCode).To(Equal(http.StatusForbidden))
+				})
+			})
+
+			Describe("GET /outbox/messages/failed", func() {
+				It("should return 403 without outbox permissions", func() {
+					req, err := http.NewRequest(
+						http.MethodGet,
+						integration.HTTPServerAddress()+"/api/v1/outbox/messages/failed",
+						nil,
+					)
+					Expect(err).ToNot(HaveOccurred())
+					req.Header.Set("Authorization", "Bearer "+adminAccessToken)
+
+					resp, err := client.Do(req)
+					Expect(err).ToNot(HaveOccurred())
+					defer resp.Body.Close()
+					Expect(resp.StatusCode).To(Equal(http.StatusForbidden))
+				})
+
+				It("should return 401 without auth token", func() {
+					resp, err := client.Get(integration.HTTPServerAddress() + "/api/v1/outbox/messages/failed")
+					Expect(err).ToNot(HaveOccurred())
+					defer resp.Body.Close()
+					Expect(resp.StatusCode).To(Equal(http.StatusUnauthorized))
+				})
+			})
+
+			Describe("PUT /users/me", func() {
+				It("should update current user email", func() {
+					newEmail := fmt.Sprintf("updated-%s@example.com", integration.GenerateRandomString("email"))
+					reqBody := UpdateSelfRequest{
+						Email: newEmail,
+					}
+					bodyBytes, err := json.Marshal(reqBody)
+					Expect(err).ToNot(HaveOccurred())
+
+					req, err := http.NewRequest(
+						http.MethodPut,
+						integration.HTTPServerAddress()+"/api/v1/users/me",
+						bytes.NewReader(bodyBytes),
+					)
+					Expect(err).ToNot(HaveOccurred())
+					req.Header.Set("Authorization", "Bearer "+adminAccessToken)
+					req.Header.Set("Content-Type", "application/json")
+
+					resp, err := client.Do(req)
+					Expect(err).ToNot(HaveOccurred())
+					defer resp.Body.Close()
+
+					Expect(resp.StatusCode).To(Equal(http.StatusOK))
+				})
+
+				It("should update current user password", func() {
+					reqBody := UpdateSelfRequest{
+						Password: "NewPassword123!",
+					}
+					bodyBytes, err := json.Marshal(reqBody)
+					Expect(err).ToNot(HaveOccurred())
+
+					req, err := http.NewRequest(
+						http.MethodPut,
+						integration.HTTPServerAddress()+"/api/v1/users/me",
+						bytes.NewReader(bodyBytes),
+					)
+					Expect(err).ToNot(HaveOccurred())
+					req.Header.Set("Authorization", "Bearer "+adminAccessToken)
+					req.Header.Set("Content-Type", "application/json")
+
+					resp, err := client.Do(req)
+					Expect(err).ToNot(HaveOccurred())
+					defer resp.Body.Close()
+
+					Expect(resp.StatusCode).To(Equal(http.StatusOK))
+				})
+			})
+
+			Describe("GET /users", func() {
+				It("should list users", func() {
+					req, err := http.NewRequest(
+						http.MethodGet,
+						integration.HTTPServerAddress()+"/api/v1/users?limit=10&sort=created_at&order=desc",
+						nil,
+					)
+					Expect(err).ToNot(HaveOccurred())
+					req.Header.Set("Authorization", "Bearer "+adminAccessToken)
+
+					resp, err := client.Do(req)
+					Expect(err).ToNot(HaveOccurred())
+					defer resp.Body.Close()
+
+					// The endpoint might not exist (404) or require permissions (403)
+					if resp.StatusCode == http.StatusOK {
+						var list UserList
+						err = json.NewDecoder(resp.Body).Decode(&list)
+						Expect(err).ToNot(HaveOccurred())
+						Expect(len(list.Users)).To(BeNumerically("<=", 10))
+					} else {
+						Expect(resp.StatusCode).To(BeElementOf(http.StatusForbidden, http.StatusNotFound))
+					}
+				})
+			})
+
+			Describe("POST /users", func() {
+				It("should create a new user", func() {
+					newUserEmail := fmt.Sprintf("newuser-%s@example.com", integration.GenerateRandomString("user"))
+					reqBody := CreateUserRequest{
 						Email:    newUserEmail,
 						Password: "NewUserPass123!",
 					}
@@ -710,6 +1582,691 @@ var _ = Describe("Auth API Integration Tests", func() {
 					}
 				})
 			})
+
+			Describe("POST /users/{uuid}/suspend", func() {
+				It("should return 403 without suspend permission", func() {
+					req, err := http.NewRequest(
+						http.MethodPost,
+						integration.HTTPServerAddress()+"/api/v1/users/00000000-0000-0000-0000-000000000001/suspend",
+						strings.NewReader(`{"reason":"test"}`),
+					)
+					Expect(err).ToNot(HaveOccurred())
+					req.Header.Set("Content-Type", "application/json")
+					req.Header.Set("Authorization", "Bearer "+adminAccessToken)
+
+					resp, err := client.Do(req)
+					Expect(err).ToNot(HaveOccurred())
+					defer resp.Body.Close()
+					Expect(resp.StatusCode).To(Equal(http.StatusForbidden))
+				})
+			})
+
+			Describe("POST /users/{uuid}/impersonate", func() {
+				It("should return 403 without impersonate permission", func() {
+					req, err := http.NewRequest(
+						http.MethodPost,
+						integration.HTTPServerAddress()+"/api/v1/users/00000000-0000-0000-0000-000000000001/impersonate",
+						nil,
+					)
+					Expect(err).ToNot(HaveOccurred())
+					req.Header.Set("Authorization", "Bearer "+adminAccessToken)
+
+					resp, err := client.Do(req)
+					Expect(err).ToNot(HaveOccurred())
+					defer resp.Body.Close()
+					Expect(resp.StatusCode).To(Equal(http.StatusForbidden))
+				})
+			})
+		})
+	})
+
+	Describe("OAuth Endpoints", func() {
+		const redirectURI = "http://localhost:3000/callback"
+
+		var (
+			userUUID     string
+			userToken    string
+			clientID     string
+			clientSecret string
+		)
+
+		// oauth clients are registered by administrators, http api is used by regular users here,
+		// so client is registered through admin grpc api
+		BeforeEach(func() {
+			email := fmt.Sprintf("oauth-%s@example.com", integration.GenerateRandomString("user"))
+			password := "TestPass123!"
+
+			bodyBytes, err := json.Marshal(SignupRequest{Email: email, Password: password})
+			Expect(err).ToNot(HaveOccurred())
+			resp, err := client.Post(
+				integration.HTTPServerAddress()+"/api/v1/auth/signup",
+				"application/json",
+				bytes.NewReader(bodyBytes),
+			)
+			Expect(err).ToNot(HaveOccurred())
+			defer resp.Body.Close()
+			Expect(resp.StatusCode).To(Equal(http.StatusCreated))
+
+			var user User
+			err = json.NewDecoder(resp.Body).Decode(&user)
+			Expect(err).ToNot(HaveOccurred())
+			userUUID = user.UUID
+
+			bodyBytes, err = json.Marshal(LoginRequest{Email: email, Password: password})
+			Expect(err).ToNot(HaveOccurred())
+			loginResp, err := client.Post(
+				integration.HTTPServerAddress()+"/api/v1/auth/login",
+				"application/json",
+				bytes.NewReader(bodyBytes),
+			)
+			Expect(err).ToNot(HaveOccurred())
+			defer loginResp.Body.Close()
+			Expect(loginResp.StatusCode).To(Equal(http.StatusOK))
+
+			var tokens Tokens
+			err = json.NewDecoder(loginResp.Body).Decode(&tokens)
+			Expect(err).ToNot(HaveOccurred())
+			userToken = tokens.AccessToken
+
+			conn, err := grpc.NewClient(
+				integration.GRPCServerAddress(),
+				grpc.WithTransportCredentials(insecure.NewCredentials()),
+			)
+			Expect(err).ToNot(HaveOccurred())
+			defer conn.Close()
+
+			created, err := pb.NewAuthServiceClient(conn).CreateOAuthClient(
+				context.Background(), &pb.CreateOAuthClientRequest{
+					UserUuid:     userUUID,
+					Name:         "test",
+					RedirectUris: []string{redirectURI},
+					GrantTypes:   []string{"authorization_code", "refresh_token", "client_credentials"},
+					Permissions:  []string{"users:read_self"},
+				},
+			)
+			Expect(err).ToNot(HaveOccurred())
+			clientID = created.Client.ClientId
+			clientSecret = created.ClientSecret
+			Expect(clientSecret).ToNot(BeEmpty())
+		})
+
+		requestToken := func(form url.Values, withSecret bool) *http.Response {
+			req, err := http.NewRequest(
+				http.MethodPost,
+				integration.HTTPServerAddress()+"/api/v1/oauth/token",
+				strings.NewReader(form.Encode()),
+			)
+			Expect(err).ToNot(HaveOccurred())
+			req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+			if withSecret {
+				req.SetBasicAuth(url.QueryEscape(clientID), url.QueryEscape(clientSecret))
+			}
+			resp, err := client.Do(req)
+			Expect(err).ToNot(HaveOccurred())
+			return resp
+		}
+
+		It("should issue tokens with authorization code and pkce", func() {
+			verifier := integration.GenerateRandomString("verifier-") + strings.Repeat("x", 43)
+			sum := sha256.Sum256([]byte(verifier))
+			challenge := base64.RawURLEncoding.EncodeToString(sum[:])
+
+			bodyBytes, err := json.Marshal(AuthorizeRequest{
+				ResponseType:        "code",
+				ClientID:            clientID,
+				RedirectURI:         redirectURI,
+				Scope:               "openid email",
+				State:               "xyz",
+				Nonce:               "n-0S6_WzA2Mj",
+				CodeChallenge:       challenge,
+				CodeChallengeMethod: "S256",
+			})
+			Expect(err).ToNot(HaveOccurred())
+			req, err := http.NewRequest(
+				http.MethodPost,
+				integration.HTTPServerAddress()+"/api/v1/oauth/authorize",
+				bytes.NewReader(bodyBytes),
+			)
+			Expect(err).ToNot(HaveOccurred())
+			req.Header.Set("Content-Type", "application/json")
+			req.Header.Set("Authorization", "Bearer "+userToken)
+
+			resp, err := client.Do(req)
+			Expect(err).ToNot(HaveOccurred())
+			defer resp.Body.Close()
+			Expect(resp.StatusCode).To(Equal(http.StatusOK))
+
+			var authorized AuthorizeResponse
+			err = json.NewDecoder(resp.Body).Decode(&authorized)
+			Expect(err).ToNot(HaveOccurred())
+			location, err := url.Parse(authorized.RedirectTo)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(location.Query().Get("state")).To(Equal("xyz"))
+			code := location.Query().Get("code")
+			Expect(code).ToNot(BeEmpty())
+
+			form := url.Values{
+				"grant_type":    {"authorization_code"},
+				"code":          {code},
+				"redirect_uri":  {redirectURI},
+				"code_verifier": {verifier},
+			}
+			tokenResp := requestToken(form, true)
+			defer tokenResp.Body.Close()
+			Expect(tokenResp.StatusCode).To(Equal(http.StatusOK))
+			Expect(tokenResp.Header.Get("Cache-Control")).To(Equal("no-store"))
+
+			var tokens OAuthTokens
+			err = json.NewDecoder(tokenResp.Body).Decode(&tokens)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(tokens.TokenType).To(Equal("Bearer"))
+			Expect(tokens.AccessToken).ToNot(BeEmpty())
+			Expect(tokens.RefreshToken).ToNot(BeEmpty())
+			Expect(tokens.IDToken).ToNot(BeEmpty())
+
+			// code can be used only once
+			reuseResp := requestToken(form, true)
+			defer reuseResp.Body.Close()
+			Expect(reuseResp.StatusCode).To(Equal(http.StatusBadRequest))
+			var oauthErr OAuthError
+			err = json.NewDecoder(reuseResp.Body).Decode(&oauthErr)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(oauthErr.Error).To(Equal("invalid_grant"))
+
+			req, err = http.NewRequest(
+				http.MethodGet,
+				integration.HTTPServerAddress()+"/api/v1/oauth/userinfo",
+				nil,
+			)
+			Expect(err).ToNot(HaveOccurred())
+			req.Header.Set("Authorization", "Bearer "+tokens.AccessToken)
+
+			infoResp, err := client.Do(req)
+			Expect(err).ToNot(HaveOccurred())
+			defer infoResp.Body.Close()
+			Expect(infoResp.StatusCode).To(Equal(http.StatusOK))
+
+			var info UserInfo
+			err = json.NewDecoder(infoResp.Body).Decode(&info)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(info.Subject).To(Equal(userUUID))
+
+			refreshResp := requestToken(url.Values{
+				"grant_type":    {"refresh_token"},
+				"refresh_token": {tokens.RefreshToken},
+			}, true)
+			defer refreshResp.Body.Close()
+			Expect(refreshResp.StatusCode).To(Equal(http.StatusOK))
+		})
+
+		It("should reject code exchange with wrong code verifier", func() {
+			sum := sha256.Sum256([]byte(strings.Repeat("a", 43)))
+			bodyBytes, err := json.Marshal(AuthorizeRequest{
+				ResponseType:        "code",
+				ClientID:            clientID,
+				CodeChallenge:       base64.RawURLEncoding.EncodeToString(sum[:]),
+				CodeChallengeMethod: "S256",
+			})
+			Expect(err).ToNot(HaveOccurred())
+			req, err := http.NewRequest(
+				http.MethodPost,
+				integration.HTTPServerAddress()+"/api/v1/oauth/authorize",
+				bytes.NewReader(bodyBytes),
+			)
+			Expect(err).ToNot(HaveOccurred())
+			req.Header.Set("Content-Type", "application/json")
+			req.Header.Set("Authorization", "Bearer "+userToken)
+
+			resp, err := client.Do(req)
+			Expect(err).ToNot(HaveOccurred())
+			defer resp.Body.Close()
+			Expect(resp.StatusCode).To(Equal(http.StatusOK))
+
+			var authorized AuthorizeResponse
+			err = json.NewDecoder(resp.Body).Decode(&authorized)
+			Expect(err).ToNot(HaveOccurred())
+			location, err := url.Parse(authorized.RedirectTo)
+			Expect(err).ToNot(HaveOccurred())
+
+			tokenResp := requestToken(url.Values{
+				"grant_type":    {"authorization_code"},
+				"code":          {location.Query().Get("code")},
+				"redirect_uri":  {redirectURI},
+				"code_verifier": {strings.Repeat("b", 43)},
+			}, true)
+			defer tokenResp.Body.Close()
+			Expect(tokenResp.StatusCode).To(Equal(http.StatusBadRequest))
+		})
+
+		It("should redirect back to client when login page is not configured", func() {
+			noRedirectClient := &http.Client{
+				Timeout: 5 * time.Second,
+				CheckRedirect: func(*http.Request, []*http.Request) error {
+					return http.ErrUseLastResponse
+				},
+			}
+			params := url.Values{
+				"response_type": {"code"},
+				"client_id":     {clientID},
+				"state":         {"xyz"},
+			}
+			resp, err := noRedirectClient.Get(
+				integration.HTTPServerAddress() + "/api/v1/oauth/authorize?" + params.Encode(),
+			)
+			Expect(err).ToNot(HaveOccurred())
+			defer resp.Body.Close()
+			Expect(resp.StatusCode).To(Equal(http.StatusFound))
+
+			location, err := url.Parse(resp.Header.Get("Location"))
+			Expect(err).ToNot(HaveOccurred())
+			Expect(location.Query().Get("error")).To(Equal("login_required"))
+			Expect(location.Query().Get("state")).To(Equal("xyz"))
+		})
+
+		It("should not redirect to unregistered uri", func() {
+			params := url.Values{
+				"response_type": {"code"},
+				"client_id":     {clientID},
+				"redirect_uri":  {"https://evil.example.com/callback"},
+			}
+			resp, err := client.Get(
+				integration.HTTPServerAddress() + "/api/v1/oauth/authorize?" + params.Encode(),
+			)
+			Expect(err).ToNot(HaveOccurred())
+			defer resp.Body.Close()
+			Expect(resp.StatusCode).To(Equal(http.StatusBadRequest))
+		})
+
+		It("should issue api token with client credentials", func() {
+			resp := requestToken(url.Values{
+				"grant_type": {"client_credentials"},
+				"scope":      {"users:read_self"},
+			}, true)
+			defer resp.Body.Close()
+			Expect(resp.StatusCode).To(Equal(http.StatusOK))
+
+			var tokens OAuthTokens
+			err := json.NewDecoder(resp.Body).Decode(&tokens)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(tokens.RefreshToken).To(BeEmpty())
+			Expect(tokens.Scope).To(Equal("users:read_self"))
+
+			req, err := http.NewRequest(
+				http.MethodGet,
+				integration.HTTPServerAddress()+"/api/v1/users/me",
+				nil,
+			)
+			Expect(err).ToNot(HaveOccurred())
+			req.Header.Set("Authorization", "Bearer "+tokens.AccessToken)
+
+			meResp, err := client.Do(req)
+			Expect(err).ToNot(HaveOccurred())
+			defer meResp.Body.Close()
+			Expect(meResp.StatusCode).To(Equal(http.StatusOK))
+
+			// permission which was not granted to client
+			scopeResp := requestToken(url.Values{
+				"grant_type": {"client_credentials"},
+				"scope":      {"users:update_self"},
+			}, true)
+			defer scopeResp.Body.Close()
+			Expect(scopeResp.StatusCode).To(Equal(http.StatusBadRequest))
+		})
+
+		It("should return invalid_client for wrong secret", func() {
+			resp := requestToken(url.Values{
+				"grant_type":    {"client_credentials"},
+				"client_id":     {clientID},
+				"client_secret": {"wrong"},
+			}, false)
+			defer resp.Body.Close()
+			Expect(resp.StatusCode).To(Equal(http.StatusUnauthorized))
+
+			var oauthErr OAuthError
+			err := json.NewDecoder(resp.Body).Decode(&oauthErr)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(oauthErr.Error).To(Equal("invalid_client"))
+		})
+
+		It("should return 403 on client management without permission", func() {
+			req, err := http.NewRequest(
+				http.MethodGet,
+				integration.HTTPServerAddress()+"/api/v1/oauth/clients",
+				nil,
+			)
+			Expect(err).ToNot(HaveOccurred())
+			req.Header.Set("Authorization", "Bearer "+userToken)
+
+			resp, err := client.Do(req)
+			Expect(err).ToNot(HaveOccurred())
+			defer resp.Body.Close()
+			Expect(resp.StatusCode).To(Equal(http.StatusForbidden))
+		})
+	})
+
+	Describe("Organization Endpoints", func() {
+		var tokens Tokens
+
+		BeforeEach(func() {
+			email := fmt.Sprintf("org-%s@example.com", integration.GenerateRandomString("user"))
+			password := "TestPass123!"
+
+			bodyBytes, err := json.Marshal(SignupRequest{Email: email, Password: password})
+			Expect(err).ToNot(HaveOccurred())
+			resp, err := client.Post(
+				integration.HTTPServerAddress()+"/api/v1/auth/signup",
+				"application/json",
+				bytes.NewReader(bodyBytes),
+			)
+			Expect(err).ToNot(HaveOccurred())
+			resp.Body.Close()
+			Expect(resp.StatusCode).To(Equal(http.StatusCreated))
+
+			bodyBytes, err = json.Marshal(LoginRequest{Email: email, Password: password})
+			Expect(err).ToNot(HaveOccurred())
+			loginResp, err := client.Post(
+				integration.HTTPServerAddress()+"/api/v1/auth/login",
+				"application/json",
+				bytes.NewReader(bodyBytes),
+			)
+			Expect(err).ToNot(HaveOccurred())
+			defer loginResp.Body.Close()
+			Expect(loginResp.StatusCode).To(Equal(http.StatusOK))
+
+			err = json.NewDecoder(loginResp.Body).Decode(&tokens)
+			Expect(err).ToNot(HaveOccurred())
+		})
+
+		switchTenant := func(organizationUUID string) *http.Response {
+			bodyBytes, err := json.Marshal(SwitchTenantRequest{
+				Token:            tokens.RefreshToken,
+				OrganizationUUID: organizationUUID,
+			})
+			Expect(err).ToNot(HaveOccurred())
+			resp, err := client.Post(
+				integration.HTTPServerAddress()+"/api/v1/auth/tenant",
+				"application/json",
+				bytes.NewReader(bodyBytes),
+			)
+			Expect(err).ToNot(HaveOccurred())
+			return resp
+		}
+
+		It("should list no organizations for new user", func() {
+			req, err := http.NewRequest(
+				http.MethodGet,
+				integration.HTTPServerAddress()+"/api/v1/users/me/organizations",
+				nil,
+			)
+			Expect(err).ToNot(HaveOccurred())
+			req.Header.Set("Authorization", "Bearer "+tokens.AccessToken)
+
+			resp, err := client.Do(req)
+			Expect(err).ToNot(HaveOccurred())
+			defer resp.Body.Close()
+			Expect(resp.StatusCode).To(Equal(http.StatusOK))
+
+			var organizations []Organization
+			err = json.NewDecoder(resp.Body).Decode(&organizations)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(organizations).To(BeEmpty())
+		})
+
+		It("should return 403 on organization management without permission", func() {
+			bodyBytes, err := json.Marshal(Organization{Name: "Acme", Slug: "acme"})
+			Expect(err).ToNot(HaveOccurred())
+			req, err := http.NewRequest(
+				http.MethodPost,
+				integration.HTTPServerAddress()+"/api/v1/organizations",
+				bytes.NewReader(bodyBytes),
+			)
+			Expect(err).ToNot(HaveOccurred())
+			req.Header.Set("Content-Type", "application/json")
+			req.Header.Set("Authorization", "Bearer "+tokens.AccessToken)
+
+			resp, err := client.Do(req)
+			Expect(err).ToNot(HaveOccurred())
+			defer resp.Body.Close()
+			Expect(resp.StatusCode).To(Equal(http.StatusForbidden))
+		})
+
+		It("should not switch to organization user is not member of", func() {
+			resp := switchTenant("00000000-0000-0000-0000-000000000001")
+			defer resp.Body.Close()
+			Expect(resp.StatusCode).To(Equal(http.StatusForbidden))
+		})
+
+		It("should switch to unscoped session", func() {
+			resp := switchTenant("")
+			defer resp.Body.Close()
+			Expect(resp.StatusCode).To(Equal(http.StatusOK))
+
+			var switched Tokens
+			err := json.NewDecoder(resp.Body).Decode(&switched)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(switched.AccessToken).ToNot(BeEmpty())
+			Expect(switched.RefreshToken).ToNot(Equal(tokens.RefreshToken))
+		})
+	})
+
+	Describe("History Endpoints", func() {
+		It("should list history of current user with request metadata", func() {
+			email := fmt.Sprintf("history-%s@example.com", integration.GenerateRandomString("user"))
+			password := "TestPass123!"
+
+			bodyBytes, err := json.Marshal(SignupRequest{Email: email, Password: password})
+			Expect(err).ToNot(HaveOccurred())
+			resp, err := client.Post(
+				integration.HTTPServerAddress()+"/api/v1/auth/signup",
+				"application/json",
+				bytes.NewReader(bodyBytes),
+			)
+			Expect(err).ToNot(HaveOccurred())
+			resp.Body.Close()
+			Expect(resp.StatusCode).To(Equal(http.StatusCreated))
+
+			bodyBytes, err = json.Marshal(LoginRequest{Email: email, Password: password})
+			Expect(err).ToNot(HaveOccurred())
+			req, err := http.NewRequest(
+				http.MethodPost,
+				integration.HTTPServerAddress()+"/api/v1/auth/login",
+				bytes.NewReader(bodyBytes),
+			)
+			Expect(err).ToNot(HaveOccurred())
+			req.Header.Set("Content-Type", "application/json")
+			req.Header.Set("User-Agent", "history-integration-test")
+			loginResp, err := client.Do(req)
+			Expect(err).ToNot(HaveOccurred())
+			defer loginResp.Body.Close()
+			Expect(loginResp.StatusCode).To(Equal(http.StatusOK))
+
+			var tokens Tokens
+			err = json.NewDecoder(loginResp.Body).Decode(&tokens)
+			Expect(err).ToNot(HaveOccurred())
+
+			listHistory := func(query string) []HistoryRecord {
+				req, err := http.NewRequest(
+					http.MethodGet,
+					integration.HTTPServerAddress()+"/api/v1/users/me/history"+query,
+					nil,
+				)
+				Expect(err).ToNot(HaveOccurred())
+				req.Header.Set("Authorization", "Bearer "+tokens.AccessToken)
+				resp, err := client.Do(req)
+				Expect(err).ToNot(HaveOccurred())
+				defer resp.Body.Close()
+				Expect(resp.StatusCode).To(Equal(http.StatusOK))
+				var records []HistoryRecord
+				Expect(json.NewDecoder(resp.Body).Decode(&records)).To(Succeed())
+				return records
+			}
+
+			// history is written asynchronously through outbox
+			var records []HistoryRecord
+			Eventually(func() []HistoryRecord {
+				records = listHistory("?event_type=auth.login")
+				return records
+			}, 15*time.Second, 250*time.Millisecond).Should(HaveLen(1))
+
+			Expect(records[0].EventType).To(Equal("auth.login"))
+			Expect(records[0].Metadata["user_agent"]).To(Equal("history-integration-test"))
+			Expect(records[0].Metadata["request_id"]).ToNot(BeEmpty())
+			Expect(records[0].Metadata["ip_address"]).ToNot(BeEmpty())
+
+			future := url.QueryEscape(time.Now().Add(time.Hour).Format(time.RFC3339))
+			Expect(listHistory("?from=" + future)).To(BeEmpty())
+
+			req, err = http.NewRequest(
+				http.MethodGet,
+				integration.HTTPServerAddress()+"/api/v1/users/00000000-0000-0000-0000-000000000001/history",
+				nil,
+			)
+			Expect(err).ToNot(HaveOccurred())
+			req.Header.Set("Authorization", "Bearer "+tokens.AccessToken)
+
+			resp, err = client.Do(req)
+			Expect(err).ToNot(HaveOccurred())
+			defer resp.Body.Close()
+			Expect(resp.StatusCode).To(Equal(http.StatusForbidden))
+		})
+	})
+
+	Describe("Data Request Endpoints", func() {
+		var tokens Tokens
+
+		BeforeEach(func() {
+			email := fmt.Sprintf("gdpr-%s@example.com", integration.GenerateRandomString("user"))
+			password := "TestPass123!"
+
+			bodyBytes, err := json.Marshal(SignupRequest{Email: email, Password: password})
+			Expect(err).ToNot(HaveOccurred())
+			resp, err := client.Post(
+				integration.HTTPServerAddress()+"/api/v1/auth/signup",
+				"application/json",
+				bytes.NewReader(bodyBytes),
+			)
+			Expect(err).ToNot(HaveOccurred())
+			resp.Body.Close()
+			Expect(resp.StatusCode).To(Equal(http.StatusCreated))
+
+			bodyBytes, err = json.Marshal(LoginRequest{Email: email, Password: password})
+			Expect(err).ToNot(HaveOccurred())
+			loginResp, err := client.Post(
+				integration.HTTPServerAddress()+"/api/v1/auth/login",
+				"application/json",
+				bytes.NewReader(bodyBytes),
+			)
+			Expect(err).ToNot(HaveOccurred())
+			defer loginResp.Body.Close()
+			Expect(loginResp.StatusCode).To(Equal(http.StatusOK))
+
+			err = json.NewDecoder(loginResp.Body).Decode(&tokens)
+			Expect(err).ToNot(HaveOccurred())
+		})
+
+		doRequest := func(method string, path string, body any) *http.Response {
+			var reader io.Reader
+			if body != nil {
+				bodyBytes, err := json.Marshal(body)
+				Expect(err).ToNot(HaveOccurred())
+				reader = bytes.NewReader(bodyBytes)
+			}
+			req, err := http.NewRequest(method, integration.HTTPServerAddress()+path, reader)
+			Expect(err).ToNot(HaveOccurred())
+			req.Header.Set("Content-Type", "application/json")
+			req.Header.Set("Authorization", "Bearer "+tokens.AccessToken)
+			resp, err := client.Do(req)
+			Expect(err).ToNot(HaveOccurred())
+			return resp
+		}
+
+		It("should export data of current user", func() {
+			resp := doRequest(http.MethodPost, "/api/v1/users/me/data-requests", DataRequestRequest{Type: "export"})
+			defer resp.Body.Close()
+			Expect(resp.StatusCode).To(Equal(http.StatusAccepted))
+
+			var request DataRequest
+			err := json.NewDecoder(resp.Body).Decode(&request)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(request.Type).To(Equal("export"))
+			Expect(request.Status).To(Equal("pending"))
+
+			Eventually(func() string {
+				resp := doRequest(http.MethodGet, "/api/v1/users/me/data-requests/"+request.ID, nil)
+				defer resp.Body.Close()
+				Expect(resp.StatusCode).To(Equal(http.StatusOK))
+				var polled DataRequest
+				Expect(json.NewDecoder(resp.Body).Decode(&polled)).To(Succeed())
+				return polled.Status
+			}, 10*time.Second, 200*time.Millisecond).Should(Equal("completed"))
+
+			resp2 := doRequest(http.MethodGet, "/api/v1/users/me/data-requests/"+request.ID+"/archive", nil)
+			defer resp2.Body.Close()
+			Expect(resp2.StatusCode).To(Equal(http.StatusOK))
+			Expect(resp2.Header.Get("Content-Type")).To(Equal("application/zip"))
+
+			archive, err := io.ReadAll(resp2.Body)
+			Expect(err).ToNot(HaveOccurred())
+			zr, err := zip.NewReader(bytes.NewReader(archive), int64(len(archive)))
+			Expect(err).ToNot(HaveOccurred())
+			names := make([]string, len(zr.File))
+			for i, file := range zr.File {
+				names[i] = file.Name
+			}
+			Expect(names).To(ConsistOf("api_tokens.json", "history.json", "profile.json", "roles.json"))
+		})
+
+		It("should not allow erasure of current user", func() {
+			resp := doRequest(http.MethodPost, "/api/v1/users/me/data-requests", DataRequestRequest{Type: "erasure"})
+			defer resp.Body.Close()
+			Expect(resp.StatusCode).To(Equal(http.StatusBadRequest))
+		})
+
+		It("should return 404 for unknown data request", func() {
+			resp := doRequest(http.MethodGet,
+				"/api/v1/users/me/data-requests/00000000-0000-0000-0000-000000000001", nil)
+			defer resp.Body.Close()
+			Expect(resp.StatusCode).To(Equal(http.StatusNotFound))
+		})
+	})
+
+	Describe("Well-known Endpoints", func() {
+		Describe("GET /.well-known/openid-configuration", func() {
+			It("should return discovery document", func() {
+				resp, err := client.Get(integration.HTTPServerAddress() + "/.well-known/openid-configuration")
+				Expect(err).ToNot(HaveOccurred())
+				defer resp.Body.Close()
+
+				Expect(resp.StatusCode).To(Equal(http.StatusOK))
+
+				var doc map[string]any
+				err = json.NewDecoder(resp.Body).Decode(&doc)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(doc["issuer"]).ToNot(BeEmpty())
+				Expect(doc["token_endpoint"]).To(HaveSuffix("/api/v1/oauth/token"))
+				Expect(doc["jwks_uri"]).To(HaveSuffix("/.well-known/jwks.json"))
+				Expect(doc["code_challenge_methods_supported"]).To(ConsistOf("S256"))
+			})
+		})
+
+		Describe("GET /.well-known/jwks.json", func() {
+			It("should return key set without symmetric keys", func() {
+				resp, err := client.Get(integration.HTTPServerAddress() + "/.well-known/jwks.json")
+				Expect(err).ToNot(HaveOccurred())
+				defer resp.Body.Close()
+
+				Expect(resp.StatusCode).To(Equal(http.StatusOK))
+
+				var jwks struct {
+					Keys []map[string]any `json:"keys"`
+				}
+				err = json.NewDecoder(resp.Body).Decode(&jwks)
+				Expect(err).ToNot(HaveOccurred())
+				for _, key := range jwks.Keys {
+					Expect(key["kty"]).ToNot(Equal("oct"))
+					Expect(key["kid"]).ToNot(BeEmpty())
+				}
+			})
 		})
 	})
 })
@@ -121,8 +121,7 @@ function runUserManagementOperations() {
 
 function listUsers() {
     const data = {
-        limit: 10,
-        offset: 0
+        limit: 10
     };
     
     const params = {
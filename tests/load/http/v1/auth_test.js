@@ -402,7 +402,7 @@ function updateMe(accessToken) {
 }
 
 function listUsers(accessToken) {
-    const url = `${ADDR}/api/v1/users?limit=10&offset=0`;
+    const url = `${ADDR}/api/v1/users?limit=10`;
     const params = {
         headers: {
             'Authorization': `Bearer ${accessToken}`,